@@ -0,0 +1,227 @@
+package sourcegraph
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+// personalAccessTokenPrefix begins every personal access token, so
+// that servers can distinguish them from OAuth2 access tokens (and so
+// that leaked tokens are easy to recognize).
+const personalAccessTokenPrefix = "sgp_"
+
+// NewPersonalAccessToken generates a new random personal access
+// token. It returns the token (to give to the user) and its hash (to
+// store in AccessToken.TokenHash).
+func NewPersonalAccessToken() (token string, hash []byte, err error) {
+	s, err := randomURLString()
+	if err != nil {
+		return "", nil, err
+	}
+	token = personalAccessTokenPrefix + s
+	return token, HashAccessToken(token), nil
+}
+
+// IsPersonalAccessToken reports whether token is (in form) a personal
+// access token, as opposed to an OAuth2 access token.
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, personalAccessTokenPrefix) && len(token) > len(personalAccessTokenPrefix)
+}
+
+// HashAccessToken returns the SHA-256 hash of a personal access token.
+func HashAccessToken(token string) []byte {
+	h := sha256.Sum256([]byte(token))
+	return h[:]
+}
+
+// Expired reports whether t has expired at time now.
+func (t *AccessToken) Expired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(t.ExpiresAt.Time())
+}
+
+// AuthInfo returns the AuthInfo describing a request that was
+// authenticated with t (as reported by Auth.Identify).
+func (t *AccessToken) AuthInfo() *AuthInfo {
+	return &AuthInfo{
+		UID:           t.User.UID,
+		Domain:        t.User.Domain,
+		Login:         t.User.Login,
+		AccessTokenID: t.ID,
+	}
+}
+
+// PersonalAccessToken is Credentials that authenticate API requests
+// with a static personal access token (e.g., one created for a CI
+// bot using AccessTokens.Create).
+type PersonalAccessToken string
+
+// ErrEmptyPersonalAccessToken is returned by PersonalAccessToken.Token
+// if the token is empty.
+var ErrEmptyPersonalAccessToken = errors.New("empty personal access token")
+
+// Token implements oauth2.TokenSource.
+func (t PersonalAccessToken) Token() (*oauth2.Token, error) {
+	if t == "" {
+		return nil, ErrEmptyPersonalAccessToken
+	}
+	return &oauth2.Token{AccessToken: string(t), TokenType: "Bearer"}, nil
+}
+
+// MemoryAccessTokenStore is an in-memory AccessTokensServer. It also
+// authenticates requests made with the personal access tokens it
+// creates (see Authenticate).
+//
+// It doesn't check that the caller may manage the specified user's
+// tokens, or that the requested scope doesn't exceed the caller's own
+// access; wrap it in an AuthorizedAccessTokensServer (which checks the
+// scope) and check the user in a handler to do so.
+type MemoryAccessTokenStore struct {
+	mu     sync.Mutex
+	tokens []*AccessToken   // in the order they were created
+	nextID int64            // the ID of the last created token
+	now    func() time.Time // for testing
+}
+
+func (s *MemoryAccessTokenStore) timeNow() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+// Create implements AccessTokensServer.
+func (s *MemoryAccessTokenStore) Create(ctx context.Context, op *AccessTokensCreateOp) (*NewAccessToken, error) {
+	if op.User.UID == 0 && op.User.Login == "" {
+		return nil, grpc.Errorf(codes.InvalidArgument, "access token user must be specified")
+	}
+	if op.Name == "" {
+		return nil, grpc.Errorf(codes.InvalidArgument, "access token name must be specified")
+	}
+	if len(op.Scope) == 0 {
+		return nil, grpc.Errorf(codes.InvalidArgument, "access token must have at least one scope")
+	}
+	for _, scope := range op.Scope {
+		if ScopePermission([]string{scope}) == NoPermission {
+			return nil, grpc.Errorf(codes.InvalidArgument, "invalid access token scope %q", scope)
+		}
+	}
+	now := s.timeNow()
+	if op.ExpiresAt != nil && !now.Before(op.ExpiresAt.Time()) {
+		return nil, grpc.Errorf(codes.InvalidArgument, "access token expiry %s is in the past", op.ExpiresAt.Time())
+	}
+
+	token, hash, err := NewPersonalAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.tokens {
+		if sameUser(t.User, op.User) && t.Name == op.Name {
+			return nil, grpc.Errorf(codes.AlreadyExists, "access token %q already exists", op.Name)
+		}
+	}
+	s.nextID++
+	t := &AccessToken{
+		ID:        s.nextID,
+		User:      op.User,
+		Name:      op.Name,
+		Scope:     op.Scope,
+		CreatedAt: pbtypes.NewTimestamp(now),
+		ExpiresAt: op.ExpiresAt,
+		TokenHash: hash,
+	}
+	s.tokens = append(s.tokens, t)
+	return &NewAccessToken{AccessToken: *withoutHash(t), Token: token}, nil
+}
+
+// List implements AccessTokensServer.
+func (s *MemoryAccessTokenStore) List(ctx context.Context, opt *AccessTokensListOptions) (*AccessTokenList, error) {
+	s.mu.Lock()
+	var matches []*AccessToken
+	for _, t := range s.tokens {
+		if sameUser(t.User, opt.User) {
+			matches = append(matches, withoutHash(t))
+		}
+	}
+	s.mu.Unlock()
+
+	list := &AccessTokenList{ListResponse: ListResponse{Total: int32(len(matches))}}
+	if offset := opt.Offset(); offset < len(matches) {
+		matches = matches[offset:]
+		if limit := opt.Limit(); limit < len(matches) {
+			matches = matches[:limit]
+		}
+		list.Tokens = matches
+	}
+	return list, nil
+}
+
+// Revoke implements AccessTokensServer.
+func (s *MemoryAccessTokenStore) Revoke(ctx context.Context, spec *AccessTokenSpec) (*pbtypes.Void, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, t := range s.tokens {
+		if t.ID == spec.ID && sameUser(t.User, spec.User) {
+			s.tokens = append(s.tokens[:i], s.tokens[i+1:]...)
+			return &pbtypes.Void{}, nil
+		}
+	}
+	return nil, grpc.Errorf(codes.NotFound, "access token %d not found", spec.ID)
+}
+
+// Authenticate returns the (unexpired, unrevoked) personal access
+// token whose hash matches token, and records that it was used. The
+// returned token's AuthInfo method describes the authenticated
+// request. If there is no such token, an Unauthenticated error is
+// returned.
+func (s *MemoryAccessTokenStore) Authenticate(ctx context.Context, token string) (*AccessToken, error) {
+	if !IsPersonalAccessToken(token) {
+		return nil, grpc.Errorf(codes.Unauthenticated, "not a personal access token")
+	}
+	hash := HashAccessToken(token)
+	now := s.timeNow()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.tokens {
+		if subtle.ConstantTimeCompare(t.TokenHash, hash) != 1 {
+			continue
+		}
+		if t.Expired(now) {
+			return nil, grpc.Errorf(codes.Unauthenticated, "personal access token %q expired at %s", t.Name, t.ExpiresAt.Time())
+		}
+		lastUsed := pbtypes.NewTimestamp(now)
+		t.LastUsedAt = &lastUsed
+		return withoutHash(t), nil
+	}
+	return nil, grpc.Errorf(codes.Unauthenticated, "invalid or revoked personal access token")
+}
+
+// withoutHash returns a copy of t without its TokenHash, suitable for
+// returning to API clients.
+func withoutHash(t *AccessToken) *AccessToken {
+	cpy := *t
+	cpy.TokenHash = nil
+	return &cpy
+}
+
+// sameUser reports whether a and b refer to the same user: by UID if
+// both have one, and otherwise by login (and domain).
+func sameUser(a, b UserSpec) bool {
+	if a.UID != 0 && b.UID != 0 {
+		return a.UID == b.UID
+	}
+	return a.Login != "" && a.Login == b.Login && a.Domain == b.Domain
+}
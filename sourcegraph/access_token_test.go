@@ -0,0 +1,163 @@
+package sourcegraph
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+func TestNewPersonalAccessToken(t *testing.T) {
+	token, hash, err := NewPersonalAccessToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsPersonalAccessToken(token) {
+		t.Errorf("got token %q, want a personal access token", token)
+	}
+	if !bytes.Equal(hash, HashAccessToken(token)) {
+		t.Errorf("got hash %x, want %x", hash, HashAccessToken(token))
+	}
+	if token2, _, _ := NewPersonalAccessToken(); token2 == token {
+		t.Errorf("got the same token %q twice", token)
+	}
+
+	for _, s := range []string{"", personalAccessTokenPrefix, "oauth-token"} {
+		if IsPersonalAccessToken(s) {
+			t.Errorf("%q: got IsPersonalAccessToken true, want false", s)
+		}
+	}
+}
+
+func TestMemoryAccessTokenStore(t *testing.T) {
+	now := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &MemoryAccessTokenStore{now: func() time.Time { return now }}
+	ctx := context.Background()
+	alice := UserSpec{UID: 1, Login: "alice"}
+	expiresAt := pbtypes.NewTimestamp(now.Add(time.Hour))
+
+	created, err := s.Create(ctx, &AccessTokensCreateOp{User: alice, Name: "ci", Scope: []string{ReadScope}, ExpiresAt: &expiresAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsPersonalAccessToken(created.Token) {
+		t.Errorf("got token %q, want a personal access token", created.Token)
+	}
+	if created.AccessToken.ID == 0 || created.AccessToken.TokenHash != nil {
+		t.Errorf("got created token %+v, want an ID and no hash", created.AccessToken)
+	}
+	if _, err := s.Create(ctx, &AccessTokensCreateOp{User: alice, Name: "other", Scope: []string{WriteScope}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the hash is stored.
+	for _, stored := range s.tokens {
+		if stored.TokenHash == nil {
+			t.Errorf("stored token %q has no hash", stored.Name)
+		}
+	}
+
+	list, err := s.List(ctx, &AccessTokensListOptions{User: UserSpec{UID: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Tokens) != 2 || list.Total != 2 {
+		t.Fatalf("got %d tokens (total %d), want 2", len(list.Tokens), list.Total)
+	}
+	if list.Tokens[0].Name != "ci" || list.Tokens[0].TokenHash != nil || list.Tokens[0].LastUsedAt != nil {
+		t.Errorf("got listed token %+v, want ci (without hash, never used)", list.Tokens[0])
+	}
+	if list, _ := s.List(ctx, &AccessTokensListOptions{User: UserSpec{UID: 2}}); len(list.Tokens) != 0 {
+		t.Errorf("got %d tokens for another user, want 0", len(list.Tokens))
+	}
+
+	// Authenticating with the token records that it was used.
+	now = now.Add(time.Minute)
+	tok, err := s.Authenticate(ctx, created.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := tok.AuthInfo(); a.UID != 1 || a.Login != "alice" || a.AccessTokenID != created.AccessToken.ID {
+		t.Errorf("got AuthInfo %+v, want alice authenticated with token %d", a, created.AccessToken.ID)
+	}
+	if tok.LastUsedAt == nil || !tok.LastUsedAt.Time().Equal(now) {
+		t.Errorf("got LastUsedAt %v, want %v", tok.LastUsedAt, now)
+	}
+	list, _ = s.List(ctx, &AccessTokensListOptions{User: alice})
+	if list.Tokens[0].LastUsedAt == nil {
+		t.Error("got no LastUsedAt in listed token after use")
+	}
+
+	if _, err := s.Authenticate(ctx, created.Token+"x"); grpc.Code(err) != codes.Unauthenticated {
+		t.Errorf("wrong token: got error %v, want Unauthenticated", err)
+	}
+
+	// Expired tokens are rejected.
+	now = now.Add(time.Hour)
+	if _, err := s.Authenticate(ctx, created.Token); grpc.Code(err) != codes.Unauthenticated {
+		t.Errorf("expired token: got error %v, want Unauthenticated", err)
+	}
+
+	// Tokens may only be revoked by specifying their user.
+	spec := &AccessTokenSpec{ID: created.AccessToken.ID, User: UserSpec{UID: 2}}
+	if _, err := s.Revoke(ctx, spec); grpc.Code(err) != codes.NotFound {
+		t.Errorf("revoking another user's token: got error %v, want NotFound", err)
+	}
+	spec.User = alice
+	if _, err := s.Revoke(ctx, spec); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := s.List(ctx, &AccessTokensListOptions{User: alice}); len(list.Tokens) != 1 {
+		t.Errorf("got %d tokens after revoking, want 1", len(list.Tokens))
+	}
+	if _, err := s.Revoke(ctx, spec); grpc.Code(err) != codes.NotFound {
+		t.Errorf("revoking twice: got error %v, want NotFound", err)
+	}
+}
+
+func TestMemoryAccessTokenStore_Create_invalid(t *testing.T) {
+	now := time.Now()
+	s := &MemoryAccessTokenStore{now: func() time.Time { return now }}
+	alice := UserSpec{UID: 1}
+	if _, err := s.Create(context.Background(), &AccessTokensCreateOp{User: alice, Name: "ci", Scope: []string{ReadScope}}); err != nil {
+		t.Fatal(err)
+	}
+	past := pbtypes.NewTimestamp(now.Add(-time.Second))
+
+	tests := []struct {
+		op       AccessTokensCreateOp
+		wantCode codes.Code
+	}{
+		{AccessTokensCreateOp{Name: "n", Scope: []string{ReadScope}}, codes.InvalidArgument},
+		{AccessTokensCreateOp{User: alice, Scope: []string{ReadScope}}, codes.InvalidArgument},
+		{AccessTokensCreateOp{User: alice, Name: "n"}, codes.InvalidArgument},
+		{AccessTokensCreateOp{User: alice, Name: "n", Scope: []string{"other"}}, codes.InvalidArgument},
+		{AccessTokensCreateOp{User: alice, Name: "n", Scope: []string{ReadScope}, ExpiresAt: &past}, codes.InvalidArgument},
+		{AccessTokensCreateOp{User: alice, Name: "ci", Scope: []string{ReadScope}}, codes.AlreadyExists},
+		{AccessTokensCreateOp{User: UserSpec{UID: 2}, Name: "ci", Scope: []string{ReadScope}}, codes.OK},
+	}
+	for _, test := range tests {
+		if _, err := s.Create(context.Background(), &test.op); grpc.Code(err) != test.wantCode {
+			t.Errorf("%+v: got error %v, want code %s", test.op, err, test.wantCode)
+		}
+	}
+}
+
+func TestPersonalAccessToken(t *testing.T) {
+	ctx := WithCredentials(context.Background(), PersonalAccessToken("sgp_abc"))
+	md, err := (contextCredentials{}).GetRequestMetadata(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Bearer sgp_abc"; md["authorization"] != want {
+		t.Errorf("got authorization metadata %q, want %q", md["authorization"], want)
+	}
+
+	if _, err := PersonalAccessToken("").Token(); err != ErrEmptyPersonalAccessToken {
+		t.Errorf("got error %v, want %v", err, ErrEmptyPersonalAccessToken)
+	}
+}
@@ -0,0 +1,264 @@
+package sourcegraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+// AuditedMethods are the administrative and security-sensitive RPC
+// methods whose calls are recorded by the Audited*Server wrappers (if
+// the AuditLogger's Methods is nil).
+var AuditedMethods = map[string]bool{
+	"AccessTokens.Create":                  true,
+	"AccessTokens.Revoke":                  true,
+	"Accounts.RequestPasswordReset":        true,
+	"Accounts.ResetPassword":               true,
+	"MirroredRepoSSHKeys.Create":           true,
+	"MirroredRepoSSHKeys.Delete":           true,
+	"MirroredRepoSSHKeys.Get":              true,
+	"RegisteredClients.Create":             true,
+	"RegisteredClients.Delete":             true,
+	"RegisteredClients.SetUserPermissions": true,
+	"RegisteredClients.Update":             true,
+	"Repos.Delete":                         true,
+	"Repos.Disable":                        true,
+	"Repos.Enable":                         true,
+	"UserKeys.AddKey":                      true,
+	"UserKeys.DeleteKey":                   true,
+}
+
+// redactedFields are the (JSON) field names of requests whose values
+// are secret and are redacted in audit event targets.
+var redactedFields = map[string]bool{
+	"access_token":  true,
+	"assertion":     true,
+	"client_secret": true,
+	"pass":          true,
+	"password":      true,
+	"pem":           true, // SSHPrivateKey
+	"refresh_token": true,
+	"token":         true,
+}
+
+// redactedValue replaces the values of redacted fields.
+const redactedValue = "REDACTED"
+
+// An AuditEventStore stores audit events. It is also an
+// AuditLogServer, so it may be registered with a gRPC server to serve
+// the AuditLog service.
+type AuditEventStore interface {
+	// Add records e, assigning its ID.
+	Add(ctx context.Context, e *AuditEvent) error
+
+	// List lists the events that match opt, most recent first. It
+	// returns the page of events specified by opt.ListOptions, and the
+	// total number of matching events.
+	List(ctx context.Context, opt *AuditEventListOptions) (*AuditEventList, error)
+}
+
+// An AuditLogger records calls to audited methods (made through the
+// Audited*Server wrappers) in an AuditEventStore.
+type AuditLogger struct {
+	// Store stores the recorded events.
+	Store AuditEventStore
+
+	// Identify returns the authenticated client and user of the
+	// request in ctx (the event's actor).
+	Identify func(ctx context.Context) (*AuthInfo, error)
+
+	// Methods is the set of RPC methods (e.g., "Repos.Delete") whose
+	// calls are recorded. If nil, AuditedMethods is used.
+	Methods map[string]bool
+}
+
+// record records a call to method with arg, which returned err, if
+// method is audited. Failures to record the event are logged (the
+// call has already been performed). If l is nil, nothing is recorded.
+func (l *AuditLogger) record(ctx context.Context, method string, arg interface{}, err error) {
+	if l == nil {
+		return
+	}
+	methods := l.Methods
+	if methods == nil {
+		methods = AuditedMethods
+	}
+	if !methods[method] {
+		return
+	}
+
+	e := &AuditEvent{
+		Method: method,
+		Target: auditTarget(arg),
+		Time:   pbtypes.NewTimestamp(time.Now()),
+		Result: grpc.Code(err).String(),
+	}
+	if l.Identify != nil {
+		a, err := l.Identify(ctx)
+		if err != nil {
+			log.Printf("Warning: identifying actor of audited call to %s: %s", method, err)
+		} else if a != nil {
+			e.Actor = UserSpec{UID: a.UID, Login: a.Login, Domain: a.Domain}
+			e.ClientID = a.ClientID
+		}
+	}
+	if err := l.Store.Add(ctx, e); err != nil {
+		log.Printf("Warning: recording audit event for call to %s: %s", method, err)
+	}
+}
+
+// auditTarget returns the target of an audit event for a request: the
+// URI of the repository it refers to, or else the request encoded as
+// JSON with secrets redacted.
+func auditTarget(arg interface{}) string {
+	if uri := requestRepoURI(arg); uri != "" {
+		return uri
+	}
+	data, err := redactedJSON(arg)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// redactedJSON returns the JSON encoding of v, with the values of
+// redactedFields (at any depth) replaced.
+func redactedJSON(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var tree interface{}
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	redact(tree)
+	return json.Marshal(tree)
+}
+
+func redact(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range v {
+			if redactedFields[k] {
+				v[k] = redactedValue
+			} else {
+				redact(fv)
+			}
+		}
+	case []interface{}:
+		for _, ev := range v {
+			redact(ev)
+		}
+	}
+}
+
+// MemoryAuditEventStore is an in-memory AuditEventStore.
+type MemoryAuditEventStore struct {
+	mu     sync.Mutex
+	events []*AuditEvent // in the order they were added
+}
+
+// Add implements AuditEventStore.
+func (s *MemoryAuditEventStore) Add(ctx context.Context, e *AuditEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cpy := *e
+	cpy.ID = int64(len(s.events) + 1)
+	s.events = append(s.events, &cpy)
+	e.ID = cpy.ID
+	return nil
+}
+
+// List implements AuditEventStore (and AuditLogServer).
+func (s *MemoryAuditEventStore) List(ctx context.Context, opt *AuditEventListOptions) (*AuditEventList, error) {
+	s.mu.Lock()
+	var matches []*AuditEvent
+	for _, e := range s.events {
+		if opt.matches(e) {
+			cpy := *e
+			matches = append(matches, &cpy)
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Sort(auditEventsByTime(matches))
+	list := &AuditEventList{ListResponse: ListResponse{Total: int32(len(matches))}}
+	if offset := opt.Offset(); offset < len(matches) {
+		matches = matches[offset:]
+		if limit := opt.Limit(); limit < len(matches) {
+			matches = matches[:limit]
+		}
+		list.Events = matches
+	}
+	return list, nil
+}
+
+// matches reports whether e matches the criteria in opt.
+func (opt *AuditEventListOptions) matches(e *AuditEvent) bool {
+	switch {
+	case opt.Method != "" && e.Method != opt.Method:
+		return false
+	case opt.Actor.UID != 0 && e.Actor.UID != opt.Actor.UID:
+		return false
+	case opt.Actor.UID == 0 && opt.Actor.Login != "" && e.Actor.Login != opt.Actor.Login:
+		return false
+	case opt.ClientID != "" && e.ClientID != opt.ClientID:
+		return false
+	case opt.Target != "" && e.Target != opt.Target:
+		return false
+	case opt.Since != nil && e.Time.Time().Before(opt.Since.Time()):
+		return false
+	case opt.Until != nil && !e.Time.Time().Before(opt.Until.Time()):
+		return false
+	}
+	return true
+}
+
+// auditEventsByTime sorts events, most recent first.
+type auditEventsByTime []*AuditEvent
+
+func (v auditEventsByTime) Len() int      { return len(v) }
+func (v auditEventsByTime) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
+func (v auditEventsByTime) Less(i, j int) bool {
+	ti, tj := v[i].Time.Time(), v[j].Time.Time()
+	if !ti.Equal(tj) {
+		return ti.After(tj)
+	}
+	return v[i].ID > v[j].ID
+}
+
+// ExportAuditLog writes the audit events that match opt (starting at
+// opt's page, and continuing through all subsequent pages) to w as
+// JSON lines (one JSON-encoded AuditEvent per line), most recent
+// first.
+func ExportAuditLog(ctx context.Context, c AuditLogClient, opt AuditEventListOptions, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	opt.Page = int32(opt.PageOrDefault())
+	opt.PerPage = int32(opt.PerPageOrDefault())
+	for {
+		list, err := c.List(ctx, &opt)
+		if err != nil {
+			return err
+		}
+		for _, e := range list.Events {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		if len(list.Events) < opt.PerPageOrDefault() || opt.Offset()+len(list.Events) >= int(list.Total) {
+			return nil
+		}
+		opt.Page++
+	}
+}
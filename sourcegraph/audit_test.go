@@ -0,0 +1,203 @@
+package sourcegraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+func TestAuditedServer(t *testing.T) {
+	store := &MemoryAuditEventStore{}
+	l := &AuditLogger{
+		Store: store,
+		Identify: func(ctx context.Context) (*AuthInfo, error) {
+			return &AuthInfo{ClientID: "c", UID: 1, Login: "alice"}, nil
+		},
+	}
+	s := &AuditedReposServer{ReposServer: &testReposServer{}, Log: l}
+	ctx := context.Background()
+
+	if _, err := s.Get(ctx, &RepoSpec{URI: "r"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Delete(ctx, &RepoSpec{URI: "r"}); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := store.List(ctx, &AuditEventListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Events) != 1 {
+		t.Fatalf("got %d events, want 1 (only Repos.Delete is audited)", len(list.Events))
+	}
+	e := list.Events[0]
+	e.Time = pbtypes.Timestamp{}
+	want := &AuditEvent{
+		ID:       1,
+		Actor:    UserSpec{UID: 1, Login: "alice"},
+		ClientID: "c",
+		Method:   "Repos.Delete",
+		Target:   "r",
+		Result:   codes.OK.String(),
+	}
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("got event %+v, want %+v", e, want)
+	}
+
+	// A nil AuditLogger records nothing.
+	s.Log = nil
+	if _, err := s.Delete(ctx, &RepoSpec{URI: "r"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuditedServer_stream(t *testing.T) {
+	store := &MemoryAuditEventStore{}
+	l := &AuditLogger{Store: store, Methods: map[string]bool{"Search.StreamSearch": true}}
+	s := &AuditedSearchServer{SearchServer: &testSearchServer{}, Log: l}
+	stream := &testStreamSearchServer{testServerStream{ctx: context.Background()}}
+	if err := s.StreamSearch(&SearchOptions{}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := store.List(context.Background(), &AuditEventListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Events) != 1 || list.Events[0].Method != "Search.StreamSearch" || list.Events[0].Result != codes.OK.String() {
+		t.Errorf("got events %+v, want one successful Search.StreamSearch event", list.Events)
+	}
+}
+
+func TestAuditTarget_redacted(t *testing.T) {
+	tests := []struct {
+		arg    interface{}
+		secret string
+	}{
+		{&NewPassword{Password: "p4ss", Token: &PasswordResetToken{Token: "t0k"}}, "p4ss"},
+		{&NewPassword{Password: "p4ss", Token: &PasswordResetToken{Token: "t0k"}}, "t0k"},
+		{&RegisteredClient{ID: "c", ClientSecret: "s3cret"}, "s3cret"},
+	}
+	for _, test := range tests {
+		target := auditTarget(test.arg)
+		if target == "" || strings.Contains(target, test.secret) {
+			t.Errorf("%+v: got target %q, want %q redacted", test.arg, target, test.secret)
+		}
+		if !strings.Contains(target, redactedValue) {
+			t.Errorf("%+v: got target %q, want it to contain %q", test.arg, target, redactedValue)
+		}
+	}
+	if target := auditTarget(&RegisteredClient{ID: "c"}); !strings.Contains(target, `"id":"c"`) {
+		t.Errorf("got target %q, want it to contain the client ID", target)
+	}
+}
+
+func newTestAuditEventStore(t *testing.T, start time.Time, events ...AuditEvent) *MemoryAuditEventStore {
+	s := &MemoryAuditEventStore{}
+	for i, e := range events {
+		e.Time = pbtypes.NewTimestamp(start.Add(time.Duration(i) * time.Minute))
+		if err := s.Add(context.Background(), &e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+func TestMemoryAuditEventStore_List(t *testing.T) {
+	start := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := newTestAuditEventStore(t, start,
+		AuditEvent{Method: "Repos.Delete", Actor: UserSpec{UID: 1, Login: "alice"}, ClientID: "c", Target: "r1"},
+		AuditEvent{Method: "Repos.Delete", Actor: UserSpec{UID: 2, Login: "bob"}, ClientID: "c", Target: "r2"},
+		AuditEvent{Method: "Repos.Disable", Actor: UserSpec{UID: 1, Login: "alice"}, ClientID: "d", Target: "r1"},
+		AuditEvent{Method: "UserKeys.AddKey", Actor: UserSpec{UID: 2, Login: "bob"}, ClientID: "d"},
+	)
+	at := func(minutes int) *pbtypes.Timestamp {
+		ts := pbtypes.NewTimestamp(start.Add(time.Duration(minutes) * time.Minute))
+		return &ts
+	}
+
+	tests := []struct {
+		opt       AuditEventListOptions
+		wantIDs   []int64
+		wantTotal int32
+	}{
+		{AuditEventListOptions{}, []int64{4, 3, 2, 1}, 4},
+		{AuditEventListOptions{Method: "Repos.Delete"}, []int64{2, 1}, 2},
+		{AuditEventListOptions{Actor: UserSpec{UID: 1}}, []int64{3, 1}, 2},
+		{AuditEventListOptions{Actor: UserSpec{Login: "bob"}}, []int64{4, 2}, 2},
+		{AuditEventListOptions{ClientID: "d"}, []int64{4, 3}, 2},
+		{AuditEventListOptions{Target: "r1"}, []int64{3, 1}, 2},
+		{AuditEventListOptions{Since: at(1), Until: at(3)}, []int64{3, 2}, 2},
+		{AuditEventListOptions{ListOptions: ListOptions{PerPage: 3}}, []int64{4, 3, 2}, 4},
+		{AuditEventListOptions{ListOptions: ListOptions{PerPage: 3, Page: 2}}, []int64{1}, 4},
+		{AuditEventListOptions{ListOptions: ListOptions{PerPage: 3, Page: 3}}, nil, 4},
+		{AuditEventListOptions{Method: "Repos.Enable"}, nil, 0},
+	}
+	for _, test := range tests {
+		list, err := s.List(context.Background(), &test.opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []int64
+		for _, e := range list.Events {
+			ids = append(ids, e.ID)
+		}
+		if !reflect.DeepEqual(ids, test.wantIDs) {
+			t.Errorf("%+v: got IDs %v, want %v", test.opt, ids, test.wantIDs)
+		}
+		if list.Total != test.wantTotal {
+			t.Errorf("%+v: got total %d, want %d", test.opt, list.Total, test.wantTotal)
+		}
+	}
+}
+
+// testAuditLogClient is an AuditLogClient that lists events from an
+// AuditEventStore.
+type testAuditLogClient struct {
+	AuditEventStore
+	calls int
+}
+
+func (c *testAuditLogClient) List(ctx context.Context, in *AuditEventListOptions, opts ...grpc.CallOption) (*AuditEventList, error) {
+	c.calls++
+	return c.AuditEventStore.List(ctx, in)
+}
+
+func TestExportAuditLog(t *testing.T) {
+	start := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	var events []AuditEvent
+	for i := 0; i < 5; i++ {
+		events = append(events, AuditEvent{Method: "Repos.Delete"})
+	}
+	c := &testAuditLogClient{AuditEventStore: newTestAuditEventStore(t, start, events...)}
+
+	var buf bytes.Buffer
+	opt := AuditEventListOptions{ListOptions: ListOptions{PerPage: 2}}
+	if err := ExportAuditLog(context.Background(), c, opt, &buf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	var ids []int64
+	for _, line := range lines {
+		var e AuditEvent
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("line %q: %s", line, err)
+		}
+		ids = append(ids, e.ID)
+	}
+	if want := []int64{5, 4, 3, 2, 1}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("got IDs %v, want %v", ids, want)
+	}
+	if c.calls != 3 {
+		t.Errorf("got %d List calls, want 3", c.calls)
+	}
+}
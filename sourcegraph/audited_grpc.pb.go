@@ -0,0 +1,897 @@
+// GENERATED CODE - DO NOT EDIT!
+//
+// Generated by:
+//
+//   go run gen/wrappers.go -kind audit -o audited_grpc.pb.go sourcegraph.pb.go
+//
+// Called via:
+//
+//   go generate
+//
+
+package sourcegraph
+
+import (
+	"golang.org/x/net/context"
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+	"sourcegraph.com/sourcegraph/srclib/unit"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+type AuditedAccessTokensServer struct {
+	AccessTokensServer
+	Log *AuditLogger
+}
+
+func (s *AuditedAccessTokensServer) Create(ctx context.Context, in *AccessTokensCreateOp) (*NewAccessToken, error) {
+	result, err := s.AccessTokensServer.Create(ctx, in)
+	s.Log.record(ctx, "AccessTokens.Create", in, err)
+	return result, err
+}
+
+func (s *AuditedAccessTokensServer) List(ctx context.Context, in *AccessTokensListOptions) (*AccessTokenList, error) {
+	result, err := s.AccessTokensServer.List(ctx, in)
+	s.Log.record(ctx, "AccessTokens.List", in, err)
+	return result, err
+}
+
+func (s *AuditedAccessTokensServer) Revoke(ctx context.Context, in *AccessTokenSpec) (*pbtypes.Void, error) {
+	result, err := s.AccessTokensServer.Revoke(ctx, in)
+	s.Log.record(ctx, "AccessTokens.Revoke", in, err)
+	return result, err
+}
+
+type AuditedAccountsServer struct {
+	AccountsServer
+	Log *AuditLogger
+}
+
+func (s *AuditedAccountsServer) Create(ctx context.Context, in *NewAccount) (*UserSpec, error) {
+	result, err := s.AccountsServer.Create(ctx, in)
+	s.Log.record(ctx, "Accounts.Create", in, err)
+	return result, err
+}
+
+func (s *AuditedAccountsServer) RequestPasswordReset(ctx context.Context, in *EmailAddr) (*User, error) {
+	result, err := s.AccountsServer.RequestPasswordReset(ctx, in)
+	s.Log.record(ctx, "Accounts.RequestPasswordReset", in, err)
+	return result, err
+}
+
+func (s *AuditedAccountsServer) ResetPassword(ctx context.Context, in *NewPassword) (*pbtypes.Void, error) {
+	result, err := s.AccountsServer.ResetPassword(ctx, in)
+	s.Log.record(ctx, "Accounts.ResetPassword", in, err)
+	return result, err
+}
+
+func (s *AuditedAccountsServer) Update(ctx context.Context, in *User) (*pbtypes.Void, error) {
+	result, err := s.AccountsServer.Update(ctx, in)
+	s.Log.record(ctx, "Accounts.Update", in, err)
+	return result, err
+}
+
+type AuditedAuditLogServer struct {
+	AuditLogServer
+	Log *AuditLogger
+}
+
+func (s *AuditedAuditLogServer) List(ctx context.Context, in *AuditEventListOptions) (*AuditEventList, error) {
+	result, err := s.AuditLogServer.List(ctx, in)
+	s.Log.record(ctx, "AuditLog.List", in, err)
+	return result, err
+}
+
+type AuditedAuthServer struct {
+	AuthServer
+	Log *AuditLogger
+}
+
+func (s *AuditedAuthServer) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest) (*AuthorizationCode, error) {
+	result, err := s.AuthServer.GetAuthorizationCode(ctx, in)
+	s.Log.record(ctx, "Auth.GetAuthorizationCode", in, err)
+	return result, err
+}
+
+func (s *AuditedAuthServer) GetAccessToken(ctx context.Context, in *AccessTokenRequest) (*AccessTokenResponse, error) {
+	result, err := s.AuthServer.GetAccessToken(ctx, in)
+	s.Log.record(ctx, "Auth.GetAccessToken", in, err)
+	return result, err
+}
+
+func (s *AuditedAuthServer) Identify(ctx context.Context, in *pbtypes.Void) (*AuthInfo, error) {
+	result, err := s.AuthServer.Identify(ctx, in)
+	s.Log.record(ctx, "Auth.Identify", in, err)
+	return result, err
+}
+
+func (s *AuditedAuthServer) GetPermissions(ctx context.Context, in *pbtypes.Void) (*UserPermissions, error) {
+	result, err := s.AuthServer.GetPermissions(ctx, in)
+	s.Log.record(ctx, "Auth.GetPermissions", in, err)
+	return result, err
+}
+
+type AuditedBuildsServer struct {
+	BuildsServer
+	Log *AuditLogger
+}
+
+func (s *AuditedBuildsServer) Get(ctx context.Context, in *BuildSpec) (*Build, error) {
+	result, err := s.BuildsServer.Get(ctx, in)
+	s.Log.record(ctx, "Builds.Get", in, err)
+	return result, err
+}
+
+func (s *AuditedBuildsServer) GetRepoBuildInfo(ctx context.Context, in *BuildsGetRepoBuildInfoOp) (*RepoBuildInfo, error) {
+	result, err := s.BuildsServer.GetRepoBuildInfo(ctx, in)
+	s.Log.record(ctx, "Builds.GetRepoBuildInfo", in, err)
+	return result, err
+}
+
+func (s *AuditedBuildsServer) List(ctx context.Context, in *BuildListOptions) (*BuildList, error) {
+	result, err := s.BuildsServer.List(ctx, in)
+	s.Log.record(ctx, "Builds.List", in, err)
+	return result, err
+}
+
+func (s *AuditedBuildsServer) Create(ctx context.Context, in *BuildsCreateOp) (*Build, error) {
+	result, err := s.BuildsServer.Create(ctx, in)
+	s.Log.record(ctx, "Builds.Create", in, err)
+	return result, err
+}
+
+func (s *AuditedBuildsServer) Update(ctx context.Context, in *BuildsUpdateOp) (*Build, error) {
+	result, err := s.BuildsServer.Update(ctx, in)
+	s.Log.record(ctx, "Builds.Update", in, err)
+	return result, err
+}
+
+func (s *AuditedBuildsServer) ListBuildTasks(ctx context.Context, in *BuildsListBuildTasksOp) (*BuildTaskList, error) {
+	result, err := s.BuildsServer.ListBuildTasks(ctx, in)
+	s.Log.record(ctx, "Builds.ListBuildTasks", in, err)
+	return result, err
+}
+
+func (s *AuditedBuildsServer) CreateTasks(ctx context.Context, in *BuildsCreateTasksOp) (*BuildTaskList, error) {
+	result, err := s.BuildsServer.CreateTasks(ctx, in)
+	s.Log.record(ctx, "Builds.CreateTasks", in, err)
+	return result, err
+}
+
+func (s *AuditedBuildsServer) UpdateTask(ctx context.Context, in *BuildsUpdateTaskOp) (*BuildTask, error) {
+	result, err := s.BuildsServer.UpdateTask(ctx, in)
+	s.Log.record(ctx, "Builds.UpdateTask", in, err)
+	return result, err
+}
+
+func (s *AuditedBuildsServer) GetLog(ctx context.Context, in *BuildsGetLogOp) (*LogEntries, error) {
+	result, err := s.BuildsServer.GetLog(ctx, in)
+	s.Log.record(ctx, "Builds.GetLog", in, err)
+	return result, err
+}
+
+func (s *AuditedBuildsServer) GetTaskLog(ctx context.Context, in *BuildsGetTaskLogOp) (*LogEntries, error) {
+	result, err := s.BuildsServer.GetTaskLog(ctx, in)
+	s.Log.record(ctx, "Builds.GetTaskLog", in, err)
+	return result, err
+}
+
+func (s *AuditedBuildsServer) DequeueNext(ctx context.Context, in *BuildsDequeueNextOp) (*Build, error) {
+	result, err := s.BuildsServer.DequeueNext(ctx, in)
+	s.Log.record(ctx, "Builds.DequeueNext", in, err)
+	return result, err
+}
+
+type AuditedChangesetsServer struct {
+	ChangesetsServer
+	Log *AuditLogger
+}
+
+func (s *AuditedChangesetsServer) Create(ctx context.Context, in *ChangesetCreateOp) (*Changeset, error) {
+	result, err := s.ChangesetsServer.Create(ctx, in)
+	s.Log.record(ctx, "Changesets.Create", in, err)
+	return result, err
+}
+
+func (s *AuditedChangesetsServer) Get(ctx context.Context, in *ChangesetSpec) (*Changeset, error) {
+	result, err := s.ChangesetsServer.Get(ctx, in)
+	s.Log.record(ctx, "Changesets.Get", in, err)
+	return result, err
+}
+
+func (s *AuditedChangesetsServer) List(ctx context.Context, in *ChangesetListOp) (*ChangesetList, error) {
+	result, err := s.ChangesetsServer.List(ctx, in)
+	s.Log.record(ctx, "Changesets.List", in, err)
+	return result, err
+}
+
+func (s *AuditedChangesetsServer) Update(ctx context.Context, in *ChangesetUpdateOp) (*ChangesetEvent, error) {
+	result, err := s.ChangesetsServer.Update(ctx, in)
+	s.Log.record(ctx, "Changesets.Update", in, err)
+	return result, err
+}
+
+func (s *AuditedChangesetsServer) Merge(ctx context.Context, in *ChangesetMergeOp) (*ChangesetEvent, error) {
+	result, err := s.ChangesetsServer.Merge(ctx, in)
+	s.Log.record(ctx, "Changesets.Merge", in, err)
+	return result, err
+}
+
+func (s *AuditedChangesetsServer) UpdateAffected(ctx context.Context, in *ChangesetUpdateAffectedOp) (*ChangesetEventList, error) {
+	result, err := s.ChangesetsServer.UpdateAffected(ctx, in)
+	s.Log.record(ctx, "Changesets.UpdateAffected", in, err)
+	return result, err
+}
+
+func (s *AuditedChangesetsServer) CreateReview(ctx context.Context, in *ChangesetCreateReviewOp) (*ChangesetReview, error) {
+	result, err := s.ChangesetsServer.CreateReview(ctx, in)
+	s.Log.record(ctx, "Changesets.CreateReview", in, err)
+	return result, err
+}
+
+func (s *AuditedChangesetsServer) ListReviews(ctx context.Context, in *ChangesetListReviewsOp) (*ChangesetReviewList, error) {
+	result, err := s.ChangesetsServer.ListReviews(ctx, in)
+	s.Log.record(ctx, "Changesets.ListReviews", in, err)
+	return result, err
+}
+
+func (s *AuditedChangesetsServer) ListEvents(ctx context.Context, in *ChangesetSpec) (*ChangesetEventList, error) {
+	result, err := s.ChangesetsServer.ListEvents(ctx, in)
+	s.Log.record(ctx, "Changesets.ListEvents", in, err)
+	return result, err
+}
+
+type AuditedDefsServer struct {
+	DefsServer
+	Log *AuditLogger
+}
+
+func (s *AuditedDefsServer) Get(ctx context.Context, in *DefsGetOp) (*Def, error) {
+	result, err := s.DefsServer.Get(ctx, in)
+	s.Log.record(ctx, "Defs.Get", in, err)
+	return result, err
+}
+
+func (s *AuditedDefsServer) List(ctx context.Context, in *DefListOptions) (*DefList, error) {
+	result, err := s.DefsServer.List(ctx, in)
+	s.Log.record(ctx, "Defs.List", in, err)
+	return result, err
+}
+
+func (s *AuditedDefsServer) ListRefs(ctx context.Context, in *DefsListRefsOp) (*RefList, error) {
+	result, err := s.DefsServer.ListRefs(ctx, in)
+	s.Log.record(ctx, "Defs.ListRefs", in, err)
+	return result, err
+}
+
+func (s *AuditedDefsServer) ListExamples(ctx context.Context, in *DefsListExamplesOp) (*ExampleList, error) {
+	result, err := s.DefsServer.ListExamples(ctx, in)
+	s.Log.record(ctx, "Defs.ListExamples", in, err)
+	return result, err
+}
+
+func (s *AuditedDefsServer) ListAuthors(ctx context.Context, in *DefsListAuthorsOp) (*DefAuthorList, error) {
+	result, err := s.DefsServer.ListAuthors(ctx, in)
+	s.Log.record(ctx, "Defs.ListAuthors", in, err)
+	return result, err
+}
+
+func (s *AuditedDefsServer) ListClients(ctx context.Context, in *DefsListClientsOp) (*DefClientList, error) {
+	result, err := s.DefsServer.ListClients(ctx, in)
+	s.Log.record(ctx, "Defs.ListClients", in, err)
+	return result, err
+}
+
+type AuditedDeltasServer struct {
+	DeltasServer
+	Log *AuditLogger
+}
+
+func (s *AuditedDeltasServer) Get(ctx context.Context, in *DeltaSpec) (*Delta, error) {
+	result, err := s.DeltasServer.Get(ctx, in)
+	s.Log.record(ctx, "Deltas.Get", in, err)
+	return result, err
+}
+
+func (s *AuditedDeltasServer) ListUnits(ctx context.Context, in *DeltasListUnitsOp) (*UnitDeltaList, error) {
+	result, err := s.DeltasServer.ListUnits(ctx, in)
+	s.Log.record(ctx, "Deltas.ListUnits", in, err)
+	return result, err
+}
+
+func (s *AuditedDeltasServer) ListDefs(ctx context.Context, in *DeltasListDefsOp) (*DeltaDefs, error) {
+	result, err := s.DeltasServer.ListDefs(ctx, in)
+	s.Log.record(ctx, "Deltas.ListDefs", in, err)
+	return result, err
+}
+
+func (s *AuditedDeltasServer) ListFiles(ctx context.Context, in *DeltasListFilesOp) (*DeltaFiles, error) {
+	result, err := s.DeltasServer.ListFiles(ctx, in)
+	s.Log.record(ctx, "Deltas.ListFiles", in, err)
+	return result, err
+}
+
+func (s *AuditedDeltasServer) ListAffectedAuthors(ctx context.Context, in *DeltasListAffectedAuthorsOp) (*DeltaAffectedPersonList, error) {
+	result, err := s.DeltasServer.ListAffectedAuthors(ctx, in)
+	s.Log.record(ctx, "Deltas.ListAffectedAuthors", in, err)
+	return result, err
+}
+
+func (s *AuditedDeltasServer) ListAffectedClients(ctx context.Context, in *DeltasListAffectedClientsOp) (*DeltaAffectedPersonList, error) {
+	result, err := s.DeltasServer.ListAffectedClients(ctx, in)
+	s.Log.record(ctx, "Deltas.ListAffectedClients", in, err)
+	return result, err
+}
+
+type AuditedDiscussionsServer struct {
+	DiscussionsServer
+	Log *AuditLogger
+}
+
+func (s *AuditedDiscussionsServer) Create(ctx context.Context, in *Discussion) (*Discussion, error) {
+	result, err := s.DiscussionsServer.Create(ctx, in)
+	s.Log.record(ctx, "Discussions.Create", in, err)
+	return result, err
+}
+
+func (s *AuditedDiscussionsServer) Get(ctx context.Context, in *DiscussionSpec) (*Discussion, error) {
+	result, err := s.DiscussionsServer.Get(ctx, in)
+	s.Log.record(ctx, "Discussions.Get", in, err)
+	return result, err
+}
+
+func (s *AuditedDiscussionsServer) List(ctx context.Context, in *DiscussionListOp) (*DiscussionList, error) {
+	result, err := s.DiscussionsServer.List(ctx, in)
+	s.Log.record(ctx, "Discussions.List", in, err)
+	return result, err
+}
+
+func (s *AuditedDiscussionsServer) CreateComment(ctx context.Context, in *DiscussionCommentCreateOp) (*DiscussionComment, error) {
+	result, err := s.DiscussionsServer.CreateComment(ctx, in)
+	s.Log.record(ctx, "Discussions.CreateComment", in, err)
+	return result, err
+}
+
+func (s *AuditedDiscussionsServer) UpdateRating(ctx context.Context, in *DiscussionRatingUpdateOp) (*pbtypes.Void, error) {
+	result, err := s.DiscussionsServer.UpdateRating(ctx, in)
+	s.Log.record(ctx, "Discussions.UpdateRating", in, err)
+	return result, err
+}
+
+type AuditedGraphUplinkServer struct {
+	GraphUplinkServer
+	Log *AuditLogger
+}
+
+func (s *AuditedGraphUplinkServer) Push(ctx context.Context, in *MetricsSnapshot) (*pbtypes.Void, error) {
+	result, err := s.GraphUplinkServer.Push(ctx, in)
+	s.Log.record(ctx, "GraphUplink.Push", in, err)
+	return result, err
+}
+
+func (s *AuditedGraphUplinkServer) PushEvents(ctx context.Context, in *UserEventList) (*pbtypes.Void, error) {
+	result, err := s.GraphUplinkServer.PushEvents(ctx, in)
+	s.Log.record(ctx, "GraphUplink.PushEvents", in, err)
+	return result, err
+}
+
+type AuditedMarkdownServer struct {
+	MarkdownServer
+	Log *AuditLogger
+}
+
+func (s *AuditedMarkdownServer) Render(ctx context.Context, in *MarkdownRenderOp) (*MarkdownData, error) {
+	result, err := s.MarkdownServer.Render(ctx, in)
+	s.Log.record(ctx, "Markdown.Render", in, err)
+	return result, err
+}
+
+type AuditedMetaServer struct {
+	MetaServer
+	Log *AuditLogger
+}
+
+func (s *AuditedMetaServer) Status(ctx context.Context, in *pbtypes.Void) (*ServerStatus, error) {
+	result, err := s.MetaServer.Status(ctx, in)
+	s.Log.record(ctx, "Meta.Status", in, err)
+	return result, err
+}
+
+func (s *AuditedMetaServer) Config(ctx context.Context, in *pbtypes.Void) (*ServerConfig, error) {
+	result, err := s.MetaServer.Config(ctx, in)
+	s.Log.record(ctx, "Meta.Config", in, err)
+	return result, err
+}
+
+func (s *AuditedMetaServer) PubKey(ctx context.Context, in *pbtypes.Void) (*ServerPubKey, error) {
+	result, err := s.MetaServer.PubKey(ctx, in)
+	s.Log.record(ctx, "Meta.PubKey", in, err)
+	return result, err
+}
+
+type AuditedMirrorReposServer struct {
+	MirrorReposServer
+	Log *AuditLogger
+}
+
+func (s *AuditedMirrorReposServer) RefreshVCS(ctx context.Context, in *MirrorReposRefreshVCSOp) (*pbtypes.Void, error) {
+	result, err := s.MirrorReposServer.RefreshVCS(ctx, in)
+	s.Log.record(ctx, "MirrorRepos.RefreshVCS", in, err)
+	return result, err
+}
+
+type AuditedMirroredRepoSSHKeysServer struct {
+	MirroredRepoSSHKeysServer
+	Log *AuditLogger
+}
+
+func (s *AuditedMirroredRepoSSHKeysServer) Create(ctx context.Context, in *MirroredRepoSSHKeysCreateOp) (*pbtypes.Void, error) {
+	result, err := s.MirroredRepoSSHKeysServer.Create(ctx, in)
+	s.Log.record(ctx, "MirroredRepoSSHKeys.Create", in, err)
+	return result, err
+}
+
+func (s *AuditedMirroredRepoSSHKeysServer) Get(ctx context.Context, in *RepoSpec) (*SSHPrivateKey, error) {
+	result, err := s.MirroredRepoSSHKeysServer.Get(ctx, in)
+	s.Log.record(ctx, "MirroredRepoSSHKeys.Get", in, err)
+	return result, err
+}
+
+func (s *AuditedMirroredRepoSSHKeysServer) Delete(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	result, err := s.MirroredRepoSSHKeysServer.Delete(ctx, in)
+	s.Log.record(ctx, "MirroredRepoSSHKeys.Delete", in, err)
+	return result, err
+}
+
+type AuditedNotifyServer struct {
+	NotifyServer
+	Log *AuditLogger
+}
+
+func (s *AuditedNotifyServer) GenericEvent(ctx context.Context, in *NotifyGenericEvent) (*pbtypes.Void, error) {
+	result, err := s.NotifyServer.GenericEvent(ctx, in)
+	s.Log.record(ctx, "Notify.GenericEvent", in, err)
+	return result, err
+}
+
+type AuditedOrgsServer struct {
+	OrgsServer
+	Log *AuditLogger
+}
+
+func (s *AuditedOrgsServer) Get(ctx context.Context, in *OrgSpec) (*Org, error) {
+	result, err := s.OrgsServer.Get(ctx, in)
+	s.Log.record(ctx, "Orgs.Get", in, err)
+	return result, err
+}
+
+func (s *AuditedOrgsServer) List(ctx context.Context, in *OrgsListOp) (*OrgList, error) {
+	result, err := s.OrgsServer.List(ctx, in)
+	s.Log.record(ctx, "Orgs.List", in, err)
+	return result, err
+}
+
+func (s *AuditedOrgsServer) ListMembers(ctx context.Context, in *OrgsListMembersOp) (*UserList, error) {
+	result, err := s.OrgsServer.ListMembers(ctx, in)
+	s.Log.record(ctx, "Orgs.ListMembers", in, err)
+	return result, err
+}
+
+type AuditedPeopleServer struct {
+	PeopleServer
+	Log *AuditLogger
+}
+
+func (s *AuditedPeopleServer) Get(ctx context.Context, in *PersonSpec) (*Person, error) {
+	result, err := s.PeopleServer.Get(ctx, in)
+	s.Log.record(ctx, "People.Get", in, err)
+	return result, err
+}
+
+type AuditedRegisteredClientsServer struct {
+	RegisteredClientsServer
+	Log *AuditLogger
+}
+
+func (s *AuditedRegisteredClientsServer) Get(ctx context.Context, in *RegisteredClientSpec) (*RegisteredClient, error) {
+	result, err := s.RegisteredClientsServer.Get(ctx, in)
+	s.Log.record(ctx, "RegisteredClients.Get", in, err)
+	return result, err
+}
+
+func (s *AuditedRegisteredClientsServer) GetCurrent(ctx context.Context, in *pbtypes.Void) (*RegisteredClient, error) {
+	result, err := s.RegisteredClientsServer.GetCurrent(ctx, in)
+	s.Log.record(ctx, "RegisteredClients.GetCurrent", in, err)
+	return result, err
+}
+
+func (s *AuditedRegisteredClientsServer) Create(ctx context.Context, in *RegisteredClient) (*RegisteredClient, error) {
+	result, err := s.RegisteredClientsServer.Create(ctx, in)
+	s.Log.record(ctx, "RegisteredClients.Create", in, err)
+	return result, err
+}
+
+func (s *AuditedRegisteredClientsServer) Update(ctx context.Context, in *RegisteredClient) (*pbtypes.Void, error) {
+	result, err := s.RegisteredClientsServer.Update(ctx, in)
+	s.Log.record(ctx, "RegisteredClients.Update", in, err)
+	return result, err
+}
+
+func (s *AuditedRegisteredClientsServer) Delete(ctx context.Context, in *RegisteredClientSpec) (*pbtypes.Void, error) {
+	result, err := s.RegisteredClientsServer.Delete(ctx, in)
+	s.Log.record(ctx, "RegisteredClients.Delete", in, err)
+	return result, err
+}
+
+func (s *AuditedRegisteredClientsServer) List(ctx context.Context, in *RegisteredClientListOptions) (*RegisteredClientList, error) {
+	result, err := s.RegisteredClientsServer.List(ctx, in)
+	s.Log.record(ctx, "RegisteredClients.List", in, err)
+	return result, err
+}
+
+func (s *AuditedRegisteredClientsServer) GetUserPermissions(ctx context.Context, in *UserPermissionsOptions) (*UserPermissions, error) {
+	result, err := s.RegisteredClientsServer.GetUserPermissions(ctx, in)
+	s.Log.record(ctx, "RegisteredClients.GetUserPermissions", in, err)
+	return result, err
+}
+
+func (s *AuditedRegisteredClientsServer) SetUserPermissions(ctx context.Context, in *UserPermissions) (*pbtypes.Void, error) {
+	result, err := s.RegisteredClientsServer.SetUserPermissions(ctx, in)
+	s.Log.record(ctx, "RegisteredClients.SetUserPermissions", in, err)
+	return result, err
+}
+
+func (s *AuditedRegisteredClientsServer) ListUserPermissions(ctx context.Context, in *RegisteredClientSpec) (*UserPermissionsList, error) {
+	result, err := s.RegisteredClientsServer.ListUserPermissions(ctx, in)
+	s.Log.record(ctx, "RegisteredClients.ListUserPermissions", in, err)
+	return result, err
+}
+
+type AuditedRepoBadgesServer struct {
+	RepoBadgesServer
+	Log *AuditLogger
+}
+
+func (s *AuditedRepoBadgesServer) ListBadges(ctx context.Context, in *RepoSpec) (*BadgeList, error) {
+	result, err := s.RepoBadgesServer.ListBadges(ctx, in)
+	s.Log.record(ctx, "RepoBadges.ListBadges", in, err)
+	return result, err
+}
+
+func (s *AuditedRepoBadgesServer) ListCounters(ctx context.Context, in *RepoSpec) (*CounterList, error) {
+	result, err := s.RepoBadgesServer.ListCounters(ctx, in)
+	s.Log.record(ctx, "RepoBadges.ListCounters", in, err)
+	return result, err
+}
+
+func (s *AuditedRepoBadgesServer) RecordHit(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	result, err := s.RepoBadgesServer.RecordHit(ctx, in)
+	s.Log.record(ctx, "RepoBadges.RecordHit", in, err)
+	return result, err
+}
+
+func (s *AuditedRepoBadgesServer) CountHits(ctx context.Context, in *RepoBadgesCountHitsOp) (*RepoBadgesCountHitsResult, error) {
+	result, err := s.RepoBadgesServer.CountHits(ctx, in)
+	s.Log.record(ctx, "RepoBadges.CountHits", in, err)
+	return result, err
+}
+
+type AuditedRepoStatusesServer struct {
+	RepoStatusesServer
+	Log *AuditLogger
+}
+
+func (s *AuditedRepoStatusesServer) GetCombined(ctx context.Context, in *RepoRevSpec) (*CombinedStatus, error) {
+	result, err := s.RepoStatusesServer.GetCombined(ctx, in)
+	s.Log.record(ctx, "RepoStatuses.GetCombined", in, err)
+	return result, err
+}
+
+func (s *AuditedRepoStatusesServer) Create(ctx context.Context, in *RepoStatusesCreateOp) (*RepoStatus, error) {
+	result, err := s.RepoStatusesServer.Create(ctx, in)
+	s.Log.record(ctx, "RepoStatuses.Create", in, err)
+	return result, err
+}
+
+type AuditedRepoTreeServer struct {
+	RepoTreeServer
+	Log *AuditLogger
+}
+
+func (s *AuditedRepoTreeServer) Get(ctx context.Context, in *RepoTreeGetOp) (*TreeEntry, error) {
+	result, err := s.RepoTreeServer.Get(ctx, in)
+	s.Log.record(ctx, "RepoTree.Get", in, err)
+	return result, err
+}
+
+func (s *AuditedRepoTreeServer) Search(ctx context.Context, in *RepoTreeSearchOp) (*VCSSearchResultList, error) {
+	result, err := s.RepoTreeServer.Search(ctx, in)
+	s.Log.record(ctx, "RepoTree.Search", in, err)
+	return result, err
+}
+
+func (s *AuditedRepoTreeServer) List(ctx context.Context, in *RepoTreeListOp) (*RepoTreeListResult, error) {
+	result, err := s.RepoTreeServer.List(ctx, in)
+	s.Log.record(ctx, "RepoTree.List", in, err)
+	return result, err
+}
+
+type AuditedReposServer struct {
+	ReposServer
+	Log *AuditLogger
+}
+
+func (s *AuditedReposServer) Get(ctx context.Context, in *RepoSpec) (*Repo, error) {
+	result, err := s.ReposServer.Get(ctx, in)
+	s.Log.record(ctx, "Repos.Get", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) List(ctx context.Context, in *RepoListOptions) (*RepoList, error) {
+	result, err := s.ReposServer.List(ctx, in)
+	s.Log.record(ctx, "Repos.List", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) Create(ctx context.Context, in *ReposCreateOp) (*Repo, error) {
+	result, err := s.ReposServer.Create(ctx, in)
+	s.Log.record(ctx, "Repos.Create", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) Update(ctx context.Context, in *ReposUpdateOp) (*Repo, error) {
+	result, err := s.ReposServer.Update(ctx, in)
+	s.Log.record(ctx, "Repos.Update", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) Delete(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	result, err := s.ReposServer.Delete(ctx, in)
+	s.Log.record(ctx, "Repos.Delete", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) GetReadme(ctx context.Context, in *RepoRevSpec) (*Readme, error) {
+	result, err := s.ReposServer.GetReadme(ctx, in)
+	s.Log.record(ctx, "Repos.GetReadme", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) Enable(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	result, err := s.ReposServer.Enable(ctx, in)
+	s.Log.record(ctx, "Repos.Enable", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) Disable(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	result, err := s.ReposServer.Disable(ctx, in)
+	s.Log.record(ctx, "Repos.Disable", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) GetConfig(ctx context.Context, in *RepoSpec) (*RepoConfig, error) {
+	result, err := s.ReposServer.GetConfig(ctx, in)
+	s.Log.record(ctx, "Repos.GetConfig", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) GetCommit(ctx context.Context, in *RepoRevSpec) (*vcs.Commit, error) {
+	result, err := s.ReposServer.GetCommit(ctx, in)
+	s.Log.record(ctx, "Repos.GetCommit", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) ListCommits(ctx context.Context, in *ReposListCommitsOp) (*CommitList, error) {
+	result, err := s.ReposServer.ListCommits(ctx, in)
+	s.Log.record(ctx, "Repos.ListCommits", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) ListBranches(ctx context.Context, in *ReposListBranchesOp) (*BranchList, error) {
+	result, err := s.ReposServer.ListBranches(ctx, in)
+	s.Log.record(ctx, "Repos.ListBranches", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) ListTags(ctx context.Context, in *ReposListTagsOp) (*TagList, error) {
+	result, err := s.ReposServer.ListTags(ctx, in)
+	s.Log.record(ctx, "Repos.ListTags", in, err)
+	return result, err
+}
+
+func (s *AuditedReposServer) ListCommitters(ctx context.Context, in *ReposListCommittersOp) (*CommitterList, error) {
+	result, err := s.ReposServer.ListCommitters(ctx, in)
+	s.Log.record(ctx, "Repos.ListCommitters", in, err)
+	return result, err
+}
+
+type AuditedSavedSearchesServer struct {
+	SavedSearchesServer
+	Log *AuditLogger
+}
+
+func (s *AuditedSavedSearchesServer) Create(ctx context.Context, in *SavedSearchesCreateOp) (*SavedSearch, error) {
+	result, err := s.SavedSearchesServer.Create(ctx, in)
+	s.Log.record(ctx, "SavedSearches.Create", in, err)
+	return result, err
+}
+
+func (s *AuditedSavedSearchesServer) List(ctx context.Context, in *SavedSearchListOptions) (*SavedSearchList, error) {
+	result, err := s.SavedSearchesServer.List(ctx, in)
+	s.Log.record(ctx, "SavedSearches.List", in, err)
+	return result, err
+}
+
+func (s *AuditedSavedSearchesServer) Delete(ctx context.Context, in *SavedSearchSpec) (*pbtypes.Void, error) {
+	result, err := s.SavedSearchesServer.Delete(ctx, in)
+	s.Log.record(ctx, "SavedSearches.Delete", in, err)
+	return result, err
+}
+
+func (s *AuditedSavedSearchesServer) Run(ctx context.Context, in *SavedSearchSpec) (*SavedSearchRunResult, error) {
+	result, err := s.SavedSearchesServer.Run(ctx, in)
+	s.Log.record(ctx, "SavedSearches.Run", in, err)
+	return result, err
+}
+
+type AuditedSearchServer struct {
+	SearchServer
+	Log *AuditLogger
+}
+
+func (s *AuditedSearchServer) Search(ctx context.Context, in *SearchOptions) (*SearchResults, error) {
+	result, err := s.SearchServer.Search(ctx, in)
+	s.Log.record(ctx, "Search.Search", in, err)
+	return result, err
+}
+
+func (s *AuditedSearchServer) SearchTokens(ctx context.Context, in *TokenSearchOptions) (*DefList, error) {
+	result, err := s.SearchServer.SearchTokens(ctx, in)
+	s.Log.record(ctx, "Search.SearchTokens", in, err)
+	return result, err
+}
+
+func (s *AuditedSearchServer) SearchText(ctx context.Context, in *TextSearchOptions) (*VCSSearchResultList, error) {
+	result, err := s.SearchServer.SearchText(ctx, in)
+	s.Log.record(ctx, "Search.SearchText", in, err)
+	return result, err
+}
+
+func (s *AuditedSearchServer) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions) (*MultiTextSearchResults, error) {
+	result, err := s.SearchServer.SearchTextMulti(ctx, in)
+	s.Log.record(ctx, "Search.SearchTextMulti", in, err)
+	return result, err
+}
+
+func (s *AuditedSearchServer) Complete(ctx context.Context, in *RawQuery) (*Completions, error) {
+	result, err := s.SearchServer.Complete(ctx, in)
+	s.Log.record(ctx, "Search.Complete", in, err)
+	return result, err
+}
+
+func (s *AuditedSearchServer) Suggest(ctx context.Context, in *RawQuery) (*SuggestionList, error) {
+	result, err := s.SearchServer.Suggest(ctx, in)
+	s.Log.record(ctx, "Search.Suggest", in, err)
+	return result, err
+}
+
+func (s *AuditedSearchServer) StreamSearch(in *SearchOptions, stream Search_StreamSearchServer) error {
+	err := s.SearchServer.StreamSearch(in, stream)
+	s.Log.record(stream.Context(), "Search.StreamSearch", in, err)
+	return err
+}
+
+type AuditedStorageServer struct {
+	StorageServer
+	Log *AuditLogger
+}
+
+func (s *AuditedStorageServer) Create(ctx context.Context, in *StorageName) (*StorageError, error) {
+	result, err := s.StorageServer.Create(ctx, in)
+	s.Log.record(ctx, "Storage.Create", in, err)
+	return result, err
+}
+
+func (s *AuditedStorageServer) RemoveAll(ctx context.Context, in *StorageName) (*StorageError, error) {
+	result, err := s.StorageServer.RemoveAll(ctx, in)
+	s.Log.record(ctx, "Storage.RemoveAll", in, err)
+	return result, err
+}
+
+func (s *AuditedStorageServer) Read(ctx context.Context, in *StorageReadOp) (*StorageRead, error) {
+	result, err := s.StorageServer.Read(ctx, in)
+	s.Log.record(ctx, "Storage.Read", in, err)
+	return result, err
+}
+
+func (s *AuditedStorageServer) Write(ctx context.Context, in *StorageWriteOp) (*StorageWrite, error) {
+	result, err := s.StorageServer.Write(ctx, in)
+	s.Log.record(ctx, "Storage.Write", in, err)
+	return result, err
+}
+
+func (s *AuditedStorageServer) Stat(ctx context.Context, in *StorageName) (*StorageStat, error) {
+	result, err := s.StorageServer.Stat(ctx, in)
+	s.Log.record(ctx, "Storage.Stat", in, err)
+	return result, err
+}
+
+func (s *AuditedStorageServer) ReadDir(ctx context.Context, in *StorageName) (*StorageReadDir, error) {
+	result, err := s.StorageServer.ReadDir(ctx, in)
+	s.Log.record(ctx, "Storage.ReadDir", in, err)
+	return result, err
+}
+
+func (s *AuditedStorageServer) Close(ctx context.Context, in *StorageName) (*StorageError, error) {
+	result, err := s.StorageServer.Close(ctx, in)
+	s.Log.record(ctx, "Storage.Close", in, err)
+	return result, err
+}
+
+type AuditedUnitsServer struct {
+	UnitsServer
+	Log *AuditLogger
+}
+
+func (s *AuditedUnitsServer) Get(ctx context.Context, in *UnitSpec) (*unit.RepoSourceUnit, error) {
+	result, err := s.UnitsServer.Get(ctx, in)
+	s.Log.record(ctx, "Units.Get", in, err)
+	return result, err
+}
+
+func (s *AuditedUnitsServer) List(ctx context.Context, in *UnitListOptions) (*RepoSourceUnitList, error) {
+	result, err := s.UnitsServer.List(ctx, in)
+	s.Log.record(ctx, "Units.List", in, err)
+	return result, err
+}
+
+type AuditedUserKeysServer struct {
+	UserKeysServer
+	Log *AuditLogger
+}
+
+func (s *AuditedUserKeysServer) AddKey(ctx context.Context, in *SSHPublicKey) (*pbtypes.Void, error) {
+	result, err := s.UserKeysServer.AddKey(ctx, in)
+	s.Log.record(ctx, "UserKeys.AddKey", in, err)
+	return result, err
+}
+
+func (s *AuditedUserKeysServer) LookupUser(ctx context.Context, in *SSHPublicKey) (*UserSpec, error) {
+	result, err := s.UserKeysServer.LookupUser(ctx, in)
+	s.Log.record(ctx, "UserKeys.LookupUser", in, err)
+	return result, err
+}
+
+func (s *AuditedUserKeysServer) DeleteKey(ctx context.Context, in *pbtypes.Void) (*pbtypes.Void, error) {
+	result, err := s.UserKeysServer.DeleteKey(ctx, in)
+	s.Log.record(ctx, "UserKeys.DeleteKey", in, err)
+	return result, err
+}
+
+type AuditedUsersServer struct {
+	UsersServer
+	Log *AuditLogger
+}
+
+func (s *AuditedUsersServer) Get(ctx context.Context, in *UserSpec) (*User, error) {
+	result, err := s.UsersServer.Get(ctx, in)
+	s.Log.record(ctx, "Users.Get", in, err)
+	return result, err
+}
+
+func (s *AuditedUsersServer) GetWithEmail(ctx context.Context, in *EmailAddr) (*User, error) {
+	result, err := s.UsersServer.GetWithEmail(ctx, in)
+	s.Log.record(ctx, "Users.GetWithEmail", in, err)
+	return result, err
+}
+
+func (s *AuditedUsersServer) ListEmails(ctx context.Context, in *UserSpec) (*EmailAddrList, error) {
+	result, err := s.UsersServer.ListEmails(ctx, in)
+	s.Log.record(ctx, "Users.ListEmails", in, err)
+	return result, err
+}
+
+func (s *AuditedUsersServer) List(ctx context.Context, in *UsersListOptions) (*UserList, error) {
+	result, err := s.UsersServer.List(ctx, in)
+	s.Log.record(ctx, "Users.List", in, err)
+	return result, err
+}
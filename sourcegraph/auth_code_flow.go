@@ -0,0 +1,315 @@
+package sourcegraph
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+// AuthCodeFlow obtains an access token for a command-line tool using
+// the OAuth2 authorization code flow for native apps (see RFC 8252),
+// so that users don't need to paste tokens manually.
+//
+// The user is sent to the server's authorization page, where they
+// approve the request. The page obtains an authorization code (using
+// Auth.GetAuthorizationCode) and redirects the user's browser to a
+// loopback HTTP listener started by the flow. The flow then exchanges
+// the code for an access token (using Auth.GetAccessToken). The
+// request is protected by a state parameter and by PKCE (see RFC
+// 7636).
+type AuthCodeFlow struct {
+	// Client is the registered client of the command-line tool. Its
+	// RedirectURIs must include a loopback redirect URI (e.g.,
+	// "http://127.0.0.1/oauth2/callback"). If the URI has no port, the
+	// listener uses any available port.
+	Client *RegisteredClient
+
+	// Auth is used to exchange the authorization code for an access
+	// token.
+	Auth AuthClient
+
+	// AuthorizeURL is the URL of the server's authorization page.
+	AuthorizeURL string
+
+	// TokenURL is the token endpoint URL that is sent in the
+	// AccessTokenRequest (if any).
+	TokenURL string
+
+	// Scope is the requested scope of the access token.
+	Scope []string
+
+	// OpenURL is called with the authorization URL that the user must
+	// visit (e.g., to open it in a browser, or to print it). It is
+	// required.
+	OpenURL func(url string) error
+
+	// Store, if set, persists the access token obtained by the flow.
+	// Use NewStoredAuthCredentials to make API calls with the stored
+	// token (and to refresh it).
+	Store TokenStore
+}
+
+// Errors returned by AuthCodeFlow.Run.
+var (
+	ErrNoLoopbackRedirectURI = errors.New("registered client has no loopback redirect URI (such as http://127.0.0.1/callback)")
+	ErrAuthCodeStateMismatch = errors.New("authorization response state does not match the request's state")
+)
+
+// Run runs the flow and returns the access token, after saving it in
+// the flow's Store (if any). It blocks until the user's browser is
+// redirected to the loopback listener or ctx is done.
+func (f *AuthCodeFlow) Run(ctx context.Context) (*oauth2.Token, error) {
+	redirect, err := f.loopbackRedirectURI()
+	if err != nil {
+		return nil, err
+	}
+	port := redirect.Port()
+	if port == "" {
+		port = "0"
+	}
+	l, err := net.Listen("tcp", net.JoinHostPort(redirect.Hostname(), port))
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+	redirect.Host = l.Addr().String()
+	if !f.Client.ValidRedirectURI(redirect.String()) {
+		return nil, fmt.Errorf("redirect URI %s is not registered for client %q", redirect, f.Client.ID)
+	}
+
+	state, err := randomURLString()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomURLString()
+	if err != nil {
+		return nil, err
+	}
+	authURL, err := f.authCodeURL(redirect.String(), state, pkceChallengeS256(verifier))
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(redirect.Path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var res result
+		switch {
+		case subtle.ConstantTimeCompare([]byte(q.Get("state")), []byte(state)) != 1:
+			// Ignore the request (it might be forged), but keep waiting
+			// for the real redirect.
+			http.Error(w, ErrAuthCodeStateMismatch.Error(), http.StatusBadRequest)
+			return
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("authorization failed: %s %s", q.Get("error"), q.Get("error_description"))
+			http.Error(w, res.err.Error(), http.StatusForbidden)
+		case q.Get("code") == "":
+			res.err = errors.New("authorization response has no code")
+			http.Error(w, res.err.Error(), http.StatusBadRequest)
+		default:
+			res.code = q.Get("code")
+			fmt.Fprintln(w, "Authorization succeeded. You may close this window.")
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})
+	go http.Serve(l, mux)
+
+	if err := f.OpenURL(authURL); err != nil {
+		return nil, err
+	}
+
+	var res result
+	select {
+	case res = <-results:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if res.err != nil {
+		return nil, res.err
+	}
+
+	tok, err := NewAuthCredentials(ctx, f.Auth, &AccessTokenRequest{
+		AuthorizationGrant: &AccessTokenRequest_AuthorizationCode{
+			AuthorizationCode: &AuthorizationCode{
+				Code:         res.code,
+				RedirectURI:  redirect.String(),
+				CodeVerifier: verifier,
+			},
+		},
+		TokenURL: f.TokenURL,
+		Scope:    f.Scope,
+	}).Token()
+	if err != nil {
+		return nil, err
+	}
+	if f.Store != nil {
+		if err := f.Store.SaveToken(tok); err != nil {
+			return nil, err
+		}
+	}
+	return tok, nil
+}
+
+// loopbackRedirectURI returns the first of the client's loopback
+// redirect URIs.
+func (f *AuthCodeFlow) loopbackRedirectURI() (*url.URL, error) {
+	for _, uri := range f.Client.RedirectURIs {
+		u, err := url.Parse(uri)
+		if err == nil && isLoopbackURL(u) {
+			if u.Path == "" {
+				u.Path = "/"
+			}
+			return u, nil
+		}
+	}
+	return nil, ErrNoLoopbackRedirectURI
+}
+
+// authCodeURL returns the URL of the authorization page for the
+// request.
+func (f *AuthCodeFlow) authCodeURL(redirectURI, state, codeChallenge string) (string, error) {
+	u, err := url.Parse(f.AuthorizeURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", f.Client.ID)
+	q.Set("redirect_uri", redirectURI)
+	if len(f.Scope) > 0 {
+		q.Set("scope", strings.Join(f.Scope, " "))
+	}
+	q.Set("state", state)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// randomURLString returns a random URL-safe string with 256 bits of
+// entropy, suitable for use as a state or PKCE code verifier.
+func randomURLString() (string, error) {
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return b64url.EncodeToString(b[:]), nil
+}
+
+func pkceChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return b64url.EncodeToString(sum[:])
+}
+
+// VerifyCodeVerifier reports whether the PKCE code verifier of an
+// AuthorizationCode grant matches the code challenge of the
+// authorization request that the code was issued for. If the request
+// had no code challenge, it reports true.
+func (r *AuthorizationCodeRequest) VerifyCodeVerifier(verifier string) bool {
+	var challenge string
+	switch r.CodeChallengeMethod {
+	case "", "plain":
+		if r.CodeChallenge == "" {
+			return true
+		}
+		challenge = verifier
+	case "S256":
+		challenge = pkceChallengeS256(verifier)
+	default:
+		return false
+	}
+	return verifier != "" && subtle.ConstantTimeCompare([]byte(challenge), []byte(r.CodeChallenge)) == 1
+}
+
+// A TokenStore persists an access token (e.g., between runs of a
+// command-line tool). It is also Credentials whose Token method
+// returns the stored token. The stored token may have expired if it
+// has a refresh token, so use NewStoredAuthCredentials (which
+// refreshes the token) instead of passing a TokenStore to
+// WithCredentials directly.
+type TokenStore interface {
+	Credentials
+
+	// SaveToken stores tok, replacing the stored token (if any).
+	SaveToken(tok *oauth2.Token) error
+}
+
+// ErrNoStoredToken is returned by a TokenStore's Token method when no
+// usable token is stored (e.g., if the stored token has expired and
+// can't be refreshed).
+var ErrNoStoredToken = errors.New("no stored access token (log in to obtain one)")
+
+// FileTokenStore is a TokenStore that stores the token in a JSON file
+// that only the current user may read.
+type FileTokenStore struct {
+	Path string
+}
+
+// Token implements oauth2.TokenSource. It returns the stored token if
+// it is valid or may be refreshed (i.e., it has a refresh token), or
+// else ErrNoStoredToken.
+func (s *FileTokenStore) Token() (*oauth2.Token, error) {
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, ErrNoStoredToken
+	} else if err != nil {
+		return nil, err
+	}
+	var tok oauth2.Token
+	if err := json.Unmarshal(data, &tok); err != nil {
+		return nil, fmt.Errorf("invalid stored access token in %s: %s", s.Path, err)
+	}
+	if !tok.Valid() && tok.RefreshToken == "" {
+		return nil, ErrNoStoredToken
+	}
+	return &tok, nil
+}
+
+// SaveToken implements TokenStore.
+func (s *FileTokenStore) SaveToken(tok *oauth2.Token) error {
+	data, err := json.Marshal(tok)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(s.Path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := f.Chmod(0600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.Path)
+}
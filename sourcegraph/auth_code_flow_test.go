@@ -0,0 +1,301 @@
+package sourcegraph
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// testAuthCodeServer issues authorization codes and exchanges them
+// for access tokens, checking the redirect URI and PKCE code verifier.
+type testAuthCodeServer struct {
+	AuthClient
+
+	mu    sync.Mutex
+	codes map[string]*AuthorizationCodeRequest
+}
+
+func (s *testAuthCodeServer) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest, opts ...grpc.CallOption) (*AuthorizationCode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.codes == nil {
+		s.codes = map[string]*AuthorizationCodeRequest{}
+	}
+	code := fmt.Sprintf("code%d", len(s.codes)+1)
+	s.codes[code] = in
+	return &AuthorizationCode{Code: code, RedirectURI: in.RedirectURI}, nil
+}
+
+func (s *testAuthCodeServer) GetAccessToken(ctx context.Context, in *AccessTokenRequest, opts ...grpc.CallOption) (*AccessTokenResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	grant := in.GetAuthorizationCode()
+	if grant == nil {
+		return nil, grpc.Errorf(codes.InvalidArgument, "not an authorization code grant")
+	}
+	req, ok := s.codes[grant.Code]
+	if !ok {
+		return nil, grpc.Errorf(codes.PermissionDenied, "invalid code")
+	}
+	delete(s.codes, grant.Code)
+	if grant.RedirectURI != req.RedirectURI {
+		return nil, grpc.Errorf(codes.PermissionDenied, "redirect URI mismatch")
+	}
+	if !req.VerifyCodeVerifier(grant.CodeVerifier) {
+		return nil, grpc.Errorf(codes.PermissionDenied, "invalid code verifier")
+	}
+	return &AccessTokenResponse{AccessToken: "t-" + grant.Code, TokenType: "Bearer", ExpiresInSec: 3600, Scope: req.Scope}, nil
+}
+
+// newTestAuthorizePage returns a test server for an authorization
+// page that obtains an authorization code from auth (as if the user
+// approved the request) and redirects to the request's redirect URI.
+// If deny is true, it redirects with an access_denied error.
+func newTestAuthorizePage(auth AuthClient, deny bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		redirect := q.Get("redirect_uri") + "?state=" + url.QueryEscape(q.Get("state"))
+		if deny {
+			http.Redirect(w, r, redirect+"&error=access_denied", http.StatusFound)
+			return
+		}
+		code, err := auth.GetAuthorizationCode(context.Background(), &AuthorizationCodeRequest{
+			ResponseType:        q.Get("response_type"),
+			ClientID:            q.Get("client_id"),
+			RedirectURI:         q.Get("redirect_uri"),
+			Scope:               strings.Fields(q.Get("scope")),
+			CodeChallenge:       q.Get("code_challenge"),
+			CodeChallengeMethod: q.Get("code_challenge_method"),
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		http.Redirect(w, r, redirect+"&code="+url.QueryEscape(code.Code), http.StatusFound)
+	}))
+}
+
+// visitURL simulates the user's browser visiting u.
+func visitURL(u string) error {
+	go func() {
+		resp, err := http.Get(u)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	return nil
+}
+
+func newTestAuthCodeFlow(t *testing.T, deny bool) (*AuthCodeFlow, *testAuthCodeServer, func()) {
+	dir, err := ioutil.TempDir("", "auth-code-flow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := &testAuthCodeServer{}
+	page := newTestAuthorizePage(auth, deny)
+	flow := &AuthCodeFlow{
+		Client:       &RegisteredClient{ID: "cli", RedirectURIs: []string{"https://example.com/callback", "http://127.0.0.1/oauth2/callback"}},
+		Auth:         auth,
+		AuthorizeURL: page.URL + "/login/oauth/authorize",
+		Scope:        []string{"read", "write"},
+		OpenURL:      visitURL,
+		Store:        &FileTokenStore{Path: filepath.Join(dir, "token.json")},
+	}
+	return flow, auth, func() {
+		page.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestAuthCodeFlow(t *testing.T) {
+	flow, auth, done := newTestAuthCodeFlow(t, false)
+	defer done()
+
+	var authURL string
+	flow.OpenURL = func(u string) error {
+		authURL = u
+		return visitURL(u)
+	}
+	tok, err := flow.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "t-code1"; tok.AccessToken != want {
+		t.Errorf("got access token %q, want %q", tok.AccessToken, want)
+	}
+	if len(auth.codes) != 0 {
+		t.Errorf("got %d unexchanged codes, want 0", len(auth.codes))
+	}
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if redirectURI := q.Get("redirect_uri"); !strings.HasPrefix(redirectURI, "http://127.0.0.1:") || !strings.HasSuffix(redirectURI, "/oauth2/callback") {
+		t.Errorf("got redirect_uri %q, want the loopback listener's URI", redirectURI)
+	}
+	if q.Get("state") == "" || q.Get("code_challenge") == "" || q.Get("code_challenge_method") != "S256" {
+		t.Errorf("got authorization URL %s, want state and S256 code challenge", authURL)
+	}
+	if q.Get("client_id") != "cli" || q.Get("scope") != "read write" {
+		t.Errorf("got authorization URL %s, want client ID and scope", authURL)
+	}
+
+	// The token was persisted and may be used as Credentials.
+	var cred Credentials = flow.Store
+	stored, err := cred.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.AccessToken != tok.AccessToken {
+		t.Errorf("got stored access token %q, want %q", stored.AccessToken, tok.AccessToken)
+	}
+}
+
+func TestAuthCodeFlow_denied(t *testing.T) {
+	flow, _, done := newTestAuthCodeFlow(t, true)
+	defer done()
+
+	if _, err := flow.Run(context.Background()); err == nil || !strings.Contains(err.Error(), "access_denied") {
+		t.Errorf("got error %v, want access_denied", err)
+	}
+	if _, err := flow.Store.Token(); err != ErrNoStoredToken {
+		t.Errorf("got error %v, want %v", err, ErrNoStoredToken)
+	}
+}
+
+func TestAuthCodeFlow_forgedState(t *testing.T) {
+	flow, _, done := newTestAuthCodeFlow(t, false)
+	defer done()
+
+	flow.OpenURL = func(u string) error {
+		redirectURI, err := url.Parse(u)
+		if err != nil {
+			return err
+		}
+		// A forged redirect (e.g., from a malicious page) must be
+		// rejected without ending the flow.
+		resp, err := http.Get(redirectURI.Query().Get("redirect_uri") + "?state=forged&code=forged")
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("forged redirect: got HTTP status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+		}
+		return visitURL(u)
+	}
+	tok, err := flow.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "t-code1"; tok.AccessToken != want {
+		t.Errorf("got access token %q, want %q", tok.AccessToken, want)
+	}
+}
+
+func TestAuthCodeFlow_noLoopbackRedirectURI(t *testing.T) {
+	flow, _, done := newTestAuthCodeFlow(t, false)
+	defer done()
+
+	flow.Client.RedirectURIs = []string{"https://example.com/callback", "http://localhost/callback"}
+	if _, err := flow.Run(context.Background()); err != ErrNoLoopbackRedirectURI {
+		t.Errorf("got error %v, want %v", err, ErrNoLoopbackRedirectURI)
+	}
+}
+
+func TestAuthCodeFlow_canceled(t *testing.T) {
+	flow, _, done := newTestAuthCodeFlow(t, false)
+	defer done()
+
+	flow.OpenURL = func(string) error { return nil } // the user never visits the URL
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := flow.Run(ctx); err != context.DeadlineExceeded {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestAuthorizationCodeRequest_VerifyCodeVerifier(t *testing.T) {
+	const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	tests := []struct {
+		req      AuthorizationCodeRequest
+		verifier string
+		want     bool
+	}{
+		// Example from RFC 7636 appendix B.
+		{AuthorizationCodeRequest{CodeChallenge: "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", CodeChallengeMethod: "S256"}, verifier, true},
+		{AuthorizationCodeRequest{CodeChallenge: "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", CodeChallengeMethod: "S256"}, "other", false},
+		{AuthorizationCodeRequest{CodeChallenge: "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", CodeChallengeMethod: "S256"}, "", false},
+		{AuthorizationCodeRequest{CodeChallenge: verifier, CodeChallengeMethod: "plain"}, verifier, true},
+		{AuthorizationCodeRequest{CodeChallenge: verifier}, verifier, true},
+		{AuthorizationCodeRequest{CodeChallenge: verifier, CodeChallengeMethod: "plain"}, "other", false},
+		{AuthorizationCodeRequest{CodeChallenge: verifier, CodeChallengeMethod: "unknown"}, verifier, false},
+		{AuthorizationCodeRequest{}, "", true},
+	}
+	for _, test := range tests {
+		if got := test.req.VerifyCodeVerifier(test.verifier); got != test.want {
+			t.Errorf("%+v: verifier %q: got %v, want %v", test.req, test.verifier, got, test.want)
+		}
+	}
+}
+
+func TestFileTokenStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file-token-store")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	s := &FileTokenStore{Path: filepath.Join(dir, "sub", "token.json")}
+
+	if _, err := s.Token(); err != ErrNoStoredToken {
+		t.Errorf("got error %v, want %v", err, ErrNoStoredToken)
+	}
+
+	want := &oauth2.Token{AccessToken: "a", TokenType: "Bearer", RefreshToken: "r", Expiry: time.Now().Add(time.Hour).Round(time.Second)}
+	if err := s.SaveToken(want); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(s.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := fi.Mode().Perm(); mode != 0600 {
+		t.Errorf("got file mode %o, want 0600", mode)
+	}
+	tok, err := s.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != want.AccessToken || tok.RefreshToken != want.RefreshToken || !tok.Expiry.Equal(want.Expiry) {
+		t.Errorf("got token %+v, want %+v", tok, want)
+	}
+
+	// Expired tokens are returned only if they may be refreshed.
+	if err := s.SaveToken(&oauth2.Token{AccessToken: "b", RefreshToken: "r", Expiry: time.Now().Add(-time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	if tok, err := s.Token(); err != nil || tok.AccessToken != "b" {
+		t.Errorf("got token %v (err %v), want expired token b with refresh token", tok, err)
+	}
+	if err := s.SaveToken(&oauth2.Token{AccessToken: "b", Expiry: time.Now().Add(-time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Token(); err != ErrNoStoredToken {
+		t.Errorf("got error %v for expired token, want %v", err, ErrNoStoredToken)
+	}
+}
@@ -0,0 +1,287 @@
+package sourcegraph
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// AuthCredentials are Credentials that obtain access tokens from the
+// Auth service (using Auth.GetAccessToken). The initial token is
+// obtained by exchanging an authorization grant (an
+// AuthorizationCode, LoginCredentials or BearerJWT). The token is
+// cached and refreshed (using the refresh token issued with it, if
+// any) shortly before it expires.
+//
+// Concurrent calls to Token (e.g., by many API calls that use the
+// credentials) share a single exchange or refresh.
+type AuthCredentials struct {
+	// RefreshBefore is how long before the token expires that it is
+	// refreshed. If zero, 1 minute is used.
+	RefreshBefore time.Duration
+
+	ctx   context.Context
+	auth  AuthClient
+	req   AccessTokenRequest // the initial grant request
+	store TokenStore         // saves new tokens (if set)
+
+	newAssertion func() (*BearerJWT, error) // signs the BearerJWT grant of each exchange (if set)
+
+	mu         sync.Mutex
+	tok        *oauth2.Token
+	codeUsed   bool             // whether the request's authorization code was exchanged
+	refreshing *tokenRefresh    // the refresh in progress (if any)
+	now        func() time.Time // for testing
+}
+
+// tokenRefresh is an exchange or refresh in progress. Its fields are
+// set before done is closed.
+type tokenRefresh struct {
+	done chan struct{}
+	err  error
+}
+
+// Errors returned by AuthCredentials.Token when a new token is needed
+// but can't be obtained.
+var (
+	// ErrAuthorizationCodeUsed means that the authorization code
+	// grant was already exchanged and no refresh token was issued.
+	ErrAuthorizationCodeUsed = errors.New("authorization code was already exchanged for an access token (and no refresh token was issued)")
+
+	// ErrAccessTokenExpired means that the (stored) token expired and
+	// has no refresh token, and there is no authorization grant.
+	ErrAccessTokenExpired = errors.New("access token has expired and can't be refreshed (log in again to obtain a new one)")
+)
+
+// NewAuthCredentials returns credentials that obtain access tokens
+// from auth, starting by exchanging the authorization grant in req.
+// The values of ctx are used for the calls to auth, whose client must
+// not use the returned credentials itself. Because the credentials
+// outlive ctx, its cancelation and deadline are ignored.
+//
+// If a refresh token is rejected (e.g., because it was revoked) and
+// the initial grant may be exchanged again (LoginCredentials), the
+// initial grant is exchanged for a new token. A BearerJWT in req is
+// exchanged only once, because its assertion soon expires; use
+// NewJWTAuthCredentials to sign a new assertion for each exchange.
+func NewAuthCredentials(ctx context.Context, auth AuthClient, req *AccessTokenRequest) *AuthCredentials {
+	return &AuthCredentials{ctx: valuesOnlyContext{ctx}, auth: auth, req: *req, now: time.Now}
+}
+
+// NewJWTAuthCredentials returns credentials that obtain access tokens
+// from auth by exchanging BearerJWT grants. Each exchange (except for
+// refreshes, if a refresh token was issued) uses a new assertion
+// returned by newAssertion, which is typically a func that calls
+// NewJWTBearerAssertion. The TokenURL and Scope of the requests are
+// those of req, whose authorization grant is ignored. See
+// NewAuthCredentials for how ctx is used.
+func NewJWTAuthCredentials(ctx context.Context, auth AuthClient, req *AccessTokenRequest, newAssertion func() (*BearerJWT, error)) *AuthCredentials {
+	c := NewAuthCredentials(ctx, auth, req)
+	c.req.AuthorizationGrant = nil
+	c.newAssertion = newAssertion
+	return c
+}
+
+// NewStoredAuthCredentials returns credentials that use the token in
+// store (e.g., one saved by AuthCodeFlow.Run), refresh it using auth
+// (and its refresh token) shortly before it expires, and save each new
+// token in store. The TokenURL and Scope of the refresh requests are
+// those of req. If store has no token (or the refresh token is
+// rejected), the authorization grant in req (if any) is exchanged for
+// a new token.
+func NewStoredAuthCredentials(ctx context.Context, auth AuthClient, store TokenStore, req *AccessTokenRequest) (*AuthCredentials, error) {
+	tok, err := store.Token()
+	if err != nil && err != ErrNoStoredToken {
+		return nil, err
+	}
+	c := NewAuthCredentials(ctx, auth, req)
+	c.tok = tok
+	c.store = store
+	return c, nil
+}
+
+// valuesOnlyContext is a context that has its parent's values, but is
+// never canceled and has no deadline.
+type valuesOnlyContext struct{ parent context.Context }
+
+func (valuesOnlyContext) Deadline() (deadline time.Time, ok bool) { return }
+func (valuesOnlyContext) Done() <-chan struct{}                   { return nil }
+func (valuesOnlyContext) Err() error                              { return nil }
+func (c valuesOnlyContext) Value(key interface{}) interface{}     { return c.parent.Value(key) }
+
+// Token implements oauth2.TokenSource.
+func (c *AuthCredentials) Token() (*oauth2.Token, error) {
+	c.mu.Lock()
+	for {
+		if c.tok != nil && !c.needsRefresh(c.tok) {
+			tok := *c.tok
+			c.mu.Unlock()
+			return &tok, nil
+		}
+		if c.refreshing == nil {
+			break
+		}
+
+		// Wait for the refresh in progress (unless the current token
+		// is still valid).
+		if c.tok != nil && !c.expired(c.tok) {
+			tok := *c.tok
+			c.mu.Unlock()
+			return &tok, nil
+		}
+		r := c.refreshing
+		c.mu.Unlock()
+		<-r.done
+		if r.err != nil {
+			return nil, r.err
+		}
+		c.mu.Lock()
+	}
+
+	r := &tokenRefresh{done: make(chan struct{})}
+	c.refreshing = r
+	req, err := c.grantRequestLocked()
+	c.mu.Unlock()
+
+	var tok *oauth2.Token
+	if err != nil {
+		r.err = err
+	} else {
+		tok, r.err = c.exchange(req)
+		if r.err != nil && req.GetRefreshToken() != "" && refreshTokenRejected(r.err) && c.reusableGrant() {
+			if req, r.err = c.initialGrantRequest(); r.err == nil {
+				tok, r.err = c.exchange(req)
+			}
+		}
+	}
+	if r.err == nil && c.store != nil {
+		if err := c.store.SaveToken(tok); err != nil {
+			log.Printf("Warning: saving access token: %s", err)
+		}
+	}
+
+	c.mu.Lock()
+	err = r.err
+	if err == nil {
+		c.tok = tok
+	} else if c.tok != nil && !c.expired(c.tok) {
+		// A proactive refresh failed, but the current token may still
+		// be used (and the refresh is retried on the next call).
+		tok, err = c.tok, nil
+	}
+	c.refreshing = nil
+	c.mu.Unlock()
+	close(r.done)
+
+	if err != nil {
+		return nil, err
+	}
+	cpy := *tok
+	return &cpy, nil
+}
+
+// grantRequestLocked returns the request that obtains a new token: a
+// refresh token grant if a refresh token was issued, or else the
+// initial grant. It returns an error if there is no grant that may be
+// exchanged (e.g., if the initial grant was an authorization code that
+// was already exchanged).
+func (c *AuthCredentials) grantRequestLocked() (*AccessTokenRequest, error) {
+	req := c.req
+	if c.tok != nil && c.tok.RefreshToken != "" {
+		req.AuthorizationGrant = &AccessTokenRequest_RefreshToken{RefreshToken: c.tok.RefreshToken}
+		return &req, nil
+	}
+	if c.newAssertion != nil {
+		return c.initialGrantRequest()
+	}
+	switch req.AuthorizationGrant.(type) {
+	case nil:
+		if c.tok == nil {
+			return nil, ErrNoStoredToken
+		}
+		return nil, ErrAccessTokenExpired
+	case *AccessTokenRequest_AuthorizationCode:
+		if c.codeUsed {
+			return nil, ErrAuthorizationCodeUsed
+		}
+		c.codeUsed = true
+	}
+	return &req, nil
+}
+
+// reusableGrant reports whether the initial grant may be exchanged
+// again (unlike an authorization code, which may only be exchanged
+// once, or a fixed assertion, which soon expires).
+func (c *AuthCredentials) reusableGrant() bool {
+	if c.newAssertion != nil {
+		return true
+	}
+	_, ok := c.req.AuthorizationGrant.(*AccessTokenRequest_ResourceOwnerPassword)
+	return ok
+}
+
+// initialGrantRequest returns a request that exchanges the initial
+// grant, which is a newly signed assertion if the credentials were
+// created by NewJWTAuthCredentials.
+func (c *AuthCredentials) initialGrantRequest() (*AccessTokenRequest, error) {
+	req := c.req
+	if c.newAssertion != nil {
+		bearer, err := c.newAssertion()
+		if err != nil {
+			return nil, err
+		}
+		req.AuthorizationGrant = &AccessTokenRequest_BearerJWT{BearerJWT: bearer}
+	}
+	return &req, nil
+}
+
+// refreshTokenRejected reports whether err is the server's rejection
+// of a refresh token grant (e.g., because the refresh token was
+// revoked or has expired), as opposed to a transient error.
+func refreshTokenRejected(err error) bool {
+	switch grpc.Code(err) {
+	case codes.Unauthenticated, codes.PermissionDenied, codes.InvalidArgument:
+		return true
+	}
+	return false
+}
+
+func (c *AuthCredentials) exchange(req *AccessTokenRequest) (*oauth2.Token, error) {
+	resp, err := c.auth.GetAccessToken(c.ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	tok := &oauth2.Token{
+		AccessToken:  resp.AccessToken,
+		TokenType:    resp.TokenType,
+		RefreshToken: resp.RefreshToken,
+	}
+	if resp.ExpiresInSec > 0 {
+		tok.Expiry = c.now().Add(time.Duration(resp.ExpiresInSec) * time.Second)
+	}
+	if tok.RefreshToken == "" && req.GetRefreshToken() != "" {
+		// Keep using the refresh token if a new one wasn't issued.
+		tok.RefreshToken = req.GetRefreshToken()
+	}
+	return tok, nil
+}
+
+// needsRefresh reports whether tok expires within RefreshBefore.
+func (c *AuthCredentials) needsRefresh(tok *oauth2.Token) bool {
+	refreshBefore := c.RefreshBefore
+	if refreshBefore == 0 {
+		refreshBefore = time.Minute
+	}
+	return !tok.Expiry.IsZero() && !c.now().Add(refreshBefore).Before(tok.Expiry)
+}
+
+// expired reports whether tok has expired.
+func (c *AuthCredentials) expired(tok *oauth2.Token) bool {
+	return !tok.Expiry.IsZero() && !c.now().Before(tok.Expiry)
+}
@@ -0,0 +1,331 @@
+package sourcegraph
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// testAuthClient issues access tokens that expire after expiresInSec,
+// each with a new refresh token.
+type testAuthClient struct {
+	AuthClient
+
+	mu            sync.Mutex
+	reqs          []*AccessTokenRequest
+	expiresInSec  int32
+	noRefresh     bool
+	rejectRefresh bool // reject refresh token grants
+	err           error
+	delay         time.Duration
+}
+
+func (c *testAuthClient) GetAccessToken(ctx context.Context, in *AccessTokenRequest, opts ...grpc.CallOption) (*AccessTokenResponse, error) {
+	time.Sleep(c.delay)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reqs = append(c.reqs, in)
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.rejectRefresh && in.GetRefreshToken() != "" {
+		return nil, grpc.Errorf(codes.Unauthenticated, "refresh token revoked")
+	}
+	n := strconv.Itoa(len(c.reqs))
+	resp := &AccessTokenResponse{AccessToken: "a" + n, TokenType: "Bearer", ExpiresInSec: c.expiresInSec}
+	if !c.noRefresh {
+		resp.RefreshToken = "r" + n
+	}
+	return resp, nil
+}
+
+func (c *testAuthClient) numReqs() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.reqs)
+}
+
+// newTestAuthCredentials returns credentials whose clock is advanced
+// by calling the returned func.
+func newTestAuthCredentials(auth AuthClient, req *AccessTokenRequest) (*AuthCredentials, func(time.Duration)) {
+	now := time.Now()
+	var mu sync.Mutex
+	cred := NewAuthCredentials(context.Background(), auth, req)
+	cred.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	return cred, func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+}
+
+var testPasswordGrant = &AccessTokenRequest{
+	AuthorizationGrant: &AccessTokenRequest_ResourceOwnerPassword{ResourceOwnerPassword: &LoginCredentials{Login: "u", Password: "p"}},
+}
+
+func TestAuthCredentials(t *testing.T) {
+	auth := &testAuthClient{expiresInSec: 600}
+	cred, advance := newTestAuthCredentials(auth, testPasswordGrant)
+
+	tok, err := cred.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "a1" || tok.RefreshToken != "r1" {
+		t.Errorf("got token %+v, want a1/r1", tok)
+	}
+	if auth.reqs[0].GetResourceOwnerPassword() == nil {
+		t.Errorf("got request %+v, want password grant", auth.reqs[0])
+	}
+
+	// The token is cached.
+	advance(8 * time.Minute)
+	if tok, _ := cred.Token(); tok.AccessToken != "a1" {
+		t.Errorf("got token %q, want cached a1", tok.AccessToken)
+	}
+
+	// It is refreshed shortly before it expires.
+	advance(90 * time.Second)
+	tok, err = cred.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "a2" {
+		t.Errorf("got token %q, want refreshed a2", tok.AccessToken)
+	}
+	if got := auth.reqs[1].GetRefreshToken(); got != "r1" {
+		t.Errorf("got refresh grant %q, want r1", got)
+	}
+}
+
+func TestAuthCredentials_concurrent(t *testing.T) {
+	auth := &testAuthClient{expiresInSec: 600, delay: 20 * time.Millisecond}
+	cred, _ := newTestAuthCredentials(auth, testPasswordGrant)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := (contextCredentials{}).GetRequestMetadata(WithCredentials(context.Background(), cred)); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := auth.numReqs(); n != 1 {
+		t.Errorf("got %d token requests, want 1", n)
+	}
+}
+
+func TestAuthCredentials_refreshFailure(t *testing.T) {
+	auth := &testAuthClient{expiresInSec: 600}
+	cred, advance := newTestAuthCredentials(auth, testPasswordGrant)
+	if _, err := cred.Token(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed proactive refresh returns the current token.
+	auth.err = grpc.Errorf(codes.Unavailable, "x")
+	advance(590 * time.Second)
+	if tok, err := cred.Token(); err != nil || tok.AccessToken != "a1" {
+		t.Errorf("got token %v (err %v), want current a1", tok, err)
+	}
+
+	// After it expires, the error is returned.
+	advance(time.Minute)
+	if _, err := cred.Token(); err != auth.err {
+		t.Errorf("got err %v, want %v", err, auth.err)
+	}
+}
+
+func TestAuthCredentials_authorizationCodeUsed(t *testing.T) {
+	auth := &testAuthClient{expiresInSec: 600, noRefresh: true}
+	cred, advance := newTestAuthCredentials(auth, &AccessTokenRequest{
+		AuthorizationGrant: &AccessTokenRequest_AuthorizationCode{AuthorizationCode: &AuthorizationCode{Code: "c"}},
+	})
+	if _, err := cred.Token(); err != nil {
+		t.Fatal(err)
+	}
+	advance(time.Hour)
+	if _, err := cred.Token(); err != ErrAuthorizationCodeUsed {
+		t.Errorf("got err %v, want %v", err, ErrAuthorizationCodeUsed)
+	}
+	if n := auth.numReqs(); n != 1 {
+		t.Errorf("got %d token requests, want 1", n)
+	}
+}
+
+func TestAuthCredentials_refreshTokenRejected(t *testing.T) {
+	auth := &testAuthClient{expiresInSec: 600, rejectRefresh: true}
+	cred, advance := newTestAuthCredentials(auth, testPasswordGrant)
+	if _, err := cred.Token(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The initial grant is exchanged again.
+	advance(time.Hour)
+	tok, err := cred.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "a3" {
+		t.Errorf("got token %q, want a3", tok.AccessToken)
+	}
+	if n := auth.numReqs(); n != 3 {
+		t.Fatalf("got %d token requests, want 3", n)
+	}
+	if auth.reqs[1].GetRefreshToken() != "r1" || auth.reqs[2].GetResourceOwnerPassword() == nil {
+		t.Errorf("got requests %+v, want a refresh token grant and then the password grant", auth.reqs[1:])
+	}
+
+	// An authorization code can't be exchanged again.
+	auth = &testAuthClient{expiresInSec: 600, rejectRefresh: true}
+	cred, advance = newTestAuthCredentials(auth, &AccessTokenRequest{
+		AuthorizationGrant: &AccessTokenRequest_AuthorizationCode{AuthorizationCode: &AuthorizationCode{Code: "c"}},
+	})
+	if _, err := cred.Token(); err != nil {
+		t.Fatal(err)
+	}
+	advance(time.Hour)
+	if _, err := cred.Token(); grpc.Code(err) != codes.Unauthenticated {
+		t.Errorf("got err %v, want Unauthenticated", err)
+	}
+	if n := auth.numReqs(); n != 2 {
+		t.Errorf("got %d token requests, want 2", n)
+	}
+}
+
+func TestAuthCredentials_canceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	auth := &testAuthClient{expiresInSec: 600}
+	cred := NewAuthCredentials(ctx, auth, testPasswordGrant)
+	cancel()
+
+	// The credentials outlive the context they were created with.
+	if _, err := cred.Token(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewStoredAuthCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stored-auth-credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	store := &FileTokenStore{Path: filepath.Join(dir, "token.json")}
+	auth := &testAuthClient{expiresInSec: 600}
+	req := &AccessTokenRequest{TokenURL: "u"}
+
+	// Without a stored token or a grant, no token can be obtained.
+	cred, err := NewStoredAuthCredentials(context.Background(), auth, store, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cred.Token(); err != ErrNoStoredToken {
+		t.Errorf("got err %v, want %v", err, ErrNoStoredToken)
+	}
+
+	// An expired stored token is refreshed, and the new token is
+	// saved.
+	if err := store.SaveToken(&oauth2.Token{AccessToken: "a0", TokenType: "Bearer", RefreshToken: "r0", Expiry: time.Now().Add(-time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	cred, err = NewStoredAuthCredentials(context.Background(), auth, store, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok, err := cred.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok.AccessToken != "a1" {
+		t.Errorf("got token %q, want a1", tok.AccessToken)
+	}
+	if n := auth.numReqs(); n != 1 || auth.reqs[0].GetRefreshToken() != "r0" || auth.reqs[0].TokenURL != "u" {
+		t.Errorf("got requests %+v, want a refresh token grant of r0 to u", auth.reqs)
+	}
+	if saved, err := store.Token(); err != nil || saved.AccessToken != "a1" || saved.RefreshToken != "r1" {
+		t.Errorf("got saved token %+v (err %v), want a1 with refresh token r1", saved, err)
+	}
+
+	// A valid stored token is used as is.
+	cred, err = NewStoredAuthCredentials(context.Background(), auth, store, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok, err := cred.Token(); err != nil || tok.AccessToken != "a1" {
+		t.Errorf("got token %v (err %v), want stored a1", tok, err)
+	}
+	if n := auth.numReqs(); n != 1 {
+		t.Errorf("got %d token requests, want 1", n)
+	}
+
+	// If the stored token expired and can't be refreshed, the grant
+	// is exchanged.
+	if err := store.SaveToken(&oauth2.Token{AccessToken: "a1", TokenType: "Bearer", Expiry: time.Now().Add(-time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	cred, err = NewStoredAuthCredentials(context.Background(), auth, store, testPasswordGrant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok, err := cred.Token(); err != nil || tok.AccessToken != "a2" {
+		t.Errorf("got token %v (err %v), want a2", tok, err)
+	}
+	if n := auth.numReqs(); n != 2 || auth.reqs[1].GetResourceOwnerPassword() == nil {
+		t.Errorf("got requests %+v, want a password grant", auth.reqs)
+	}
+}
+
+func TestNewJWTAuthCredentials(t *testing.T) {
+	auth := &testAuthClient{expiresInSec: 600, noRefresh: true}
+	var n int
+	cred := NewJWTAuthCredentials(context.Background(), auth, &AccessTokenRequest{TokenURL: "u"}, func() (*BearerJWT, error) {
+		n++
+		return &BearerJWT{Assertion: "j" + strconv.Itoa(n)}, nil
+	})
+	now := time.Now()
+	cred.now = func() time.Time { return now }
+
+	// Each exchange uses a new assertion.
+	for i := 0; i < 2; i++ {
+		if _, err := cred.Token(); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(time.Hour)
+	}
+	if n := auth.numReqs(); n != 2 {
+		t.Fatalf("got %d token requests, want 2", n)
+	}
+	for i, req := range auth.reqs {
+		if jwt, want := req.GetBearerJWT(), "j"+strconv.Itoa(i+1); jwt == nil || jwt.Assertion != want || req.TokenURL != "u" {
+			t.Errorf("request %d: got %+v, want assertion %q", i, req, want)
+		}
+	}
+
+	// An error signing the assertion is returned.
+	errSign := errors.New("sign")
+	cred.newAssertion = func() (*BearerJWT, error) { return nil, errSign }
+	if _, err := cred.Token(); err != errSign {
+		t.Errorf("got err %v, want %v", err, errSign)
+	}
+}
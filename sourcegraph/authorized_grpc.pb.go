@@ -0,0 +1,1020 @@
+// GENERATED CODE - DO NOT EDIT!
+//
+// Generated by:
+//
+//   go run gen/wrappers.go -kind authz -o authorized_grpc.pb.go sourcegraph.pb.go
+//
+// Called via:
+//
+//   go generate
+//
+
+package sourcegraph
+
+import (
+	"golang.org/x/net/context"
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+	"sourcegraph.com/sourcegraph/srclib/unit"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+type AuthorizedAccessTokensServer struct {
+	AccessTokensServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedAccessTokensServer) Create(ctx context.Context, in *AccessTokensCreateOp) (*NewAccessToken, error) {
+	if err := s.Checker.CheckMethod(ctx, "AccessTokens.Create", in); err != nil {
+		return nil, err
+	}
+	return s.AccessTokensServer.Create(ctx, in)
+}
+
+func (s *AuthorizedAccessTokensServer) List(ctx context.Context, in *AccessTokensListOptions) (*AccessTokenList, error) {
+	if err := s.Checker.CheckMethod(ctx, "AccessTokens.List", in); err != nil {
+		return nil, err
+	}
+	return s.AccessTokensServer.List(ctx, in)
+}
+
+func (s *AuthorizedAccessTokensServer) Revoke(ctx context.Context, in *AccessTokenSpec) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "AccessTokens.Revoke", in); err != nil {
+		return nil, err
+	}
+	return s.AccessTokensServer.Revoke(ctx, in)
+}
+
+type AuthorizedAccountsServer struct {
+	AccountsServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedAccountsServer) Create(ctx context.Context, in *NewAccount) (*UserSpec, error) {
+	if err := s.Checker.CheckMethod(ctx, "Accounts.Create", in); err != nil {
+		return nil, err
+	}
+	return s.AccountsServer.Create(ctx, in)
+}
+
+func (s *AuthorizedAccountsServer) RequestPasswordReset(ctx context.Context, in *EmailAddr) (*User, error) {
+	if err := s.Checker.CheckMethod(ctx, "Accounts.RequestPasswordReset", in); err != nil {
+		return nil, err
+	}
+	return s.AccountsServer.RequestPasswordReset(ctx, in)
+}
+
+func (s *AuthorizedAccountsServer) ResetPassword(ctx context.Context, in *NewPassword) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "Accounts.ResetPassword", in); err != nil {
+		return nil, err
+	}
+	return s.AccountsServer.ResetPassword(ctx, in)
+}
+
+func (s *AuthorizedAccountsServer) Update(ctx context.Context, in *User) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "Accounts.Update", in); err != nil {
+		return nil, err
+	}
+	return s.AccountsServer.Update(ctx, in)
+}
+
+type AuthorizedAuditLogServer struct {
+	AuditLogServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedAuditLogServer) List(ctx context.Context, in *AuditEventListOptions) (*AuditEventList, error) {
+	if err := s.Checker.CheckMethod(ctx, "AuditLog.List", in); err != nil {
+		return nil, err
+	}
+	return s.AuditLogServer.List(ctx, in)
+}
+
+type AuthorizedAuthServer struct {
+	AuthServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedAuthServer) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest) (*AuthorizationCode, error) {
+	if err := s.Checker.CheckMethod(ctx, "Auth.GetAuthorizationCode", in); err != nil {
+		return nil, err
+	}
+	return s.AuthServer.GetAuthorizationCode(ctx, in)
+}
+
+func (s *AuthorizedAuthServer) GetAccessToken(ctx context.Context, in *AccessTokenRequest) (*AccessTokenResponse, error) {
+	if err := s.Checker.CheckMethod(ctx, "Auth.GetAccessToken", in); err != nil {
+		return nil, err
+	}
+	return s.AuthServer.GetAccessToken(ctx, in)
+}
+
+func (s *AuthorizedAuthServer) Identify(ctx context.Context, in *pbtypes.Void) (*AuthInfo, error) {
+	if err := s.Checker.CheckMethod(ctx, "Auth.Identify", in); err != nil {
+		return nil, err
+	}
+	return s.AuthServer.Identify(ctx, in)
+}
+
+func (s *AuthorizedAuthServer) GetPermissions(ctx context.Context, in *pbtypes.Void) (*UserPermissions, error) {
+	if err := s.Checker.CheckMethod(ctx, "Auth.GetPermissions", in); err != nil {
+		return nil, err
+	}
+	return s.AuthServer.GetPermissions(ctx, in)
+}
+
+type AuthorizedBuildsServer struct {
+	BuildsServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedBuildsServer) Get(ctx context.Context, in *BuildSpec) (*Build, error) {
+	if err := s.Checker.CheckMethod(ctx, "Builds.Get", in); err != nil {
+		return nil, err
+	}
+	return s.BuildsServer.Get(ctx, in)
+}
+
+func (s *AuthorizedBuildsServer) GetRepoBuildInfo(ctx context.Context, in *BuildsGetRepoBuildInfoOp) (*RepoBuildInfo, error) {
+	if err := s.Checker.CheckMethod(ctx, "Builds.GetRepoBuildInfo", in); err != nil {
+		return nil, err
+	}
+	return s.BuildsServer.GetRepoBuildInfo(ctx, in)
+}
+
+func (s *AuthorizedBuildsServer) List(ctx context.Context, in *BuildListOptions) (*BuildList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Builds.List", in); err != nil {
+		return nil, err
+	}
+	return s.BuildsServer.List(ctx, in)
+}
+
+func (s *AuthorizedBuildsServer) Create(ctx context.Context, in *BuildsCreateOp) (*Build, error) {
+	if err := s.Checker.CheckMethod(ctx, "Builds.Create", in); err != nil {
+		return nil, err
+	}
+	return s.BuildsServer.Create(ctx, in)
+}
+
+func (s *AuthorizedBuildsServer) Update(ctx context.Context, in *BuildsUpdateOp) (*Build, error) {
+	if err := s.Checker.CheckMethod(ctx, "Builds.Update", in); err != nil {
+		return nil, err
+	}
+	return s.BuildsServer.Update(ctx, in)
+}
+
+func (s *AuthorizedBuildsServer) ListBuildTasks(ctx context.Context, in *BuildsListBuildTasksOp) (*BuildTaskList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Builds.ListBuildTasks", in); err != nil {
+		return nil, err
+	}
+	return s.BuildsServer.ListBuildTasks(ctx, in)
+}
+
+func (s *AuthorizedBuildsServer) CreateTasks(ctx context.Context, in *BuildsCreateTasksOp) (*BuildTaskList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Builds.CreateTasks", in); err != nil {
+		return nil, err
+	}
+	return s.BuildsServer.CreateTasks(ctx, in)
+}
+
+func (s *AuthorizedBuildsServer) UpdateTask(ctx context.Context, in *BuildsUpdateTaskOp) (*BuildTask, error) {
+	if err := s.Checker.CheckMethod(ctx, "Builds.UpdateTask", in); err != nil {
+		return nil, err
+	}
+	return s.BuildsServer.UpdateTask(ctx, in)
+}
+
+func (s *AuthorizedBuildsServer) GetLog(ctx context.Context, in *BuildsGetLogOp) (*LogEntries, error) {
+	if err := s.Checker.CheckMethod(ctx, "Builds.GetLog", in); err != nil {
+		return nil, err
+	}
+	return s.BuildsServer.GetLog(ctx, in)
+}
+
+func (s *AuthorizedBuildsServer) GetTaskLog(ctx context.Context, in *BuildsGetTaskLogOp) (*LogEntries, error) {
+	if err := s.Checker.CheckMethod(ctx, "Builds.GetTaskLog", in); err != nil {
+		return nil, err
+	}
+	return s.BuildsServer.GetTaskLog(ctx, in)
+}
+
+func (s *AuthorizedBuildsServer) DequeueNext(ctx context.Context, in *BuildsDequeueNextOp) (*Build, error) {
+	if err := s.Checker.CheckMethod(ctx, "Builds.DequeueNext", in); err != nil {
+		return nil, err
+	}
+	return s.BuildsServer.DequeueNext(ctx, in)
+}
+
+type AuthorizedChangesetsServer struct {
+	ChangesetsServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedChangesetsServer) Create(ctx context.Context, in *ChangesetCreateOp) (*Changeset, error) {
+	if err := s.Checker.CheckMethod(ctx, "Changesets.Create", in); err != nil {
+		return nil, err
+	}
+	return s.ChangesetsServer.Create(ctx, in)
+}
+
+func (s *AuthorizedChangesetsServer) Get(ctx context.Context, in *ChangesetSpec) (*Changeset, error) {
+	if err := s.Checker.CheckMethod(ctx, "Changesets.Get", in); err != nil {
+		return nil, err
+	}
+	return s.ChangesetsServer.Get(ctx, in)
+}
+
+func (s *AuthorizedChangesetsServer) List(ctx context.Context, in *ChangesetListOp) (*ChangesetList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Changesets.List", in); err != nil {
+		return nil, err
+	}
+	return s.ChangesetsServer.List(ctx, in)
+}
+
+func (s *AuthorizedChangesetsServer) Update(ctx context.Context, in *ChangesetUpdateOp) (*ChangesetEvent, error) {
+	if err := s.Checker.CheckMethod(ctx, "Changesets.Update", in); err != nil {
+		return nil, err
+	}
+	return s.ChangesetsServer.Update(ctx, in)
+}
+
+func (s *AuthorizedChangesetsServer) Merge(ctx context.Context, in *ChangesetMergeOp) (*ChangesetEvent, error) {
+	if err := s.Checker.CheckMethod(ctx, "Changesets.Merge", in); err != nil {
+		return nil, err
+	}
+	return s.ChangesetsServer.Merge(ctx, in)
+}
+
+func (s *AuthorizedChangesetsServer) UpdateAffected(ctx context.Context, in *ChangesetUpdateAffectedOp) (*ChangesetEventList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Changesets.UpdateAffected", in); err != nil {
+		return nil, err
+	}
+	return s.ChangesetsServer.UpdateAffected(ctx, in)
+}
+
+func (s *AuthorizedChangesetsServer) CreateReview(ctx context.Context, in *ChangesetCreateReviewOp) (*ChangesetReview, error) {
+	if err := s.Checker.CheckMethod(ctx, "Changesets.CreateReview", in); err != nil {
+		return nil, err
+	}
+	return s.ChangesetsServer.CreateReview(ctx, in)
+}
+
+func (s *AuthorizedChangesetsServer) ListReviews(ctx context.Context, in *ChangesetListReviewsOp) (*ChangesetReviewList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Changesets.ListReviews", in); err != nil {
+		return nil, err
+	}
+	return s.ChangesetsServer.ListReviews(ctx, in)
+}
+
+func (s *AuthorizedChangesetsServer) ListEvents(ctx context.Context, in *ChangesetSpec) (*ChangesetEventList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Changesets.ListEvents", in); err != nil {
+		return nil, err
+	}
+	return s.ChangesetsServer.ListEvents(ctx, in)
+}
+
+type AuthorizedDefsServer struct {
+	DefsServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedDefsServer) Get(ctx context.Context, in *DefsGetOp) (*Def, error) {
+	if err := s.Checker.CheckMethod(ctx, "Defs.Get", in); err != nil {
+		return nil, err
+	}
+	return s.DefsServer.Get(ctx, in)
+}
+
+func (s *AuthorizedDefsServer) List(ctx context.Context, in *DefListOptions) (*DefList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Defs.List", in); err != nil {
+		return nil, err
+	}
+	return s.DefsServer.List(ctx, in)
+}
+
+func (s *AuthorizedDefsServer) ListRefs(ctx context.Context, in *DefsListRefsOp) (*RefList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Defs.ListRefs", in); err != nil {
+		return nil, err
+	}
+	return s.DefsServer.ListRefs(ctx, in)
+}
+
+func (s *AuthorizedDefsServer) ListExamples(ctx context.Context, in *DefsListExamplesOp) (*ExampleList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Defs.ListExamples", in); err != nil {
+		return nil, err
+	}
+	return s.DefsServer.ListExamples(ctx, in)
+}
+
+func (s *AuthorizedDefsServer) ListAuthors(ctx context.Context, in *DefsListAuthorsOp) (*DefAuthorList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Defs.ListAuthors", in); err != nil {
+		return nil, err
+	}
+	return s.DefsServer.ListAuthors(ctx, in)
+}
+
+func (s *AuthorizedDefsServer) ListClients(ctx context.Context, in *DefsListClientsOp) (*DefClientList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Defs.ListClients", in); err != nil {
+		return nil, err
+	}
+	return s.DefsServer.ListClients(ctx, in)
+}
+
+type AuthorizedDeltasServer struct {
+	DeltasServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedDeltasServer) Get(ctx context.Context, in *DeltaSpec) (*Delta, error) {
+	if err := s.Checker.CheckMethod(ctx, "Deltas.Get", in); err != nil {
+		return nil, err
+	}
+	return s.DeltasServer.Get(ctx, in)
+}
+
+func (s *AuthorizedDeltasServer) ListUnits(ctx context.Context, in *DeltasListUnitsOp) (*UnitDeltaList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Deltas.ListUnits", in); err != nil {
+		return nil, err
+	}
+	return s.DeltasServer.ListUnits(ctx, in)
+}
+
+func (s *AuthorizedDeltasServer) ListDefs(ctx context.Context, in *DeltasListDefsOp) (*DeltaDefs, error) {
+	if err := s.Checker.CheckMethod(ctx, "Deltas.ListDefs", in); err != nil {
+		return nil, err
+	}
+	return s.DeltasServer.ListDefs(ctx, in)
+}
+
+func (s *AuthorizedDeltasServer) ListFiles(ctx context.Context, in *DeltasListFilesOp) (*DeltaFiles, error) {
+	if err := s.Checker.CheckMethod(ctx, "Deltas.ListFiles", in); err != nil {
+		return nil, err
+	}
+	return s.DeltasServer.ListFiles(ctx, in)
+}
+
+func (s *AuthorizedDeltasServer) ListAffectedAuthors(ctx context.Context, in *DeltasListAffectedAuthorsOp) (*DeltaAffectedPersonList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Deltas.ListAffectedAuthors", in); err != nil {
+		return nil, err
+	}
+	return s.DeltasServer.ListAffectedAuthors(ctx, in)
+}
+
+func (s *AuthorizedDeltasServer) ListAffectedClients(ctx context.Context, in *DeltasListAffectedClientsOp) (*DeltaAffectedPersonList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Deltas.ListAffectedClients", in); err != nil {
+		return nil, err
+	}
+	return s.DeltasServer.ListAffectedClients(ctx, in)
+}
+
+type AuthorizedDiscussionsServer struct {
+	DiscussionsServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedDiscussionsServer) Create(ctx context.Context, in *Discussion) (*Discussion, error) {
+	if err := s.Checker.CheckMethod(ctx, "Discussions.Create", in); err != nil {
+		return nil, err
+	}
+	return s.DiscussionsServer.Create(ctx, in)
+}
+
+func (s *AuthorizedDiscussionsServer) Get(ctx context.Context, in *DiscussionSpec) (*Discussion, error) {
+	if err := s.Checker.CheckMethod(ctx, "Discussions.Get", in); err != nil {
+		return nil, err
+	}
+	return s.DiscussionsServer.Get(ctx, in)
+}
+
+func (s *AuthorizedDiscussionsServer) List(ctx context.Context, in *DiscussionListOp) (*DiscussionList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Discussions.List", in); err != nil {
+		return nil, err
+	}
+	return s.DiscussionsServer.List(ctx, in)
+}
+
+func (s *AuthorizedDiscussionsServer) CreateComment(ctx context.Context, in *DiscussionCommentCreateOp) (*DiscussionComment, error) {
+	if err := s.Checker.CheckMethod(ctx, "Discussions.CreateComment", in); err != nil {
+		return nil, err
+	}
+	return s.DiscussionsServer.CreateComment(ctx, in)
+}
+
+func (s *AuthorizedDiscussionsServer) UpdateRating(ctx context.Context, in *DiscussionRatingUpdateOp) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "Discussions.UpdateRating", in); err != nil {
+		return nil, err
+	}
+	return s.DiscussionsServer.UpdateRating(ctx, in)
+}
+
+type AuthorizedGraphUplinkServer struct {
+	GraphUplinkServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedGraphUplinkServer) Push(ctx context.Context, in *MetricsSnapshot) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "GraphUplink.Push", in); err != nil {
+		return nil, err
+	}
+	return s.GraphUplinkServer.Push(ctx, in)
+}
+
+func (s *AuthorizedGraphUplinkServer) PushEvents(ctx context.Context, in *UserEventList) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "GraphUplink.PushEvents", in); err != nil {
+		return nil, err
+	}
+	return s.GraphUplinkServer.PushEvents(ctx, in)
+}
+
+type AuthorizedMarkdownServer struct {
+	MarkdownServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedMarkdownServer) Render(ctx context.Context, in *MarkdownRenderOp) (*MarkdownData, error) {
+	if err := s.Checker.CheckMethod(ctx, "Markdown.Render", in); err != nil {
+		return nil, err
+	}
+	return s.MarkdownServer.Render(ctx, in)
+}
+
+type AuthorizedMetaServer struct {
+	MetaServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedMetaServer) Status(ctx context.Context, in *pbtypes.Void) (*ServerStatus, error) {
+	if err := s.Checker.CheckMethod(ctx, "Meta.Status", in); err != nil {
+		return nil, err
+	}
+	return s.MetaServer.Status(ctx, in)
+}
+
+func (s *AuthorizedMetaServer) Config(ctx context.Context, in *pbtypes.Void) (*ServerConfig, error) {
+	if err := s.Checker.CheckMethod(ctx, "Meta.Config", in); err != nil {
+		return nil, err
+	}
+	return s.MetaServer.Config(ctx, in)
+}
+
+func (s *AuthorizedMetaServer) PubKey(ctx context.Context, in *pbtypes.Void) (*ServerPubKey, error) {
+	if err := s.Checker.CheckMethod(ctx, "Meta.PubKey", in); err != nil {
+		return nil, err
+	}
+	return s.MetaServer.PubKey(ctx, in)
+}
+
+type AuthorizedMirrorReposServer struct {
+	MirrorReposServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedMirrorReposServer) RefreshVCS(ctx context.Context, in *MirrorReposRefreshVCSOp) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "MirrorRepos.RefreshVCS", in); err != nil {
+		return nil, err
+	}
+	return s.MirrorReposServer.RefreshVCS(ctx, in)
+}
+
+type AuthorizedMirroredRepoSSHKeysServer struct {
+	MirroredRepoSSHKeysServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedMirroredRepoSSHKeysServer) Create(ctx context.Context, in *MirroredRepoSSHKeysCreateOp) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "MirroredRepoSSHKeys.Create", in); err != nil {
+		return nil, err
+	}
+	return s.MirroredRepoSSHKeysServer.Create(ctx, in)
+}
+
+func (s *AuthorizedMirroredRepoSSHKeysServer) Get(ctx context.Context, in *RepoSpec) (*SSHPrivateKey, error) {
+	if err := s.Checker.CheckMethod(ctx, "MirroredRepoSSHKeys.Get", in); err != nil {
+		return nil, err
+	}
+	return s.MirroredRepoSSHKeysServer.Get(ctx, in)
+}
+
+func (s *AuthorizedMirroredRepoSSHKeysServer) Delete(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "MirroredRepoSSHKeys.Delete", in); err != nil {
+		return nil, err
+	}
+	return s.MirroredRepoSSHKeysServer.Delete(ctx, in)
+}
+
+type AuthorizedNotifyServer struct {
+	NotifyServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedNotifyServer) GenericEvent(ctx context.Context, in *NotifyGenericEvent) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "Notify.GenericEvent", in); err != nil {
+		return nil, err
+	}
+	return s.NotifyServer.GenericEvent(ctx, in)
+}
+
+type AuthorizedOrgsServer struct {
+	OrgsServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedOrgsServer) Get(ctx context.Context, in *OrgSpec) (*Org, error) {
+	if err := s.Checker.CheckMethod(ctx, "Orgs.Get", in); err != nil {
+		return nil, err
+	}
+	return s.OrgsServer.Get(ctx, in)
+}
+
+func (s *AuthorizedOrgsServer) List(ctx context.Context, in *OrgsListOp) (*OrgList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Orgs.List", in); err != nil {
+		return nil, err
+	}
+	return s.OrgsServer.List(ctx, in)
+}
+
+func (s *AuthorizedOrgsServer) ListMembers(ctx context.Context, in *OrgsListMembersOp) (*UserList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Orgs.ListMembers", in); err != nil {
+		return nil, err
+	}
+	return s.OrgsServer.ListMembers(ctx, in)
+}
+
+type AuthorizedPeopleServer struct {
+	PeopleServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedPeopleServer) Get(ctx context.Context, in *PersonSpec) (*Person, error) {
+	if err := s.Checker.CheckMethod(ctx, "People.Get", in); err != nil {
+		return nil, err
+	}
+	return s.PeopleServer.Get(ctx, in)
+}
+
+type AuthorizedRegisteredClientsServer struct {
+	RegisteredClientsServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedRegisteredClientsServer) Get(ctx context.Context, in *RegisteredClientSpec) (*RegisteredClient, error) {
+	if err := s.Checker.CheckMethod(ctx, "RegisteredClients.Get", in); err != nil {
+		return nil, err
+	}
+	return s.RegisteredClientsServer.Get(ctx, in)
+}
+
+func (s *AuthorizedRegisteredClientsServer) GetCurrent(ctx context.Context, in *pbtypes.Void) (*RegisteredClient, error) {
+	if err := s.Checker.CheckMethod(ctx, "RegisteredClients.GetCurrent", in); err != nil {
+		return nil, err
+	}
+	return s.RegisteredClientsServer.GetCurrent(ctx, in)
+}
+
+func (s *AuthorizedRegisteredClientsServer) Create(ctx context.Context, in *RegisteredClient) (*RegisteredClient, error) {
+	if err := s.Checker.CheckMethod(ctx, "RegisteredClients.Create", in); err != nil {
+		return nil, err
+	}
+	return s.RegisteredClientsServer.Create(ctx, in)
+}
+
+func (s *AuthorizedRegisteredClientsServer) Update(ctx context.Context, in *RegisteredClient) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "RegisteredClients.Update", in); err != nil {
+		return nil, err
+	}
+	return s.RegisteredClientsServer.Update(ctx, in)
+}
+
+func (s *AuthorizedRegisteredClientsServer) Delete(ctx context.Context, in *RegisteredClientSpec) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "RegisteredClients.Delete", in); err != nil {
+		return nil, err
+	}
+	return s.RegisteredClientsServer.Delete(ctx, in)
+}
+
+func (s *AuthorizedRegisteredClientsServer) List(ctx context.Context, in *RegisteredClientListOptions) (*RegisteredClientList, error) {
+	if err := s.Checker.CheckMethod(ctx, "RegisteredClients.List", in); err != nil {
+		return nil, err
+	}
+	return s.RegisteredClientsServer.List(ctx, in)
+}
+
+func (s *AuthorizedRegisteredClientsServer) GetUserPermissions(ctx context.Context, in *UserPermissionsOptions) (*UserPermissions, error) {
+	if err := s.Checker.CheckMethod(ctx, "RegisteredClients.GetUserPermissions", in); err != nil {
+		return nil, err
+	}
+	return s.RegisteredClientsServer.GetUserPermissions(ctx, in)
+}
+
+func (s *AuthorizedRegisteredClientsServer) SetUserPermissions(ctx context.Context, in *UserPermissions) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "RegisteredClients.SetUserPermissions", in); err != nil {
+		return nil, err
+	}
+	return s.RegisteredClientsServer.SetUserPermissions(ctx, in)
+}
+
+func (s *AuthorizedRegisteredClientsServer) ListUserPermissions(ctx context.Context, in *RegisteredClientSpec) (*UserPermissionsList, error) {
+	if err := s.Checker.CheckMethod(ctx, "RegisteredClients.ListUserPermissions", in); err != nil {
+		return nil, err
+	}
+	return s.RegisteredClientsServer.ListUserPermissions(ctx, in)
+}
+
+type AuthorizedRepoBadgesServer struct {
+	RepoBadgesServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedRepoBadgesServer) ListBadges(ctx context.Context, in *RepoSpec) (*BadgeList, error) {
+	if err := s.Checker.CheckMethod(ctx, "RepoBadges.ListBadges", in); err != nil {
+		return nil, err
+	}
+	return s.RepoBadgesServer.ListBadges(ctx, in)
+}
+
+func (s *AuthorizedRepoBadgesServer) ListCounters(ctx context.Context, in *RepoSpec) (*CounterList, error) {
+	if err := s.Checker.CheckMethod(ctx, "RepoBadges.ListCounters", in); err != nil {
+		return nil, err
+	}
+	return s.RepoBadgesServer.ListCounters(ctx, in)
+}
+
+func (s *AuthorizedRepoBadgesServer) RecordHit(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "RepoBadges.RecordHit", in); err != nil {
+		return nil, err
+	}
+	return s.RepoBadgesServer.RecordHit(ctx, in)
+}
+
+func (s *AuthorizedRepoBadgesServer) CountHits(ctx context.Context, in *RepoBadgesCountHitsOp) (*RepoBadgesCountHitsResult, error) {
+	if err := s.Checker.CheckMethod(ctx, "RepoBadges.CountHits", in); err != nil {
+		return nil, err
+	}
+	return s.RepoBadgesServer.CountHits(ctx, in)
+}
+
+type AuthorizedRepoStatusesServer struct {
+	RepoStatusesServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedRepoStatusesServer) GetCombined(ctx context.Context, in *RepoRevSpec) (*CombinedStatus, error) {
+	if err := s.Checker.CheckMethod(ctx, "RepoStatuses.GetCombined", in); err != nil {
+		return nil, err
+	}
+	return s.RepoStatusesServer.GetCombined(ctx, in)
+}
+
+func (s *AuthorizedRepoStatusesServer) Create(ctx context.Context, in *RepoStatusesCreateOp) (*RepoStatus, error) {
+	if err := s.Checker.CheckMethod(ctx, "RepoStatuses.Create", in); err != nil {
+		return nil, err
+	}
+	return s.RepoStatusesServer.Create(ctx, in)
+}
+
+type AuthorizedRepoTreeServer struct {
+	RepoTreeServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedRepoTreeServer) Get(ctx context.Context, in *RepoTreeGetOp) (*TreeEntry, error) {
+	if err := s.Checker.CheckMethod(ctx, "RepoTree.Get", in); err != nil {
+		return nil, err
+	}
+	return s.RepoTreeServer.Get(ctx, in)
+}
+
+func (s *AuthorizedRepoTreeServer) Search(ctx context.Context, in *RepoTreeSearchOp) (*VCSSearchResultList, error) {
+	if err := s.Checker.CheckMethod(ctx, "RepoTree.Search", in); err != nil {
+		return nil, err
+	}
+	return s.RepoTreeServer.Search(ctx, in)
+}
+
+func (s *AuthorizedRepoTreeServer) List(ctx context.Context, in *RepoTreeListOp) (*RepoTreeListResult, error) {
+	if err := s.Checker.CheckMethod(ctx, "RepoTree.List", in); err != nil {
+		return nil, err
+	}
+	return s.RepoTreeServer.List(ctx, in)
+}
+
+type AuthorizedReposServer struct {
+	ReposServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedReposServer) Get(ctx context.Context, in *RepoSpec) (*Repo, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.Get", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.Get(ctx, in)
+}
+
+func (s *AuthorizedReposServer) List(ctx context.Context, in *RepoListOptions) (*RepoList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.List", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.List(ctx, in)
+}
+
+func (s *AuthorizedReposServer) Create(ctx context.Context, in *ReposCreateOp) (*Repo, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.Create", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.Create(ctx, in)
+}
+
+func (s *AuthorizedReposServer) Update(ctx context.Context, in *ReposUpdateOp) (*Repo, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.Update", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.Update(ctx, in)
+}
+
+func (s *AuthorizedReposServer) Delete(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.Delete", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.Delete(ctx, in)
+}
+
+func (s *AuthorizedReposServer) GetReadme(ctx context.Context, in *RepoRevSpec) (*Readme, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.GetReadme", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.GetReadme(ctx, in)
+}
+
+func (s *AuthorizedReposServer) Enable(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.Enable", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.Enable(ctx, in)
+}
+
+func (s *AuthorizedReposServer) Disable(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.Disable", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.Disable(ctx, in)
+}
+
+func (s *AuthorizedReposServer) GetConfig(ctx context.Context, in *RepoSpec) (*RepoConfig, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.GetConfig", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.GetConfig(ctx, in)
+}
+
+func (s *AuthorizedReposServer) GetCommit(ctx context.Context, in *RepoRevSpec) (*vcs.Commit, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.GetCommit", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.GetCommit(ctx, in)
+}
+
+func (s *AuthorizedReposServer) ListCommits(ctx context.Context, in *ReposListCommitsOp) (*CommitList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.ListCommits", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.ListCommits(ctx, in)
+}
+
+func (s *AuthorizedReposServer) ListBranches(ctx context.Context, in *ReposListBranchesOp) (*BranchList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.ListBranches", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.ListBranches(ctx, in)
+}
+
+func (s *AuthorizedReposServer) ListTags(ctx context.Context, in *ReposListTagsOp) (*TagList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.ListTags", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.ListTags(ctx, in)
+}
+
+func (s *AuthorizedReposServer) ListCommitters(ctx context.Context, in *ReposListCommittersOp) (*CommitterList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Repos.ListCommitters", in); err != nil {
+		return nil, err
+	}
+	return s.ReposServer.ListCommitters(ctx, in)
+}
+
+type AuthorizedSavedSearchesServer struct {
+	SavedSearchesServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedSavedSearchesServer) Create(ctx context.Context, in *SavedSearchesCreateOp) (*SavedSearch, error) {
+	if err := s.Checker.CheckMethod(ctx, "SavedSearches.Create", in); err != nil {
+		return nil, err
+	}
+	return s.SavedSearchesServer.Create(ctx, in)
+}
+
+func (s *AuthorizedSavedSearchesServer) List(ctx context.Context, in *SavedSearchListOptions) (*SavedSearchList, error) {
+	if err := s.Checker.CheckMethod(ctx, "SavedSearches.List", in); err != nil {
+		return nil, err
+	}
+	return s.SavedSearchesServer.List(ctx, in)
+}
+
+func (s *AuthorizedSavedSearchesServer) Delete(ctx context.Context, in *SavedSearchSpec) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "SavedSearches.Delete", in); err != nil {
+		return nil, err
+	}
+	return s.SavedSearchesServer.Delete(ctx, in)
+}
+
+func (s *AuthorizedSavedSearchesServer) Run(ctx context.Context, in *SavedSearchSpec) (*SavedSearchRunResult, error) {
+	if err := s.Checker.CheckMethod(ctx, "SavedSearches.Run", in); err != nil {
+		return nil, err
+	}
+	return s.SavedSearchesServer.Run(ctx, in)
+}
+
+type AuthorizedSearchServer struct {
+	SearchServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedSearchServer) Search(ctx context.Context, in *SearchOptions) (*SearchResults, error) {
+	if err := s.Checker.CheckMethod(ctx, "Search.Search", in); err != nil {
+		return nil, err
+	}
+	return s.SearchServer.Search(ctx, in)
+}
+
+func (s *AuthorizedSearchServer) SearchTokens(ctx context.Context, in *TokenSearchOptions) (*DefList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Search.SearchTokens", in); err != nil {
+		return nil, err
+	}
+	return s.SearchServer.SearchTokens(ctx, in)
+}
+
+func (s *AuthorizedSearchServer) SearchText(ctx context.Context, in *TextSearchOptions) (*VCSSearchResultList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Search.SearchText", in); err != nil {
+		return nil, err
+	}
+	return s.SearchServer.SearchText(ctx, in)
+}
+
+func (s *AuthorizedSearchServer) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions) (*MultiTextSearchResults, error) {
+	if err := s.Checker.CheckMethod(ctx, "Search.SearchTextMulti", in); err != nil {
+		return nil, err
+	}
+	return s.SearchServer.SearchTextMulti(ctx, in)
+}
+
+func (s *AuthorizedSearchServer) Complete(ctx context.Context, in *RawQuery) (*Completions, error) {
+	if err := s.Checker.CheckMethod(ctx, "Search.Complete", in); err != nil {
+		return nil, err
+	}
+	return s.SearchServer.Complete(ctx, in)
+}
+
+func (s *AuthorizedSearchServer) Suggest(ctx context.Context, in *RawQuery) (*SuggestionList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Search.Suggest", in); err != nil {
+		return nil, err
+	}
+	return s.SearchServer.Suggest(ctx, in)
+}
+
+func (s *AuthorizedSearchServer) StreamSearch(in *SearchOptions, stream Search_StreamSearchServer) error {
+	if err := s.Checker.CheckMethod(stream.Context(), "Search.StreamSearch", in); err != nil {
+		return err
+	}
+	return s.SearchServer.StreamSearch(in, stream)
+}
+
+type AuthorizedStorageServer struct {
+	StorageServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedStorageServer) Create(ctx context.Context, in *StorageName) (*StorageError, error) {
+	if err := s.Checker.CheckMethod(ctx, "Storage.Create", in); err != nil {
+		return nil, err
+	}
+	return s.StorageServer.Create(ctx, in)
+}
+
+func (s *AuthorizedStorageServer) RemoveAll(ctx context.Context, in *StorageName) (*StorageError, error) {
+	if err := s.Checker.CheckMethod(ctx, "Storage.RemoveAll", in); err != nil {
+		return nil, err
+	}
+	return s.StorageServer.RemoveAll(ctx, in)
+}
+
+func (s *AuthorizedStorageServer) Read(ctx context.Context, in *StorageReadOp) (*StorageRead, error) {
+	if err := s.Checker.CheckMethod(ctx, "Storage.Read", in); err != nil {
+		return nil, err
+	}
+	return s.StorageServer.Read(ctx, in)
+}
+
+func (s *AuthorizedStorageServer) Write(ctx context.Context, in *StorageWriteOp) (*StorageWrite, error) {
+	if err := s.Checker.CheckMethod(ctx, "Storage.Write", in); err != nil {
+		return nil, err
+	}
+	return s.StorageServer.Write(ctx, in)
+}
+
+func (s *AuthorizedStorageServer) Stat(ctx context.Context, in *StorageName) (*StorageStat, error) {
+	if err := s.Checker.CheckMethod(ctx, "Storage.Stat", in); err != nil {
+		return nil, err
+	}
+	return s.StorageServer.Stat(ctx, in)
+}
+
+func (s *AuthorizedStorageServer) ReadDir(ctx context.Context, in *StorageName) (*StorageReadDir, error) {
+	if err := s.Checker.CheckMethod(ctx, "Storage.ReadDir", in); err != nil {
+		return nil, err
+	}
+	return s.StorageServer.ReadDir(ctx, in)
+}
+
+func (s *AuthorizedStorageServer) Close(ctx context.Context, in *StorageName) (*StorageError, error) {
+	if err := s.Checker.CheckMethod(ctx, "Storage.Close", in); err != nil {
+		return nil, err
+	}
+	return s.StorageServer.Close(ctx, in)
+}
+
+type AuthorizedUnitsServer struct {
+	UnitsServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedUnitsServer) Get(ctx context.Context, in *UnitSpec) (*unit.RepoSourceUnit, error) {
+	if err := s.Checker.CheckMethod(ctx, "Units.Get", in); err != nil {
+		return nil, err
+	}
+	return s.UnitsServer.Get(ctx, in)
+}
+
+func (s *AuthorizedUnitsServer) List(ctx context.Context, in *UnitListOptions) (*RepoSourceUnitList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Units.List", in); err != nil {
+		return nil, err
+	}
+	return s.UnitsServer.List(ctx, in)
+}
+
+type AuthorizedUserKeysServer struct {
+	UserKeysServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedUserKeysServer) AddKey(ctx context.Context, in *SSHPublicKey) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "UserKeys.AddKey", in); err != nil {
+		return nil, err
+	}
+	return s.UserKeysServer.AddKey(ctx, in)
+}
+
+func (s *AuthorizedUserKeysServer) LookupUser(ctx context.Context, in *SSHPublicKey) (*UserSpec, error) {
+	if err := s.Checker.CheckMethod(ctx, "UserKeys.LookupUser", in); err != nil {
+		return nil, err
+	}
+	return s.UserKeysServer.LookupUser(ctx, in)
+}
+
+func (s *AuthorizedUserKeysServer) DeleteKey(ctx context.Context, in *pbtypes.Void) (*pbtypes.Void, error) {
+	if err := s.Checker.CheckMethod(ctx, "UserKeys.DeleteKey", in); err != nil {
+		return nil, err
+	}
+	return s.UserKeysServer.DeleteKey(ctx, in)
+}
+
+type AuthorizedUsersServer struct {
+	UsersServer
+	Checker *PermissionChecker
+}
+
+func (s *AuthorizedUsersServer) Get(ctx context.Context, in *UserSpec) (*User, error) {
+	if err := s.Checker.CheckMethod(ctx, "Users.Get", in); err != nil {
+		return nil, err
+	}
+	return s.UsersServer.Get(ctx, in)
+}
+
+func (s *AuthorizedUsersServer) GetWithEmail(ctx context.Context, in *EmailAddr) (*User, error) {
+	if err := s.Checker.CheckMethod(ctx, "Users.GetWithEmail", in); err != nil {
+		return nil, err
+	}
+	return s.UsersServer.GetWithEmail(ctx, in)
+}
+
+func (s *AuthorizedUsersServer) ListEmails(ctx context.Context, in *UserSpec) (*EmailAddrList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Users.ListEmails", in); err != nil {
+		return nil, err
+	}
+	return s.UsersServer.ListEmails(ctx, in)
+}
+
+func (s *AuthorizedUsersServer) List(ctx context.Context, in *UsersListOptions) (*UserList, error) {
+	if err := s.Checker.CheckMethod(ctx, "Users.List", in); err != nil {
+		return nil, err
+	}
+	return s.UsersServer.List(ctx, in)
+}
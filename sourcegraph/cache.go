@@ -0,0 +1,73 @@
+package sourcegraph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync/atomic"
+
+	"golang.org/x/net/context"
+	"sourcegraph.com/sourcegraph/grpccache"
+)
+
+// WithCache returns a copy of the parent context whose API clients
+// (constructed using NewClientFromContext) cache API responses in
+// cache, instead of in the package-level Cache. The cache's KeyPart
+// must be set (see ClientOptions.Cache).
+func WithCache(parent context.Context, cache *grpccache.Cache) context.Context {
+	return context.WithValue(parent, cacheKey, cache)
+}
+
+// cacheFromContext returns the cache previously set in the context by
+// WithCache, or the package-level Cache if none was set.
+func cacheFromContext(ctx context.Context) *grpccache.Cache {
+	if cache, ok := ctx.Value(cacheKey).(*grpccache.Cache); ok {
+		return cache
+	}
+	return Cache
+}
+
+// credentialsErrorCount is the number of times that
+// CredentialsCacheKeyPart failed to obtain a token.
+var credentialsErrorCount uint64
+
+// CredentialsCacheKeyPart returns a string that identifies the
+// credentials (and client metadata) that API calls made with ctx are
+// authenticated with. It is suitable as the KeyPart of a
+// grpccache.Cache, because it partitions the cache so that cached
+// responses obtained using one user's credentials are never returned
+// to another user.
+//
+// It returns "" if ctx has no credentials or client metadata. The
+// trace context metadata (see Tracer) is ignored.
+func CredentialsCacheKeyPart(ctx context.Context) string {
+	cred := CredentialsFromContext(ctx)
+	md := clientMetadataFromContext(ctx)
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		if k == traceIDMetadataKey || k == spanIDMetadataKey {
+			continue // differs for each call
+		}
+		keys = append(keys, k)
+	}
+	if cred == nil && len(keys) == 0 {
+		return ""
+	}
+
+	h := sha256.New()
+	if cred != nil {
+		tok, err := cred.Token()
+		if err != nil {
+			// Return a key part that is never returned again, so that
+			// nothing is shared with other calls.
+			return fmt.Sprintf("error %d", atomic.AddUint64(&credentialsErrorCount, 1))
+		}
+		fmt.Fprintf(h, "%q %q\n", tok.Type(), tok.AccessToken)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(h, "%q: %q\n", k, md[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,264 @@
+package sourcegraph
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A CacheStorage stores the cached API responses of a StorageCache.
+// Implementations must be safe for concurrent use.
+type CacheStorage interface {
+	// Get returns the entry stored under key, if any. It may return
+	// expired entries.
+	Get(key string) (e *CacheEntry, ok bool)
+
+	// Set stores e under key, replacing any existing entry.
+	Set(key string, e *CacheEntry) error
+
+	// Delete deletes the entry stored under key (if any).
+	Delete(key string) error
+
+	// Walk calls fn for each stored entry. It stops and returns the
+	// error if fn returns an error.
+	Walk(fn func(key string, e *CacheEntry) error) error
+}
+
+// A CacheEntry is a cached API response.
+type CacheEntry struct {
+	Method  string    // the API method (e.g., "Repos.Get")
+	Repo    string    // the URI of the repository that the request refers to (if any)
+	Data    []byte    // the marshaled response
+	Expires time.Time // when the entry expires (as set by the server's cache-control trailer)
+}
+
+func (e *CacheEntry) size() int64 {
+	return int64(len(e.Method) + len(e.Repo) + len(e.Data))
+}
+
+// LRUCacheStorage is an in-memory CacheStorage that evicts the least
+// recently used entries when its size limit is exceeded.
+type LRUCacheStorage struct {
+	// MaxBytes is the maximum total size of the stored keys and
+	// entries. If zero, the size is not limited.
+	MaxBytes int64
+
+	// TTL is the maximum time that an entry is stored, even if the
+	// server allowed it to be cached for longer. If zero, entries are
+	// stored until they expire.
+	TTL time.Duration
+
+	mu    sync.Mutex
+	ll    *list.List // of *lruItem, most recently used first
+	items map[string]*list.Element
+	size  int64
+}
+
+type lruItem struct {
+	key   string
+	entry *CacheEntry
+	added time.Time
+}
+
+// NewLRUCacheStorage returns a new in-memory LRU cache storage with
+// the given size limit (in bytes) and TTL. See the LRUCacheStorage
+// fields for the meaning of zero values.
+func NewLRUCacheStorage(maxBytes int64, ttl time.Duration) *LRUCacheStorage {
+	return &LRUCacheStorage{MaxBytes: maxBytes, TTL: ttl}
+}
+
+// Get implements CacheStorage.
+func (s *LRUCacheStorage) Get(key string) (*CacheEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+	item := el.Value.(*lruItem)
+	if s.TTL > 0 && time.Since(item.added) > s.TTL {
+		s.removeElement(el)
+		return nil, false
+	}
+	s.ll.MoveToFront(el)
+	return item.entry, true
+}
+
+// Set implements CacheStorage.
+func (s *LRUCacheStorage) Set(key string, e *CacheEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.items == nil {
+		s.ll = list.New()
+		s.items = map[string]*list.Element{}
+	}
+	if el, ok := s.items[key]; ok {
+		s.removeElement(el)
+	}
+	s.items[key] = s.ll.PushFront(&lruItem{key: key, entry: e, added: time.Now()})
+	s.size += int64(len(key)) + e.size()
+	for s.MaxBytes > 0 && s.size > s.MaxBytes {
+		s.removeElement(s.ll.Back())
+	}
+	return nil
+}
+
+// Delete implements CacheStorage.
+func (s *LRUCacheStorage) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if el, ok := s.items[key]; ok {
+		s.removeElement(el)
+	}
+	return nil
+}
+
+// Walk implements CacheStorage. It must not be called by fn.
+func (s *LRUCacheStorage) Walk(fn func(key string, e *CacheEntry) error) error {
+	s.mu.Lock()
+	items := make([]*lruItem, 0, len(s.items))
+	for _, el := range s.items {
+		items = append(items, el.Value.(*lruItem))
+	}
+	s.mu.Unlock()
+
+	for _, item := range items {
+		if err := fn(item.key, item.entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Len returns the number of stored entries.
+func (s *LRUCacheStorage) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+func (s *LRUCacheStorage) removeElement(el *list.Element) {
+	item := s.ll.Remove(el).(*lruItem)
+	delete(s.items, item.key)
+	s.size -= int64(len(item.key)) + item.entry.size()
+}
+
+// DiskCacheStorage is a CacheStorage that stores each entry in a file
+// in a directory, so that cached responses persist across processes
+// (e.g., runs of a CLI tool). The file's name is derived from the
+// entry's key, which consists of the method name and a hash of the
+// marshaled request (see StorageCache).
+type DiskCacheStorage struct {
+	Dir string // the directory that contains the entry files
+}
+
+// NewDiskCacheStorage returns a disk cache storage that stores entries
+// in dir, which is created if it doesn't exist.
+func NewDiskCacheStorage(dir string) (*DiskCacheStorage, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &DiskCacheStorage{Dir: dir}, nil
+}
+
+// diskCacheFile is the JSON-encoded contents of an entry file.
+type diskCacheFile struct {
+	Key   string
+	Entry *CacheEntry
+}
+
+// diskCacheTempPrefix is the file name prefix of entry files that are
+// being written.
+const diskCacheTempPrefix = ".tmp-"
+
+func (s *DiskCacheStorage) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.Dir, hex.EncodeToString(sum[:]))
+}
+
+// Get implements CacheStorage. Entry files that can't be read are
+// treated as missing.
+func (s *DiskCacheStorage) Get(key string) (*CacheEntry, bool) {
+	f, err := s.readFile(s.path(key))
+	if err != nil || f.Key != key {
+		return nil, false
+	}
+	return f.Entry, true
+}
+
+// Set implements CacheStorage.
+func (s *DiskCacheStorage) Set(key string, e *CacheEntry) error {
+	data, err := json.Marshal(diskCacheFile{Key: key, Entry: e})
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file and then rename it, so that readers
+	// never see a partially written file.
+	tmp, err := ioutil.TempFile(s.Dir, diskCacheTempPrefix)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+// Delete implements CacheStorage.
+func (s *DiskCacheStorage) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Walk implements CacheStorage. Entry files that can't be read are
+// skipped.
+func (s *DiskCacheStorage) Walk(fn func(key string, e *CacheEntry) error) error {
+	fis, err := ioutil.ReadDir(s.Dir)
+	if err != nil {
+		return err
+	}
+	for _, fi := range fis {
+		if fi.IsDir() || strings.HasPrefix(fi.Name(), diskCacheTempPrefix) {
+			continue
+		}
+		f, err := s.readFile(filepath.Join(s.Dir, fi.Name()))
+		if err != nil {
+			continue
+		}
+		if err := fn(f.Key, f.Entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *DiskCacheStorage) readFile(path string) (*diskCacheFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f diskCacheFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	if f.Entry == nil {
+		return nil, os.ErrNotExist
+	}
+	return &f, nil
+}
@@ -0,0 +1,106 @@
+package sourcegraph
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func testCacheStorage(t *testing.T, s CacheStorage) {
+	if _, ok := s.Get("k"); ok {
+		t.Fatal("got ok == true for missing key")
+	}
+
+	e := &CacheEntry{Method: "Repos.Get", Repo: "r", Data: []byte("x"), Expires: time.Unix(1e9, 0).UTC()}
+	if err := s.Set("k", e); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := s.Get("k"); !ok || !reflect.DeepEqual(got, e) {
+		t.Errorf("got entry %+v (ok == %v), want %+v", got, ok, e)
+	}
+
+	if err := s.Set("k2", &CacheEntry{Data: []byte("y")}); err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	if err := s.Walk(func(key string, e *CacheEntry) error {
+		keys = append(keys, key)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(keys)
+	if want := []string{"k", "k2"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("got walked keys %v, want %v", keys, want)
+	}
+
+	if err := s.Delete("k"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Get("k"); ok {
+		t.Error("got ok == true for deleted key")
+	}
+	if err := s.Delete("k"); err != nil {
+		t.Errorf("deleting missing key: %s", err)
+	}
+}
+
+func TestLRUCacheStorage(t *testing.T) {
+	testCacheStorage(t, NewLRUCacheStorage(0, 0))
+}
+
+func TestLRUCacheStorage_evict(t *testing.T) {
+	s := NewLRUCacheStorage(30, 0)
+	s.Set("a", &CacheEntry{Data: make([]byte, 9)}) // size 10
+	s.Set("b", &CacheEntry{Data: make([]byte, 9)})
+	s.Set("c", &CacheEntry{Data: make([]byte, 9)})
+	s.Get("a") // make "b" the least recently used
+	s.Set("d", &CacheEntry{Data: make([]byte, 9)})
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true, "d": true} {
+		if _, ok := s.Get(key); ok != want {
+			t.Errorf("%s: got ok == %v, want %v", key, ok, want)
+		}
+	}
+	if got, want := s.Len(), 3; got != want {
+		t.Errorf("got Len %d, want %d", got, want)
+	}
+}
+
+func TestLRUCacheStorage_TTL(t *testing.T) {
+	s := NewLRUCacheStorage(0, time.Millisecond)
+	s.Set("k", &CacheEntry{})
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := s.Get("k"); ok {
+		t.Error("got ok == true after TTL")
+	}
+	if got := s.Len(); got != 0 {
+		t.Errorf("got Len %d after TTL, want 0", got)
+	}
+}
+
+func TestDiskCacheStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disk-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := NewDiskCacheStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCacheStorage(t, s)
+
+	// Entries persist across instances.
+	s2, err := NewDiskCacheStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s2.Get("k2"); !ok {
+		t.Error("got ok == false for entry stored by another instance")
+	}
+}
@@ -0,0 +1,87 @@
+package sourcegraph
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"sourcegraph.com/sourcegraph/grpccache"
+)
+
+type errorTokenSource struct{}
+
+func (errorTokenSource) Token() (*oauth2.Token, error) { return nil, errors.New("x") }
+
+func TestCredentialsCacheKeyPart(t *testing.T) {
+	withToken := func(tok string) context.Context {
+		return WithCredentials(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{TokenType: "Bearer", AccessToken: tok}))
+	}
+
+	if got := CredentialsCacheKeyPart(context.Background()); got != "" {
+		t.Errorf("got key part %q without credentials, want empty", got)
+	}
+	if CredentialsCacheKeyPart(withToken("a")) != CredentialsCacheKeyPart(withToken("a")) {
+		t.Error("got different key parts for the same credentials")
+	}
+	if CredentialsCacheKeyPart(withToken("a")) == CredentialsCacheKeyPart(withToken("b")) {
+		t.Error("got the same key part for different credentials")
+	}
+	if CredentialsCacheKeyPart(withToken("a")) == "" {
+		t.Error("got empty key part with credentials")
+	}
+
+	ctx := WithClientMetadata(context.Background(), map[string]string{"authorization": "x"})
+	if CredentialsCacheKeyPart(ctx) == "" {
+		t.Error("got empty key part with client metadata")
+	}
+	if CredentialsCacheKeyPart(ctx) == CredentialsCacheKeyPart(WithClientMetadata(context.Background(), map[string]string{"authorization": "y"})) {
+		t.Error("got the same key part for different client metadata")
+	}
+
+	ctx = WithCredentials(context.Background(), errorTokenSource{})
+	if CredentialsCacheKeyPart(ctx) == CredentialsCacheKeyPart(ctx) {
+		t.Error("got the same key part twice when the token source fails")
+	}
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	cache := &grpccache.Cache{KeyPart: CredentialsCacheKeyPart}
+	c := NewClientWithOptions(nil, ClientOptions{Cache: cache})
+	if got := c.Repos.(*cacheHitTracedReposClient).ReposClient.(*CachedReposClient).Cache; got != cache {
+		t.Errorf("got Repos cache %p, want %p", got, cache)
+	}
+
+	// A cache without a KeyPart is rejected, because it would share
+	// responses between users.
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("got no panic for a cache without a KeyPart")
+			}
+		}()
+		NewClientWithOptions(nil, ClientOptions{Cache: &grpccache.Cache{}})
+	}()
+
+	c = NewClientWithOptions(nil, ClientOptions{})
+	if got := c.Repos.(*CachedReposClient).Cache; got != nil {
+		t.Errorf("got Repos cache %p, want nil", got)
+	}
+
+	// The storage cache wraps the underlying client.
+	storageCache := &StorageCache{}
+	c = NewClientWithOptions(nil, ClientOptions{StorageCache: storageCache})
+	if got := c.Repos.(*CachedReposClient).ReposClient.(*StorageCachedReposClient).Cache; got != storageCache {
+		t.Errorf("got Repos storage cache %p, want %p", got, storageCache)
+	}
+}
+
+func TestCacheFromContext(t *testing.T) {
+	if got := cacheFromContext(context.Background()); got != Cache {
+		t.Errorf("got cache %p, want package-level Cache", got)
+	}
+	cache := &grpccache.Cache{}
+	if got := cacheFromContext(WithCache(context.Background(), cache)); got != cache {
+		t.Errorf("got cache %p, want %p", got, cache)
+	}
+}
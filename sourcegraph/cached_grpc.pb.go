@@ -21,6 +21,124 @@ import (
 	"sourcegraph.com/sqs/pbtypes"
 )
 
+type CachedAccessTokensServer struct{ AccessTokensServer }
+
+func (s *CachedAccessTokensServer) Create(ctx context.Context, in *AccessTokensCreateOp) (*NewAccessToken, error) {
+	ctx, cc := grpccache.Internal_WithCacheControl(ctx)
+	result, err := s.AccessTokensServer.Create(ctx, in)
+	if !cc.IsZero() {
+		if err := grpccache.Internal_SetCacheControlTrailer(ctx, *cc); err != nil {
+			return nil, err
+		}
+	}
+	return result, err
+}
+
+func (s *CachedAccessTokensServer) List(ctx context.Context, in *AccessTokensListOptions) (*AccessTokenList, error) {
+	ctx, cc := grpccache.Internal_WithCacheControl(ctx)
+	result, err := s.AccessTokensServer.List(ctx, in)
+	if !cc.IsZero() {
+		if err := grpccache.Internal_SetCacheControlTrailer(ctx, *cc); err != nil {
+			return nil, err
+		}
+	}
+	return result, err
+}
+
+func (s *CachedAccessTokensServer) Revoke(ctx context.Context, in *AccessTokenSpec) (*pbtypes.Void, error) {
+	ctx, cc := grpccache.Internal_WithCacheControl(ctx)
+	result, err := s.AccessTokensServer.Revoke(ctx, in)
+	if !cc.IsZero() {
+		if err := grpccache.Internal_SetCacheControlTrailer(ctx, *cc); err != nil {
+			return nil, err
+		}
+	}
+	return result, err
+}
+
+type CachedAccessTokensClient struct {
+	AccessTokensClient
+	Cache *grpccache.Cache
+}
+
+func (s *CachedAccessTokensClient) Create(ctx context.Context, in *AccessTokensCreateOp, opts ...grpc.CallOption) (*NewAccessToken, error) {
+	if s.Cache != nil {
+		var cachedResult NewAccessToken
+		cached, err := s.Cache.Get(ctx, "AccessTokens.Create", in, &cachedResult)
+		if err != nil {
+			return nil, err
+		}
+		if cached {
+			return &cachedResult, nil
+		}
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AccessTokensClient.Create(ctx, in, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, err
+	}
+	if s.Cache != nil {
+		if err := s.Cache.Store(ctx, "AccessTokens.Create", in, result, trailer); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (s *CachedAccessTokensClient) List(ctx context.Context, in *AccessTokensListOptions, opts ...grpc.CallOption) (*AccessTokenList, error) {
+	if s.Cache != nil {
+		var cachedResult AccessTokenList
+		cached, err := s.Cache.Get(ctx, "AccessTokens.List", in, &cachedResult)
+		if err != nil {
+			return nil, err
+		}
+		if cached {
+			return &cachedResult, nil
+		}
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AccessTokensClient.List(ctx, in, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, err
+	}
+	if s.Cache != nil {
+		if err := s.Cache.Store(ctx, "AccessTokens.List", in, result, trailer); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (s *CachedAccessTokensClient) Revoke(ctx context.Context, in *AccessTokenSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache != nil {
+		var cachedResult pbtypes.Void
+		cached, err := s.Cache.Get(ctx, "AccessTokens.Revoke", in, &cachedResult)
+		if err != nil {
+			return nil, err
+		}
+		if cached {
+			return &cachedResult, nil
+		}
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AccessTokensClient.Revoke(ctx, in, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, err
+	}
+	if s.Cache != nil {
+		if err := s.Cache.Store(ctx, "AccessTokens.Revoke", in, result, trailer); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
 type CachedAccountsServer struct{ AccountsServer }
 
 func (s *CachedAccountsServer) Create(ctx context.Context, in *NewAccount) (*UserSpec, error) {
@@ -176,6 +294,50 @@ func (s *CachedAccountsClient) Update(ctx context.Context, in *User, opts ...grp
 	return result, nil
 }
 
+type CachedAuditLogServer struct{ AuditLogServer }
+
+func (s *CachedAuditLogServer) List(ctx context.Context, in *AuditEventListOptions) (*AuditEventList, error) {
+	ctx, cc := grpccache.Internal_WithCacheControl(ctx)
+	result, err := s.AuditLogServer.List(ctx, in)
+	if !cc.IsZero() {
+		if err := grpccache.Internal_SetCacheControlTrailer(ctx, *cc); err != nil {
+			return nil, err
+		}
+	}
+	return result, err
+}
+
+type CachedAuditLogClient struct {
+	AuditLogClient
+	Cache *grpccache.Cache
+}
+
+func (s *CachedAuditLogClient) List(ctx context.Context, in *AuditEventListOptions, opts ...grpc.CallOption) (*AuditEventList, error) {
+	if s.Cache != nil {
+		var cachedResult AuditEventList
+		cached, err := s.Cache.Get(ctx, "AuditLog.List", in, &cachedResult)
+		if err != nil {
+			return nil, err
+		}
+		if cached {
+			return &cachedResult, nil
+		}
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AuditLogClient.List(ctx, in, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, err
+	}
+	if s.Cache != nil {
+		if err := s.Cache.Store(ctx, "AuditLog.List", in, result, trailer); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
 type CachedAuthServer struct{ AuthServer }
 
 func (s *CachedAuthServer) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest) (*AuthorizationCode, error) {
@@ -3565,6 +3727,161 @@ func (s *CachedReposClient) ListCommitters(ctx context.Context, in *ReposListCom
 	return result, nil
 }
 
+type CachedSavedSearchesServer struct{ SavedSearchesServer }
+
+func (s *CachedSavedSearchesServer) Create(ctx context.Context, in *SavedSearchesCreateOp) (*SavedSearch, error) {
+	ctx, cc := grpccache.Internal_WithCacheControl(ctx)
+	result, err := s.SavedSearchesServer.Create(ctx, in)
+	if !cc.IsZero() {
+		if err := grpccache.Internal_SetCacheControlTrailer(ctx, *cc); err != nil {
+			return nil, err
+		}
+	}
+	return result, err
+}
+
+func (s *CachedSavedSearchesServer) List(ctx context.Context, in *SavedSearchListOptions) (*SavedSearchList, error) {
+	ctx, cc := grpccache.Internal_WithCacheControl(ctx)
+	result, err := s.SavedSearchesServer.List(ctx, in)
+	if !cc.IsZero() {
+		if err := grpccache.Internal_SetCacheControlTrailer(ctx, *cc); err != nil {
+			return nil, err
+		}
+	}
+	return result, err
+}
+
+func (s *CachedSavedSearchesServer) Delete(ctx context.Context, in *SavedSearchSpec) (*pbtypes.Void, error) {
+	ctx, cc := grpccache.Internal_WithCacheControl(ctx)
+	result, err := s.SavedSearchesServer.Delete(ctx, in)
+	if !cc.IsZero() {
+		if err := grpccache.Internal_SetCacheControlTrailer(ctx, *cc); err != nil {
+			return nil, err
+		}
+	}
+	return result, err
+}
+
+func (s *CachedSavedSearchesServer) Run(ctx context.Context, in *SavedSearchSpec) (*SavedSearchRunResult, error) {
+	ctx, cc := grpccache.Internal_WithCacheControl(ctx)
+	result, err := s.SavedSearchesServer.Run(ctx, in)
+	if !cc.IsZero() {
+		if err := grpccache.Internal_SetCacheControlTrailer(ctx, *cc); err != nil {
+			return nil, err
+		}
+	}
+	return result, err
+}
+
+type CachedSavedSearchesClient struct {
+	SavedSearchesClient
+	Cache *grpccache.Cache
+}
+
+func (s *CachedSavedSearchesClient) Create(ctx context.Context, in *SavedSearchesCreateOp, opts ...grpc.CallOption) (*SavedSearch, error) {
+	if s.Cache != nil {
+		var cachedResult SavedSearch
+		cached, err := s.Cache.Get(ctx, "SavedSearches.Create", in, &cachedResult)
+		if err != nil {
+			return nil, err
+		}
+		if cached {
+			return &cachedResult, nil
+		}
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SavedSearchesClient.Create(ctx, in, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, err
+	}
+	if s.Cache != nil {
+		if err := s.Cache.Store(ctx, "SavedSearches.Create", in, result, trailer); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (s *CachedSavedSearchesClient) List(ctx context.Context, in *SavedSearchListOptions, opts ...grpc.CallOption) (*SavedSearchList, error) {
+	if s.Cache != nil {
+		var cachedResult SavedSearchList
+		cached, err := s.Cache.Get(ctx, "SavedSearches.List", in, &cachedResult)
+		if err != nil {
+			return nil, err
+		}
+		if cached {
+			return &cachedResult, nil
+		}
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SavedSearchesClient.List(ctx, in, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, err
+	}
+	if s.Cache != nil {
+		if err := s.Cache.Store(ctx, "SavedSearches.List", in, result, trailer); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (s *CachedSavedSearchesClient) Delete(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache != nil {
+		var cachedResult pbtypes.Void
+		cached, err := s.Cache.Get(ctx, "SavedSearches.Delete", in, &cachedResult)
+		if err != nil {
+			return nil, err
+		}
+		if cached {
+			return &cachedResult, nil
+		}
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SavedSearchesClient.Delete(ctx, in, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, err
+	}
+	if s.Cache != nil {
+		if err := s.Cache.Store(ctx, "SavedSearches.Delete", in, result, trailer); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (s *CachedSavedSearchesClient) Run(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*SavedSearchRunResult, error) {
+	if s.Cache != nil {
+		var cachedResult SavedSearchRunResult
+		cached, err := s.Cache.Get(ctx, "SavedSearches.Run", in, &cachedResult)
+		if err != nil {
+			return nil, err
+		}
+		if cached {
+			return &cachedResult, nil
+		}
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SavedSearchesClient.Run(ctx, in, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, err
+	}
+	if s.Cache != nil {
+		if err := s.Cache.Store(ctx, "SavedSearches.Run", in, result, trailer); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
 type CachedSearchServer struct{ SearchServer }
 
 func (s *CachedSearchServer) Search(ctx context.Context, in *SearchOptions) (*SearchResults, error) {
@@ -3600,6 +3917,17 @@ func (s *CachedSearchServer) SearchText(ctx context.Context, in *TextSearchOptio
 	return result, err
 }
 
+func (s *CachedSearchServer) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions) (*MultiTextSearchResults, error) {
+	ctx, cc := grpccache.Internal_WithCacheControl(ctx)
+	result, err := s.SearchServer.SearchTextMulti(ctx, in)
+	if !cc.IsZero() {
+		if err := grpccache.Internal_SetCacheControlTrailer(ctx, *cc); err != nil {
+			return nil, err
+		}
+	}
+	return result, err
+}
+
 func (s *CachedSearchServer) Complete(ctx context.Context, in *RawQuery) (*Completions, error) {
 	ctx, cc := grpccache.Internal_WithCacheControl(ctx)
 	result, err := s.SearchServer.Complete(ctx, in)
@@ -3705,6 +4033,32 @@ func (s *CachedSearchClient) SearchText(ctx context.Context, in *TextSearchOptio
 	return result, nil
 }
 
+func (s *CachedSearchClient) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions, opts ...grpc.CallOption) (*MultiTextSearchResults, error) {
+	if s.Cache != nil {
+		var cachedResult MultiTextSearchResults
+		cached, err := s.Cache.Get(ctx, "Search.SearchTextMulti", in, &cachedResult)
+		if err != nil {
+			return nil, err
+		}
+		if cached {
+			return &cachedResult, nil
+		}
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SearchClient.SearchTextMulti(ctx, in, grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, err
+	}
+	if s.Cache != nil {
+		if err := s.Cache.Store(ctx, "Search.SearchTextMulti", in, result, trailer); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
 func (s *CachedSearchClient) Complete(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*Completions, error) {
 	if s.Cache != nil {
 		var cachedResult Completions
@@ -9,7 +9,9 @@ import (
 // is done using gRPC over HTTP/2.
 type Client struct {
 	// Services used to communicate with different parts of the Sourcegraph API.
+	AccessTokens        AccessTokensClient
 	Accounts            AccountsClient
+	AuditLog            AuditLogClient
 	Auth                AuthClient
 	Builds              BuildsClient
 	Defs                DefsClient
@@ -28,6 +30,7 @@ type Client struct {
 	RepoStatuses        RepoStatusesClient
 	RepoTree            RepoTreeClient
 	Repos               ReposClient
+	SavedSearches       SavedSearchesClient
 	Storage             StorageClient
 	Changesets          ChangesetsClient
 	Search              SearchClient
@@ -38,42 +41,152 @@ type Client struct {
 	// gRPC client connection used to communicate with the Sourcegraph
 	// API.
 	Conn *grpc.ClientConn
+
+	release func() // releases the pooled Conn, or closes an unpooled one (nil if not dialed)
+}
+
+// Close releases the client's pooled connection (if it was
+// constructed using NewClientFromContext), so that the pool may close
+// the connection once it is idle (see ConnPool.IdleTimeout). It
+// doesn't close a connection that other clients still use. The client
+// (and its services) must not be used after Close.
+//
+// Until a client is closed, its connection is never evicted for being
+// idle. If the connection isn't pooled (because the TLS config has
+// verification or client certificate callbacks), Close closes it.
+// Close does nothing if the client was constructed with
+// NewClient or NewClientWithOptions.
+func (c *Client) Close() error {
+	if c.release != nil {
+		c.release()
+	}
+	return nil
 }
 
-// Cache is the gRPC cache used to cache API responses.
+// Cache is the gRPC cache used to cache API responses of clients
+// constructed with NewClient (and of clients constructed with
+// NewClientFromContext, if no cache is set in the context using
+// WithCache). If set, its KeyPart must be set (see
+// ClientOptions.Cache).
 var Cache *grpccache.Cache
 
-// NewClient returns a Sourcegraph API client.
+// ClientOptions configure a Client constructed with
+// NewClientWithOptions.
+type ClientOptions struct {
+	// Cache is the gRPC cache used to cache API responses. If nil,
+	// responses are not cached.
+	//
+	// The cache's KeyPart must be set (NewClientWithOptions panics
+	// otherwise), because the cache may be shared by clients that
+	// use different credentials. Use CredentialsCacheKeyPart (or a
+	// func that calls it) so that cached responses obtained using
+	// one user's credentials are never returned to another user.
+	Cache *grpccache.Cache
+
+	// StorageCache caches API responses in a pluggable storage backend
+	// (such as an on-disk cache that persists across processes). If
+	// nil, it is not used.
+	StorageCache *StorageCache
+
+	// RateLimits limit the rate and concurrency of API calls. If nil,
+	// calls are not limited. Cached responses don't count toward the
+	// limits.
+	RateLimits *RateLimits
+}
+
+// NewClient returns a Sourcegraph API client that uses the
+// package-level Cache.
 func NewClient(conn *grpc.ClientConn) *Client {
+	return NewClientWithOptions(conn, ClientOptions{Cache: Cache})
+}
+
+// NewClientWithOptions returns a Sourcegraph API client configured
+// with opts.
+func NewClientWithOptions(conn *grpc.ClientConn, opts ClientOptions) *Client {
 	c := new(Client)
+	cache := opts.Cache
+	if cache != nil && cache.KeyPart == nil {
+		panic("ClientOptions.Cache has no KeyPart (use CredentialsCacheKeyPart)")
+	}
 
 	// gRPC (HTTP/2)
 	c.Conn = conn
-	c.Accounts = &CachedAccountsClient{NewAccountsClient(conn), Cache}
-	c.Auth = &CachedAuthClient{NewAuthClient(conn), Cache}
-	c.Builds = &CachedBuildsClient{NewBuildsClient(conn), Cache}
-	c.Defs = &CachedDefsClient{NewDefsClient(conn), Cache}
-	c.Deltas = &CachedDeltasClient{NewDeltasClient(conn), Cache}
-	c.Discussions = &CachedDiscussionsClient{NewDiscussionsClient(conn), Cache}
-	c.GraphUplink = &CachedGraphUplinkClient{NewGraphUplinkClient(conn), Cache}
-	c.Markdown = &CachedMarkdownClient{NewMarkdownClient(conn), Cache}
-	c.Meta = &CachedMetaClient{NewMetaClient(conn), Cache}
-	c.MirrorRepos = &CachedMirrorReposClient{NewMirrorReposClient(conn), Cache}
-	c.MirroredRepoSSHKeys = &CachedMirroredRepoSSHKeysClient{NewMirroredRepoSSHKeysClient(conn), Cache}
-	c.Notify = &CachedNotifyClient{NewNotifyClient(conn), Cache}
-	c.Orgs = &CachedOrgsClient{NewOrgsClient(conn), Cache}
-	c.People = &CachedPeopleClient{NewPeopleClient(conn), Cache}
-	c.RegisteredClients = &CachedRegisteredClientsClient{NewRegisteredClientsClient(conn), Cache}
-	c.RepoBadges = &CachedRepoBadgesClient{NewRepoBadgesClient(conn), Cache}
-	c.RepoStatuses = &CachedRepoStatusesClient{NewRepoStatusesClient(conn), Cache}
-	c.RepoTree = &CachedRepoTreeClient{NewRepoTreeClient(conn), Cache}
-	c.Repos = &CachedReposClient{NewReposClient(conn), Cache}
-	c.Storage = &CachedStorageClient{NewStorageClient(conn), Cache}
-	c.Changesets = &CachedChangesetsClient{NewChangesetsClient(conn), Cache}
-	c.Search = &CachedSearchClient{NewSearchClient(conn), Cache}
-	c.Units = &CachedUnitsClient{NewUnitsClient(conn), Cache}
-	c.Users = &CachedUsersClient{NewUsersClient(conn), Cache}
-	c.UserKeys = &CachedUserKeysClient{NewUserKeysClient(conn), Cache}
+	c.AccessTokens = NewAccessTokensClient(conn)
+	c.Accounts = NewAccountsClient(conn)
+	c.AuditLog = NewAuditLogClient(conn)
+	c.Auth = NewAuthClient(conn)
+	c.Builds = NewBuildsClient(conn)
+	c.Defs = NewDefsClient(conn)
+	c.Deltas = NewDeltasClient(conn)
+	c.Discussions = NewDiscussionsClient(conn)
+	c.GraphUplink = NewGraphUplinkClient(conn)
+	c.Markdown = NewMarkdownClient(conn)
+	c.Meta = NewMetaClient(conn)
+	c.MirrorRepos = NewMirrorReposClient(conn)
+	c.MirroredRepoSSHKeys = NewMirroredRepoSSHKeysClient(conn)
+	c.Notify = NewNotifyClient(conn)
+	c.Orgs = NewOrgsClient(conn)
+	c.People = NewPeopleClient(conn)
+	c.RegisteredClients = NewRegisteredClientsClient(conn)
+	c.RepoBadges = NewRepoBadgesClient(conn)
+	c.RepoStatuses = NewRepoStatusesClient(conn)
+	c.RepoTree = NewRepoTreeClient(conn)
+	c.Repos = NewReposClient(conn)
+	c.SavedSearches = NewSavedSearchesClient(conn)
+	c.Storage = NewStorageClient(conn)
+	c.Changesets = NewChangesetsClient(conn)
+	c.Search = NewSearchClient(conn)
+	c.Units = NewUnitsClient(conn)
+	c.Users = NewUsersClient(conn)
+	c.UserKeys = NewUserKeysClient(conn)
+
+	if opts.RateLimits != nil {
+		rateLimitClient(c, opts.RateLimits)
+	}
+
+	// The storage cache wraps the underlying clients (not the
+	// Cached*Client wrappers), because it needs the calls' trailers.
+	if opts.StorageCache != nil {
+		storageCacheClient(c, opts.StorageCache)
+	}
+
+	if cache != nil {
+		// Tag the spans of calls that the Cached*Client wrappers
+		// answer from the cache as cache hits.
+		traceCacheMissClient(c)
+	}
+
+	c.AccessTokens = &CachedAccessTokensClient{c.AccessTokens, cache}
+	c.Accounts = &CachedAccountsClient{c.Accounts, cache}
+	c.AuditLog = &CachedAuditLogClient{c.AuditLog, cache}
+	c.Auth = &CachedAuthClient{c.Auth, cache}
+	c.Builds = &CachedBuildsClient{c.Builds, cache}
+	c.Defs = &CachedDefsClient{c.Defs, cache}
+	c.Deltas = &CachedDeltasClient{c.Deltas, cache}
+	c.Discussions = &CachedDiscussionsClient{c.Discussions, cache}
+	c.GraphUplink = &CachedGraphUplinkClient{c.GraphUplink, cache}
+	c.Markdown = &CachedMarkdownClient{c.Markdown, cache}
+	c.Meta = &CachedMetaClient{c.Meta, cache}
+	c.MirrorRepos = &CachedMirrorReposClient{c.MirrorRepos, cache}
+	c.MirroredRepoSSHKeys = &CachedMirroredRepoSSHKeysClient{c.MirroredRepoSSHKeys, cache}
+	c.Notify = &CachedNotifyClient{c.Notify, cache}
+	c.Orgs = &CachedOrgsClient{c.Orgs, cache}
+	c.People = &CachedPeopleClient{c.People, cache}
+	c.RegisteredClients = &CachedRegisteredClientsClient{c.RegisteredClients, cache}
+	c.RepoBadges = &CachedRepoBadgesClient{c.RepoBadges, cache}
+	c.RepoStatuses = &CachedRepoStatusesClient{c.RepoStatuses, cache}
+	c.RepoTree = &CachedRepoTreeClient{c.RepoTree, cache}
+	c.Repos = &CachedReposClient{c.Repos, cache}
+	c.SavedSearches = &CachedSavedSearchesClient{c.SavedSearches, cache}
+	c.Storage = &CachedStorageClient{c.Storage, cache}
+	c.Changesets = &CachedChangesetsClient{c.Changesets, cache}
+	c.Search = &CachedSearchClient{c.Search, cache}
+	c.Units = &CachedUnitsClient{c.Units, cache}
+	c.Users = &CachedUsersClient{c.Users, cache}
+	c.UserKeys = &CachedUserKeysClient{c.UserKeys, cache}
+	if cache != nil {
+		traceCacheHitClient(c)
+	}
 
 	return c
 }
@@ -2,74 +2,305 @@ package sourcegraph
 
 import (
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 )
 
-var (
-	connTargetMusMu sync.Mutex
-	connTargetMus   map[string]*sync.Mutex
+// A ConnPool is a pool of gRPC client connections, which are shared by
+// the API clients constructed using NewClientFromContext. Connections
+// are keyed on their target and TLS identity (see connKey).
+//
+// The zero value is an empty pool that never evicts idle connections.
+// A ConnPool is safe for concurrent use.
+type ConnPool struct {
+	// IdleTimeout is how long a connection may go without being used
+	// for an RPC before it is closed and evicted from the pool. If
+	// zero (or negative), idle connections are never evicted.
+	//
+	// Connections that are used by API clients (constructed using
+	// NewClientFromContext) are not evicted for being idle until all
+	// of the clients are closed (see Client.Close), so that the
+	// clients keep working.
+	IdleTimeout time.Duration
 
-	connsMu sync.Mutex
-	conns   map[string]*grpc.ClientConn // keyed on GRPC target (i.e., addr)
-)
+	keyMusMu sync.Mutex
+	keyMus   map[string]*keyMutex // per-key locks (deleted when unused)
+
+	mu      sync.Mutex
+	conns   map[string]*pooledConn    // keyed on connKey
+	stats   map[string]*ConnPoolStats // keyed on target
+	janitor chan struct{}             // closed to stop the janitor (nil if not running)
+}
+
+// ConnPoolStats are statistics about a ConnPool's connections to a
+// target.
+type ConnPoolStats struct {
+	Open      int // number of pooled connections
+	Dials     int // number of connections dialed
+	Reuses    int // number of times a pooled connection was reused
+	Failures  int // number of failed dials
+	Evictions int // number of connections that were closed and evicted
+}
+
+// DefaultConnPool is the connection pool used by NewClientFromContext,
+// unless another pool is set in the context using WithConnPool.
+var DefaultConnPool = &ConnPool{}
+
+// ClosePool closes all connections in DefaultConnPool. It is intended
+// to be called by tests and at shutdown. The pool may still be used
+// afterwards.
+func ClosePool() {
+	DefaultConnPool.Close()
+}
+
+// WithConnPool returns a copy of the parent context whose API clients
+// (constructed using NewClientFromContext) obtain their connections
+// from pool instead of DefaultConnPool.
+func WithConnPool(parent context.Context, pool *ConnPool) context.Context {
+	return context.WithValue(parent, connPoolKey, pool)
+}
 
-// lockTargetMutex creates the mutex for target (if it doesn't already
-// exist) and obtains the lock. It is used to implement per-target
-// locks for pooledGRPCDial.
-func lockTargetMutex(target string) *sync.Mutex {
-	connTargetMusMu.Lock()
-	if connTargetMus == nil {
-		connTargetMus = map[string]*sync.Mutex{}
+// connPoolFromContext returns the connection pool previously set in
+// the context by WithConnPool, or DefaultConnPool if none was set.
+func connPoolFromContext(ctx context.Context) *ConnPool {
+	if pool, _ := ctx.Value(connPoolKey).(*ConnPool); pool != nil {
+		return pool
 	}
-	if _, ok := connTargetMus[target]; !ok {
-		connTargetMus[target] = new(sync.Mutex)
+	return DefaultConnPool
+}
+
+// A pooledConn is a connection in a ConnPool.
+type pooledConn struct {
+	*grpc.ClientConn
+	target   string
+	lastUsed int64 // UnixNano time of the last RPC (accessed atomically)
+	refs     int   // number of unreleased dial calls (protected by ConnPool.mu)
+}
+
+func (c *pooledConn) touch() {
+	atomic.StoreInt64(&c.lastUsed, time.Now().UnixNano())
+}
+
+func (c *pooledConn) idleSince() time.Time {
+	return time.Unix(0, atomic.LoadInt64(&c.lastUsed))
+}
+
+// connActivity implements the credentials.Credentials interface. It
+// adds no metadata, but records each RPC on the connection so that
+// active connections aren't evicted.
+type connActivity struct{ conn *pooledConn }
+
+// GetRequestMetadata implements the credentials.Credentials interface.
+func (a connActivity) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	a.conn.touch()
+	return nil, nil
+}
+
+// RequireTransportSecurity implements the credentials.Credentials interface.
+func (connActivity) RequireTransportSecurity() bool {
+	return false
+}
+
+// A keyMutex is a per-key lock, with the number of goroutines that
+// hold or are waiting for it.
+type keyMutex struct {
+	sync.Mutex
+	refs int // protected by ConnPool.keyMusMu
+}
+
+// lockKey obtains the lock for key, creating it if it doesn't already
+// exist. The returned func releases the lock and deletes it if no
+// other goroutine is waiting for it. It is used to implement per-key
+// locks for dial.
+func (p *ConnPool) lockKey(key string) (unlock func()) {
+	p.keyMusMu.Lock()
+	if p.keyMus == nil {
+		p.keyMus = map[string]*keyMutex{}
+	}
+	mu := p.keyMus[key]
+	if mu == nil {
+		mu = new(keyMutex)
+		p.keyMus[key] = mu
 	}
-	mu := connTargetMus[target]
+	mu.refs++
+	p.keyMusMu.Unlock()
+
 	mu.Lock()
-	connTargetMusMu.Unlock()
-	return mu
+	return func() {
+		mu.Unlock()
+		p.keyMusMu.Lock()
+		mu.refs--
+		if mu.refs == 0 {
+			delete(p.keyMus, key)
+		}
+		p.keyMusMu.Unlock()
+	}
 }
 
-// pooledGRPCDial is a global connection pool for grpc.Dial.
-func pooledGRPCDial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
-	// Make sure we are the only goroutine dealing with this target.
-	targetMu := lockTargetMutex(target)
-	defer targetMu.Unlock()
+// dial returns the pooled connection with the given key, or dials
+// target (and adds the connection to the pool) if there is no such
+// connection or if it was closed.
+//
+// The connection is not evicted for being idle until the returned
+// release func is called (but it may still be closed by remove or
+// Close).
+func (p *ConnPool) dial(key, target string, opts ...grpc.DialOption) (conn *grpc.ClientConn, release func(), err error) {
+	// Make sure we are the only goroutine dealing with this key.
+	unlock := p.lockKey(key)
+	defer unlock()
 
-	connsMu.Lock()
-	if conns == nil {
-		conns = map[string]*grpc.ClientConn{}
-	}
-	if conn := conns[target]; conn != nil && conn.State() != grpc.Shutdown {
-		connsMu.Unlock()
-		return conn, nil
+	p.mu.Lock()
+	p.startJanitorLocked()
+	if pc := p.conns[key]; pc != nil {
+		if pc.State() != grpc.Shutdown {
+			p.statsLocked(target).Reuses++
+			pc.touch()
+			pc.refs++
+			p.mu.Unlock()
+			return pc.ClientConn, p.releaseFunc(pc), nil
+		}
+		p.evictLocked(key, pc)
 	}
-	connsMu.Unlock()
+	p.mu.Unlock()
 
-	conn, err := grpc.Dial(target, opts...)
+	pc := &pooledConn{target: target}
+	pc.touch()
+	opts = append(opts[:len(opts):len(opts)], grpc.WithPerRPCCredentials(connActivity{pc}))
+	conn, err = grpc.Dial(target, opts...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	if err != nil {
-		return nil, err
+		p.statsLocked(target).Failures++
+		return nil, nil, err
+	}
+	p.statsLocked(target).Dials++
+	pc.ClientConn = conn
+	pc.refs++
+	if p.conns == nil {
+		p.conns = map[string]*pooledConn{}
+	}
+	p.conns[key] = pc
+	return conn, p.releaseFunc(pc), nil
+}
+
+// releaseFunc returns a func that releases a reference to pc obtained
+// by dial. Calls after the first do nothing.
+func (p *ConnPool) releaseFunc(pc *pooledConn) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			pc.refs--
+			p.mu.Unlock()
+		})
+	}
+}
+
+// remove closes and evicts the connection with the given key (if
+// any).
+func (p *ConnPool) remove(key string) {
+	// Make sure we are the only goroutine dealing with this key.
+	unlock := p.lockKey(key)
+	defer unlock()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pc := p.conns[key]; pc != nil {
+		p.evictLocked(key, pc)
+	}
+}
+
+// EvictIdle closes and evicts the connections that haven't been used
+// for an RPC for at least IdleTimeout and that aren't used by any API
+// clients. If IdleTimeout is nonzero, it is called periodically after
+// the pool's first connection is dialed.
+func (p *ConnPool) EvictIdle() {
+	if p.IdleTimeout <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, pc := range p.conns {
+		if pc.refs == 0 && time.Since(pc.idleSince()) >= p.IdleTimeout {
+			p.evictLocked(key, pc)
+		}
 	}
+}
 
-	connsMu.Lock()
-	conns[target] = conn
-	connsMu.Unlock()
+// Close closes all connections in the pool. The pool may still be
+// used afterwards.
+func (p *ConnPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, pc := range p.conns {
+		p.evictLocked(key, pc)
+	}
+	if p.janitor != nil {
+		close(p.janitor)
+		p.janitor = nil
+	}
+}
 
-	return conn, nil
+// Stats returns statistics about the pool's connections, keyed on
+// target.
+func (p *ConnPool) Stats() map[string]ConnPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := make(map[string]ConnPoolStats, len(p.stats))
+	for target, s := range p.stats {
+		stats[target] = *s
+	}
+	for _, pc := range p.conns {
+		s := stats[pc.target]
+		s.Open++
+		stats[pc.target] = s
+	}
+	return stats
 }
 
-// removeConnFromPool deletes the ClientConnection to the specified target
-// from the connection pool.
-func removeConnFromPool(target string) {
-	// Make sure we are the only goroutine dealing with this target.
-	targetMu := lockTargetMutex(target)
-	defer targetMu.Unlock()
+func (p *ConnPool) statsLocked(target string) *ConnPoolStats {
+	if p.stats == nil {
+		p.stats = map[string]*ConnPoolStats{}
+	}
+	if p.stats[target] == nil {
+		p.stats[target] = new(ConnPoolStats)
+	}
+	return p.stats[target]
+}
 
-	connsMu.Lock()
-	defer connsMu.Unlock()
+func (p *ConnPool) evictLocked(key string, pc *pooledConn) {
+	delete(p.conns, key)
+	p.statsLocked(pc.target).Evictions++
+	pc.Close()
+}
 
-	if conns != nil {
-		conns[target] = nil
+// startJanitorLocked starts a goroutine that periodically evicts idle
+// connections, if IdleTimeout is nonzero and it isn't already
+// running.
+func (p *ConnPool) startJanitorLocked() {
+	if p.IdleTimeout <= 0 || p.janitor != nil {
+		return
+	}
+	interval := p.IdleTimeout / 2
+	if interval == 0 {
+		interval = p.IdleTimeout
 	}
+	stop := make(chan struct{})
+	p.janitor = stop
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				p.EvictIdle()
+			case <-stop:
+				return
+			}
+		}
+	}()
 }
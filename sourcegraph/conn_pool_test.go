@@ -0,0 +1,303 @@
+package sourcegraph
+
+import (
+	"net"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// startTestServer starts a gRPC server (with no services) and returns
+// its address.
+func startTestServer(t *testing.T) (addr string, stop func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	go s.Serve(l)
+	return l.Addr().String(), s.Stop
+}
+
+var testDialOpts = []grpc.DialOption{grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(time.Second)}
+
+func TestConnPool_reuse(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+	p := &ConnPool{}
+	defer p.Close()
+
+	c1, _, err := p.dial("k1", addr, testDialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, _, err := p.dial("k1", addr, testDialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1 != c2 {
+		t.Error("got different conns for the same key")
+	}
+	c3, _, err := p.dial("k2", addr, testDialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1 == c3 {
+		t.Error("got the same conn for different keys")
+	}
+
+	want := ConnPoolStats{Open: 2, Dials: 2, Reuses: 1}
+	if got := p.Stats()[addr]; got != want {
+		t.Errorf("got stats %+v, want %+v", got, want)
+	}
+}
+
+func TestConnPool_failure(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	p := &ConnPool{}
+	if _, _, err := p.dial(addr, addr, grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(100*time.Millisecond)); err == nil {
+		t.Fatal("got err == nil, want non-nil")
+	}
+	want := ConnPoolStats{Failures: 1}
+	if got := p.Stats()[addr]; got != want {
+		t.Errorf("got stats %+v, want %+v", got, want)
+	}
+}
+
+func TestConnPool_remove(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+	p := &ConnPool{}
+	defer p.Close()
+
+	c1, _, err := p.dial("k", addr, testDialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.remove("k")
+	if c1.State() != grpc.Shutdown {
+		t.Errorf("got removed conn state %v, want %v", c1.State(), grpc.Shutdown)
+	}
+
+	c2, _, err := p.dial("k", addr, testDialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1 == c2 {
+		t.Error("got removed conn, want new conn")
+	}
+	want := ConnPoolStats{Open: 1, Dials: 2, Evictions: 1}
+	if got := p.Stats()[addr]; got != want {
+		t.Errorf("got stats %+v, want %+v", got, want)
+	}
+}
+
+func TestConnPool_closed(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+	p := &ConnPool{}
+	defer p.Close()
+
+	c1, _, err := p.dial("k", addr, testDialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c1.Close()
+
+	c2, _, err := p.dial("k", addr, testDialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1 == c2 {
+		t.Error("got closed conn, want new conn")
+	}
+	want := ConnPoolStats{Open: 1, Dials: 2, Evictions: 1}
+	if got := p.Stats()[addr]; got != want {
+		t.Errorf("got stats %+v, want %+v", got, want)
+	}
+}
+
+func TestConnPool_EvictIdle(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+	p := &ConnPool{IdleTimeout: time.Hour}
+	defer p.Close()
+
+	active, release, err := p.dial("active", addr, testDialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	idle, release, err := p.dial("idle", addr, testDialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	release() // only the first call has an effect
+	referenced, _, err := p.dial("referenced", addr, testDialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Make all conns idle, and then record an RPC on one of them.
+	p.mu.Lock()
+	for _, pc := range p.conns {
+		pc.lastUsed = time.Now().Add(-2 * time.Hour).UnixNano()
+	}
+	activity := connActivity{p.conns["active"]}
+	p.mu.Unlock()
+	if _, err := activity.GetRequestMetadata(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	p.EvictIdle()
+	if active.State() == grpc.Shutdown {
+		t.Error("active conn was closed")
+	}
+	if referenced.State() == grpc.Shutdown {
+		t.Error("unreleased conn was closed")
+	}
+	if idle.State() != grpc.Shutdown {
+		t.Errorf("got idle conn state %v, want %v", idle.State(), grpc.Shutdown)
+	}
+	want := ConnPoolStats{Open: 2, Dials: 3, Evictions: 1}
+	if got := p.Stats()[addr]; got != want {
+		t.Errorf("got stats %+v, want %+v", got, want)
+	}
+}
+
+func TestConnPool_clientReference(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+	p := &ConnPool{}
+	defer p.Close()
+
+	refs := func() int {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		var refs int
+		for _, pc := range p.conns {
+			refs += pc.refs
+		}
+		return refs
+	}
+
+	ctx := WithConnPool(context.Background(), p)
+	ctx = WithGRPCEndpoint(ctx, &url.URL{Host: addr})
+	c := NewClientFromContext(ctx)
+	if got := refs(); got != 1 {
+		t.Errorf("got %d references to pooled conns, want 1 (the client's)", got)
+	}
+	c.Close()
+	c.Close() // only the first call has an effect
+	if got := refs(); got != 0 {
+		t.Errorf("got %d references to pooled conns after Close, want 0", got)
+	}
+
+	// Closing an unpooled client does nothing.
+	if err := NewClientWithOptions(nil, ClientOptions{}).Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConnPool_transientFailure(t *testing.T) {
+	addr, stop := startTestServer(t)
+	p := &ConnPool{}
+	defer p.Close()
+
+	c1, _, err := p.dial("k", addr, testDialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop()
+	for deadline := time.Now().Add(5 * time.Second); c1.State() == grpc.Ready; {
+		if time.Now().After(deadline) {
+			t.Fatal("conn is still ready after the server stopped")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// The conn reconnects by itself, so it is reused (and not
+	// closed, because other clients may use it).
+	c2, _, err := p.dial("k", addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1 != c2 {
+		t.Error("got new conn, want the failed conn to be reused")
+	}
+	if c1.State() == grpc.Shutdown {
+		t.Error("failed conn was closed")
+	}
+}
+
+func TestConnPool_keyLocks(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+	p := &ConnPool{}
+	defer p.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := p.dial("k", addr, testDialOpts...); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	p.remove("k")
+
+	// The per-key locks are deleted when they're unused.
+	p.keyMusMu.Lock()
+	defer p.keyMusMu.Unlock()
+	if len(p.keyMus) != 0 {
+		t.Errorf("got %d key locks, want 0", len(p.keyMus))
+	}
+}
+
+func TestConnPool_Close(t *testing.T) {
+	addr, stop := startTestServer(t)
+	defer stop()
+	p := &ConnPool{IdleTimeout: time.Hour}
+
+	c1, _, err := p.dial("k", addr, testDialOpts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Close()
+	if c1.State() != grpc.Shutdown {
+		t.Errorf("got conn state %v after Close, want %v", c1.State(), grpc.Shutdown)
+	}
+	if got := p.Stats()[addr].Open; got != 0 {
+		t.Errorf("got %d open conns after Close, want 0", got)
+	}
+
+	// The pool may still be used after Close.
+	if _, _, err := p.dial("k", addr, testDialOpts...); err != nil {
+		t.Fatal(err)
+	}
+	p.Close()
+}
+
+func TestWithConnPool(t *testing.T) {
+	if got := connPoolFromContext(context.Background()); got != DefaultConnPool {
+		t.Errorf("got pool %p, want DefaultConnPool", got)
+	}
+	p := &ConnPool{}
+	if got := connPoolFromContext(WithConnPool(context.Background(), p)); got != p {
+		t.Errorf("got pool %p, want %p", got, p)
+	}
+}
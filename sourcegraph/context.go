@@ -2,6 +2,7 @@ package sourcegraph
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"net/url"
@@ -12,6 +13,7 @@ import (
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/oauth"
 )
@@ -23,6 +25,16 @@ const (
 	httpEndpointKey
 	credentialsKey
 	clientMetadataKey
+	retryPolicyKey
+	tlsOptionsKey
+	connPoolKey
+	cacheKey
+	storageCacheKey
+	tracerKey
+	spanKey
+	cacheMissKey
+	metricsKey
+	rateLimitsKey
 )
 
 // WithGRPCEndpoint returns a copy of parent whose clients (obtained
@@ -33,13 +45,30 @@ func WithGRPCEndpoint(parent context.Context, url *url.URL) context.Context {
 }
 
 // GRPCEndpoint returns the context's gRPC endpoint URL that was
-// previously configured using WithGRPCEndpoint.
+// previously configured using WithGRPCEndpoint. It panics if none was
+// configured; use GRPCEndpointE to handle that case.
 func GRPCEndpoint(ctx context.Context) *url.URL {
+	url, err := GRPCEndpointE(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return url
+}
+
+// ErrNoGRPCEndpoint is returned by GRPCEndpointE and
+// NewClientFromContextE if no gRPC endpoint URL was configured in the
+// context using WithGRPCEndpoint.
+var ErrNoGRPCEndpoint = errors.New("no gRPC API endpoint URL set in context")
+
+// GRPCEndpointE returns the context's gRPC endpoint URL that was
+// previously configured using WithGRPCEndpoint, or ErrNoGRPCEndpoint
+// if none was configured.
+func GRPCEndpointE(ctx context.Context) (*url.URL, error) {
 	url, _ := ctx.Value(grpcEndpointKey).(*url.URL)
 	if url == nil {
-		panic("no gRPC API endpoint URL set in context")
+		return nil, ErrNoGRPCEndpoint
 	}
-	return url
+	return url, nil
 }
 
 // Credentials authenticate gRPC requests made by an API client.
@@ -86,14 +115,59 @@ func clientMetadataFromContext(ctx context.Context) map[string]string {
 	return cred
 }
 
+// WithRetryPolicy returns a copy of the parent context whose API
+// clients (constructed using NewClientFromContext) retry failed calls
+// according to policy. If policy is nil, calls are not retried.
+func WithRetryPolicy(parent context.Context, policy *RetryPolicy) context.Context {
+	return context.WithValue(parent, retryPolicyKey, policy)
+}
+
+// RetryPolicyFromContext returns the retry policy (if any) previously
+// set in the context by WithRetryPolicy.
+func RetryPolicyFromContext(ctx context.Context) *RetryPolicy {
+	policy, _ := ctx.Value(retryPolicyKey).(*RetryPolicy)
+	return policy
+}
+
 // NewClientFromContext returns a Sourcegraph API client that
 // communicates with the Sourcegraph gRPC endpoint in ctx (i.e.,
-// GRPCEndpoint(ctx)).
+// GRPCEndpoint(ctx)). It doesn't wait for the connection to be
+// established, so errors connecting to the endpoint are returned by
+// the client's calls. It panics if the client can't be constructed
+// (e.g., if no endpoint is set in ctx); use NewClientFromContextE to
+// handle errors.
 func NewClientFromContext(ctx context.Context) *Client {
 	newClientFromContextMu.RLock()
 	f := newClientFromContext
 	newClientFromContextMu.RUnlock()
-	return f(ctx)
+	if f != nil {
+		return f(ctx)
+	}
+	c, err := dialClientFromContext(ctx, false)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// NewClientFromContextE returns a Sourcegraph API client that
+// communicates with the Sourcegraph gRPC endpoint in ctx (i.e.,
+// GRPCEndpoint(ctx)). Unlike NewClientFromContext, it waits until the
+// connection is established (or the dial times out), so that dial
+// errors are returned here.
+//
+// If no endpoint is set in ctx, it returns ErrNoGRPCEndpoint. If the
+// endpoint can't be dialed, it returns a *DialTimeoutError if the
+// connection wasn't established in time, a *TLSError if the TLS
+// handshake failed, and a *DialError otherwise.
+func NewClientFromContextE(ctx context.Context) (*Client, error) {
+	newClientFromContextMu.RLock()
+	f := newClientFromContext
+	newClientFromContextMu.RUnlock()
+	if f != nil {
+		return f(ctx), nil
+	}
+	return dialClientFromContext(ctx, true)
 }
 
 // MockNewClientFromContext allows a test to mock out the return value of
@@ -109,27 +183,33 @@ func MockNewClientFromContext(f func(ctx context.Context) *Client) {
 // implementation
 func RestoreNewClientFromContext() {
 	newClientFromContextMu.Lock()
-	newClientFromContext = realNewClientFromContext
+	newClientFromContext = nil
 	newClientFromContextMu.Unlock()
 }
 
 var (
 	maxDialTimeout         = 10 * time.Second
+	tlsProbeTimeout        = 2 * time.Second // see dialError
 	newClientFromContextMu sync.RWMutex
-	newClientFromContext   = realNewClientFromContext
+	newClientFromContext   func(ctx context.Context) *Client // mock (if set)
 )
-var realNewClientFromContext = func(ctx context.Context) *Client {
+
+// dialClientFromContext returns a client that communicates with the
+// gRPC endpoint in ctx, using a pooled connection (if one exists and
+// the TLS config may be pooled; see poolableTLSConfig). If block is
+// true, it waits until a new connection is established.
+func dialClientFromContext(ctx context.Context, block bool) (*Client, error) {
 	opts := []grpc.DialOption{
 		grpc.WithCodec(GRPCCodec),
 	}
 
-	grpcEndpoint := GRPCEndpoint(ctx)
-	if grpcEndpoint.Scheme == "https" {
-		creds := credentials.NewClientTLSFromCert(nil, "")
-		if host, _, _ := net.SplitHostPort(grpcEndpoint.Host); host == "localhost" {
-			creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})
-		}
-		opts = append(opts, grpc.WithTransportCredentials(creds))
+	grpcEndpoint, err := GRPCEndpointE(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := tlsConfigFromContext(ctx, grpcEndpoint)
+	if tlsConfig != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
 	} else {
 		opts = append(opts, grpc.WithInsecure())
 	}
@@ -140,7 +220,9 @@ var realNewClientFromContext = func(ctx context.Context) *Client {
 	opts = append(opts, grpc.WithPerRPCCredentials(contextCredentials{}))
 
 	// Dial timeout is the lesser of the ctx deadline or
-	// maxDialTimeout.
+	// maxDialTimeout. If blocking, wait until the connection is
+	// established so that dial errors are returned here (instead of
+	// from the first call).
 	var timeout time.Duration
 	if d, ok := ctx.Deadline(); ok && time.Now().Add(maxDialTimeout).After(d) {
 		timeout = d.Sub(time.Now())
@@ -148,13 +230,130 @@ var realNewClientFromContext = func(ctx context.Context) *Client {
 		timeout = maxDialTimeout
 	}
 	opts = append(opts, grpc.WithTimeout(timeout))
+	if block {
+		opts = append(opts, grpc.WithBlock())
+	}
 
-	conn, err := pooledGRPCDial(hostWithExplicitPort(grpcEndpoint), opts...)
+	target := hostWithExplicitPort(grpcEndpoint)
+	var (
+		conn    *grpc.ClientConn
+		release func()
+	)
+	if poolableTLSConfig(tlsConfig) {
+		conn, release, err = connPoolFromContext(ctx).dial(connKey(target, tlsConfig), target, opts...)
+	} else {
+		// The client owns the connection, which is closed by
+		// Client.Close.
+		conn, err = grpc.Dial(target, opts...)
+		release = func() { conn.Close() }
+	}
 	if err != nil {
-		panic(err)
+		if !block {
+			return nil, &DialError{Target: target, Err: err}
+		}
+		return nil, dialError(target, timeout, tlsConfig, err)
 	}
-	c := NewClient(conn)
-	return c
+	limits := rateLimitsFromContext(ctx)
+	c := NewClientWithOptions(conn, ClientOptions{
+		Cache:        cacheFromContext(ctx),
+		StorageCache: storageCacheFromContext(ctx),
+		RateLimits:   limits,
+	})
+	if policy := RetryPolicyFromContext(ctx); policy != nil {
+		if limits != nil && limits.maxRetries() > 0 {
+			// The rate limits (inside the retries) already retry
+			// calls that fail with codes.ResourceExhausted, so
+			// don't multiply their retries.
+			policy = policy.withoutCode(codes.ResourceExhausted)
+		}
+		retryClient(c, policy)
+	}
+	if m := metricsFromContext(ctx); m != nil {
+		instrumentClient(c, m)
+	}
+	if tracer := tracerFromContext(ctx); tracer != nil {
+		// Trace outside of retries, so that each call has one span.
+		traceClient(c, tracer)
+	}
+	c.release = release
+	return c, nil
+}
+
+// dialError returns the error to return to callers of
+// NewClientFromContextE when a blocking dial of target failed with
+// err.
+//
+// gRPC retries failed TLS handshakes until the dial times out, so if
+// tlsConfig is non-nil, dialError performs a TLS handshake with
+// target to find out whether the dial failed because of a TLS error.
+func dialError(target string, timeout time.Duration, tlsConfig *tls.Config, err error) error {
+	if tlsConfig != nil {
+		if tlsErr := tlsHandshakeError(target, tlsConfig); tlsErr != nil {
+			return &TLSError{Target: target, Err: tlsErr}
+		}
+	}
+	if isTimeout(err) {
+		return &DialTimeoutError{Target: target, Timeout: timeout}
+	}
+	return &DialError{Target: target, Err: err}
+}
+
+// tlsHandshakeError connects to target and returns the error (if any)
+// from the TLS handshake. It returns nil if the handshake succeeds or
+// can't be attempted (e.g., because target is unreachable).
+func tlsHandshakeError(target string, tlsConfig *tls.Config) error {
+	conn, err := net.DialTimeout("tcp", target, tlsProbeTimeout)
+	if err != nil {
+		return nil
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(tlsProbeTimeout))
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		if isTimeout(err) {
+			return nil
+		}
+		return err
+	}
+
+	// In TLS 1.3, the server might reject the client certificate after
+	// the client considers the handshake complete, so check for an
+	// alert from the server.
+	if _, err := tlsConn.Read(make([]byte, 1)); err != nil && isTLSAlert(err) {
+		return err
+	}
+	return nil
+}
+
+// isTLSAlert reports whether err (or an error that it wraps) is a TLS
+// alert received from the remote peer.
+func isTLSAlert(err error) bool {
+	for err != nil {
+		if e, ok := err.(*net.OpError); ok && e.Op == "remote error" {
+			return true
+		}
+		wrapper, ok := err.(interface {
+			Unwrap() error
+		})
+		if !ok {
+			return false
+		}
+		err = wrapper.Unwrap()
+	}
+	return false
+}
+
+// isTimeout reports whether err indicates that an operation timed
+// out.
+func isTimeout(err error) bool {
+	if err == grpc.ErrClientConnTimeout || err == context.DeadlineExceeded {
+		return true
+	}
+	if e, ok := err.(net.Error); ok && e.Timeout() {
+		return true
+	}
+	return false
 }
 
 // hostWithExplicitPort returns u's host with an explicit port number
@@ -173,13 +372,15 @@ func hostWithExplicitPort(u *url.URL) string {
 	return u.Host
 }
 
-// RemovePooledGRPCConn removes the pooled grpc.ClientConnection to the gRPC endpoint
-// in the context. The result of calling this function  is that the pooled connection for
-// this endpoint will be reset, so the subsequent call to NewClientFromContext() would have
-// to dial a new gRPC connection to this endpoint.
+// RemovePooledGRPCConn closes and removes the pooled grpc.ClientConn to the gRPC
+// endpoint in the context (from the context's pool; see WithConnPool). The result of
+// calling this function is that the pooled connection for this endpoint will be reset,
+// so the subsequent call to NewClientFromContext() would have to dial a new gRPC
+// connection to this endpoint.
 var RemovePooledGRPCConn = func(ctx context.Context) {
 	grpcEndpoint := GRPCEndpoint(ctx)
-	removeConnFromPool(grpcEndpoint.Host)
+	key := connKey(hostWithExplicitPort(grpcEndpoint), tlsConfigFromContext(ctx, grpcEndpoint))
+	connPoolFromContext(ctx).remove(key)
 }
 
 // contextCredentials implements the credentials.Credentials interface.
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os/exec"
 	"reflect"
@@ -92,3 +94,114 @@ func (s *testMetaServer) Status(ctx context.Context, _ *pbtypes.Void) (*ServerSt
 	}
 	return &ServerStatus{}, nil
 }
+
+func TestGRPCEndpointE(t *testing.T) {
+	if _, err := GRPCEndpointE(context.Background()); err != ErrNoGRPCEndpoint {
+		t.Errorf("got err %v, want %v", err, ErrNoGRPCEndpoint)
+	}
+
+	want := &url.URL{Host: "example.com"}
+	got, err := GRPCEndpointE(WithGRPCEndpoint(context.Background(), want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("got endpoint %v, want %v", got, want)
+	}
+}
+
+func TestNewClientFromContextE_noEndpoint(t *testing.T) {
+	if _, err := NewClientFromContextE(context.Background()); err != ErrNoGRPCEndpoint {
+		t.Errorf("got err %v, want %v", err, ErrNoGRPCEndpoint)
+	}
+}
+
+func TestNewClientFromContextE_dialTimeout(t *testing.T) {
+	defer func(d time.Duration) { tlsProbeTimeout = d }(tlsProbeTimeout)
+	tlsProbeTimeout = 100 * time.Millisecond
+
+	// The listener accepts connections but never completes the TLS
+	// handshake.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, c := range conns {
+				c.Close()
+			}
+		}()
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, c)
+		}
+	}()
+
+	pool := &ConnPool{}
+	defer pool.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	ctx = WithConnPool(ctx, pool)
+	ctx = WithGRPCEndpoint(ctx, &url.URL{Scheme: "https", Host: l.Addr().String()})
+	_, err = NewClientFromContextE(ctx)
+	if _, ok := err.(*DialTimeoutError); !ok {
+		t.Fatalf("got err %v (%T), want *DialTimeoutError", err, err)
+	}
+}
+
+func TestNewClientFromContext_nonBlocking(t *testing.T) {
+	// Nothing is listening on the endpoint, but NewClientFromContext
+	// doesn't wait for the connection.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pool := &ConnPool{}
+	defer pool.Close()
+	ctx = WithConnPool(ctx, pool)
+	ctx = WithGRPCEndpoint(ctx, &url.URL{Scheme: "https", Host: addr})
+	start := time.Now()
+	if c := NewClientFromContext(ctx); c == nil {
+		t.Fatal("got nil client")
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("NewClientFromContext took %s, want it not to wait for the connection", d)
+	}
+}
+
+func TestNewClientFromContextE_tlsError(t *testing.T) {
+	// The server's certificate is self-signed, so it isn't trusted.
+	s := httptest.NewTLSServer(http.NotFoundHandler())
+	defer s.Close()
+
+	pool := &ConnPool{}
+	defer pool.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	ctx = WithConnPool(ctx, pool)
+	ctx = WithGRPCEndpoint(ctx, &url.URL{Scheme: "https", Host: s.Listener.Addr().String()})
+	_, err := NewClientFromContextE(ctx)
+	if _, ok := err.(*TLSError); !ok {
+		t.Fatalf("got err %v (%T), want *TLSError", err, err)
+	}
+}
+
+func TestNewClientFromContext_panics(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrNoGRPCEndpoint {
+			t.Errorf("got panic %v, want %v", r, ErrNoGRPCEndpoint)
+		}
+	}()
+	NewClientFromContext(context.Background())
+}
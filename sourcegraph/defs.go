@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"path"
+	"regexp"
+	"strings"
 
 	"sourcegraph.com/sourcegraph/srclib/graph"
 	"sourcegraph.com/sourcegraph/srclib/store"
@@ -84,6 +86,26 @@ func (o *DefListOptions) DefFilters() []store.DefFilter {
 	if o.Query != "" {
 		fs = append(fs, store.ByDefQuery(o.Query))
 	}
+	if o.Regexp != "" {
+		if re, err := regexp.Compile(o.Regexp); err != nil {
+			log.Printf("WARNING: In DefListOptions.DefFilters, o.Regexp==%q is not a valid regular expression (%s). It will be ignored.", o.Regexp, err)
+		} else {
+			fs = append(fs, store.DefFilterFunc(func(def *graph.Def) bool {
+				return re.MatchString(def.Name)
+			}))
+		}
+	}
+	if len(o.Languages) > 0 {
+		fs = append(fs, store.DefFilterFunc(func(def *graph.Def) bool {
+			lang := defLanguage(def)
+			for _, l := range o.Languages {
+				if lang != "" && strings.EqualFold(l, lang) {
+					return true
+				}
+			}
+			return false
+		}))
+	}
 	if len(o.RepoRevs) > 0 {
 		vs := make([]store.Version, len(o.RepoRevs))
 		for i, repoRev := range o.RepoRevs {
@@ -141,6 +163,49 @@ func (o *DefListOptions) DefFilters() []store.DefFilter {
 	return fs
 }
 
+// unitTypeLanguages maps srclib source unit types to the programming
+// languages of their defs.
+var unitTypeLanguages = map[string]string{
+	"GoPackage":       "go",
+	"JavaArtifact":    "java",
+	"PipPackage":      "python",
+	"CommonJSPackage": "javascript",
+	"RubyGem":         "ruby",
+	"RubyProgram":     "ruby",
+}
+
+// fileExtLanguages maps file extensions to programming languages, for
+// defs whose source unit type is not in unitTypeLanguages.
+var fileExtLanguages = map[string]string{
+	".go":    "go",
+	".java":  "java",
+	".py":    "python",
+	".js":    "javascript",
+	".jsx":   "javascript",
+	".ts":    "typescript",
+	".rb":    "ruby",
+	".c":     "c",
+	".h":     "c",
+	".cc":    "c++",
+	".cpp":   "c++",
+	".cs":    "c#",
+	".php":   "php",
+	".scala": "scala",
+}
+
+// defLanguage returns the programming language that def is written
+// in (e.g., "go"), determined by its source unit type or else by its
+// file's extension. It returns "" if the language is unknown.
+//
+// srclib doesn't record the language of defs, so this is used to
+// filter defs by DefListOptions.Languages.
+func defLanguage(def *graph.Def) string {
+	if lang, ok := unitTypeLanguages[def.UnitType]; ok {
+		return lang
+	}
+	return fileExtLanguages[strings.ToLower(path.Ext(def.File))]
+}
+
 type Refs []*Ref
 
 func (r *Ref) sortKey() string     { return fmt.Sprintf("%+v", r) }
@@ -61,3 +61,48 @@ func TestDefListOptions_Offset(t *testing.T) {
 		t.Errorf("got %+v, want %+v", defs, wantDefs)
 	}
 }
+
+func TestDefListOptions_Regexp(t *testing.T) {
+	defs := []*graph.Def{
+		{DefKey: graph.DefKey{Path: "a"}, Name: "NewFoo"},
+		{DefKey: graph.DefKey{Path: "b"}, Name: "Foo"},
+		{DefKey: graph.DefKey{Path: "c"}, Name: "NewBar"},
+	}
+
+	opt := &DefListOptions{Regexp: "^New"}
+	got := store.DefFilters(opt.DefFilters()).SelectDefs(defs...)
+
+	want := []*graph.Def{defs[0], defs[2]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestDefListOptions_Languages(t *testing.T) {
+	defs := []*graph.Def{
+		{DefKey: graph.DefKey{UnitType: "GoPackage", Path: "a"}, File: "a.go"},
+		{DefKey: graph.DefKey{UnitType: "JavaArtifact", Path: "b"}, File: "b.java"},
+		{DefKey: graph.DefKey{UnitType: "OtherUnit", Path: "c"}, File: "c/C.PY"},
+		{DefKey: graph.DefKey{UnitType: "OtherUnit", Path: "d"}, File: "d.unknown"},
+	}
+
+	tests := map[string][]*graph.Def{
+		"go":     {defs[0]},
+		"Python": {defs[2]},
+		"java":   {defs[1]},
+		"ruby":   nil,
+	}
+	for lang, want := range tests {
+		opt := &DefListOptions{Languages: []string{lang}}
+		got := store.DefFilters(opt.DefFilters()).SelectDefs(defs...)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %+v, want %+v", lang, got, want)
+		}
+	}
+
+	opt := &DefListOptions{Languages: []string{"go", "python"}}
+	got := store.DefFilters(opt.DefFilters()).SelectDefs(defs...)
+	if want := []*graph.Def{defs[0], defs[2]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
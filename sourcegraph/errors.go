@@ -1,6 +1,9 @@
 package sourcegraph
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 // InvalidOptionsError indicates that the provided XxxOptions
 // (RepoListOptions, DefGetOptions, etc.) was invalid.
@@ -25,3 +28,37 @@ type NotImplementedError struct{ What string }
 func (e *NotImplementedError) Error() string { return e.What + " is not implemented" }
 
 func (e *NotImplementedError) HTTPStatusCode() int { return http.StatusNotFound }
+
+// DialError indicates that a connection to a gRPC endpoint couldn't
+// be established.
+type DialError struct {
+	Target string // the address of the gRPC endpoint
+	Err    error  // the underlying error
+}
+
+func (e *DialError) Error() string {
+	return "dialing gRPC endpoint " + e.Target + " failed: " + e.Err.Error()
+}
+
+// DialTimeoutError indicates that a connection to a gRPC endpoint
+// wasn't established before the dial timed out (e.g., because the
+// server is unreachable).
+type DialTimeoutError struct {
+	Target  string        // the address of the gRPC endpoint
+	Timeout time.Duration // the dial timeout
+}
+
+func (e *DialTimeoutError) Error() string {
+	return "dialing gRPC endpoint " + e.Target + " timed out after " + e.Timeout.String()
+}
+
+// TLSError indicates that the TLS handshake with a gRPC endpoint
+// failed (e.g., because its certificate isn't trusted).
+type TLSError struct {
+	Target string // the address of the gRPC endpoint
+	Err    error  // the error from the TLS handshake
+}
+
+func (e *TLSError) Error() string {
+	return "TLS handshake with gRPC endpoint " + e.Target + " failed: " + e.Err.Error()
+}
@@ -11,6 +11,14 @@ package sourcegraph
 //
 //go:generate go run gen/goreplace.go -from "pbtypes1" -to "pbtypes" cached_grpc.pb.go mock/sourcegraph.pb_mock.go
 
+//go:generate go run gen/wrappers.go -kind retry -o retry_grpc.pb.go sourcegraph.pb.go
+//go:generate go run gen/wrappers.go -kind storagecache -o storage_cached_grpc.pb.go sourcegraph.pb.go
+//go:generate go run gen/wrappers.go -kind trace -o traced_grpc.pb.go sourcegraph.pb.go
+//go:generate go run gen/wrappers.go -kind metrics -o instrumented_grpc.pb.go sourcegraph.pb.go
+//go:generate go run gen/wrappers.go -kind ratelimit -o rate_limited_grpc.pb.go sourcegraph.pb.go
+//go:generate go run gen/wrappers.go -kind authz -o authorized_grpc.pb.go sourcegraph.pb.go
+//go:generate go run gen/wrappers.go -kind audit -o audited_grpc.pb.go sourcegraph.pb.go
+
 //go:generate goimports -w cached_grpc.pb.go mock/sourcegraph.pb_mock.go
 
 //go:generate go generate ./mock
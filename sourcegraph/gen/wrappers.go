@@ -0,0 +1,745 @@
+//go:build generate
+// +build generate
+
+// Command wrappers generates wrappers for the gRPC client (and server)
+// interfaces of each service in a .pb.go file, like the Cached*Client
+// wrappers generated by grpccache-gen.
+//
+// The kind of wrapper is chosen with the -kind flag. Server-streaming
+// methods are passed to the templates separately from unary methods
+// (as ClientStreams and ServerStreams); a kind that doesn't wrap them
+// passes them through to the embedded interface.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var (
+	kind    = flag.String("kind", "", "kind of wrappers to generate (one of: "+strings.Join(kindNames(), ", ")+")")
+	outFile = flag.String("o", "", "output file (default: stdout)")
+	pkgName = flag.String("pkg", "sourcegraph", "package name of the output file")
+
+	fset = token.NewFileSet()
+)
+
+func main() {
+	flag.Parse()
+	log.SetFlags(0)
+
+	tmpl, ok := kinds[*kind]
+	if !ok {
+		log.Fatalf("unknown -kind %q", *kind)
+	}
+	if flag.NArg() == 0 {
+		log.Fatal("no input files")
+	}
+
+	data := &fileData{Package: *pkgName, imports: map[string]string{}}
+	for name, importPath := range templateImports {
+		data.imports[name] = importPath
+	}
+	for _, filename := range flag.Args() {
+		if err := data.parseFile(filename); err != nil {
+			log.Fatal(err)
+		}
+	}
+	sort.Sort(servicesByName(data.Services))
+
+	var body bytes.Buffer
+	if err := template.Must(template.New(*kind).Parse(tmpl)).Execute(&body, data); err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// GENERATED CODE - DO NOT EDIT!")
+	fmt.Fprintln(&buf, "//")
+	fmt.Fprintln(&buf, "// Generated by:")
+	fmt.Fprintln(&buf, "//")
+	fmt.Fprintf(&buf, "//   go run gen/wrappers.go %s\n", strings.Join(os.Args[1:], " "))
+	fmt.Fprintln(&buf, "//")
+	fmt.Fprintln(&buf, "// Called via:")
+	fmt.Fprintln(&buf, "//")
+	fmt.Fprintln(&buf, "//   go generate")
+	fmt.Fprintln(&buf, "//")
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "package %s\n\n", data.Package)
+	fmt.Fprintln(&buf, "import (")
+	for _, imp := range data.importList(body.Bytes()) {
+		fmt.Fprintln(&buf, imp)
+	}
+	fmt.Fprintln(&buf, ")")
+	buf.Write(body.Bytes())
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %s\n%s", err, buf.Bytes())
+	}
+	if *outFile == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := ioutil.WriteFile(*outFile, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type fileData struct {
+	Package  string
+	Services []*service
+
+	imports map[string]string // package name -> import path
+}
+
+type service struct {
+	Name          string // e.g., "Repos"
+	ClientMethods []*method
+	ServerMethods []*method
+	ClientStreams []*streamMethod
+	ServerStreams []*streamMethod
+}
+
+type method struct {
+	Name    string // e.g., "Get"
+	InType  string // e.g., "*RepoSpec"
+	OutType string // e.g., "*Repo"
+}
+
+// OutElem returns the type that OutType points to (e.g., "Repo").
+func (m *method) OutElem() string { return strings.TrimPrefix(m.OutType, "*") }
+
+// A streamMethod is a server-streaming RPC method, which takes a
+// single request and sends a stream of responses.
+type streamMethod struct {
+	Name       string // e.g., "StreamSearch"
+	InType     string // e.g., "*SearchOptions"
+	StreamType string // e.g., "Search_StreamSearchServer"
+	OutType    string // e.g., "*SearchEvent" (the type of each response)
+}
+
+type servicesByName []*service
+
+func (v servicesByName) Len() int           { return len(v) }
+func (v servicesByName) Less(i, j int) bool { return v[i].Name < v[j].Name }
+func (v servicesByName) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+
+// parseFile adds the services whose client and server interfaces are
+// defined in filename.
+func (d *fileData) parseFile(filename string) error {
+	f, err := parser.ParseFile(fset, filename, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	for _, imp := range f.Imports {
+		importPath, _ := strconv.Unquote(imp.Path.Value)
+		d.imports[importName(imp)] = importPath
+	}
+
+	svcs := map[string]*service{}
+	streamOutTypes := map[string]string{} // stream interface name -> response type
+	getService := func(name string) *service {
+		if svcs[name] == nil {
+			svcs[name] = &service{Name: name}
+			d.Services = append(d.Services, svcs[name])
+		}
+		return svcs[name]
+	}
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			spec := spec.(*ast.TypeSpec)
+			iface, ok := spec.Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+			name := spec.Name.Name
+			if strings.Contains(name, "_") {
+				// Stream interface (e.g., Search_StreamSearchClient).
+				if out := d.streamOutType(iface); out != "" {
+					streamOutTypes[name] = out
+				}
+				continue
+			}
+			switch {
+			case strings.HasSuffix(name, "Client"):
+				svc := getService(strings.TrimSuffix(name, "Client"))
+				svc.ClientMethods = d.unaryMethods(iface, 3)
+				svc.ClientStreams = d.streamMethods(iface, true)
+			case strings.HasSuffix(name, "Server"):
+				svc := getService(strings.TrimSuffix(name, "Server"))
+				svc.ServerMethods = d.unaryMethods(iface, 2)
+				svc.ServerStreams = d.streamMethods(iface, false)
+			}
+		}
+	}
+	for _, svc := range svcs {
+		for _, m := range append(svc.ClientStreams, svc.ServerStreams...) {
+			m.OutType = streamOutTypes[m.StreamType]
+		}
+	}
+	return nil
+}
+
+// streamOutType returns the type of the responses received (by Recv)
+// or sent (by Send) on the stream interface iface, or "" if it has
+// neither method.
+func (d *fileData) streamOutType(iface *ast.InterfaceType) string {
+	for _, field := range iface.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			continue
+		}
+		switch field.Names[0].Name {
+		case "Recv":
+			if ft.Results.NumFields() == 2 {
+				return d.typeString(ft.Results.List[0].Type)
+			}
+		case "Send":
+			if ft.Params.NumFields() == 1 {
+				return d.typeString(ft.Params.List[0].Type)
+			}
+		}
+	}
+	return ""
+}
+
+// unaryMethods returns the unary RPC methods of iface, which take
+// nparams parameters (ctx, in and, for clients, opts) and return a
+// pointer to the result and an error.
+func (d *fileData) unaryMethods(iface *ast.InterfaceType, nparams int) []*method {
+	var methods []*method
+	for _, field := range iface.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			continue
+		}
+		if ft.Params.NumFields() != nparams || ft.Results.NumFields() != 2 {
+			continue
+		}
+		in := ft.Params.List[1].Type
+		if len(ft.Params.List[0].Names) > 1 {
+			in = ft.Params.List[0].Type
+		}
+		out := ft.Results.List[0].Type
+		if _, ok := out.(*ast.StarExpr); !ok {
+			continue // streaming method
+		}
+		methods = append(methods, &method{
+			Name:    field.Names[0].Name,
+			InType:  d.typeString(in),
+			OutType: d.typeString(out),
+		})
+	}
+	return methods
+}
+
+// streamMethods returns the server-streaming RPC methods of iface. On
+// clients, they take (ctx, in, opts) and return the stream and an
+// error; on servers, they take (in, stream) and return an error.
+func (d *fileData) streamMethods(iface *ast.InterfaceType, client bool) []*streamMethod {
+	var methods []*streamMethod
+	for _, field := range iface.Methods.List {
+		ft, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			continue
+		}
+		var params []ast.Expr
+		for _, p := range ft.Params.List {
+			n := len(p.Names)
+			if n == 0 {
+				n = 1 // unnamed parameter
+			}
+			for i := 0; i < n; i++ {
+				params = append(params, p.Type)
+			}
+		}
+		var in, stream ast.Expr
+		switch {
+		case client && len(params) == 3 && ft.Results.NumFields() == 2:
+			in, stream = params[1], ft.Results.List[0].Type
+		case !client && len(params) == 2 && ft.Results.NumFields() == 1:
+			in, stream = params[0], params[1]
+		default:
+			continue
+		}
+		if _, ok := in.(*ast.StarExpr); !ok {
+			continue
+		}
+		if id, ok := stream.(*ast.Ident); !ok || !strings.Contains(id.Name, "_") {
+			continue // not a stream (e.g., a unary method)
+		}
+		methods = append(methods, &streamMethod{
+			Name:       field.Names[0].Name,
+			InType:     d.typeString(in),
+			StreamType: d.typeString(stream),
+		})
+	}
+	return methods
+}
+
+// typeString returns the source of the type expression x, with
+// package selectors replaced by the import path's default package
+// name (e.g., "pbtypes1.Void" becomes "pbtypes.Void").
+func (d *fileData) typeString(x ast.Expr) string {
+	ast.Inspect(x, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok {
+				if importPath, ok := d.imports[pkg.Name]; ok {
+					pkg.Name = path.Base(importPath)
+				}
+			}
+			return false
+		}
+		return true
+	})
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, x); err != nil {
+		log.Fatal(err)
+	}
+	return buf.String()
+}
+
+// importList returns the import specs of the packages that are
+// referred to in src: the standard library packages, then (after an
+// empty line) the others.
+func (d *fileData) importList(src []byte) []string {
+	seen := map[string]bool{}
+	var std, imports []string
+	for _, importPath := range d.imports {
+		name := path.Base(importPath)
+		if seen[importPath] || !regexp.MustCompile(`\b`+name+`\.`).Match(src) {
+			continue
+		}
+		seen[importPath] = true
+		if !strings.Contains(strings.SplitN(importPath, "/", 2)[0], ".") {
+			std = append(std, strconv.Quote(importPath))
+			continue
+		}
+		imports = append(imports, strconv.Quote(importPath))
+	}
+	sort.Strings(std)
+	sort.Strings(imports)
+	if len(std) > 0 && len(imports) > 0 {
+		std = append(std, "")
+	}
+	return append(std, imports...)
+}
+
+func importName(imp *ast.ImportSpec) string {
+	if imp.Name != nil {
+		return imp.Name.Name
+	}
+	importPath, _ := strconv.Unquote(imp.Path.Value)
+	return path.Base(importPath)
+}
+
+func kindNames() []string {
+	var names []string
+	for name := range kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// templateImports are the packages (keyed on package name) that the
+// templates may refer to, in addition to those imported by the input
+// files.
+var templateImports = map[string]string{
+	"io":       "io",
+	"log":      "log",
+	"metadata": "google.golang.org/grpc/metadata",
+}
+
+// kinds maps the name of each kind of wrapper to the template that
+// generates it. The template is executed with a *fileData.
+var kinds = map[string]string{
+	"retry":        retryTemplate,
+	"storagecache": storageCacheTemplate,
+	"trace":        traceTemplate,
+	"metrics":      metricsTemplate,
+	"ratelimit":    rateLimitTemplate,
+	"authz":        authzTemplate,
+	"audit":        auditTemplate,
+}
+
+const retryTemplate = `
+{{range .Services}}{{$svc := .Name}}
+type Retry{{$svc}}Client struct {
+	{{$svc}}Client
+	Policy *RetryPolicy
+}
+{{range .ClientMethods}}
+func (s *Retry{{$svc}}Client) {{.Name}}(ctx context.Context, in {{.InType}}, opts ...grpc.CallOption) ({{.OutType}}, error) {
+	var result {{.OutType}}
+	err := s.Policy.do(ctx, "{{$svc}}.{{.Name}}", func() error {
+		var err error
+		result, err = s.{{$svc}}Client.{{.Name}}(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+{{end}}{{end}}
+// retryClient wraps each of c's services in a Retry*Client that
+// retries calls according to policy.
+func retryClient(c *Client, policy *RetryPolicy) {
+{{- range .Services}}
+	c.{{.Name}} = &Retry{{.Name}}Client{c.{{.Name}}, policy}
+{{- end}}
+}
+`
+
+const storageCacheTemplate = `
+{{range .Services}}{{$svc := .Name}}
+type StorageCached{{$svc}}Client struct {
+	{{$svc}}Client
+	Cache *StorageCache
+}
+{{range .ClientMethods}}
+func (s *StorageCached{{$svc}}Client) {{.Name}}(ctx context.Context, in {{.InType}}, opts ...grpc.CallOption) ({{.OutType}}, error) {
+	if s.Cache == nil || !IsIdempotent("{{$svc}}.{{.Name}}") {
+		return s.{{$svc}}Client.{{.Name}}(ctx, in, opts...)
+	}
+
+	var cachedResult {{.OutElem}}
+	cached, err := s.Cache.Get(ctx, "{{$svc}}.{{.Name}}", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.{{$svc}}Client.{{.Name}}(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "{{$svc}}.{{.Name}}", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "{{$svc}}.{{.Name}}", err)
+	}
+	return result, nil
+}
+{{end}}{{end}}
+// storageCacheClient wraps each of c's services in a
+// StorageCached*Client that caches responses in cache. Only the
+// responses of idempotent methods (see IsIdempotent) are cached.
+func storageCacheClient(c *Client, cache *StorageCache) {
+{{- range .Services}}
+	c.{{.Name}} = &StorageCached{{.Name}}Client{c.{{.Name}}, cache}
+{{- end}}
+}
+`
+
+const traceTemplate = `
+{{range .Services}}{{$svc := .Name}}
+type Traced{{$svc}}Client struct {
+	{{$svc}}Client
+	Tracer *Tracer
+}
+{{range .ClientMethods}}
+func (s *Traced{{$svc}}Client) {{.Name}}(ctx context.Context, in {{.InType}}, opts ...grpc.CallOption) ({{.OutType}}, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "{{$svc}}.{{.Name}}", in)
+	result, err := s.{{$svc}}Client.{{.Name}}(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+{{end}}{{range .ClientStreams}}
+func (s *Traced{{$svc}}Client) {{.Name}}(ctx context.Context, in {{.InType}}, opts ...grpc.CallOption) ({{.StreamType}}, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "{{$svc}}.{{.Name}}", in)
+	stream, err := s.{{$svc}}Client.{{.Name}}(ctx, in, opts...)
+	if err != nil {
+		span.finishCall(err)
+		return nil, err
+	}
+	return &traced{{$svc}}{{.Name}}Client{stream, span}, nil
+}
+
+// traced{{$svc}}{{.Name}}Client finishes the span of the call when the
+// stream ends.
+type traced{{$svc}}{{.Name}}Client struct {
+	{{.StreamType}}
+	span *Span
+}
+
+func (x *traced{{$svc}}{{.Name}}Client) Recv() ({{.OutType}}, error) {
+	m, err := x.{{.StreamType}}.Recv()
+	if err != nil && x.span != nil {
+		if err == io.EOF {
+			x.span.finishCall(nil)
+		} else {
+			x.span.finishCall(err)
+		}
+		x.span = nil
+	}
+	return m, err
+}
+{{end}}
+type Traced{{$svc}}Server struct {
+	{{$svc}}Server
+	Tracer *Tracer
+}
+{{range .ServerMethods}}
+func (s *Traced{{$svc}}Server) {{.Name}}(ctx context.Context, in {{.InType}}) ({{.OutType}}, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "{{$svc}}.{{.Name}}", in)
+	result, err := s.{{$svc}}Server.{{.Name}}(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+{{end}}{{range .ServerStreams}}
+func (s *Traced{{$svc}}Server) {{.Name}}(in {{.InType}}, stream {{.StreamType}}) error {
+	ctx, span := s.Tracer.startServerSpan(stream.Context(), "{{$svc}}.{{.Name}}", in)
+	err := s.{{$svc}}Server.{{.Name}}(in, &traced{{$svc}}{{.Name}}Server{stream, ctx})
+	span.finishCall(err)
+	return err
+}
+
+// traced{{$svc}}{{.Name}}Server is a stream whose context has the span
+// of the call.
+type traced{{$svc}}{{.Name}}Server struct {
+	{{.StreamType}}
+	ctx context.Context
+}
+
+func (x *traced{{$svc}}{{.Name}}Server) Context() context.Context { return x.ctx }
+{{end}}
+type cacheHitTraced{{$svc}}Client struct {
+	{{$svc}}Client
+}
+{{range .ClientMethods}}
+func (s *cacheHitTraced{{$svc}}Client) {{.Name}}(ctx context.Context, in {{.InType}}, opts ...grpc.CallOption) ({{.OutType}}, error) {
+	var result {{.OutType}}
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.{{$svc}}Client.{{.Name}}(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+{{end}}
+type cacheMissTraced{{$svc}}Client struct {
+	{{$svc}}Client
+}
+{{range .ClientMethods}}
+func (s *cacheMissTraced{{$svc}}Client) {{.Name}}(ctx context.Context, in {{.InType}}, opts ...grpc.CallOption) ({{.OutType}}, error) {
+	traceCacheMiss(ctx)
+	return s.{{$svc}}Client.{{.Name}}(ctx, in, opts...)
+}
+{{end}}{{end}}
+// traceClient wraps each of c's services in a Traced*Client that
+// records spans with tracer.
+func traceClient(c *Client, tracer *Tracer) {
+{{- range .Services}}
+	c.{{.Name}} = &Traced{{.Name}}Client{c.{{.Name}}, tracer}
+{{- end}}
+}
+
+// traceCacheHitClient wraps each of c's services (the Cached*Client
+// wrappers) so that the spans of calls that they answer from the
+// cache are tagged as cache hits. The services that the Cached*Client
+// wrappers wrap must have been wrapped by traceCacheMissClient.
+func traceCacheHitClient(c *Client) {
+{{- range .Services}}
+	c.{{.Name}} = &cacheHitTraced{{.Name}}Client{c.{{.Name}}}
+{{- end}}
+}
+
+// traceCacheMissClient wraps each of c's services so that calls that
+// reach them are not tagged as cache hits (see traceCacheHitClient).
+func traceCacheMissClient(c *Client) {
+{{- range .Services}}
+	c.{{.Name}} = &cacheMissTraced{{.Name}}Client{c.{{.Name}}}
+{{- end}}
+}
+`
+
+const metricsTemplate = `
+{{range .Services}}{{$svc := .Name}}
+type Instrumented{{$svc}}Client struct {
+	{{$svc}}Client
+	Metrics *Metrics
+}
+{{range .ClientMethods}}
+func (s *Instrumented{{$svc}}Client) {{.Name}}(ctx context.Context, in {{.InType}}, opts ...grpc.CallOption) (result {{.OutType}}, err error) {
+	done := s.Metrics.start("{{$svc}}.{{.Name}}")
+	defer func() { done(err) }()
+	return s.{{$svc}}Client.{{.Name}}(ctx, in, opts...)
+}
+{{end}}{{range .ClientStreams}}
+func (s *Instrumented{{$svc}}Client) {{.Name}}(ctx context.Context, in {{.InType}}, opts ...grpc.CallOption) ({{.StreamType}}, error) {
+	done := s.Metrics.start("{{$svc}}.{{.Name}}")
+	stream, err := s.{{$svc}}Client.{{.Name}}(ctx, in, opts...)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+	return &instrumented{{$svc}}{{.Name}}Client{stream, done}, nil
+}
+
+// instrumented{{$svc}}{{.Name}}Client records the end of the call when
+// the stream ends.
+type instrumented{{$svc}}{{.Name}}Client struct {
+	{{.StreamType}}
+	done func(error)
+}
+
+func (x *instrumented{{$svc}}{{.Name}}Client) Recv() ({{.OutType}}, error) {
+	m, err := x.{{.StreamType}}.Recv()
+	if err != nil && x.done != nil {
+		if err == io.EOF {
+			x.done(nil)
+		} else {
+			x.done(err)
+		}
+		x.done = nil
+	}
+	return m, err
+}
+{{end}}
+type Instrumented{{$svc}}Server struct {
+	{{$svc}}Server
+	Metrics *Metrics
+}
+{{range .ServerMethods}}
+func (s *Instrumented{{$svc}}Server) {{.Name}}(ctx context.Context, in {{.InType}}) (result {{.OutType}}, err error) {
+	done := s.Metrics.start("{{$svc}}.{{.Name}}")
+	defer func() { done(err) }()
+	return s.{{$svc}}Server.{{.Name}}(ctx, in)
+}
+{{end}}{{range .ServerStreams}}
+func (s *Instrumented{{$svc}}Server) {{.Name}}(in {{.InType}}, stream {{.StreamType}}) (err error) {
+	done := s.Metrics.start("{{$svc}}.{{.Name}}")
+	defer func() { done(err) }()
+	return s.{{$svc}}Server.{{.Name}}(in, stream)
+}
+{{end}}{{end}}
+// instrumentClient wraps each of c's services in an
+// Instrumented*Client that records metrics in m.
+func instrumentClient(c *Client, m *Metrics) {
+{{- range .Services}}
+	c.{{.Name}} = &Instrumented{{.Name}}Client{c.{{.Name}}, m}
+{{- end}}
+}
+`
+
+const rateLimitTemplate = `
+{{range .Services}}{{$svc := .Name}}
+type RateLimited{{$svc}}Client struct {
+	{{$svc}}Client
+	Limits *RateLimits
+}
+{{range .ClientMethods}}
+func (s *RateLimited{{$svc}}Client) {{.Name}}(ctx context.Context, in {{.InType}}, opts ...grpc.CallOption) ({{.OutType}}, error) {
+	var result {{.OutType}}
+	err := s.Limits.do(ctx, "{{$svc}}.{{.Name}}", func() error {
+		var err error
+		result, err = s.{{$svc}}Client.{{.Name}}(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+{{end}}{{range .ClientStreams}}
+func (s *RateLimited{{$svc}}Client) {{.Name}}(ctx context.Context, in {{.InType}}, opts ...grpc.CallOption) ({{.StreamType}}, error) {
+	end, err := s.Limits.startStream(ctx, "{{$svc}}.{{.Name}}")
+	if err != nil {
+		return nil, err
+	}
+	stream, err := s.{{$svc}}Client.{{.Name}}(ctx, in, opts...)
+	if err != nil {
+		end(err)
+		return nil, err
+	}
+	return &rateLimited{{$svc}}{{.Name}}Client{stream, end}, nil
+}
+
+// rateLimited{{$svc}}{{.Name}}Client holds the call's in-flight slot
+// until the stream ends.
+type rateLimited{{$svc}}{{.Name}}Client struct {
+	{{.StreamType}}
+	end func(error)
+}
+
+func (x *rateLimited{{$svc}}{{.Name}}Client) Recv() ({{.OutType}}, error) {
+	m, err := x.{{.StreamType}}.Recv()
+	if err != nil && x.end != nil {
+		if err == io.EOF {
+			x.end(nil)
+		} else {
+			x.end(err)
+		}
+		x.end = nil
+	}
+	return m, err
+}
+{{end}}{{end}}
+// rateLimitClient wraps each of c's services in a RateLimited*Client
+// that limits calls according to limits.
+func rateLimitClient(c *Client, limits *RateLimits) {
+{{- range .Services}}
+	c.{{.Name}} = &RateLimited{{.Name}}Client{c.{{.Name}}, limits}
+{{- end}}
+}
+`
+
+const authzTemplate = `
+{{range .Services}}{{$svc := .Name}}
+type Authorized{{$svc}}Server struct {
+	{{$svc}}Server
+	Checker *PermissionChecker
+}
+{{range .ServerMethods}}
+func (s *Authorized{{$svc}}Server) {{.Name}}(ctx context.Context, in {{.InType}}) ({{.OutType}}, error) {
+	if err := s.Checker.CheckMethod(ctx, "{{$svc}}.{{.Name}}", in); err != nil {
+		return nil, err
+	}
+	return s.{{$svc}}Server.{{.Name}}(ctx, in)
+}
+{{end}}{{range .ServerStreams}}
+func (s *Authorized{{$svc}}Server) {{.Name}}(in {{.InType}}, stream {{.StreamType}}) error {
+	if err := s.Checker.CheckMethod(stream.Context(), "{{$svc}}.{{.Name}}", in); err != nil {
+		return err
+	}
+	return s.{{$svc}}Server.{{.Name}}(in, stream)
+}
+{{end}}{{end}}`
+
+const auditTemplate = `
+{{range .Services}}{{$svc := .Name}}
+type Audited{{$svc}}Server struct {
+	{{$svc}}Server
+	Log *AuditLogger
+}
+{{range .ServerMethods}}
+func (s *Audited{{$svc}}Server) {{.Name}}(ctx context.Context, in {{.InType}}) ({{.OutType}}, error) {
+	result, err := s.{{$svc}}Server.{{.Name}}(ctx, in)
+	s.Log.record(ctx, "{{$svc}}.{{.Name}}", in, err)
+	return result, err
+}
+{{end}}{{range .ServerStreams}}
+func (s *Audited{{$svc}}Server) {{.Name}}(in {{.InType}}, stream {{.StreamType}}) error {
+	err := s.{{$svc}}Server.{{.Name}}(in, stream)
+	s.Log.record(stream.Context(), "{{$svc}}.{{.Name}}", in, err)
+	return err
+}
+{{end}}{{end}}`
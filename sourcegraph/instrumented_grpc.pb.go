@@ -0,0 +1,1835 @@
+// GENERATED CODE - DO NOT EDIT!
+//
+// Generated by:
+//
+//   go run gen/wrappers.go -kind metrics -o instrumented_grpc.pb.go sourcegraph.pb.go
+//
+// Called via:
+//
+//   go generate
+//
+
+package sourcegraph
+
+import (
+	"io"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+	"sourcegraph.com/sourcegraph/srclib/unit"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+type InstrumentedAccessTokensClient struct {
+	AccessTokensClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedAccessTokensClient) Create(ctx context.Context, in *AccessTokensCreateOp, opts ...grpc.CallOption) (result *NewAccessToken, err error) {
+	done := s.Metrics.start("AccessTokens.Create")
+	defer func() { done(err) }()
+	return s.AccessTokensClient.Create(ctx, in, opts...)
+}
+
+func (s *InstrumentedAccessTokensClient) List(ctx context.Context, in *AccessTokensListOptions, opts ...grpc.CallOption) (result *AccessTokenList, err error) {
+	done := s.Metrics.start("AccessTokens.List")
+	defer func() { done(err) }()
+	return s.AccessTokensClient.List(ctx, in, opts...)
+}
+
+func (s *InstrumentedAccessTokensClient) Revoke(ctx context.Context, in *AccessTokenSpec, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("AccessTokens.Revoke")
+	defer func() { done(err) }()
+	return s.AccessTokensClient.Revoke(ctx, in, opts...)
+}
+
+type InstrumentedAccessTokensServer struct {
+	AccessTokensServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedAccessTokensServer) Create(ctx context.Context, in *AccessTokensCreateOp) (result *NewAccessToken, err error) {
+	done := s.Metrics.start("AccessTokens.Create")
+	defer func() { done(err) }()
+	return s.AccessTokensServer.Create(ctx, in)
+}
+
+func (s *InstrumentedAccessTokensServer) List(ctx context.Context, in *AccessTokensListOptions) (result *AccessTokenList, err error) {
+	done := s.Metrics.start("AccessTokens.List")
+	defer func() { done(err) }()
+	return s.AccessTokensServer.List(ctx, in)
+}
+
+func (s *InstrumentedAccessTokensServer) Revoke(ctx context.Context, in *AccessTokenSpec) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("AccessTokens.Revoke")
+	defer func() { done(err) }()
+	return s.AccessTokensServer.Revoke(ctx, in)
+}
+
+type InstrumentedAccountsClient struct {
+	AccountsClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedAccountsClient) Create(ctx context.Context, in *NewAccount, opts ...grpc.CallOption) (result *UserSpec, err error) {
+	done := s.Metrics.start("Accounts.Create")
+	defer func() { done(err) }()
+	return s.AccountsClient.Create(ctx, in, opts...)
+}
+
+func (s *InstrumentedAccountsClient) RequestPasswordReset(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (result *User, err error) {
+	done := s.Metrics.start("Accounts.RequestPasswordReset")
+	defer func() { done(err) }()
+	return s.AccountsClient.RequestPasswordReset(ctx, in, opts...)
+}
+
+func (s *InstrumentedAccountsClient) ResetPassword(ctx context.Context, in *NewPassword, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Accounts.ResetPassword")
+	defer func() { done(err) }()
+	return s.AccountsClient.ResetPassword(ctx, in, opts...)
+}
+
+func (s *InstrumentedAccountsClient) Update(ctx context.Context, in *User, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Accounts.Update")
+	defer func() { done(err) }()
+	return s.AccountsClient.Update(ctx, in, opts...)
+}
+
+type InstrumentedAccountsServer struct {
+	AccountsServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedAccountsServer) Create(ctx context.Context, in *NewAccount) (result *UserSpec, err error) {
+	done := s.Metrics.start("Accounts.Create")
+	defer func() { done(err) }()
+	return s.AccountsServer.Create(ctx, in)
+}
+
+func (s *InstrumentedAccountsServer) RequestPasswordReset(ctx context.Context, in *EmailAddr) (result *User, err error) {
+	done := s.Metrics.start("Accounts.RequestPasswordReset")
+	defer func() { done(err) }()
+	return s.AccountsServer.RequestPasswordReset(ctx, in)
+}
+
+func (s *InstrumentedAccountsServer) ResetPassword(ctx context.Context, in *NewPassword) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Accounts.ResetPassword")
+	defer func() { done(err) }()
+	return s.AccountsServer.ResetPassword(ctx, in)
+}
+
+func (s *InstrumentedAccountsServer) Update(ctx context.Context, in *User) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Accounts.Update")
+	defer func() { done(err) }()
+	return s.AccountsServer.Update(ctx, in)
+}
+
+type InstrumentedAuditLogClient struct {
+	AuditLogClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedAuditLogClient) List(ctx context.Context, in *AuditEventListOptions, opts ...grpc.CallOption) (result *AuditEventList, err error) {
+	done := s.Metrics.start("AuditLog.List")
+	defer func() { done(err) }()
+	return s.AuditLogClient.List(ctx, in, opts...)
+}
+
+type InstrumentedAuditLogServer struct {
+	AuditLogServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedAuditLogServer) List(ctx context.Context, in *AuditEventListOptions) (result *AuditEventList, err error) {
+	done := s.Metrics.start("AuditLog.List")
+	defer func() { done(err) }()
+	return s.AuditLogServer.List(ctx, in)
+}
+
+type InstrumentedAuthClient struct {
+	AuthClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedAuthClient) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest, opts ...grpc.CallOption) (result *AuthorizationCode, err error) {
+	done := s.Metrics.start("Auth.GetAuthorizationCode")
+	defer func() { done(err) }()
+	return s.AuthClient.GetAuthorizationCode(ctx, in, opts...)
+}
+
+func (s *InstrumentedAuthClient) GetAccessToken(ctx context.Context, in *AccessTokenRequest, opts ...grpc.CallOption) (result *AccessTokenResponse, err error) {
+	done := s.Metrics.start("Auth.GetAccessToken")
+	defer func() { done(err) }()
+	return s.AuthClient.GetAccessToken(ctx, in, opts...)
+}
+
+func (s *InstrumentedAuthClient) Identify(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (result *AuthInfo, err error) {
+	done := s.Metrics.start("Auth.Identify")
+	defer func() { done(err) }()
+	return s.AuthClient.Identify(ctx, in, opts...)
+}
+
+func (s *InstrumentedAuthClient) GetPermissions(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (result *UserPermissions, err error) {
+	done := s.Metrics.start("Auth.GetPermissions")
+	defer func() { done(err) }()
+	return s.AuthClient.GetPermissions(ctx, in, opts...)
+}
+
+type InstrumentedAuthServer struct {
+	AuthServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedAuthServer) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest) (result *AuthorizationCode, err error) {
+	done := s.Metrics.start("Auth.GetAuthorizationCode")
+	defer func() { done(err) }()
+	return s.AuthServer.GetAuthorizationCode(ctx, in)
+}
+
+func (s *InstrumentedAuthServer) GetAccessToken(ctx context.Context, in *AccessTokenRequest) (result *AccessTokenResponse, err error) {
+	done := s.Metrics.start("Auth.GetAccessToken")
+	defer func() { done(err) }()
+	return s.AuthServer.GetAccessToken(ctx, in)
+}
+
+func (s *InstrumentedAuthServer) Identify(ctx context.Context, in *pbtypes.Void) (result *AuthInfo, err error) {
+	done := s.Metrics.start("Auth.Identify")
+	defer func() { done(err) }()
+	return s.AuthServer.Identify(ctx, in)
+}
+
+func (s *InstrumentedAuthServer) GetPermissions(ctx context.Context, in *pbtypes.Void) (result *UserPermissions, err error) {
+	done := s.Metrics.start("Auth.GetPermissions")
+	defer func() { done(err) }()
+	return s.AuthServer.GetPermissions(ctx, in)
+}
+
+type InstrumentedBuildsClient struct {
+	BuildsClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedBuildsClient) Get(ctx context.Context, in *BuildSpec, opts ...grpc.CallOption) (result *Build, err error) {
+	done := s.Metrics.start("Builds.Get")
+	defer func() { done(err) }()
+	return s.BuildsClient.Get(ctx, in, opts...)
+}
+
+func (s *InstrumentedBuildsClient) GetRepoBuildInfo(ctx context.Context, in *BuildsGetRepoBuildInfoOp, opts ...grpc.CallOption) (result *RepoBuildInfo, err error) {
+	done := s.Metrics.start("Builds.GetRepoBuildInfo")
+	defer func() { done(err) }()
+	return s.BuildsClient.GetRepoBuildInfo(ctx, in, opts...)
+}
+
+func (s *InstrumentedBuildsClient) List(ctx context.Context, in *BuildListOptions, opts ...grpc.CallOption) (result *BuildList, err error) {
+	done := s.Metrics.start("Builds.List")
+	defer func() { done(err) }()
+	return s.BuildsClient.List(ctx, in, opts...)
+}
+
+func (s *InstrumentedBuildsClient) Create(ctx context.Context, in *BuildsCreateOp, opts ...grpc.CallOption) (result *Build, err error) {
+	done := s.Metrics.start("Builds.Create")
+	defer func() { done(err) }()
+	return s.BuildsClient.Create(ctx, in, opts...)
+}
+
+func (s *InstrumentedBuildsClient) Update(ctx context.Context, in *BuildsUpdateOp, opts ...grpc.CallOption) (result *Build, err error) {
+	done := s.Metrics.start("Builds.Update")
+	defer func() { done(err) }()
+	return s.BuildsClient.Update(ctx, in, opts...)
+}
+
+func (s *InstrumentedBuildsClient) ListBuildTasks(ctx context.Context, in *BuildsListBuildTasksOp, opts ...grpc.CallOption) (result *BuildTaskList, err error) {
+	done := s.Metrics.start("Builds.ListBuildTasks")
+	defer func() { done(err) }()
+	return s.BuildsClient.ListBuildTasks(ctx, in, opts...)
+}
+
+func (s *InstrumentedBuildsClient) CreateTasks(ctx context.Context, in *BuildsCreateTasksOp, opts ...grpc.CallOption) (result *BuildTaskList, err error) {
+	done := s.Metrics.start("Builds.CreateTasks")
+	defer func() { done(err) }()
+	return s.BuildsClient.CreateTasks(ctx, in, opts...)
+}
+
+func (s *InstrumentedBuildsClient) UpdateTask(ctx context.Context, in *BuildsUpdateTaskOp, opts ...grpc.CallOption) (result *BuildTask, err error) {
+	done := s.Metrics.start("Builds.UpdateTask")
+	defer func() { done(err) }()
+	return s.BuildsClient.UpdateTask(ctx, in, opts...)
+}
+
+func (s *InstrumentedBuildsClient) GetLog(ctx context.Context, in *BuildsGetLogOp, opts ...grpc.CallOption) (result *LogEntries, err error) {
+	done := s.Metrics.start("Builds.GetLog")
+	defer func() { done(err) }()
+	return s.BuildsClient.GetLog(ctx, in, opts...)
+}
+
+func (s *InstrumentedBuildsClient) GetTaskLog(ctx context.Context, in *BuildsGetTaskLogOp, opts ...grpc.CallOption) (result *LogEntries, err error) {
+	done := s.Metrics.start("Builds.GetTaskLog")
+	defer func() { done(err) }()
+	return s.BuildsClient.GetTaskLog(ctx, in, opts...)
+}
+
+func (s *InstrumentedBuildsClient) DequeueNext(ctx context.Context, in *BuildsDequeueNextOp, opts ...grpc.CallOption) (result *Build, err error) {
+	done := s.Metrics.start("Builds.DequeueNext")
+	defer func() { done(err) }()
+	return s.BuildsClient.DequeueNext(ctx, in, opts...)
+}
+
+type InstrumentedBuildsServer struct {
+	BuildsServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedBuildsServer) Get(ctx context.Context, in *BuildSpec) (result *Build, err error) {
+	done := s.Metrics.start("Builds.Get")
+	defer func() { done(err) }()
+	return s.BuildsServer.Get(ctx, in)
+}
+
+func (s *InstrumentedBuildsServer) GetRepoBuildInfo(ctx context.Context, in *BuildsGetRepoBuildInfoOp) (result *RepoBuildInfo, err error) {
+	done := s.Metrics.start("Builds.GetRepoBuildInfo")
+	defer func() { done(err) }()
+	return s.BuildsServer.GetRepoBuildInfo(ctx, in)
+}
+
+func (s *InstrumentedBuildsServer) List(ctx context.Context, in *BuildListOptions) (result *BuildList, err error) {
+	done := s.Metrics.start("Builds.List")
+	defer func() { done(err) }()
+	return s.BuildsServer.List(ctx, in)
+}
+
+func (s *InstrumentedBuildsServer) Create(ctx context.Context, in *BuildsCreateOp) (result *Build, err error) {
+	done := s.Metrics.start("Builds.Create")
+	defer func() { done(err) }()
+	return s.BuildsServer.Create(ctx, in)
+}
+
+func (s *InstrumentedBuildsServer) Update(ctx context.Context, in *BuildsUpdateOp) (result *Build, err error) {
+	done := s.Metrics.start("Builds.Update")
+	defer func() { done(err) }()
+	return s.BuildsServer.Update(ctx, in)
+}
+
+func (s *InstrumentedBuildsServer) ListBuildTasks(ctx context.Context, in *BuildsListBuildTasksOp) (result *BuildTaskList, err error) {
+	done := s.Metrics.start("Builds.ListBuildTasks")
+	defer func() { done(err) }()
+	return s.BuildsServer.ListBuildTasks(ctx, in)
+}
+
+func (s *InstrumentedBuildsServer) CreateTasks(ctx context.Context, in *BuildsCreateTasksOp) (result *BuildTaskList, err error) {
+	done := s.Metrics.start("Builds.CreateTasks")
+	defer func() { done(err) }()
+	return s.BuildsServer.CreateTasks(ctx, in)
+}
+
+func (s *InstrumentedBuildsServer) UpdateTask(ctx context.Context, in *BuildsUpdateTaskOp) (result *BuildTask, err error) {
+	done := s.Metrics.start("Builds.UpdateTask")
+	defer func() { done(err) }()
+	return s.BuildsServer.UpdateTask(ctx, in)
+}
+
+func (s *InstrumentedBuildsServer) GetLog(ctx context.Context, in *BuildsGetLogOp) (result *LogEntries, err error) {
+	done := s.Metrics.start("Builds.GetLog")
+	defer func() { done(err) }()
+	return s.BuildsServer.GetLog(ctx, in)
+}
+
+func (s *InstrumentedBuildsServer) GetTaskLog(ctx context.Context, in *BuildsGetTaskLogOp) (result *LogEntries, err error) {
+	done := s.Metrics.start("Builds.GetTaskLog")
+	defer func() { done(err) }()
+	return s.BuildsServer.GetTaskLog(ctx, in)
+}
+
+func (s *InstrumentedBuildsServer) DequeueNext(ctx context.Context, in *BuildsDequeueNextOp) (result *Build, err error) {
+	done := s.Metrics.start("Builds.DequeueNext")
+	defer func() { done(err) }()
+	return s.BuildsServer.DequeueNext(ctx, in)
+}
+
+type InstrumentedChangesetsClient struct {
+	ChangesetsClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedChangesetsClient) Create(ctx context.Context, in *ChangesetCreateOp, opts ...grpc.CallOption) (result *Changeset, err error) {
+	done := s.Metrics.start("Changesets.Create")
+	defer func() { done(err) }()
+	return s.ChangesetsClient.Create(ctx, in, opts...)
+}
+
+func (s *InstrumentedChangesetsClient) Get(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (result *Changeset, err error) {
+	done := s.Metrics.start("Changesets.Get")
+	defer func() { done(err) }()
+	return s.ChangesetsClient.Get(ctx, in, opts...)
+}
+
+func (s *InstrumentedChangesetsClient) List(ctx context.Context, in *ChangesetListOp, opts ...grpc.CallOption) (result *ChangesetList, err error) {
+	done := s.Metrics.start("Changesets.List")
+	defer func() { done(err) }()
+	return s.ChangesetsClient.List(ctx, in, opts...)
+}
+
+func (s *InstrumentedChangesetsClient) Update(ctx context.Context, in *ChangesetUpdateOp, opts ...grpc.CallOption) (result *ChangesetEvent, err error) {
+	done := s.Metrics.start("Changesets.Update")
+	defer func() { done(err) }()
+	return s.ChangesetsClient.Update(ctx, in, opts...)
+}
+
+func (s *InstrumentedChangesetsClient) Merge(ctx context.Context, in *ChangesetMergeOp, opts ...grpc.CallOption) (result *ChangesetEvent, err error) {
+	done := s.Metrics.start("Changesets.Merge")
+	defer func() { done(err) }()
+	return s.ChangesetsClient.Merge(ctx, in, opts...)
+}
+
+func (s *InstrumentedChangesetsClient) UpdateAffected(ctx context.Context, in *ChangesetUpdateAffectedOp, opts ...grpc.CallOption) (result *ChangesetEventList, err error) {
+	done := s.Metrics.start("Changesets.UpdateAffected")
+	defer func() { done(err) }()
+	return s.ChangesetsClient.UpdateAffected(ctx, in, opts...)
+}
+
+func (s *InstrumentedChangesetsClient) CreateReview(ctx context.Context, in *ChangesetCreateReviewOp, opts ...grpc.CallOption) (result *ChangesetReview, err error) {
+	done := s.Metrics.start("Changesets.CreateReview")
+	defer func() { done(err) }()
+	return s.ChangesetsClient.CreateReview(ctx, in, opts...)
+}
+
+func (s *InstrumentedChangesetsClient) ListReviews(ctx context.Context, in *ChangesetListReviewsOp, opts ...grpc.CallOption) (result *ChangesetReviewList, err error) {
+	done := s.Metrics.start("Changesets.ListReviews")
+	defer func() { done(err) }()
+	return s.ChangesetsClient.ListReviews(ctx, in, opts...)
+}
+
+func (s *InstrumentedChangesetsClient) ListEvents(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (result *ChangesetEventList, err error) {
+	done := s.Metrics.start("Changesets.ListEvents")
+	defer func() { done(err) }()
+	return s.ChangesetsClient.ListEvents(ctx, in, opts...)
+}
+
+type InstrumentedChangesetsServer struct {
+	ChangesetsServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedChangesetsServer) Create(ctx context.Context, in *ChangesetCreateOp) (result *Changeset, err error) {
+	done := s.Metrics.start("Changesets.Create")
+	defer func() { done(err) }()
+	return s.ChangesetsServer.Create(ctx, in)
+}
+
+func (s *InstrumentedChangesetsServer) Get(ctx context.Context, in *ChangesetSpec) (result *Changeset, err error) {
+	done := s.Metrics.start("Changesets.Get")
+	defer func() { done(err) }()
+	return s.ChangesetsServer.Get(ctx, in)
+}
+
+func (s *InstrumentedChangesetsServer) List(ctx context.Context, in *ChangesetListOp) (result *ChangesetList, err error) {
+	done := s.Metrics.start("Changesets.List")
+	defer func() { done(err) }()
+	return s.ChangesetsServer.List(ctx, in)
+}
+
+func (s *InstrumentedChangesetsServer) Update(ctx context.Context, in *ChangesetUpdateOp) (result *ChangesetEvent, err error) {
+	done := s.Metrics.start("Changesets.Update")
+	defer func() { done(err) }()
+	return s.ChangesetsServer.Update(ctx, in)
+}
+
+func (s *InstrumentedChangesetsServer) Merge(ctx context.Context, in *ChangesetMergeOp) (result *ChangesetEvent, err error) {
+	done := s.Metrics.start("Changesets.Merge")
+	defer func() { done(err) }()
+	return s.ChangesetsServer.Merge(ctx, in)
+}
+
+func (s *InstrumentedChangesetsServer) UpdateAffected(ctx context.Context, in *ChangesetUpdateAffectedOp) (result *ChangesetEventList, err error) {
+	done := s.Metrics.start("Changesets.UpdateAffected")
+	defer func() { done(err) }()
+	return s.ChangesetsServer.UpdateAffected(ctx, in)
+}
+
+func (s *InstrumentedChangesetsServer) CreateReview(ctx context.Context, in *ChangesetCreateReviewOp) (result *ChangesetReview, err error) {
+	done := s.Metrics.start("Changesets.CreateReview")
+	defer func() { done(err) }()
+	return s.ChangesetsServer.CreateReview(ctx, in)
+}
+
+func (s *InstrumentedChangesetsServer) ListReviews(ctx context.Context, in *ChangesetListReviewsOp) (result *ChangesetReviewList, err error) {
+	done := s.Metrics.start("Changesets.ListReviews")
+	defer func() { done(err) }()
+	return s.ChangesetsServer.ListReviews(ctx, in)
+}
+
+func (s *InstrumentedChangesetsServer) ListEvents(ctx context.Context, in *ChangesetSpec) (result *ChangesetEventList, err error) {
+	done := s.Metrics.start("Changesets.ListEvents")
+	defer func() { done(err) }()
+	return s.ChangesetsServer.ListEvents(ctx, in)
+}
+
+type InstrumentedDefsClient struct {
+	DefsClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedDefsClient) Get(ctx context.Context, in *DefsGetOp, opts ...grpc.CallOption) (result *Def, err error) {
+	done := s.Metrics.start("Defs.Get")
+	defer func() { done(err) }()
+	return s.DefsClient.Get(ctx, in, opts...)
+}
+
+func (s *InstrumentedDefsClient) List(ctx context.Context, in *DefListOptions, opts ...grpc.CallOption) (result *DefList, err error) {
+	done := s.Metrics.start("Defs.List")
+	defer func() { done(err) }()
+	return s.DefsClient.List(ctx, in, opts...)
+}
+
+func (s *InstrumentedDefsClient) ListRefs(ctx context.Context, in *DefsListRefsOp, opts ...grpc.CallOption) (result *RefList, err error) {
+	done := s.Metrics.start("Defs.ListRefs")
+	defer func() { done(err) }()
+	return s.DefsClient.ListRefs(ctx, in, opts...)
+}
+
+func (s *InstrumentedDefsClient) ListExamples(ctx context.Context, in *DefsListExamplesOp, opts ...grpc.CallOption) (result *ExampleList, err error) {
+	done := s.Metrics.start("Defs.ListExamples")
+	defer func() { done(err) }()
+	return s.DefsClient.ListExamples(ctx, in, opts...)
+}
+
+func (s *InstrumentedDefsClient) ListAuthors(ctx context.Context, in *DefsListAuthorsOp, opts ...grpc.CallOption) (result *DefAuthorList, err error) {
+	done := s.Metrics.start("Defs.ListAuthors")
+	defer func() { done(err) }()
+	return s.DefsClient.ListAuthors(ctx, in, opts...)
+}
+
+func (s *InstrumentedDefsClient) ListClients(ctx context.Context, in *DefsListClientsOp, opts ...grpc.CallOption) (result *DefClientList, err error) {
+	done := s.Metrics.start("Defs.ListClients")
+	defer func() { done(err) }()
+	return s.DefsClient.ListClients(ctx, in, opts...)
+}
+
+type InstrumentedDefsServer struct {
+	DefsServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedDefsServer) Get(ctx context.Context, in *DefsGetOp) (result *Def, err error) {
+	done := s.Metrics.start("Defs.Get")
+	defer func() { done(err) }()
+	return s.DefsServer.Get(ctx, in)
+}
+
+func (s *InstrumentedDefsServer) List(ctx context.Context, in *DefListOptions) (result *DefList, err error) {
+	done := s.Metrics.start("Defs.List")
+	defer func() { done(err) }()
+	return s.DefsServer.List(ctx, in)
+}
+
+func (s *InstrumentedDefsServer) ListRefs(ctx context.Context, in *DefsListRefsOp) (result *RefList, err error) {
+	done := s.Metrics.start("Defs.ListRefs")
+	defer func() { done(err) }()
+	return s.DefsServer.ListRefs(ctx, in)
+}
+
+func (s *InstrumentedDefsServer) ListExamples(ctx context.Context, in *DefsListExamplesOp) (result *ExampleList, err error) {
+	done := s.Metrics.start("Defs.ListExamples")
+	defer func() { done(err) }()
+	return s.DefsServer.ListExamples(ctx, in)
+}
+
+func (s *InstrumentedDefsServer) ListAuthors(ctx context.Context, in *DefsListAuthorsOp) (result *DefAuthorList, err error) {
+	done := s.Metrics.start("Defs.ListAuthors")
+	defer func() { done(err) }()
+	return s.DefsServer.ListAuthors(ctx, in)
+}
+
+func (s *InstrumentedDefsServer) ListClients(ctx context.Context, in *DefsListClientsOp) (result *DefClientList, err error) {
+	done := s.Metrics.start("Defs.ListClients")
+	defer func() { done(err) }()
+	return s.DefsServer.ListClients(ctx, in)
+}
+
+type InstrumentedDeltasClient struct {
+	DeltasClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedDeltasClient) Get(ctx context.Context, in *DeltaSpec, opts ...grpc.CallOption) (result *Delta, err error) {
+	done := s.Metrics.start("Deltas.Get")
+	defer func() { done(err) }()
+	return s.DeltasClient.Get(ctx, in, opts...)
+}
+
+func (s *InstrumentedDeltasClient) ListUnits(ctx context.Context, in *DeltasListUnitsOp, opts ...grpc.CallOption) (result *UnitDeltaList, err error) {
+	done := s.Metrics.start("Deltas.ListUnits")
+	defer func() { done(err) }()
+	return s.DeltasClient.ListUnits(ctx, in, opts...)
+}
+
+func (s *InstrumentedDeltasClient) ListDefs(ctx context.Context, in *DeltasListDefsOp, opts ...grpc.CallOption) (result *DeltaDefs, err error) {
+	done := s.Metrics.start("Deltas.ListDefs")
+	defer func() { done(err) }()
+	return s.DeltasClient.ListDefs(ctx, in, opts...)
+}
+
+func (s *InstrumentedDeltasClient) ListFiles(ctx context.Context, in *DeltasListFilesOp, opts ...grpc.CallOption) (result *DeltaFiles, err error) {
+	done := s.Metrics.start("Deltas.ListFiles")
+	defer func() { done(err) }()
+	return s.DeltasClient.ListFiles(ctx, in, opts...)
+}
+
+func (s *InstrumentedDeltasClient) ListAffectedAuthors(ctx context.Context, in *DeltasListAffectedAuthorsOp, opts ...grpc.CallOption) (result *DeltaAffectedPersonList, err error) {
+	done := s.Metrics.start("Deltas.ListAffectedAuthors")
+	defer func() { done(err) }()
+	return s.DeltasClient.ListAffectedAuthors(ctx, in, opts...)
+}
+
+func (s *InstrumentedDeltasClient) ListAffectedClients(ctx context.Context, in *DeltasListAffectedClientsOp, opts ...grpc.CallOption) (result *DeltaAffectedPersonList, err error) {
+	done := s.Metrics.start("Deltas.ListAffectedClients")
+	defer func() { done(err) }()
+	return s.DeltasClient.ListAffectedClients(ctx, in, opts...)
+}
+
+type InstrumentedDeltasServer struct {
+	DeltasServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedDeltasServer) Get(ctx context.Context, in *DeltaSpec) (result *Delta, err error) {
+	done := s.Metrics.start("Deltas.Get")
+	defer func() { done(err) }()
+	return s.DeltasServer.Get(ctx, in)
+}
+
+func (s *InstrumentedDeltasServer) ListUnits(ctx context.Context, in *DeltasListUnitsOp) (result *UnitDeltaList, err error) {
+	done := s.Metrics.start("Deltas.ListUnits")
+	defer func() { done(err) }()
+	return s.DeltasServer.ListUnits(ctx, in)
+}
+
+func (s *InstrumentedDeltasServer) ListDefs(ctx context.Context, in *DeltasListDefsOp) (result *DeltaDefs, err error) {
+	done := s.Metrics.start("Deltas.ListDefs")
+	defer func() { done(err) }()
+	return s.DeltasServer.ListDefs(ctx, in)
+}
+
+func (s *InstrumentedDeltasServer) ListFiles(ctx context.Context, in *DeltasListFilesOp) (result *DeltaFiles, err error) {
+	done := s.Metrics.start("Deltas.ListFiles")
+	defer func() { done(err) }()
+	return s.DeltasServer.ListFiles(ctx, in)
+}
+
+func (s *InstrumentedDeltasServer) ListAffectedAuthors(ctx context.Context, in *DeltasListAffectedAuthorsOp) (result *DeltaAffectedPersonList, err error) {
+	done := s.Metrics.start("Deltas.ListAffectedAuthors")
+	defer func() { done(err) }()
+	return s.DeltasServer.ListAffectedAuthors(ctx, in)
+}
+
+func (s *InstrumentedDeltasServer) ListAffectedClients(ctx context.Context, in *DeltasListAffectedClientsOp) (result *DeltaAffectedPersonList, err error) {
+	done := s.Metrics.start("Deltas.ListAffectedClients")
+	defer func() { done(err) }()
+	return s.DeltasServer.ListAffectedClients(ctx, in)
+}
+
+type InstrumentedDiscussionsClient struct {
+	DiscussionsClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedDiscussionsClient) Create(ctx context.Context, in *Discussion, opts ...grpc.CallOption) (result *Discussion, err error) {
+	done := s.Metrics.start("Discussions.Create")
+	defer func() { done(err) }()
+	return s.DiscussionsClient.Create(ctx, in, opts...)
+}
+
+func (s *InstrumentedDiscussionsClient) Get(ctx context.Context, in *DiscussionSpec, opts ...grpc.CallOption) (result *Discussion, err error) {
+	done := s.Metrics.start("Discussions.Get")
+	defer func() { done(err) }()
+	return s.DiscussionsClient.Get(ctx, in, opts...)
+}
+
+func (s *InstrumentedDiscussionsClient) List(ctx context.Context, in *DiscussionListOp, opts ...grpc.CallOption) (result *DiscussionList, err error) {
+	done := s.Metrics.start("Discussions.List")
+	defer func() { done(err) }()
+	return s.DiscussionsClient.List(ctx, in, opts...)
+}
+
+func (s *InstrumentedDiscussionsClient) CreateComment(ctx context.Context, in *DiscussionCommentCreateOp, opts ...grpc.CallOption) (result *DiscussionComment, err error) {
+	done := s.Metrics.start("Discussions.CreateComment")
+	defer func() { done(err) }()
+	return s.DiscussionsClient.CreateComment(ctx, in, opts...)
+}
+
+func (s *InstrumentedDiscussionsClient) UpdateRating(ctx context.Context, in *DiscussionRatingUpdateOp, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Discussions.UpdateRating")
+	defer func() { done(err) }()
+	return s.DiscussionsClient.UpdateRating(ctx, in, opts...)
+}
+
+type InstrumentedDiscussionsServer struct {
+	DiscussionsServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedDiscussionsServer) Create(ctx context.Context, in *Discussion) (result *Discussion, err error) {
+	done := s.Metrics.start("Discussions.Create")
+	defer func() { done(err) }()
+	return s.DiscussionsServer.Create(ctx, in)
+}
+
+func (s *InstrumentedDiscussionsServer) Get(ctx context.Context, in *DiscussionSpec) (result *Discussion, err error) {
+	done := s.Metrics.start("Discussions.Get")
+	defer func() { done(err) }()
+	return s.DiscussionsServer.Get(ctx, in)
+}
+
+func (s *InstrumentedDiscussionsServer) List(ctx context.Context, in *DiscussionListOp) (result *DiscussionList, err error) {
+	done := s.Metrics.start("Discussions.List")
+	defer func() { done(err) }()
+	return s.DiscussionsServer.List(ctx, in)
+}
+
+func (s *InstrumentedDiscussionsServer) CreateComment(ctx context.Context, in *DiscussionCommentCreateOp) (result *DiscussionComment, err error) {
+	done := s.Metrics.start("Discussions.CreateComment")
+	defer func() { done(err) }()
+	return s.DiscussionsServer.CreateComment(ctx, in)
+}
+
+func (s *InstrumentedDiscussionsServer) UpdateRating(ctx context.Context, in *DiscussionRatingUpdateOp) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Discussions.UpdateRating")
+	defer func() { done(err) }()
+	return s.DiscussionsServer.UpdateRating(ctx, in)
+}
+
+type InstrumentedGraphUplinkClient struct {
+	GraphUplinkClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedGraphUplinkClient) Push(ctx context.Context, in *MetricsSnapshot, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("GraphUplink.Push")
+	defer func() { done(err) }()
+	return s.GraphUplinkClient.Push(ctx, in, opts...)
+}
+
+func (s *InstrumentedGraphUplinkClient) PushEvents(ctx context.Context, in *UserEventList, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("GraphUplink.PushEvents")
+	defer func() { done(err) }()
+	return s.GraphUplinkClient.PushEvents(ctx, in, opts...)
+}
+
+type InstrumentedGraphUplinkServer struct {
+	GraphUplinkServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedGraphUplinkServer) Push(ctx context.Context, in *MetricsSnapshot) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("GraphUplink.Push")
+	defer func() { done(err) }()
+	return s.GraphUplinkServer.Push(ctx, in)
+}
+
+func (s *InstrumentedGraphUplinkServer) PushEvents(ctx context.Context, in *UserEventList) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("GraphUplink.PushEvents")
+	defer func() { done(err) }()
+	return s.GraphUplinkServer.PushEvents(ctx, in)
+}
+
+type InstrumentedMarkdownClient struct {
+	MarkdownClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedMarkdownClient) Render(ctx context.Context, in *MarkdownRenderOp, opts ...grpc.CallOption) (result *MarkdownData, err error) {
+	done := s.Metrics.start("Markdown.Render")
+	defer func() { done(err) }()
+	return s.MarkdownClient.Render(ctx, in, opts...)
+}
+
+type InstrumentedMarkdownServer struct {
+	MarkdownServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedMarkdownServer) Render(ctx context.Context, in *MarkdownRenderOp) (result *MarkdownData, err error) {
+	done := s.Metrics.start("Markdown.Render")
+	defer func() { done(err) }()
+	return s.MarkdownServer.Render(ctx, in)
+}
+
+type InstrumentedMetaClient struct {
+	MetaClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedMetaClient) Status(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (result *ServerStatus, err error) {
+	done := s.Metrics.start("Meta.Status")
+	defer func() { done(err) }()
+	return s.MetaClient.Status(ctx, in, opts...)
+}
+
+func (s *InstrumentedMetaClient) Config(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (result *ServerConfig, err error) {
+	done := s.Metrics.start("Meta.Config")
+	defer func() { done(err) }()
+	return s.MetaClient.Config(ctx, in, opts...)
+}
+
+func (s *InstrumentedMetaClient) PubKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (result *ServerPubKey, err error) {
+	done := s.Metrics.start("Meta.PubKey")
+	defer func() { done(err) }()
+	return s.MetaClient.PubKey(ctx, in, opts...)
+}
+
+type InstrumentedMetaServer struct {
+	MetaServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedMetaServer) Status(ctx context.Context, in *pbtypes.Void) (result *ServerStatus, err error) {
+	done := s.Metrics.start("Meta.Status")
+	defer func() { done(err) }()
+	return s.MetaServer.Status(ctx, in)
+}
+
+func (s *InstrumentedMetaServer) Config(ctx context.Context, in *pbtypes.Void) (result *ServerConfig, err error) {
+	done := s.Metrics.start("Meta.Config")
+	defer func() { done(err) }()
+	return s.MetaServer.Config(ctx, in)
+}
+
+func (s *InstrumentedMetaServer) PubKey(ctx context.Context, in *pbtypes.Void) (result *ServerPubKey, err error) {
+	done := s.Metrics.start("Meta.PubKey")
+	defer func() { done(err) }()
+	return s.MetaServer.PubKey(ctx, in)
+}
+
+type InstrumentedMirrorReposClient struct {
+	MirrorReposClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedMirrorReposClient) RefreshVCS(ctx context.Context, in *MirrorReposRefreshVCSOp, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("MirrorRepos.RefreshVCS")
+	defer func() { done(err) }()
+	return s.MirrorReposClient.RefreshVCS(ctx, in, opts...)
+}
+
+type InstrumentedMirrorReposServer struct {
+	MirrorReposServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedMirrorReposServer) RefreshVCS(ctx context.Context, in *MirrorReposRefreshVCSOp) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("MirrorRepos.RefreshVCS")
+	defer func() { done(err) }()
+	return s.MirrorReposServer.RefreshVCS(ctx, in)
+}
+
+type InstrumentedMirroredRepoSSHKeysClient struct {
+	MirroredRepoSSHKeysClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedMirroredRepoSSHKeysClient) Create(ctx context.Context, in *MirroredRepoSSHKeysCreateOp, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("MirroredRepoSSHKeys.Create")
+	defer func() { done(err) }()
+	return s.MirroredRepoSSHKeysClient.Create(ctx, in, opts...)
+}
+
+func (s *InstrumentedMirroredRepoSSHKeysClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (result *SSHPrivateKey, err error) {
+	done := s.Metrics.start("MirroredRepoSSHKeys.Get")
+	defer func() { done(err) }()
+	return s.MirroredRepoSSHKeysClient.Get(ctx, in, opts...)
+}
+
+func (s *InstrumentedMirroredRepoSSHKeysClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("MirroredRepoSSHKeys.Delete")
+	defer func() { done(err) }()
+	return s.MirroredRepoSSHKeysClient.Delete(ctx, in, opts...)
+}
+
+type InstrumentedMirroredRepoSSHKeysServer struct {
+	MirroredRepoSSHKeysServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedMirroredRepoSSHKeysServer) Create(ctx context.Context, in *MirroredRepoSSHKeysCreateOp) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("MirroredRepoSSHKeys.Create")
+	defer func() { done(err) }()
+	return s.MirroredRepoSSHKeysServer.Create(ctx, in)
+}
+
+func (s *InstrumentedMirroredRepoSSHKeysServer) Get(ctx context.Context, in *RepoSpec) (result *SSHPrivateKey, err error) {
+	done := s.Metrics.start("MirroredRepoSSHKeys.Get")
+	defer func() { done(err) }()
+	return s.MirroredRepoSSHKeysServer.Get(ctx, in)
+}
+
+func (s *InstrumentedMirroredRepoSSHKeysServer) Delete(ctx context.Context, in *RepoSpec) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("MirroredRepoSSHKeys.Delete")
+	defer func() { done(err) }()
+	return s.MirroredRepoSSHKeysServer.Delete(ctx, in)
+}
+
+type InstrumentedNotifyClient struct {
+	NotifyClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedNotifyClient) GenericEvent(ctx context.Context, in *NotifyGenericEvent, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Notify.GenericEvent")
+	defer func() { done(err) }()
+	return s.NotifyClient.GenericEvent(ctx, in, opts...)
+}
+
+type InstrumentedNotifyServer struct {
+	NotifyServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedNotifyServer) GenericEvent(ctx context.Context, in *NotifyGenericEvent) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Notify.GenericEvent")
+	defer func() { done(err) }()
+	return s.NotifyServer.GenericEvent(ctx, in)
+}
+
+type InstrumentedOrgsClient struct {
+	OrgsClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedOrgsClient) Get(ctx context.Context, in *OrgSpec, opts ...grpc.CallOption) (result *Org, err error) {
+	done := s.Metrics.start("Orgs.Get")
+	defer func() { done(err) }()
+	return s.OrgsClient.Get(ctx, in, opts...)
+}
+
+func (s *InstrumentedOrgsClient) List(ctx context.Context, in *OrgsListOp, opts ...grpc.CallOption) (result *OrgList, err error) {
+	done := s.Metrics.start("Orgs.List")
+	defer func() { done(err) }()
+	return s.OrgsClient.List(ctx, in, opts...)
+}
+
+func (s *InstrumentedOrgsClient) ListMembers(ctx context.Context, in *OrgsListMembersOp, opts ...grpc.CallOption) (result *UserList, err error) {
+	done := s.Metrics.start("Orgs.ListMembers")
+	defer func() { done(err) }()
+	return s.OrgsClient.ListMembers(ctx, in, opts...)
+}
+
+type InstrumentedOrgsServer struct {
+	OrgsServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedOrgsServer) Get(ctx context.Context, in *OrgSpec) (result *Org, err error) {
+	done := s.Metrics.start("Orgs.Get")
+	defer func() { done(err) }()
+	return s.OrgsServer.Get(ctx, in)
+}
+
+func (s *InstrumentedOrgsServer) List(ctx context.Context, in *OrgsListOp) (result *OrgList, err error) {
+	done := s.Metrics.start("Orgs.List")
+	defer func() { done(err) }()
+	return s.OrgsServer.List(ctx, in)
+}
+
+func (s *InstrumentedOrgsServer) ListMembers(ctx context.Context, in *OrgsListMembersOp) (result *UserList, err error) {
+	done := s.Metrics.start("Orgs.ListMembers")
+	defer func() { done(err) }()
+	return s.OrgsServer.ListMembers(ctx, in)
+}
+
+type InstrumentedPeopleClient struct {
+	PeopleClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedPeopleClient) Get(ctx context.Context, in *PersonSpec, opts ...grpc.CallOption) (result *Person, err error) {
+	done := s.Metrics.start("People.Get")
+	defer func() { done(err) }()
+	return s.PeopleClient.Get(ctx, in, opts...)
+}
+
+type InstrumentedPeopleServer struct {
+	PeopleServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedPeopleServer) Get(ctx context.Context, in *PersonSpec) (result *Person, err error) {
+	done := s.Metrics.start("People.Get")
+	defer func() { done(err) }()
+	return s.PeopleServer.Get(ctx, in)
+}
+
+type InstrumentedRegisteredClientsClient struct {
+	RegisteredClientsClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedRegisteredClientsClient) Get(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (result *RegisteredClient, err error) {
+	done := s.Metrics.start("RegisteredClients.Get")
+	defer func() { done(err) }()
+	return s.RegisteredClientsClient.Get(ctx, in, opts...)
+}
+
+func (s *InstrumentedRegisteredClientsClient) GetCurrent(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (result *RegisteredClient, err error) {
+	done := s.Metrics.start("RegisteredClients.GetCurrent")
+	defer func() { done(err) }()
+	return s.RegisteredClientsClient.GetCurrent(ctx, in, opts...)
+}
+
+func (s *InstrumentedRegisteredClientsClient) Create(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (result *RegisteredClient, err error) {
+	done := s.Metrics.start("RegisteredClients.Create")
+	defer func() { done(err) }()
+	return s.RegisteredClientsClient.Create(ctx, in, opts...)
+}
+
+func (s *InstrumentedRegisteredClientsClient) Update(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("RegisteredClients.Update")
+	defer func() { done(err) }()
+	return s.RegisteredClientsClient.Update(ctx, in, opts...)
+}
+
+func (s *InstrumentedRegisteredClientsClient) Delete(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("RegisteredClients.Delete")
+	defer func() { done(err) }()
+	return s.RegisteredClientsClient.Delete(ctx, in, opts...)
+}
+
+func (s *InstrumentedRegisteredClientsClient) List(ctx context.Context, in *RegisteredClientListOptions, opts ...grpc.CallOption) (result *RegisteredClientList, err error) {
+	done := s.Metrics.start("RegisteredClients.List")
+	defer func() { done(err) }()
+	return s.RegisteredClientsClient.List(ctx, in, opts...)
+}
+
+func (s *InstrumentedRegisteredClientsClient) GetUserPermissions(ctx context.Context, in *UserPermissionsOptions, opts ...grpc.CallOption) (result *UserPermissions, err error) {
+	done := s.Metrics.start("RegisteredClients.GetUserPermissions")
+	defer func() { done(err) }()
+	return s.RegisteredClientsClient.GetUserPermissions(ctx, in, opts...)
+}
+
+func (s *InstrumentedRegisteredClientsClient) SetUserPermissions(ctx context.Context, in *UserPermissions, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("RegisteredClients.SetUserPermissions")
+	defer func() { done(err) }()
+	return s.RegisteredClientsClient.SetUserPermissions(ctx, in, opts...)
+}
+
+func (s *InstrumentedRegisteredClientsClient) ListUserPermissions(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (result *UserPermissionsList, err error) {
+	done := s.Metrics.start("RegisteredClients.ListUserPermissions")
+	defer func() { done(err) }()
+	return s.RegisteredClientsClient.ListUserPermissions(ctx, in, opts...)
+}
+
+type InstrumentedRegisteredClientsServer struct {
+	RegisteredClientsServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedRegisteredClientsServer) Get(ctx context.Context, in *RegisteredClientSpec) (result *RegisteredClient, err error) {
+	done := s.Metrics.start("RegisteredClients.Get")
+	defer func() { done(err) }()
+	return s.RegisteredClientsServer.Get(ctx, in)
+}
+
+func (s *InstrumentedRegisteredClientsServer) GetCurrent(ctx context.Context, in *pbtypes.Void) (result *RegisteredClient, err error) {
+	done := s.Metrics.start("RegisteredClients.GetCurrent")
+	defer func() { done(err) }()
+	return s.RegisteredClientsServer.GetCurrent(ctx, in)
+}
+
+func (s *InstrumentedRegisteredClientsServer) Create(ctx context.Context, in *RegisteredClient) (result *RegisteredClient, err error) {
+	done := s.Metrics.start("RegisteredClients.Create")
+	defer func() { done(err) }()
+	return s.RegisteredClientsServer.Create(ctx, in)
+}
+
+func (s *InstrumentedRegisteredClientsServer) Update(ctx context.Context, in *RegisteredClient) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("RegisteredClients.Update")
+	defer func() { done(err) }()
+	return s.RegisteredClientsServer.Update(ctx, in)
+}
+
+func (s *InstrumentedRegisteredClientsServer) Delete(ctx context.Context, in *RegisteredClientSpec) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("RegisteredClients.Delete")
+	defer func() { done(err) }()
+	return s.RegisteredClientsServer.Delete(ctx, in)
+}
+
+func (s *InstrumentedRegisteredClientsServer) List(ctx context.Context, in *RegisteredClientListOptions) (result *RegisteredClientList, err error) {
+	done := s.Metrics.start("RegisteredClients.List")
+	defer func() { done(err) }()
+	return s.RegisteredClientsServer.List(ctx, in)
+}
+
+func (s *InstrumentedRegisteredClientsServer) GetUserPermissions(ctx context.Context, in *UserPermissionsOptions) (result *UserPermissions, err error) {
+	done := s.Metrics.start("RegisteredClients.GetUserPermissions")
+	defer func() { done(err) }()
+	return s.RegisteredClientsServer.GetUserPermissions(ctx, in)
+}
+
+func (s *InstrumentedRegisteredClientsServer) SetUserPermissions(ctx context.Context, in *UserPermissions) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("RegisteredClients.SetUserPermissions")
+	defer func() { done(err) }()
+	return s.RegisteredClientsServer.SetUserPermissions(ctx, in)
+}
+
+func (s *InstrumentedRegisteredClientsServer) ListUserPermissions(ctx context.Context, in *RegisteredClientSpec) (result *UserPermissionsList, err error) {
+	done := s.Metrics.start("RegisteredClients.ListUserPermissions")
+	defer func() { done(err) }()
+	return s.RegisteredClientsServer.ListUserPermissions(ctx, in)
+}
+
+type InstrumentedRepoBadgesClient struct {
+	RepoBadgesClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedRepoBadgesClient) ListBadges(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (result *BadgeList, err error) {
+	done := s.Metrics.start("RepoBadges.ListBadges")
+	defer func() { done(err) }()
+	return s.RepoBadgesClient.ListBadges(ctx, in, opts...)
+}
+
+func (s *InstrumentedRepoBadgesClient) ListCounters(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (result *CounterList, err error) {
+	done := s.Metrics.start("RepoBadges.ListCounters")
+	defer func() { done(err) }()
+	return s.RepoBadgesClient.ListCounters(ctx, in, opts...)
+}
+
+func (s *InstrumentedRepoBadgesClient) RecordHit(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("RepoBadges.RecordHit")
+	defer func() { done(err) }()
+	return s.RepoBadgesClient.RecordHit(ctx, in, opts...)
+}
+
+func (s *InstrumentedRepoBadgesClient) CountHits(ctx context.Context, in *RepoBadgesCountHitsOp, opts ...grpc.CallOption) (result *RepoBadgesCountHitsResult, err error) {
+	done := s.Metrics.start("RepoBadges.CountHits")
+	defer func() { done(err) }()
+	return s.RepoBadgesClient.CountHits(ctx, in, opts...)
+}
+
+type InstrumentedRepoBadgesServer struct {
+	RepoBadgesServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedRepoBadgesServer) ListBadges(ctx context.Context, in *RepoSpec) (result *BadgeList, err error) {
+	done := s.Metrics.start("RepoBadges.ListBadges")
+	defer func() { done(err) }()
+	return s.RepoBadgesServer.ListBadges(ctx, in)
+}
+
+func (s *InstrumentedRepoBadgesServer) ListCounters(ctx context.Context, in *RepoSpec) (result *CounterList, err error) {
+	done := s.Metrics.start("RepoBadges.ListCounters")
+	defer func() { done(err) }()
+	return s.RepoBadgesServer.ListCounters(ctx, in)
+}
+
+func (s *InstrumentedRepoBadgesServer) RecordHit(ctx context.Context, in *RepoSpec) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("RepoBadges.RecordHit")
+	defer func() { done(err) }()
+	return s.RepoBadgesServer.RecordHit(ctx, in)
+}
+
+func (s *InstrumentedRepoBadgesServer) CountHits(ctx context.Context, in *RepoBadgesCountHitsOp) (result *RepoBadgesCountHitsResult, err error) {
+	done := s.Metrics.start("RepoBadges.CountHits")
+	defer func() { done(err) }()
+	return s.RepoBadgesServer.CountHits(ctx, in)
+}
+
+type InstrumentedRepoStatusesClient struct {
+	RepoStatusesClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedRepoStatusesClient) GetCombined(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (result *CombinedStatus, err error) {
+	done := s.Metrics.start("RepoStatuses.GetCombined")
+	defer func() { done(err) }()
+	return s.RepoStatusesClient.GetCombined(ctx, in, opts...)
+}
+
+func (s *InstrumentedRepoStatusesClient) Create(ctx context.Context, in *RepoStatusesCreateOp, opts ...grpc.CallOption) (result *RepoStatus, err error) {
+	done := s.Metrics.start("RepoStatuses.Create")
+	defer func() { done(err) }()
+	return s.RepoStatusesClient.Create(ctx, in, opts...)
+}
+
+type InstrumentedRepoStatusesServer struct {
+	RepoStatusesServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedRepoStatusesServer) GetCombined(ctx context.Context, in *RepoRevSpec) (result *CombinedStatus, err error) {
+	done := s.Metrics.start("RepoStatuses.GetCombined")
+	defer func() { done(err) }()
+	return s.RepoStatusesServer.GetCombined(ctx, in)
+}
+
+func (s *InstrumentedRepoStatusesServer) Create(ctx context.Context, in *RepoStatusesCreateOp) (result *RepoStatus, err error) {
+	done := s.Metrics.start("RepoStatuses.Create")
+	defer func() { done(err) }()
+	return s.RepoStatusesServer.Create(ctx, in)
+}
+
+type InstrumentedRepoTreeClient struct {
+	RepoTreeClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedRepoTreeClient) Get(ctx context.Context, in *RepoTreeGetOp, opts ...grpc.CallOption) (result *TreeEntry, err error) {
+	done := s.Metrics.start("RepoTree.Get")
+	defer func() { done(err) }()
+	return s.RepoTreeClient.Get(ctx, in, opts...)
+}
+
+func (s *InstrumentedRepoTreeClient) Search(ctx context.Context, in *RepoTreeSearchOp, opts ...grpc.CallOption) (result *VCSSearchResultList, err error) {
+	done := s.Metrics.start("RepoTree.Search")
+	defer func() { done(err) }()
+	return s.RepoTreeClient.Search(ctx, in, opts...)
+}
+
+func (s *InstrumentedRepoTreeClient) List(ctx context.Context, in *RepoTreeListOp, opts ...grpc.CallOption) (result *RepoTreeListResult, err error) {
+	done := s.Metrics.start("RepoTree.List")
+	defer func() { done(err) }()
+	return s.RepoTreeClient.List(ctx, in, opts...)
+}
+
+type InstrumentedRepoTreeServer struct {
+	RepoTreeServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedRepoTreeServer) Get(ctx context.Context, in *RepoTreeGetOp) (result *TreeEntry, err error) {
+	done := s.Metrics.start("RepoTree.Get")
+	defer func() { done(err) }()
+	return s.RepoTreeServer.Get(ctx, in)
+}
+
+func (s *InstrumentedRepoTreeServer) Search(ctx context.Context, in *RepoTreeSearchOp) (result *VCSSearchResultList, err error) {
+	done := s.Metrics.start("RepoTree.Search")
+	defer func() { done(err) }()
+	return s.RepoTreeServer.Search(ctx, in)
+}
+
+func (s *InstrumentedRepoTreeServer) List(ctx context.Context, in *RepoTreeListOp) (result *RepoTreeListResult, err error) {
+	done := s.Metrics.start("RepoTree.List")
+	defer func() { done(err) }()
+	return s.RepoTreeServer.List(ctx, in)
+}
+
+type InstrumentedReposClient struct {
+	ReposClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedReposClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (result *Repo, err error) {
+	done := s.Metrics.start("Repos.Get")
+	defer func() { done(err) }()
+	return s.ReposClient.Get(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) List(ctx context.Context, in *RepoListOptions, opts ...grpc.CallOption) (result *RepoList, err error) {
+	done := s.Metrics.start("Repos.List")
+	defer func() { done(err) }()
+	return s.ReposClient.List(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) Create(ctx context.Context, in *ReposCreateOp, opts ...grpc.CallOption) (result *Repo, err error) {
+	done := s.Metrics.start("Repos.Create")
+	defer func() { done(err) }()
+	return s.ReposClient.Create(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) Update(ctx context.Context, in *ReposUpdateOp, opts ...grpc.CallOption) (result *Repo, err error) {
+	done := s.Metrics.start("Repos.Update")
+	defer func() { done(err) }()
+	return s.ReposClient.Update(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Repos.Delete")
+	defer func() { done(err) }()
+	return s.ReposClient.Delete(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) GetReadme(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (result *Readme, err error) {
+	done := s.Metrics.start("Repos.GetReadme")
+	defer func() { done(err) }()
+	return s.ReposClient.GetReadme(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) Enable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Repos.Enable")
+	defer func() { done(err) }()
+	return s.ReposClient.Enable(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) Disable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Repos.Disable")
+	defer func() { done(err) }()
+	return s.ReposClient.Disable(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) GetConfig(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (result *RepoConfig, err error) {
+	done := s.Metrics.start("Repos.GetConfig")
+	defer func() { done(err) }()
+	return s.ReposClient.GetConfig(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) GetCommit(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (result *vcs.Commit, err error) {
+	done := s.Metrics.start("Repos.GetCommit")
+	defer func() { done(err) }()
+	return s.ReposClient.GetCommit(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) ListCommits(ctx context.Context, in *ReposListCommitsOp, opts ...grpc.CallOption) (result *CommitList, err error) {
+	done := s.Metrics.start("Repos.ListCommits")
+	defer func() { done(err) }()
+	return s.ReposClient.ListCommits(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) ListBranches(ctx context.Context, in *ReposListBranchesOp, opts ...grpc.CallOption) (result *BranchList, err error) {
+	done := s.Metrics.start("Repos.ListBranches")
+	defer func() { done(err) }()
+	return s.ReposClient.ListBranches(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) ListTags(ctx context.Context, in *ReposListTagsOp, opts ...grpc.CallOption) (result *TagList, err error) {
+	done := s.Metrics.start("Repos.ListTags")
+	defer func() { done(err) }()
+	return s.ReposClient.ListTags(ctx, in, opts...)
+}
+
+func (s *InstrumentedReposClient) ListCommitters(ctx context.Context, in *ReposListCommittersOp, opts ...grpc.CallOption) (result *CommitterList, err error) {
+	done := s.Metrics.start("Repos.ListCommitters")
+	defer func() { done(err) }()
+	return s.ReposClient.ListCommitters(ctx, in, opts...)
+}
+
+type InstrumentedReposServer struct {
+	ReposServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedReposServer) Get(ctx context.Context, in *RepoSpec) (result *Repo, err error) {
+	done := s.Metrics.start("Repos.Get")
+	defer func() { done(err) }()
+	return s.ReposServer.Get(ctx, in)
+}
+
+func (s *InstrumentedReposServer) List(ctx context.Context, in *RepoListOptions) (result *RepoList, err error) {
+	done := s.Metrics.start("Repos.List")
+	defer func() { done(err) }()
+	return s.ReposServer.List(ctx, in)
+}
+
+func (s *InstrumentedReposServer) Create(ctx context.Context, in *ReposCreateOp) (result *Repo, err error) {
+	done := s.Metrics.start("Repos.Create")
+	defer func() { done(err) }()
+	return s.ReposServer.Create(ctx, in)
+}
+
+func (s *InstrumentedReposServer) Update(ctx context.Context, in *ReposUpdateOp) (result *Repo, err error) {
+	done := s.Metrics.start("Repos.Update")
+	defer func() { done(err) }()
+	return s.ReposServer.Update(ctx, in)
+}
+
+func (s *InstrumentedReposServer) Delete(ctx context.Context, in *RepoSpec) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Repos.Delete")
+	defer func() { done(err) }()
+	return s.ReposServer.Delete(ctx, in)
+}
+
+func (s *InstrumentedReposServer) GetReadme(ctx context.Context, in *RepoRevSpec) (result *Readme, err error) {
+	done := s.Metrics.start("Repos.GetReadme")
+	defer func() { done(err) }()
+	return s.ReposServer.GetReadme(ctx, in)
+}
+
+func (s *InstrumentedReposServer) Enable(ctx context.Context, in *RepoSpec) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Repos.Enable")
+	defer func() { done(err) }()
+	return s.ReposServer.Enable(ctx, in)
+}
+
+func (s *InstrumentedReposServer) Disable(ctx context.Context, in *RepoSpec) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("Repos.Disable")
+	defer func() { done(err) }()
+	return s.ReposServer.Disable(ctx, in)
+}
+
+func (s *InstrumentedReposServer) GetConfig(ctx context.Context, in *RepoSpec) (result *RepoConfig, err error) {
+	done := s.Metrics.start("Repos.GetConfig")
+	defer func() { done(err) }()
+	return s.ReposServer.GetConfig(ctx, in)
+}
+
+func (s *InstrumentedReposServer) GetCommit(ctx context.Context, in *RepoRevSpec) (result *vcs.Commit, err error) {
+	done := s.Metrics.start("Repos.GetCommit")
+	defer func() { done(err) }()
+	return s.ReposServer.GetCommit(ctx, in)
+}
+
+func (s *InstrumentedReposServer) ListCommits(ctx context.Context, in *ReposListCommitsOp) (result *CommitList, err error) {
+	done := s.Metrics.start("Repos.ListCommits")
+	defer func() { done(err) }()
+	return s.ReposServer.ListCommits(ctx, in)
+}
+
+func (s *InstrumentedReposServer) ListBranches(ctx context.Context, in *ReposListBranchesOp) (result *BranchList, err error) {
+	done := s.Metrics.start("Repos.ListBranches")
+	defer func() { done(err) }()
+	return s.ReposServer.ListBranches(ctx, in)
+}
+
+func (s *InstrumentedReposServer) ListTags(ctx context.Context, in *ReposListTagsOp) (result *TagList, err error) {
+	done := s.Metrics.start("Repos.ListTags")
+	defer func() { done(err) }()
+	return s.ReposServer.ListTags(ctx, in)
+}
+
+func (s *InstrumentedReposServer) ListCommitters(ctx context.Context, in *ReposListCommittersOp) (result *CommitterList, err error) {
+	done := s.Metrics.start("Repos.ListCommitters")
+	defer func() { done(err) }()
+	return s.ReposServer.ListCommitters(ctx, in)
+}
+
+type InstrumentedSavedSearchesClient struct {
+	SavedSearchesClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedSavedSearchesClient) Create(ctx context.Context, in *SavedSearchesCreateOp, opts ...grpc.CallOption) (result *SavedSearch, err error) {
+	done := s.Metrics.start("SavedSearches.Create")
+	defer func() { done(err) }()
+	return s.SavedSearchesClient.Create(ctx, in, opts...)
+}
+
+func (s *InstrumentedSavedSearchesClient) List(ctx context.Context, in *SavedSearchListOptions, opts ...grpc.CallOption) (result *SavedSearchList, err error) {
+	done := s.Metrics.start("SavedSearches.List")
+	defer func() { done(err) }()
+	return s.SavedSearchesClient.List(ctx, in, opts...)
+}
+
+func (s *InstrumentedSavedSearchesClient) Delete(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("SavedSearches.Delete")
+	defer func() { done(err) }()
+	return s.SavedSearchesClient.Delete(ctx, in, opts...)
+}
+
+func (s *InstrumentedSavedSearchesClient) Run(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (result *SavedSearchRunResult, err error) {
+	done := s.Metrics.start("SavedSearches.Run")
+	defer func() { done(err) }()
+	return s.SavedSearchesClient.Run(ctx, in, opts...)
+}
+
+type InstrumentedSavedSearchesServer struct {
+	SavedSearchesServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedSavedSearchesServer) Create(ctx context.Context, in *SavedSearchesCreateOp) (result *SavedSearch, err error) {
+	done := s.Metrics.start("SavedSearches.Create")
+	defer func() { done(err) }()
+	return s.SavedSearchesServer.Create(ctx, in)
+}
+
+func (s *InstrumentedSavedSearchesServer) List(ctx context.Context, in *SavedSearchListOptions) (result *SavedSearchList, err error) {
+	done := s.Metrics.start("SavedSearches.List")
+	defer func() { done(err) }()
+	return s.SavedSearchesServer.List(ctx, in)
+}
+
+func (s *InstrumentedSavedSearchesServer) Delete(ctx context.Context, in *SavedSearchSpec) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("SavedSearches.Delete")
+	defer func() { done(err) }()
+	return s.SavedSearchesServer.Delete(ctx, in)
+}
+
+func (s *InstrumentedSavedSearchesServer) Run(ctx context.Context, in *SavedSearchSpec) (result *SavedSearchRunResult, err error) {
+	done := s.Metrics.start("SavedSearches.Run")
+	defer func() { done(err) }()
+	return s.SavedSearchesServer.Run(ctx, in)
+}
+
+type InstrumentedSearchClient struct {
+	SearchClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedSearchClient) Search(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (result *SearchResults, err error) {
+	done := s.Metrics.start("Search.Search")
+	defer func() { done(err) }()
+	return s.SearchClient.Search(ctx, in, opts...)
+}
+
+func (s *InstrumentedSearchClient) SearchTokens(ctx context.Context, in *TokenSearchOptions, opts ...grpc.CallOption) (result *DefList, err error) {
+	done := s.Metrics.start("Search.SearchTokens")
+	defer func() { done(err) }()
+	return s.SearchClient.SearchTokens(ctx, in, opts...)
+}
+
+func (s *InstrumentedSearchClient) SearchText(ctx context.Context, in *TextSearchOptions, opts ...grpc.CallOption) (result *VCSSearchResultList, err error) {
+	done := s.Metrics.start("Search.SearchText")
+	defer func() { done(err) }()
+	return s.SearchClient.SearchText(ctx, in, opts...)
+}
+
+func (s *InstrumentedSearchClient) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions, opts ...grpc.CallOption) (result *MultiTextSearchResults, err error) {
+	done := s.Metrics.start("Search.SearchTextMulti")
+	defer func() { done(err) }()
+	return s.SearchClient.SearchTextMulti(ctx, in, opts...)
+}
+
+func (s *InstrumentedSearchClient) Complete(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (result *Completions, err error) {
+	done := s.Metrics.start("Search.Complete")
+	defer func() { done(err) }()
+	return s.SearchClient.Complete(ctx, in, opts...)
+}
+
+func (s *InstrumentedSearchClient) Suggest(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (result *SuggestionList, err error) {
+	done := s.Metrics.start("Search.Suggest")
+	defer func() { done(err) }()
+	return s.SearchClient.Suggest(ctx, in, opts...)
+}
+
+func (s *InstrumentedSearchClient) StreamSearch(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (Search_StreamSearchClient, error) {
+	done := s.Metrics.start("Search.StreamSearch")
+	stream, err := s.SearchClient.StreamSearch(ctx, in, opts...)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+	return &instrumentedSearchStreamSearchClient{stream, done}, nil
+}
+
+// instrumentedSearchStreamSearchClient records the end of the call when
+// the stream ends.
+type instrumentedSearchStreamSearchClient struct {
+	Search_StreamSearchClient
+	done func(error)
+}
+
+func (x *instrumentedSearchStreamSearchClient) Recv() (*SearchEvent, error) {
+	m, err := x.Search_StreamSearchClient.Recv()
+	if err != nil && x.done != nil {
+		if err == io.EOF {
+			x.done(nil)
+		} else {
+			x.done(err)
+		}
+		x.done = nil
+	}
+	return m, err
+}
+
+type InstrumentedSearchServer struct {
+	SearchServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedSearchServer) Search(ctx context.Context, in *SearchOptions) (result *SearchResults, err error) {
+	done := s.Metrics.start("Search.Search")
+	defer func() { done(err) }()
+	return s.SearchServer.Search(ctx, in)
+}
+
+func (s *InstrumentedSearchServer) SearchTokens(ctx context.Context, in *TokenSearchOptions) (result *DefList, err error) {
+	done := s.Metrics.start("Search.SearchTokens")
+	defer func() { done(err) }()
+	return s.SearchServer.SearchTokens(ctx, in)
+}
+
+func (s *InstrumentedSearchServer) SearchText(ctx context.Context, in *TextSearchOptions) (result *VCSSearchResultList, err error) {
+	done := s.Metrics.start("Search.SearchText")
+	defer func() { done(err) }()
+	return s.SearchServer.SearchText(ctx, in)
+}
+
+func (s *InstrumentedSearchServer) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions) (result *MultiTextSearchResults, err error) {
+	done := s.Metrics.start("Search.SearchTextMulti")
+	defer func() { done(err) }()
+	return s.SearchServer.SearchTextMulti(ctx, in)
+}
+
+func (s *InstrumentedSearchServer) Complete(ctx context.Context, in *RawQuery) (result *Completions, err error) {
+	done := s.Metrics.start("Search.Complete")
+	defer func() { done(err) }()
+	return s.SearchServer.Complete(ctx, in)
+}
+
+func (s *InstrumentedSearchServer) Suggest(ctx context.Context, in *RawQuery) (result *SuggestionList, err error) {
+	done := s.Metrics.start("Search.Suggest")
+	defer func() { done(err) }()
+	return s.SearchServer.Suggest(ctx, in)
+}
+
+func (s *InstrumentedSearchServer) StreamSearch(in *SearchOptions, stream Search_StreamSearchServer) (err error) {
+	done := s.Metrics.start("Search.StreamSearch")
+	defer func() { done(err) }()
+	return s.SearchServer.StreamSearch(in, stream)
+}
+
+type InstrumentedStorageClient struct {
+	StorageClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedStorageClient) Create(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (result *StorageError, err error) {
+	done := s.Metrics.start("Storage.Create")
+	defer func() { done(err) }()
+	return s.StorageClient.Create(ctx, in, opts...)
+}
+
+func (s *InstrumentedStorageClient) RemoveAll(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (result *StorageError, err error) {
+	done := s.Metrics.start("Storage.RemoveAll")
+	defer func() { done(err) }()
+	return s.StorageClient.RemoveAll(ctx, in, opts...)
+}
+
+func (s *InstrumentedStorageClient) Read(ctx context.Context, in *StorageReadOp, opts ...grpc.CallOption) (result *StorageRead, err error) {
+	done := s.Metrics.start("Storage.Read")
+	defer func() { done(err) }()
+	return s.StorageClient.Read(ctx, in, opts...)
+}
+
+func (s *InstrumentedStorageClient) Write(ctx context.Context, in *StorageWriteOp, opts ...grpc.CallOption) (result *StorageWrite, err error) {
+	done := s.Metrics.start("Storage.Write")
+	defer func() { done(err) }()
+	return s.StorageClient.Write(ctx, in, opts...)
+}
+
+func (s *InstrumentedStorageClient) Stat(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (result *StorageStat, err error) {
+	done := s.Metrics.start("Storage.Stat")
+	defer func() { done(err) }()
+	return s.StorageClient.Stat(ctx, in, opts...)
+}
+
+func (s *InstrumentedStorageClient) ReadDir(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (result *StorageReadDir, err error) {
+	done := s.Metrics.start("Storage.ReadDir")
+	defer func() { done(err) }()
+	return s.StorageClient.ReadDir(ctx, in, opts...)
+}
+
+func (s *InstrumentedStorageClient) Close(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (result *StorageError, err error) {
+	done := s.Metrics.start("Storage.Close")
+	defer func() { done(err) }()
+	return s.StorageClient.Close(ctx, in, opts...)
+}
+
+type InstrumentedStorageServer struct {
+	StorageServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedStorageServer) Create(ctx context.Context, in *StorageName) (result *StorageError, err error) {
+	done := s.Metrics.start("Storage.Create")
+	defer func() { done(err) }()
+	return s.StorageServer.Create(ctx, in)
+}
+
+func (s *InstrumentedStorageServer) RemoveAll(ctx context.Context, in *StorageName) (result *StorageError, err error) {
+	done := s.Metrics.start("Storage.RemoveAll")
+	defer func() { done(err) }()
+	return s.StorageServer.RemoveAll(ctx, in)
+}
+
+func (s *InstrumentedStorageServer) Read(ctx context.Context, in *StorageReadOp) (result *StorageRead, err error) {
+	done := s.Metrics.start("Storage.Read")
+	defer func() { done(err) }()
+	return s.StorageServer.Read(ctx, in)
+}
+
+func (s *InstrumentedStorageServer) Write(ctx context.Context, in *StorageWriteOp) (result *StorageWrite, err error) {
+	done := s.Metrics.start("Storage.Write")
+	defer func() { done(err) }()
+	return s.StorageServer.Write(ctx, in)
+}
+
+func (s *InstrumentedStorageServer) Stat(ctx context.Context, in *StorageName) (result *StorageStat, err error) {
+	done := s.Metrics.start("Storage.Stat")
+	defer func() { done(err) }()
+	return s.StorageServer.Stat(ctx, in)
+}
+
+func (s *InstrumentedStorageServer) ReadDir(ctx context.Context, in *StorageName) (result *StorageReadDir, err error) {
+	done := s.Metrics.start("Storage.ReadDir")
+	defer func() { done(err) }()
+	return s.StorageServer.ReadDir(ctx, in)
+}
+
+func (s *InstrumentedStorageServer) Close(ctx context.Context, in *StorageName) (result *StorageError, err error) {
+	done := s.Metrics.start("Storage.Close")
+	defer func() { done(err) }()
+	return s.StorageServer.Close(ctx, in)
+}
+
+type InstrumentedUnitsClient struct {
+	UnitsClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedUnitsClient) Get(ctx context.Context, in *UnitSpec, opts ...grpc.CallOption) (result *unit.RepoSourceUnit, err error) {
+	done := s.Metrics.start("Units.Get")
+	defer func() { done(err) }()
+	return s.UnitsClient.Get(ctx, in, opts...)
+}
+
+func (s *InstrumentedUnitsClient) List(ctx context.Context, in *UnitListOptions, opts ...grpc.CallOption) (result *RepoSourceUnitList, err error) {
+	done := s.Metrics.start("Units.List")
+	defer func() { done(err) }()
+	return s.UnitsClient.List(ctx, in, opts...)
+}
+
+type InstrumentedUnitsServer struct {
+	UnitsServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedUnitsServer) Get(ctx context.Context, in *UnitSpec) (result *unit.RepoSourceUnit, err error) {
+	done := s.Metrics.start("Units.Get")
+	defer func() { done(err) }()
+	return s.UnitsServer.Get(ctx, in)
+}
+
+func (s *InstrumentedUnitsServer) List(ctx context.Context, in *UnitListOptions) (result *RepoSourceUnitList, err error) {
+	done := s.Metrics.start("Units.List")
+	defer func() { done(err) }()
+	return s.UnitsServer.List(ctx, in)
+}
+
+type InstrumentedUserKeysClient struct {
+	UserKeysClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedUserKeysClient) AddKey(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("UserKeys.AddKey")
+	defer func() { done(err) }()
+	return s.UserKeysClient.AddKey(ctx, in, opts...)
+}
+
+func (s *InstrumentedUserKeysClient) LookupUser(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (result *UserSpec, err error) {
+	done := s.Metrics.start("UserKeys.LookupUser")
+	defer func() { done(err) }()
+	return s.UserKeysClient.LookupUser(ctx, in, opts...)
+}
+
+func (s *InstrumentedUserKeysClient) DeleteKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("UserKeys.DeleteKey")
+	defer func() { done(err) }()
+	return s.UserKeysClient.DeleteKey(ctx, in, opts...)
+}
+
+type InstrumentedUserKeysServer struct {
+	UserKeysServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedUserKeysServer) AddKey(ctx context.Context, in *SSHPublicKey) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("UserKeys.AddKey")
+	defer func() { done(err) }()
+	return s.UserKeysServer.AddKey(ctx, in)
+}
+
+func (s *InstrumentedUserKeysServer) LookupUser(ctx context.Context, in *SSHPublicKey) (result *UserSpec, err error) {
+	done := s.Metrics.start("UserKeys.LookupUser")
+	defer func() { done(err) }()
+	return s.UserKeysServer.LookupUser(ctx, in)
+}
+
+func (s *InstrumentedUserKeysServer) DeleteKey(ctx context.Context, in *pbtypes.Void) (result *pbtypes.Void, err error) {
+	done := s.Metrics.start("UserKeys.DeleteKey")
+	defer func() { done(err) }()
+	return s.UserKeysServer.DeleteKey(ctx, in)
+}
+
+type InstrumentedUsersClient struct {
+	UsersClient
+	Metrics *Metrics
+}
+
+func (s *InstrumentedUsersClient) Get(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (result *User, err error) {
+	done := s.Metrics.start("Users.Get")
+	defer func() { done(err) }()
+	return s.UsersClient.Get(ctx, in, opts...)
+}
+
+func (s *InstrumentedUsersClient) GetWithEmail(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (result *User, err error) {
+	done := s.Metrics.start("Users.GetWithEmail")
+	defer func() { done(err) }()
+	return s.UsersClient.GetWithEmail(ctx, in, opts...)
+}
+
+func (s *InstrumentedUsersClient) ListEmails(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (result *EmailAddrList, err error) {
+	done := s.Metrics.start("Users.ListEmails")
+	defer func() { done(err) }()
+	return s.UsersClient.ListEmails(ctx, in, opts...)
+}
+
+func (s *InstrumentedUsersClient) List(ctx context.Context, in *UsersListOptions, opts ...grpc.CallOption) (result *UserList, err error) {
+	done := s.Metrics.start("Users.List")
+	defer func() { done(err) }()
+	return s.UsersClient.List(ctx, in, opts...)
+}
+
+type InstrumentedUsersServer struct {
+	UsersServer
+	Metrics *Metrics
+}
+
+func (s *InstrumentedUsersServer) Get(ctx context.Context, in *UserSpec) (result *User, err error) {
+	done := s.Metrics.start("Users.Get")
+	defer func() { done(err) }()
+	return s.UsersServer.Get(ctx, in)
+}
+
+func (s *InstrumentedUsersServer) GetWithEmail(ctx context.Context, in *EmailAddr) (result *User, err error) {
+	done := s.Metrics.start("Users.GetWithEmail")
+	defer func() { done(err) }()
+	return s.UsersServer.GetWithEmail(ctx, in)
+}
+
+func (s *InstrumentedUsersServer) ListEmails(ctx context.Context, in *UserSpec) (result *EmailAddrList, err error) {
+	done := s.Metrics.start("Users.ListEmails")
+	defer func() { done(err) }()
+	return s.UsersServer.ListEmails(ctx, in)
+}
+
+func (s *InstrumentedUsersServer) List(ctx context.Context, in *UsersListOptions) (result *UserList, err error) {
+	done := s.Metrics.start("Users.List")
+	defer func() { done(err) }()
+	return s.UsersServer.List(ctx, in)
+}
+
+// instrumentClient wraps each of c's services in an
+// Instrumented*Client that records metrics in m.
+func instrumentClient(c *Client, m *Metrics) {
+	c.AccessTokens = &InstrumentedAccessTokensClient{c.AccessTokens, m}
+	c.Accounts = &InstrumentedAccountsClient{c.Accounts, m}
+	c.AuditLog = &InstrumentedAuditLogClient{c.AuditLog, m}
+	c.Auth = &InstrumentedAuthClient{c.Auth, m}
+	c.Builds = &InstrumentedBuildsClient{c.Builds, m}
+	c.Changesets = &InstrumentedChangesetsClient{c.Changesets, m}
+	c.Defs = &InstrumentedDefsClient{c.Defs, m}
+	c.Deltas = &InstrumentedDeltasClient{c.Deltas, m}
+	c.Discussions = &InstrumentedDiscussionsClient{c.Discussions, m}
+	c.GraphUplink = &InstrumentedGraphUplinkClient{c.GraphUplink, m}
+	c.Markdown = &InstrumentedMarkdownClient{c.Markdown, m}
+	c.Meta = &InstrumentedMetaClient{c.Meta, m}
+	c.MirrorRepos = &InstrumentedMirrorReposClient{c.MirrorRepos, m}
+	c.MirroredRepoSSHKeys = &InstrumentedMirroredRepoSSHKeysClient{c.MirroredRepoSSHKeys, m}
+	c.Notify = &InstrumentedNotifyClient{c.Notify, m}
+	c.Orgs = &InstrumentedOrgsClient{c.Orgs, m}
+	c.People = &InstrumentedPeopleClient{c.People, m}
+	c.RegisteredClients = &InstrumentedRegisteredClientsClient{c.RegisteredClients, m}
+	c.RepoBadges = &InstrumentedRepoBadgesClient{c.RepoBadges, m}
+	c.RepoStatuses = &InstrumentedRepoStatusesClient{c.RepoStatuses, m}
+	c.RepoTree = &InstrumentedRepoTreeClient{c.RepoTree, m}
+	c.Repos = &InstrumentedReposClient{c.Repos, m}
+	c.SavedSearches = &InstrumentedSavedSearchesClient{c.SavedSearches, m}
+	c.Search = &InstrumentedSearchClient{c.Search, m}
+	c.Storage = &InstrumentedStorageClient{c.Storage, m}
+	c.Units = &InstrumentedUnitsClient{c.Units, m}
+	c.UserKeys = &InstrumentedUserKeysClient{c.UserKeys, m}
+	c.Users = &InstrumentedUsersClient{c.Users, m}
+}
@@ -0,0 +1,429 @@
+package sourcegraph
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// JWTClaims are the claims of a JWT assertion used for OAuth2 client
+// authentication and authorization grants. See
+// https://tools.ietf.org/html/draft-ietf-oauth-jwt-bearer-12#section-3.
+type JWTClaims struct {
+	Issuer    string      `json:"iss"`           // the client ID of the client that issued the assertion
+	Subject   string      `json:"sub"`           // the principal (the client ID, for client authentication)
+	Audience  JWTAudience `json:"aud"`           // the authorization server (e.g., its token URL)
+	ExpiresAt int64       `json:"exp"`           // the expiration time (in seconds since the epoch)
+	NotBefore int64       `json:"nbf,omitempty"` // the time before which the assertion is invalid
+	IssuedAt  int64       `json:"iat,omitempty"` // the time the assertion was issued
+	ID        string      `json:"jti,omitempty"` // a unique identifier for the assertion
+}
+
+// JWTAudience is the audience ("aud") claim of a JWT: the recipients
+// that the JWT is intended for. It is encoded as a string if it has
+// exactly one recipient, and as an array of strings otherwise (see
+// RFC 7519 section 4.1.3).
+type JWTAudience []string
+
+// MarshalJSON implements json.Marshaler.
+func (a JWTAudience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *JWTAudience) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*a = JWTAudience{s}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+// Contains reports whether aud is one of the audience's recipients.
+func (a JWTAudience) Contains(aud string) bool {
+	for _, s := range a {
+		if s == aud {
+			return true
+		}
+	}
+	return false
+}
+
+// jwtHeader is the JOSE header of a JWT.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// Errors returned by VerifyJWTAssertion.
+var (
+	ErrJWTMalformed        = errors.New("malformed JWT")
+	ErrJWTUnknownKey       = errors.New("JWT signing key not found in client's JWKS (or not a signing key)")
+	ErrJWTInvalidSignature = errors.New("invalid JWT signature")
+	ErrJWTExpired          = errors.New("JWT is expired or not yet valid")
+	ErrJWTInvalidClaims    = errors.New("JWT has invalid claims")
+)
+
+// jwtClockSkew is the allowed difference between the clocks of the
+// signer and the verifier of a JWT.
+const jwtClockSkew = time.Minute
+
+var b64url = base64.RawURLEncoding
+
+// NewJWTBearerAssertion returns a JWT assertion that authenticates
+// the client with the given ID to the authorization server (audience)
+// for the given lifetime. It is signed with key (whose key ID, which
+// must be in the client's JWKS, is keyID). The assertion may be used
+// as a BearerJWT grant in an AccessTokenRequest; to obtain tokens
+// after it expires, pass a func that calls NewJWTBearerAssertion to
+// NewJWTAuthCredentials.
+func NewJWTBearerAssertion(key crypto.Signer, keyID, clientID, audience string, lifetime time.Duration) (*BearerJWT, error) {
+	var jti [16]byte
+	if _, err := rand.Read(jti[:]); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	assertion, err := SignJWT(key, keyID, &JWTClaims{
+		Issuer:    clientID,
+		Subject:   clientID,
+		Audience:  JWTAudience{audience},
+		ExpiresAt: now.Add(lifetime).Unix(),
+		IssuedAt:  now.Unix(),
+		ID:        hex.EncodeToString(jti[:]),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &BearerJWT{Assertion: assertion}, nil
+}
+
+// SignJWT returns a JWT with the given claims, signed with key (an
+// RSA key, which uses RS256, or an ECDSA key, which uses ES256, ES384
+// or ES512 depending on its curve).
+func SignJWT(key crypto.Signer, keyID string, claims *JWTClaims) (string, error) {
+	alg, hash, err := jwtAlgorithm(key.Public())
+	if err != nil {
+		return "", err
+	}
+	header, err := json.Marshal(jwtHeader{Alg: alg, Typ: "JWT", Kid: keyID})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := b64url.EncodeToString(header) + "." + b64url.EncodeToString(payload)
+
+	h := hash.New()
+	h.Write([]byte(signingInput))
+	sig, err := key.Sign(rand.Reader, h.Sum(nil), hash)
+	if err != nil {
+		return "", err
+	}
+	if pub, ok := key.Public().(*ecdsa.PublicKey); ok {
+		// JWS uses the fixed-size R || S encoding, not ASN.1.
+		var rs struct{ R, S *big.Int }
+		if _, err := asn1.Unmarshal(sig, &rs); err != nil {
+			return "", err
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		sig = append(paddedBytes(rs.R, size), paddedBytes(rs.S, size)...)
+	}
+	return signingInput + "." + b64url.EncodeToString(sig), nil
+}
+
+// jwtAlgorithm returns the JWS algorithm and hash used for signatures
+// by the private key corresponding to pub.
+func jwtAlgorithm(pub crypto.PublicKey) (alg string, hash crypto.Hash, err error) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return "RS256", crypto.SHA256, nil
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			return "ES256", crypto.SHA256, nil
+		case elliptic.P384():
+			return "ES384", crypto.SHA384, nil
+		case elliptic.P521():
+			return "ES512", crypto.SHA512, nil
+		}
+		return "", 0, fmt.Errorf("unsupported ECDSA curve %s", pub.Curve.Params().Name)
+	}
+	return "", 0, fmt.Errorf("unsupported JWT signing key type %T", pub)
+}
+
+// VerifyJWTAssertion verifies that assertion is a valid JWT issued by
+// the client for the authorization server (which must be one of the
+// JWT's audiences). The assertion must be signed by one of the keys
+// in the client's JWKS (which is chosen by the JWT's key ID, so that
+// keys may be rotated). If the key specifies its use or algorithm,
+// they must be "sig" and the JWT's algorithm. It returns the
+// assertion's claims.
+func (c *RegisteredClient) VerifyJWTAssertion(assertion, audience string) (*JWTClaims, error) {
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		return nil, ErrJWTMalformed
+	}
+	var header jwtHeader
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+	sig, err := b64url.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrJWTMalformed
+	}
+
+	jwks, err := ParseJWKS(c.JWKS)
+	if err != nil {
+		return nil, err
+	}
+	jwk := jwks.Key(header.Kid)
+	if jwk == nil || (jwk.Use != "" && jwk.Use != "sig") {
+		return nil, ErrJWTUnknownKey
+	}
+	if jwk.Alg != "" && jwk.Alg != header.Alg {
+		// Reject algorithm substitution (the key is only for Alg).
+		return nil, ErrJWTInvalidSignature
+	}
+	pub, err := jwk.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyJWTSignature(pub, header.Alg, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var claims JWTClaims
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if claims.ExpiresAt == 0 || now.Add(-jwtClockSkew).Unix() >= claims.ExpiresAt || (claims.NotBefore != 0 && now.Add(jwtClockSkew).Unix() < claims.NotBefore) {
+		return nil, ErrJWTExpired
+	}
+	if claims.Issuer != c.ID || claims.Subject == "" || !claims.Audience.Contains(audience) {
+		return nil, ErrJWTInvalidClaims
+	}
+	return &claims, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := b64url.DecodeString(part)
+	if err != nil {
+		return ErrJWTMalformed
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return ErrJWTMalformed
+	}
+	return nil
+}
+
+func verifyJWTSignature(pub crypto.PublicKey, alg, signingInput string, sig []byte) error {
+	wantAlg, hash, err := jwtAlgorithm(pub)
+	if err != nil {
+		return err
+	}
+	if alg != wantAlg {
+		// Reject algorithm substitution (e.g., "none" or HS256).
+		return ErrJWTInvalidSignature
+	}
+	var digest []byte
+	switch hash {
+	case crypto.SHA256:
+		sum := sha256.Sum256([]byte(signingInput))
+		digest = sum[:]
+	case crypto.SHA384:
+		sum := sha512.Sum384([]byte(signingInput))
+		digest = sum[:]
+	case crypto.SHA512:
+		sum := sha512.Sum512([]byte(signingInput))
+		digest = sum[:]
+	}
+
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		if rsa.VerifyPKCS1v15(pub, hash, digest, sig) != nil {
+			return ErrJWTInvalidSignature
+		}
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return ErrJWTInvalidSignature
+		}
+		r, s := new(big.Int).SetBytes(sig[:size]), new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return ErrJWTInvalidSignature
+		}
+	}
+	return nil
+}
+
+// paddedBytes returns the big-endian bytes of n, left-padded with
+// zeros to size bytes.
+func paddedBytes(n *big.Int, size int) []byte {
+	b := n.Bytes()
+	if len(b) >= size {
+		return b
+	}
+	return append(make([]byte, size-len(b)), b...)
+}
+
+// A JWKS is a JSON Web Key Set (see RFC 7517), such as a
+// RegisteredClient's JWKS.
+type JWKS struct {
+	Keys []*JWK `json:"keys"`
+}
+
+// A JWK is a JSON Web Key (see RFC 7517 and RFC 7518). Only RSA and
+// ECDSA public keys are supported.
+type JWK struct {
+	Kty string `json:"kty"`           // "RSA" or "EC"
+	Kid string `json:"kid,omitempty"` // the key ID
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	N string `json:"n,omitempty"` // RSA modulus
+	E string `json:"e,omitempty"` // RSA exponent
+
+	Crv string `json:"crv,omitempty"` // EC curve
+	X   string `json:"x,omitempty"`   // EC x coordinate
+	Y   string `json:"y,omitempty"`   // EC y coordinate
+}
+
+// ParseJWKS parses a JSON-encoded JWKS.
+func ParseJWKS(s string) (*JWKS, error) {
+	var jwks JWKS
+	if s == "" {
+		return &jwks, nil
+	}
+	if err := json.Unmarshal([]byte(s), &jwks); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %s", err)
+	}
+	return &jwks, nil
+}
+
+// String returns the JSON encoding of the JWKS, suitable for storing
+// in RegisteredClient.JWKS.
+func (s *JWKS) String() string {
+	data, err := json.Marshal(s)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+// Key returns the key with the given key ID, or nil if there is no
+// such key. If kid is empty, the set's only key is returned (if it has
+// exactly one key).
+func (s *JWKS) Key(kid string) *JWK {
+	if kid == "" {
+		if len(s.Keys) == 1 {
+			return s.Keys[0]
+		}
+		return nil
+	}
+	for _, k := range s.Keys {
+		if k.Kid == kid {
+			return k
+		}
+	}
+	return nil
+}
+
+// NewJWK returns the JWK of an RSA or ECDSA public key, with the
+// given key ID.
+func NewJWK(pub crypto.PublicKey, kid string) (*JWK, error) {
+	alg, _, err := jwtAlgorithm(pub)
+	if err != nil {
+		return nil, err
+	}
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return &JWK{
+			Kty: "RSA", Kid: kid, Use: "sig", Alg: alg,
+			N: b64url.EncodeToString(pub.N.Bytes()),
+			E: b64url.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		return &JWK{
+			Kty: "EC", Kid: kid, Use: "sig", Alg: alg,
+			Crv: pub.Curve.Params().Name,
+			X:   b64url.EncodeToString(paddedBytes(pub.X, size)),
+			Y:   b64url.EncodeToString(paddedBytes(pub.Y, size)),
+		}, nil
+	}
+	panic("unreachable")
+}
+
+// PublicKey returns the key's RSA or ECDSA public key.
+func (k *JWK) PublicKey() (crypto.PublicKey, error) {
+	decode := func(s string) (*big.Int, error) {
+		b, err := b64url.DecodeString(s)
+		if err != nil || len(b) == 0 {
+			return nil, fmt.Errorf("invalid JWK %q: bad key parameter", k.Kid)
+		}
+		return new(big.Int).SetBytes(b), nil
+	}
+
+	switch k.Kty {
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid JWK %q: RSA exponent too large", k.Kid)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("invalid JWK %q: unsupported curve %q", k.Kid, k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("invalid JWK %q: point not on curve", k.Kid)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("invalid JWK %q: unsupported key type %q", k.Kid, k.Kty)
+}
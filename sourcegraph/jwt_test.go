@@ -0,0 +1,289 @@
+package sourcegraph
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testJWTAudience = "https://example.com/.api/oauth2/token"
+
+func generateTestJWTKeys(t *testing.T) map[string]crypto.Signer {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := map[string]crypto.Signer{"rsa": rsaKey}
+	for name, curve := range map[string]elliptic.Curve{"p256": elliptic.P256(), "p384": elliptic.P384(), "p521": elliptic.P521()} {
+		k, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[name] = k
+	}
+	return keys
+}
+
+// newTestJWKSClient returns a registered client whose JWKS contains the
+// public keys of keys (with their map keys as key IDs).
+func newTestJWKSClient(t *testing.T, keys map[string]crypto.Signer) *RegisteredClient {
+	var jwks JWKS
+	for kid, key := range keys {
+		jwk, err := NewJWK(key.Public(), kid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return &RegisteredClient{ID: "c", JWKS: jwks.String()}
+}
+
+func TestJWTBearerAssertion(t *testing.T) {
+	keys := generateTestJWTKeys(t)
+	client := newTestJWKSClient(t, keys)
+
+	for kid, key := range keys {
+		bearer, err := NewJWTBearerAssertion(key, kid, client.ID, testJWTAudience, time.Minute)
+		if err != nil {
+			t.Fatalf("%s: %s", kid, err)
+		}
+		claims, err := client.VerifyJWTAssertion(bearer.Assertion, testJWTAudience)
+		if err != nil {
+			t.Errorf("%s: %s", kid, err)
+			continue
+		}
+		if claims.Issuer != "c" || claims.Subject != "c" || !reflect.DeepEqual(claims.Audience, JWTAudience{testJWTAudience}) || claims.ID == "" {
+			t.Errorf("%s: got claims %+v", kid, claims)
+		}
+
+		// Tamper with the signature.
+		tampered := bearer.Assertion[:len(bearer.Assertion)-4] + "AAAA"
+		if tampered == bearer.Assertion {
+			tampered = bearer.Assertion[:len(bearer.Assertion)-4] + "BBBB"
+		}
+		if _, err := client.VerifyJWTAssertion(tampered, testJWTAudience); err != ErrJWTInvalidSignature {
+			t.Errorf("%s: tampered signature: got error %v, want %v", kid, err, ErrJWTInvalidSignature)
+		}
+	}
+}
+
+func TestVerifyJWTAssertion_keyRotation(t *testing.T) {
+	keys := generateTestJWTKeys(t)
+	oldKey, newKey := keys["rsa"], keys["p256"]
+
+	// Before rotation, only the old key is in the JWKS.
+	client := newTestJWKSClient(t, map[string]crypto.Signer{"k1": oldKey})
+	oldBearer, err := NewJWTBearerAssertion(oldKey, "k1", client.ID, testJWTAudience, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newBearer, err := NewJWTBearerAssertion(newKey, "k2", client.ID, testJWTAudience, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.VerifyJWTAssertion(oldBearer.Assertion, testJWTAudience); err != nil {
+		t.Errorf("old key before rotation: %s", err)
+	}
+	if _, err := client.VerifyJWTAssertion(newBearer.Assertion, testJWTAudience); err != ErrJWTUnknownKey {
+		t.Errorf("new key before rotation: got error %v, want %v", err, ErrJWTUnknownKey)
+	}
+
+	// During rotation, both keys are in the JWKS.
+	client = newTestJWKSClient(t, map[string]crypto.Signer{"k1": oldKey, "k2": newKey})
+	for _, bearer := range []*BearerJWT{oldBearer, newBearer} {
+		if _, err := client.VerifyJWTAssertion(bearer.Assertion, testJWTAudience); err != nil {
+			t.Errorf("during rotation: %s", err)
+		}
+	}
+
+	// After rotation, the old key is removed.
+	client = newTestJWKSClient(t, map[string]crypto.Signer{"k2": newKey})
+	if _, err := client.VerifyJWTAssertion(oldBearer.Assertion, testJWTAudience); err != ErrJWTUnknownKey {
+		t.Errorf("old key after rotation: got error %v, want %v", err, ErrJWTUnknownKey)
+	}
+	if _, err := client.VerifyJWTAssertion(newBearer.Assertion, testJWTAudience); err != nil {
+		t.Errorf("new key after rotation: %s", err)
+	}
+
+	// A key ID that refers to a different key fails verification.
+	client = newTestJWKSClient(t, map[string]crypto.Signer{"k1": newKey})
+	if _, err := client.VerifyJWTAssertion(oldBearer.Assertion, testJWTAudience); err != ErrJWTInvalidSignature {
+		t.Errorf("wrong key: got error %v, want %v", err, ErrJWTInvalidSignature)
+	}
+}
+
+func TestVerifyJWTAssertion_claims(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newTestJWKSClient(t, map[string]crypto.Signer{"k": key})
+	now := time.Now()
+	valid := JWTClaims{
+		Issuer:    client.ID,
+		Subject:   client.ID,
+		Audience:  JWTAudience{testJWTAudience},
+		ExpiresAt: now.Add(time.Minute).Unix(),
+	}
+
+	tests := map[string]struct {
+		modify  func(*JWTClaims)
+		wantErr error
+	}{
+		"valid":                {func(c *JWTClaims) {}, nil},
+		"expired":              {func(c *JWTClaims) { c.ExpiresAt = now.Add(-time.Hour).Unix() }, ErrJWTExpired},
+		"no exp":               {func(c *JWTClaims) { c.ExpiresAt = 0 }, ErrJWTExpired},
+		"not yet valid":        {func(c *JWTClaims) { c.NotBefore = now.Add(time.Hour).Unix() }, ErrJWTExpired},
+		"within skew":          {func(c *JWTClaims) { c.NotBefore = now.Add(time.Second).Unix() }, nil},
+		"other issuer":         {func(c *JWTClaims) { c.Issuer = "other" }, ErrJWTInvalidClaims},
+		"no subject":           {func(c *JWTClaims) { c.Subject = "" }, ErrJWTInvalidClaims},
+		"other audience":       {func(c *JWTClaims) { c.Audience = JWTAudience{"https://other.example.com"} }, ErrJWTInvalidClaims},
+		"no audience":          {func(c *JWTClaims) { c.Audience = nil }, ErrJWTInvalidClaims},
+		"audience list":        {func(c *JWTClaims) { c.Audience = JWTAudience{"https://other.example.com", testJWTAudience} }, nil},
+		"user subject (grant)": {func(c *JWTClaims) { c.Subject = "alice" }, nil},
+	}
+	for label, test := range tests {
+		claims := valid
+		test.modify(&claims)
+		assertion, err := SignJWT(key, "k", &claims)
+		if err != nil {
+			t.Fatalf("%s: %s", label, err)
+		}
+		if _, err := client.VerifyJWTAssertion(assertion, testJWTAudience); err != test.wantErr {
+			t.Errorf("%s: got error %v, want %v", label, err, test.wantErr)
+		}
+	}
+}
+
+func TestVerifyJWTAssertion_malformed(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newTestJWKSClient(t, map[string]crypto.Signer{"k": key})
+	bearer, err := NewJWTBearerAssertion(key, "k", client.ID, testJWTAudience, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(bearer.Assertion, ".")
+
+	tests := map[string]struct {
+		assertion string
+		wantErr   error
+	}{
+		"empty":      {"", ErrJWTMalformed},
+		"two parts":  {parts[0] + "." + parts[1], ErrJWTMalformed},
+		"bad header": {"!." + parts[1] + "." + parts[2], ErrJWTMalformed},
+		// The "none" algorithm must not be accepted.
+		"alg none": {b64url.EncodeToString([]byte(`{"alg":"none","kid":"k"}`)) + "." + parts[1] + ".", ErrJWTInvalidSignature},
+	}
+	for label, test := range tests {
+		if _, err := client.VerifyJWTAssertion(test.assertion, testJWTAudience); err != test.wantErr {
+			t.Errorf("%s: got error %v, want %v", label, err, test.wantErr)
+		}
+	}
+}
+
+func TestJWTAudience_JSON(t *testing.T) {
+	tests := map[string]JWTAudience{
+		`"a"`:       {"a"},
+		`["a"]`:     {"a"},
+		`["a","b"]`: {"a", "b"},
+		`[]`:        {},
+	}
+	for data, want := range tests {
+		var got JWTAudience
+		if err := json.Unmarshal([]byte(data), &got); err != nil {
+			t.Errorf("%s: %s", data, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %q, want %q", data, got, want)
+		}
+	}
+	if err := json.Unmarshal([]byte(`1`), new(JWTAudience)); err == nil {
+		t.Error("got no error decoding a number")
+	}
+
+	for _, test := range []struct {
+		aud  JWTAudience
+		want string
+	}{
+		{JWTAudience{"a"}, `"a"`},
+		{JWTAudience{"a", "b"}, `["a","b"]`},
+	} {
+		data, err := json.Marshal(test.aud)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != test.want {
+			t.Errorf("%q: got %s, want %s", test.aud, data, test.want)
+		}
+	}
+}
+
+func TestVerifyJWTAssertion_keyParameters(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bearer, err := NewJWTBearerAssertion(key, "k", "c", testJWTAudience, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		modify  func(*JWK)
+		wantErr error
+	}{
+		"sig":            {func(k *JWK) {}, nil},
+		"no use or alg":  {func(k *JWK) { k.Use, k.Alg = "", "" }, nil},
+		"encryption key": {func(k *JWK) { k.Use = "enc" }, ErrJWTUnknownKey},
+		"other alg":      {func(k *JWK) { k.Alg = "ES384" }, ErrJWTInvalidSignature},
+	}
+	for label, test := range tests {
+		jwk, err := NewJWK(key.Public(), "k")
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.modify(jwk)
+		client := &RegisteredClient{ID: "c", JWKS: (&JWKS{Keys: []*JWK{jwk}}).String()}
+		if _, err := client.VerifyJWTAssertion(bearer.Assertion, testJWTAudience); err != test.wantErr {
+			t.Errorf("%s: got error %v, want %v", label, err, test.wantErr)
+		}
+	}
+}
+
+func TestJWK_roundTrip(t *testing.T) {
+	for kid, key := range generateTestJWTKeys(t) {
+		jwk, err := NewJWK(key.Public(), kid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		jwks, err := ParseJWKS((&JWKS{Keys: []*JWK{jwk}}).String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, err := jwks.Key(kid).PublicKey()
+		if err != nil {
+			t.Fatalf("%s: %s", kid, err)
+		}
+		switch want := key.Public().(type) {
+		case *rsa.PublicKey:
+			if got := pub.(*rsa.PublicKey); got.N.Cmp(want.N) != 0 || got.E != want.E {
+				t.Errorf("%s: got %v, want %v", kid, got, want)
+			}
+		case *ecdsa.PublicKey:
+			if got := pub.(*ecdsa.PublicKey); got.Curve != want.Curve || got.X.Cmp(want.X) != 0 || got.Y.Cmp(want.Y) != 0 {
+				t.Errorf("%s: got %v, want %v", kid, got, want)
+			}
+		}
+	}
+}
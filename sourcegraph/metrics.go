@@ -0,0 +1,137 @@
+package sourcegraph
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// Metrics records Prometheus metrics about the API calls made by (or
+// handled by) the Instrumented*Client (or Instrumented*Server)
+// wrappers. It is a prometheus.Collector, so it must be registered
+// with a registry (e.g., using prometheus.MustRegister) for its
+// metrics to be exported.
+type Metrics struct {
+	// Latency is the histogram of call durations (in seconds), by
+	// service and method.
+	Latency *prometheus.HistogramVec
+
+	// Requests is the number of finished calls, by service, method and
+	// gRPC status code.
+	Requests *prometheus.CounterVec
+
+	// InFlight is the number of calls in progress, by service and
+	// method.
+	InFlight *prometheus.GaugeVec
+}
+
+// NewMetrics returns new (unregistered) metrics whose names have the
+// given namespace and subsystem (e.g., "src" and "api_client" yields
+// metrics named src_api_client_requests_total, etc.).
+func NewMetrics(namespace, subsystem string) *Metrics {
+	return &Metrics{
+		Latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "request_duration_seconds",
+			Help:      "The API call latencies in seconds.",
+		}, []string{"service", "method"}),
+		Requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_total",
+			Help:      "The number of finished API calls.",
+		}, []string{"service", "method", "code"}),
+		InFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_in_flight",
+			Help:      "The number of API calls in progress.",
+		}, []string{"service", "method"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.Latency.Describe(ch)
+	m.Requests.Describe(ch)
+	m.InFlight.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.Latency.Collect(ch)
+	m.Requests.Collect(ch)
+	m.InFlight.Collect(ch)
+}
+
+// WithMetrics returns a copy of the parent context whose API clients
+// (constructed using NewClientFromContext) record metrics in m.
+func WithMetrics(parent context.Context, m *Metrics) context.Context {
+	return context.WithValue(parent, metricsKey, m)
+}
+
+// metricsFromContext returns the metrics previously set in the
+// context by WithMetrics, or nil if none were set.
+func metricsFromContext(ctx context.Context) *Metrics {
+	m, _ := ctx.Value(metricsKey).(*Metrics)
+	return m
+}
+
+// start records the start of a call to method (e.g., "Repos.Get").
+// The returned func must be called with the call's error when the
+// call is done. If m is nil, nothing is recorded.
+func (m *Metrics) start(method string) (done func(error)) {
+	if m == nil {
+		return func(error) {}
+	}
+	service := method
+	if i := strings.Index(method, "."); i != -1 {
+		service, method = method[:i], method[i+1:]
+	}
+	inFlight := m.InFlight.WithLabelValues(service, method)
+	inFlight.Inc()
+	start := time.Now()
+	return func(err error) {
+		inFlight.Dec()
+		m.Latency.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+		m.Requests.WithLabelValues(service, method, grpc.Code(err).String()).Inc()
+	}
+}
+
+// NewMetricsSnapshot encodes the metrics gathered from g (e.g.,
+// prometheus.DefaultGatherer) in a MetricsSnapshot, in the Prometheus
+// delimited protobuf format.
+func NewMetricsSnapshot(g prometheus.Gatherer) (*MetricsSnapshot, error) {
+	mfs, err := g.Gather()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := expfmt.NewEncoder(&buf, expfmt.FmtProtoDelim)
+	for _, mf := range mfs {
+		if err := enc.Encode(mf); err != nil {
+			return nil, err
+		}
+	}
+	return &MetricsSnapshot{
+		Type:          TelemetryType_PrometheusDelimited0dot0dot4,
+		TelemetryData: buf.Bytes(),
+	}, nil
+}
+
+// PushMetrics pushes the metrics gathered from g to the upstream
+// instance that c communicates with (using GraphUplink.Push).
+func PushMetrics(ctx context.Context, c *Client, g prometheus.Gatherer) error {
+	snapshot, err := NewMetricsSnapshot(g)
+	if err != nil {
+		return err
+	}
+	_, err = c.GraphUplink.Push(ctx, snapshot)
+	return err
+}
@@ -0,0 +1,160 @@
+package sourcegraph
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// gatherMetric returns the metric with the given name and labels
+// gathered from g, or nil if there is none.
+func gatherMetric(t *testing.T, g prometheus.Gatherer, name string, labels map[string]string) *dto.Metric {
+	mfs, err := g.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range mf.Metric {
+			for _, l := range m.Label {
+				if labels[l.GetName()] != l.GetValue() {
+					continue metrics
+				}
+			}
+			return m
+		}
+	}
+	return nil
+}
+
+func TestInstrumentedClient(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics("test", "api_client")
+	reg.MustRegister(m)
+
+	c := &InstrumentedReposClient{errReposClient{err: grpc.Errorf(codes.NotFound, "x")}, m}
+	c.Get(context.Background(), &RepoSpec{URI: "r"})
+	c.Get(context.Background(), &RepoSpec{URI: "r"})
+
+	labels := map[string]string{"service": "Repos", "method": "Get", "code": "NotFound"}
+	if metric := gatherMetric(t, reg, "test_api_client_requests_total", labels); metric == nil || metric.Counter.GetValue() != 2 {
+		t.Errorf("got requests metric %v, want count 2", metric)
+	}
+	labels = map[string]string{"service": "Repos", "method": "Get"}
+	if metric := gatherMetric(t, reg, "test_api_client_request_duration_seconds", labels); metric == nil || metric.Histogram.GetSampleCount() != 2 {
+		t.Errorf("got latency metric %v, want sample count 2", metric)
+	}
+	if metric := gatherMetric(t, reg, "test_api_client_requests_in_flight", labels); metric == nil || metric.Gauge.GetValue() != 0 {
+		t.Errorf("got in-flight metric %v, want 0", metric)
+	}
+
+	// Nil Metrics record nothing.
+	c.Metrics = nil
+	c.Get(context.Background(), &RepoSpec{URI: "r"})
+}
+
+func TestNewMetricsSnapshot(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "c", Help: "c"})
+	counter.Add(3)
+	reg.MustRegister(counter)
+
+	snapshot, err := NewMetricsSnapshot(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Type != TelemetryType_PrometheusDelimited0dot0dot4 {
+		t.Errorf("got type %v, want %v", snapshot.Type, TelemetryType_PrometheusDelimited0dot0dot4)
+	}
+
+	var mf dto.MetricFamily
+	dec := expfmt.NewDecoder(bytes.NewReader(snapshot.TelemetryData), expfmt.FmtProtoDelim)
+	if err := dec.Decode(&mf); err != nil {
+		t.Fatal(err)
+	}
+	if mf.GetName() != "c" || mf.Metric[0].Counter.GetValue() != 3 {
+		t.Errorf("got metric family %v, want counter c = 3", mf)
+	}
+}
+
+type panicReposClient struct{ ReposClient }
+
+func (panicReposClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*Repo, error) {
+	panic("x")
+}
+
+func TestInstrumentedClient_panic(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics("test", "api_client")
+	reg.MustRegister(m)
+
+	c := &InstrumentedReposClient{panicReposClient{}, m}
+	func() {
+		defer func() { recover() }()
+		c.Get(context.Background(), &RepoSpec{URI: "r"})
+	}()
+
+	labels := map[string]string{"service": "Repos", "method": "Get"}
+	if metric := gatherMetric(t, reg, "test_api_client_requests_in_flight", labels); metric == nil || metric.Gauge.GetValue() != 0 {
+		t.Errorf("got in-flight metric %v, want 0 after a panic", metric)
+	}
+}
+
+func TestInstrumentedClient_stream(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics("test", "api_client")
+	reg.MustRegister(m)
+
+	c := &InstrumentedSearchClient{&testSearchClient{events: []*SearchEvent{{}}, err: io.EOF}, m}
+	stream, err := c.StreamSearch(context.Background(), &SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels := map[string]string{"service": "Search", "method": "StreamSearch"}
+	if metric := gatherMetric(t, reg, "test_api_client_requests_in_flight", labels); metric == nil || metric.Gauge.GetValue() != 1 {
+		t.Errorf("got in-flight metric %v, want 1 while streaming", metric)
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	stream.Recv()
+
+	if metric := gatherMetric(t, reg, "test_api_client_requests_in_flight", labels); metric == nil || metric.Gauge.GetValue() != 0 {
+		t.Errorf("got in-flight metric %v, want 0", metric)
+	}
+	labels["code"] = "OK"
+	if metric := gatherMetric(t, reg, "test_api_client_requests_total", labels); metric == nil || metric.Counter.GetValue() != 1 {
+		t.Errorf("got requests metric %v, want count 1", metric)
+	}
+}
+
+func TestInstrumentedServer_stream(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics("test", "api_server")
+	reg.MustRegister(m)
+
+	s := &InstrumentedSearchServer{&testSearchServer{}, m}
+	stream := &testStreamSearchServer{testServerStream{ctx: context.Background()}}
+	if err := s.StreamSearch(&SearchOptions{}, stream); err != nil {
+		t.Fatal(err)
+	}
+
+	labels := map[string]string{"service": "Search", "method": "StreamSearch", "code": "OK"}
+	if metric := gatherMetric(t, reg, "test_api_server_requests_total", labels); metric == nil || metric.Counter.GetValue() != 1 {
+		t.Errorf("got requests metric %v, want count 1", metric)
+	}
+}
@@ -1112,17 +1112,23 @@ func (s *RepoTreeServer) List(v0 context.Context, v1 *sourcegraph.RepoTreeListOp
 var _ sourcegraph.RepoTreeServer = (*RepoTreeServer)(nil)
 
 type SearchClient struct {
-	Search_       func(ctx context.Context, in *sourcegraph.SearchOptions) (*sourcegraph.SearchResults, error)
-	SearchTokens_ func(ctx context.Context, in *sourcegraph.TokenSearchOptions) (*sourcegraph.DefList, error)
-	SearchText_   func(ctx context.Context, in *sourcegraph.TextSearchOptions) (*sourcegraph.VCSSearchResultList, error)
-	Complete_     func(ctx context.Context, in *sourcegraph.RawQuery) (*sourcegraph.Completions, error)
-	Suggest_      func(ctx context.Context, in *sourcegraph.RawQuery) (*sourcegraph.SuggestionList, error)
+	Search_          func(ctx context.Context, in *sourcegraph.SearchOptions) (*sourcegraph.SearchResults, error)
+	StreamSearch_    func(ctx context.Context, in *sourcegraph.SearchOptions) (sourcegraph.Search_StreamSearchClient, error)
+	SearchTokens_    func(ctx context.Context, in *sourcegraph.TokenSearchOptions) (*sourcegraph.DefList, error)
+	SearchText_      func(ctx context.Context, in *sourcegraph.TextSearchOptions) (*sourcegraph.VCSSearchResultList, error)
+	SearchTextMulti_ func(ctx context.Context, in *sourcegraph.MultiTextSearchOptions) (*sourcegraph.MultiTextSearchResults, error)
+	Complete_        func(ctx context.Context, in *sourcegraph.RawQuery) (*sourcegraph.Completions, error)
+	Suggest_         func(ctx context.Context, in *sourcegraph.RawQuery) (*sourcegraph.SuggestionList, error)
 }
 
 func (s *SearchClient) Search(ctx context.Context, in *sourcegraph.SearchOptions, opts ...grpc.CallOption) (*sourcegraph.SearchResults, error) {
 	return s.Search_(ctx, in)
 }
 
+func (s *SearchClient) StreamSearch(ctx context.Context, in *sourcegraph.SearchOptions, opts ...grpc.CallOption) (sourcegraph.Search_StreamSearchClient, error) {
+	return s.StreamSearch_(ctx, in)
+}
+
 func (s *SearchClient) SearchTokens(ctx context.Context, in *sourcegraph.TokenSearchOptions, opts ...grpc.CallOption) (*sourcegraph.DefList, error) {
 	return s.SearchTokens_(ctx, in)
 }
@@ -1131,6 +1137,10 @@ func (s *SearchClient) SearchText(ctx context.Context, in *sourcegraph.TextSearc
 	return s.SearchText_(ctx, in)
 }
 
+func (s *SearchClient) SearchTextMulti(ctx context.Context, in *sourcegraph.MultiTextSearchOptions, opts ...grpc.CallOption) (*sourcegraph.MultiTextSearchResults, error) {
+	return s.SearchTextMulti_(ctx, in)
+}
+
 func (s *SearchClient) Complete(ctx context.Context, in *sourcegraph.RawQuery, opts ...grpc.CallOption) (*sourcegraph.Completions, error) {
 	return s.Complete_(ctx, in)
 }
@@ -1142,17 +1152,23 @@ func (s *SearchClient) Suggest(ctx context.Context, in *sourcegraph.RawQuery, op
 var _ sourcegraph.SearchClient = (*SearchClient)(nil)
 
 type SearchServer struct {
-	Search_       func(v0 context.Context, v1 *sourcegraph.SearchOptions) (*sourcegraph.SearchResults, error)
-	SearchTokens_ func(v0 context.Context, v1 *sourcegraph.TokenSearchOptions) (*sourcegraph.DefList, error)
-	SearchText_   func(v0 context.Context, v1 *sourcegraph.TextSearchOptions) (*sourcegraph.VCSSearchResultList, error)
-	Complete_     func(v0 context.Context, v1 *sourcegraph.RawQuery) (*sourcegraph.Completions, error)
-	Suggest_      func(v0 context.Context, v1 *sourcegraph.RawQuery) (*sourcegraph.SuggestionList, error)
+	Search_          func(v0 context.Context, v1 *sourcegraph.SearchOptions) (*sourcegraph.SearchResults, error)
+	StreamSearch_    func(v0 *sourcegraph.SearchOptions, v1 sourcegraph.Search_StreamSearchServer) error
+	SearchTokens_    func(v0 context.Context, v1 *sourcegraph.TokenSearchOptions) (*sourcegraph.DefList, error)
+	SearchText_      func(v0 context.Context, v1 *sourcegraph.TextSearchOptions) (*sourcegraph.VCSSearchResultList, error)
+	SearchTextMulti_ func(v0 context.Context, v1 *sourcegraph.MultiTextSearchOptions) (*sourcegraph.MultiTextSearchResults, error)
+	Complete_        func(v0 context.Context, v1 *sourcegraph.RawQuery) (*sourcegraph.Completions, error)
+	Suggest_         func(v0 context.Context, v1 *sourcegraph.RawQuery) (*sourcegraph.SuggestionList, error)
 }
 
 func (s *SearchServer) Search(v0 context.Context, v1 *sourcegraph.SearchOptions) (*sourcegraph.SearchResults, error) {
 	return s.Search_(v0, v1)
 }
 
+func (s *SearchServer) StreamSearch(v0 *sourcegraph.SearchOptions, v1 sourcegraph.Search_StreamSearchServer) error {
+	return s.StreamSearch_(v0, v1)
+}
+
 func (s *SearchServer) SearchTokens(v0 context.Context, v1 *sourcegraph.TokenSearchOptions) (*sourcegraph.DefList, error) {
 	return s.SearchTokens_(v0, v1)
 }
@@ -1161,6 +1177,10 @@ func (s *SearchServer) SearchText(v0 context.Context, v1 *sourcegraph.TextSearch
 	return s.SearchText_(v0, v1)
 }
 
+func (s *SearchServer) SearchTextMulti(v0 context.Context, v1 *sourcegraph.MultiTextSearchOptions) (*sourcegraph.MultiTextSearchResults, error) {
+	return s.SearchTextMulti_(v0, v1)
+}
+
 func (s *SearchServer) Complete(v0 context.Context, v1 *sourcegraph.RawQuery) (*sourcegraph.Completions, error) {
 	return s.Complete_(v0, v1)
 }
@@ -1171,6 +1191,56 @@ func (s *SearchServer) Suggest(v0 context.Context, v1 *sourcegraph.RawQuery) (*s
 
 var _ sourcegraph.SearchServer = (*SearchServer)(nil)
 
+type SavedSearchesClient struct {
+	Create_ func(ctx context.Context, in *sourcegraph.SavedSearchesCreateOp) (*sourcegraph.SavedSearch, error)
+	List_   func(ctx context.Context, in *sourcegraph.SavedSearchListOptions) (*sourcegraph.SavedSearchList, error)
+	Delete_ func(ctx context.Context, in *sourcegraph.SavedSearchSpec) (*pbtypes.Void, error)
+	Run_    func(ctx context.Context, in *sourcegraph.SavedSearchSpec) (*sourcegraph.SavedSearchRunResult, error)
+}
+
+func (s *SavedSearchesClient) Create(ctx context.Context, in *sourcegraph.SavedSearchesCreateOp, opts ...grpc.CallOption) (*sourcegraph.SavedSearch, error) {
+	return s.Create_(ctx, in)
+}
+
+func (s *SavedSearchesClient) List(ctx context.Context, in *sourcegraph.SavedSearchListOptions, opts ...grpc.CallOption) (*sourcegraph.SavedSearchList, error) {
+	return s.List_(ctx, in)
+}
+
+func (s *SavedSearchesClient) Delete(ctx context.Context, in *sourcegraph.SavedSearchSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	return s.Delete_(ctx, in)
+}
+
+func (s *SavedSearchesClient) Run(ctx context.Context, in *sourcegraph.SavedSearchSpec, opts ...grpc.CallOption) (*sourcegraph.SavedSearchRunResult, error) {
+	return s.Run_(ctx, in)
+}
+
+var _ sourcegraph.SavedSearchesClient = (*SavedSearchesClient)(nil)
+
+type SavedSearchesServer struct {
+	Create_ func(v0 context.Context, v1 *sourcegraph.SavedSearchesCreateOp) (*sourcegraph.SavedSearch, error)
+	List_   func(v0 context.Context, v1 *sourcegraph.SavedSearchListOptions) (*sourcegraph.SavedSearchList, error)
+	Delete_ func(v0 context.Context, v1 *sourcegraph.SavedSearchSpec) (*pbtypes.Void, error)
+	Run_    func(v0 context.Context, v1 *sourcegraph.SavedSearchSpec) (*sourcegraph.SavedSearchRunResult, error)
+}
+
+func (s *SavedSearchesServer) Create(v0 context.Context, v1 *sourcegraph.SavedSearchesCreateOp) (*sourcegraph.SavedSearch, error) {
+	return s.Create_(v0, v1)
+}
+
+func (s *SavedSearchesServer) List(v0 context.Context, v1 *sourcegraph.SavedSearchListOptions) (*sourcegraph.SavedSearchList, error) {
+	return s.List_(v0, v1)
+}
+
+func (s *SavedSearchesServer) Delete(v0 context.Context, v1 *sourcegraph.SavedSearchSpec) (*pbtypes.Void, error) {
+	return s.Delete_(v0, v1)
+}
+
+func (s *SavedSearchesServer) Run(v0 context.Context, v1 *sourcegraph.SavedSearchSpec) (*sourcegraph.SavedSearchRunResult, error) {
+	return s.Run_(v0, v1)
+}
+
+var _ sourcegraph.SavedSearchesServer = (*SavedSearchesServer)(nil)
+
 type UnitsClient struct {
 	Get_  func(ctx context.Context, in *sourcegraph.UnitSpec) (*unit.RepoSourceUnit, error)
 	List_ func(ctx context.Context, in *sourcegraph.UnitListOptions) (*sourcegraph.RepoSourceUnitList, error)
@@ -1390,3 +1460,63 @@ func (s *NotifyServer) GenericEvent(v0 context.Context, v1 *sourcegraph.NotifyGe
 }
 
 var _ sourcegraph.NotifyServer = (*NotifyServer)(nil)
+
+type AuditLogClient struct {
+	List_ func(ctx context.Context, in *sourcegraph.AuditEventListOptions) (*sourcegraph.AuditEventList, error)
+}
+
+func (s *AuditLogClient) List(ctx context.Context, in *sourcegraph.AuditEventListOptions, opts ...grpc.CallOption) (*sourcegraph.AuditEventList, error) {
+	return s.List_(ctx, in)
+}
+
+var _ sourcegraph.AuditLogClient = (*AuditLogClient)(nil)
+
+type AuditLogServer struct {
+	List_ func(v0 context.Context, v1 *sourcegraph.AuditEventListOptions) (*sourcegraph.AuditEventList, error)
+}
+
+func (s *AuditLogServer) List(v0 context.Context, v1 *sourcegraph.AuditEventListOptions) (*sourcegraph.AuditEventList, error) {
+	return s.List_(v0, v1)
+}
+
+var _ sourcegraph.AuditLogServer = (*AuditLogServer)(nil)
+
+type AccessTokensClient struct {
+	Create_ func(ctx context.Context, in *sourcegraph.AccessTokensCreateOp) (*sourcegraph.NewAccessToken, error)
+	List_   func(ctx context.Context, in *sourcegraph.AccessTokensListOptions) (*sourcegraph.AccessTokenList, error)
+	Revoke_ func(ctx context.Context, in *sourcegraph.AccessTokenSpec) (*pbtypes.Void, error)
+}
+
+func (s *AccessTokensClient) Create(ctx context.Context, in *sourcegraph.AccessTokensCreateOp, opts ...grpc.CallOption) (*sourcegraph.NewAccessToken, error) {
+	return s.Create_(ctx, in)
+}
+
+func (s *AccessTokensClient) List(ctx context.Context, in *sourcegraph.AccessTokensListOptions, opts ...grpc.CallOption) (*sourcegraph.AccessTokenList, error) {
+	return s.List_(ctx, in)
+}
+
+func (s *AccessTokensClient) Revoke(ctx context.Context, in *sourcegraph.AccessTokenSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	return s.Revoke_(ctx, in)
+}
+
+var _ sourcegraph.AccessTokensClient = (*AccessTokensClient)(nil)
+
+type AccessTokensServer struct {
+	Create_ func(v0 context.Context, v1 *sourcegraph.AccessTokensCreateOp) (*sourcegraph.NewAccessToken, error)
+	List_   func(v0 context.Context, v1 *sourcegraph.AccessTokensListOptions) (*sourcegraph.AccessTokenList, error)
+	Revoke_ func(v0 context.Context, v1 *sourcegraph.AccessTokenSpec) (*pbtypes.Void, error)
+}
+
+func (s *AccessTokensServer) Create(v0 context.Context, v1 *sourcegraph.AccessTokensCreateOp) (*sourcegraph.NewAccessToken, error) {
+	return s.Create_(v0, v1)
+}
+
+func (s *AccessTokensServer) List(v0 context.Context, v1 *sourcegraph.AccessTokensListOptions) (*sourcegraph.AccessTokenList, error) {
+	return s.List_(v0, v1)
+}
+
+func (s *AccessTokensServer) Revoke(v0 context.Context, v1 *sourcegraph.AccessTokenSpec) (*pbtypes.Void, error) {
+	return s.Revoke_(v0, v1)
+}
+
+var _ sourcegraph.AccessTokensServer = (*AccessTokensServer)(nil)
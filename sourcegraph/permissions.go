@@ -0,0 +1,397 @@
+package sourcegraph
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// A Permission is a level of access to a registered client or a
+// repository. Each level includes the levels below it (e.g., a user
+// with WritePermission also has ReadPermission).
+type Permission int
+
+const (
+	NoPermission    Permission = iota // no access (or, for a method, no permission required)
+	ReadPermission                    // read access
+	WritePermission                   // read and write access
+	AdminPermission                   // full (administrative) access
+)
+
+func (p Permission) String() string {
+	switch p {
+	case NoPermission:
+		return "none"
+	case ReadPermission:
+		return "read"
+	case WritePermission:
+		return "write"
+	case AdminPermission:
+		return "admin"
+	}
+	return "invalid"
+}
+
+// The OAuth2 scopes that limit the permissions of an access token (see
+// AccessTokenResponse.Scope). Each scope grants the corresponding
+// Permission (and those below it).
+const (
+	ReadScope  = "read"
+	WriteScope = "write"
+	AdminScope = "admin"
+)
+
+// ScopePermission returns the highest permission granted by the OAuth2
+// scopes of an access token. A nil scope means that the token's access
+// is not limited by scope, and it grants AdminPermission.
+func ScopePermission(scope []string) Permission {
+	if scope == nil {
+		return AdminPermission
+	}
+	p := NoPermission
+	for _, s := range scope {
+		var sp Permission
+		switch s {
+		case ReadScope:
+			sp = ReadPermission
+		case WriteScope:
+			sp = WritePermission
+		case AdminScope:
+			sp = AdminPermission
+		}
+		if sp > p {
+			p = sp
+		}
+	}
+	return p
+}
+
+// Permission returns the highest permission that p grants on the
+// client.
+func (p *UserPermissions) Permission() Permission {
+	switch {
+	case p == nil:
+		return NoPermission
+	case p.Admin:
+		return AdminPermission
+	case p.Write:
+		return WritePermission
+	case p.Read:
+		return ReadPermission
+	}
+	return NoPermission
+}
+
+// Permission returns the highest permission that p grants on the
+// repository.
+func (p *RepoPermissions) Permission() Permission {
+	switch {
+	case p == nil:
+		return NoPermission
+	case p.Admin:
+		return AdminPermission
+	case p.Write:
+		return WritePermission
+	case p.Read:
+		return ReadPermission
+	}
+	return NoPermission
+}
+
+// A MethodPermission is the permission required to call an RPC method.
+type MethodPermission struct {
+	// Permission is the required permission on the client (and on the
+	// repository, if Repo is true).
+	Permission Permission
+
+	// Repo is whether Permission is also required on the repository
+	// that the request refers to (e.g., the repository of a DefSpec).
+	Repo bool
+}
+
+// MethodPermissions maps each RPC method (e.g., "Repos.Get") to the
+// permission required to call it. Handlers may perform additional
+// checks (e.g., that a user may only update their own account).
+var MethodPermissions = map[string]MethodPermission{
+	"AccessTokens.Create": {WritePermission, false},
+	"AccessTokens.List":   {ReadPermission, false},
+	"AccessTokens.Revoke": {WritePermission, false},
+
+	"Accounts.Create":               {NoPermission, false},
+	"Accounts.RequestPasswordReset": {NoPermission, false},
+	"Accounts.ResetPassword":        {NoPermission, false}, // authorized by the reset token
+	"Accounts.Update":               {WritePermission, false},
+
+	"AuditLog.List": {AdminPermission, false},
+
+	"Auth.GetAuthorizationCode": {WritePermission, false},
+	"Auth.GetAccessToken":       {NoPermission, false},
+	"Auth.Identify":             {NoPermission, false},
+	"Auth.GetPermissions":       {NoPermission, false},
+
+	"Builds.Get":              {ReadPermission, true},
+	"Builds.GetRepoBuildInfo": {ReadPermission, true},
+	"Builds.List":             {ReadPermission, false},
+	"Builds.Create":           {WritePermission, true},
+	"Builds.Update":           {WritePermission, true},
+	"Builds.ListBuildTasks":   {ReadPermission, true},
+	"Builds.CreateTasks":      {WritePermission, true},
+	"Builds.UpdateTask":       {WritePermission, true},
+	"Builds.GetLog":           {ReadPermission, true},
+	"Builds.GetTaskLog":       {ReadPermission, true},
+	"Builds.DequeueNext":      {AdminPermission, false},
+
+	"Changesets.Create":         {WritePermission, true},
+	"Changesets.Get":            {ReadPermission, true},
+	"Changesets.List":           {ReadPermission, true},
+	"Changesets.Update":         {WritePermission, true},
+	"Changesets.Merge":          {WritePermission, true},
+	"Changesets.UpdateAffected": {WritePermission, true},
+	"Changesets.CreateReview":   {WritePermission, true},
+	"Changesets.ListReviews":    {ReadPermission, true},
+	"Changesets.ListEvents":     {ReadPermission, true},
+
+	"Defs.Get":          {ReadPermission, true},
+	"Defs.List":         {ReadPermission, false},
+	"Defs.ListRefs":     {ReadPermission, true},
+	"Defs.ListExamples": {ReadPermission, true},
+	"Defs.ListAuthors":  {ReadPermission, true},
+	"Defs.ListClients":  {ReadPermission, true},
+
+	"Deltas.Get":                 {ReadPermission, true},
+	"Deltas.ListUnits":           {ReadPermission, true},
+	"Deltas.ListDefs":            {ReadPermission, true},
+	"Deltas.ListFiles":           {ReadPermission, true},
+	"Deltas.ListAffectedAuthors": {ReadPermission, true},
+	"Deltas.ListAffectedClients": {ReadPermission, true},
+
+	"Discussions.Create":        {WritePermission, false},
+	"Discussions.Get":           {ReadPermission, true},
+	"Discussions.List":          {ReadPermission, true},
+	"Discussions.CreateComment": {WritePermission, false},
+	"Discussions.UpdateRating":  {WritePermission, false},
+
+	"GraphUplink.Push":       {WritePermission, false},
+	"GraphUplink.PushEvents": {WritePermission, false},
+
+	"Markdown.Render": {ReadPermission, false},
+
+	"Meta.Status": {ReadPermission, false},
+	"Meta.Config": {NoPermission, false},
+	"Meta.PubKey": {NoPermission, false},
+
+	"MirrorRepos.RefreshVCS": {WritePermission, true},
+
+	"MirroredRepoSSHKeys.Create": {AdminPermission, true},
+	"MirroredRepoSSHKeys.Get":    {AdminPermission, true},
+	"MirroredRepoSSHKeys.Delete": {AdminPermission, true},
+
+	"Notify.GenericEvent": {WritePermission, false},
+
+	"Orgs.Get":         {ReadPermission, false},
+	"Orgs.List":        {ReadPermission, false},
+	"Orgs.ListMembers": {ReadPermission, false},
+
+	"People.Get": {ReadPermission, false},
+
+	"RegisteredClients.Get":                 {ReadPermission, false},
+	"RegisteredClients.GetCurrent":          {NoPermission, false},
+	"RegisteredClients.Create":              {WritePermission, false},
+	"RegisteredClients.Update":              {WritePermission, false},
+	"RegisteredClients.Delete":              {AdminPermission, false},
+	"RegisteredClients.List":                {AdminPermission, false},
+	"RegisteredClients.GetUserPermissions":  {AdminPermission, false},
+	"RegisteredClients.SetUserPermissions":  {AdminPermission, false},
+	"RegisteredClients.ListUserPermissions": {AdminPermission, false},
+
+	"RepoBadges.ListBadges":   {ReadPermission, true},
+	"RepoBadges.ListCounters": {ReadPermission, true},
+	"RepoBadges.RecordHit":    {ReadPermission, true},
+	"RepoBadges.CountHits":    {ReadPermission, true},
+
+	"RepoStatuses.GetCombined": {ReadPermission, true},
+	"RepoStatuses.Create":      {WritePermission, true},
+
+	"RepoTree.Get":    {ReadPermission, true},
+	"RepoTree.Search": {ReadPermission, true},
+	"RepoTree.List":   {ReadPermission, true},
+
+	"Repos.Get":            {ReadPermission, true},
+	"Repos.List":           {ReadPermission, false},
+	"Repos.Create":         {WritePermission, false},
+	"Repos.Update":         {WritePermission, true},
+	"Repos.Delete":         {AdminPermission, true},
+	"Repos.GetReadme":      {ReadPermission, true},
+	"Repos.Enable":         {AdminPermission, true},
+	"Repos.Disable":        {AdminPermission, true},
+	"Repos.GetConfig":      {ReadPermission, true},
+	"Repos.GetCommit":      {ReadPermission, true},
+	"Repos.ListCommits":    {ReadPermission, true},
+	"Repos.ListBranches":   {ReadPermission, true},
+	"Repos.ListTags":       {ReadPermission, true},
+	"Repos.ListCommitters": {ReadPermission, true},
+
+	"SavedSearches.Create": {WritePermission, false},
+	"SavedSearches.List":   {ReadPermission, false},
+	"SavedSearches.Delete": {WritePermission, false},
+	"SavedSearches.Run":    {ReadPermission, false},
+
+	// The repositories of Search.SearchTextMulti must be listed in
+	// RepoRevs (so that each one's permissions are checked).
+	"Search.Search":          {ReadPermission, false},
+	"Search.SearchTokens":    {ReadPermission, false},
+	"Search.SearchText":      {ReadPermission, true},
+	"Search.SearchTextMulti": {ReadPermission, true},
+	"Search.Complete":        {ReadPermission, false},
+	"Search.Suggest":         {ReadPermission, false},
+	"Search.StreamSearch":    {ReadPermission, false},
+
+	"Storage.Create":    {WritePermission, true},
+	"Storage.RemoveAll": {WritePermission, true},
+	"Storage.Read":      {ReadPermission, true},
+	"Storage.Write":     {WritePermission, true},
+	"Storage.Stat":      {ReadPermission, true},
+	"Storage.ReadDir":   {ReadPermission, true},
+	"Storage.Close":     {WritePermission, true},
+
+	"Units.Get":  {ReadPermission, true},
+	"Units.List": {ReadPermission, false},
+
+	"UserKeys.AddKey":     {WritePermission, false},
+	"UserKeys.LookupUser": {AdminPermission, false},
+	"UserKeys.DeleteKey":  {WritePermission, false},
+
+	"Users.Get":          {ReadPermission, false},
+	"Users.GetWithEmail": {AdminPermission, false},
+	"Users.ListEmails":   {ReadPermission, false},
+	"Users.List":         {ReadPermission, false},
+}
+
+// A PermissionChecker decides whether the authenticated client and
+// user of a request may perform an operation. It combines the user's
+// permissions on the client (UserPermissions), the user's permissions
+// on the repository (RepoPermissions) and the OAuth2 scope of the
+// request's access token. Each of them must grant the required
+// permission.
+//
+// Use it in a server by wrapping each service's server in an
+// Authorized*Server, which checks the permission required by
+// MethodPermissions before calling the handler.
+type PermissionChecker struct {
+	// Identify returns the authenticated client and user of the
+	// request in ctx and the OAuth2 scope of its access token (nil if
+	// the access is not limited by scope). It is required.
+	Identify func(ctx context.Context) (*AuthInfo, []string, error)
+
+	// UserPermissions returns the permissions of the user (which may
+	// be anonymous) on the client in a. If nil, permissions on the
+	// client are not checked.
+	UserPermissions func(ctx context.Context, a *AuthInfo) (*UserPermissions, error)
+
+	// RepoPermissions returns the permissions of the user in a on
+	// the repository (e.g., Repo.Permissions). If nil, permissions on
+	// repositories are not checked.
+	RepoPermissions func(ctx context.Context, a *AuthInfo, repo string) (*RepoPermissions, error)
+
+	// Methods maps each RPC method to the permission required to call
+	// it. If nil, MethodPermissions is used. Calls to methods that are
+	// not in the map are denied.
+	Methods map[string]MethodPermission
+}
+
+// Check returns nil if the client and user in a, using an access token
+// with the given scope, have permission perm (on the client and, if
+// repo is not empty, on the repository). Otherwise, it returns an
+// error with code codes.PermissionDenied (or codes.Unauthenticated, if
+// a has no client or user).
+//
+// If c.UserPermissions is nil, nothing grants permissions to an
+// anonymous caller (with no client or user), so such callers are
+// denied all access.
+func (c *PermissionChecker) Check(ctx context.Context, a *AuthInfo, scope []string, perm Permission, repo string) error {
+	if perm == NoPermission {
+		return nil
+	}
+	anonymous := a == nil || (a.UID == 0 && a.ClientID == "")
+	deny := func(format string, args ...interface{}) error {
+		if anonymous {
+			return grpc.Errorf(codes.Unauthenticated, "authentication required for %s access", perm)
+		}
+		return grpc.Errorf(codes.PermissionDenied, format, args...)
+	}
+
+	if ScopePermission(scope) < perm {
+		return deny("access token scope does not permit %s access", perm)
+	}
+	if a == nil {
+		a = &AuthInfo{}
+	}
+	if c.UserPermissions != nil {
+		p, err := c.UserPermissions(ctx, a)
+		if err != nil {
+			return err
+		}
+		if p.Permission() < perm {
+			return deny("%s access to client %q denied", perm, a.ClientID)
+		}
+	} else if anonymous {
+		return deny("anonymous %s access denied", perm)
+	}
+	if repo != "" && c.RepoPermissions != nil {
+		p, err := c.RepoPermissions(ctx, a, repo)
+		if err != nil {
+			return err
+		}
+		if p.Permission() < perm {
+			return deny("%s access to repository %q denied", perm, repo)
+		}
+	}
+	return nil
+}
+
+// CheckMethod returns nil if the request in ctx may call method (e.g.,
+// "Repos.Get") with arg, according to the permission that the method
+// requires. For methods that require permission on a repository, the
+// permission is checked on every repository that arg refers to (e.g.,
+// both repositories of a DeltaSpec), and the call is denied if arg
+// doesn't refer to any repository.
+//
+// A request to create an access token (AccessTokens.Create) is also
+// denied if the requested scope would grant more than the caller's own
+// access, so that callers can't escalate their privileges.
+func (c *PermissionChecker) CheckMethod(ctx context.Context, method string, arg interface{}) error {
+	methods := c.Methods
+	if methods == nil {
+		methods = MethodPermissions
+	}
+	mp, ok := methods[method]
+	if !ok {
+		return grpc.Errorf(codes.PermissionDenied, "no permission is defined for method %s", method)
+	}
+	if mp.Permission == NoPermission {
+		return nil
+	}
+
+	a, scope, err := c.Identify(ctx)
+	if err != nil {
+		return err
+	}
+	if op, ok := arg.(*AccessTokensCreateOp); ok {
+		if err := c.Check(ctx, a, scope, ScopePermission(op.Scope), ""); err != nil {
+			return err
+		}
+	}
+	if !mp.Repo {
+		return c.Check(ctx, a, scope, mp.Permission, "")
+	}
+	repos := requestRepoURIs(arg)
+	if len(repos) == 0 {
+		return grpc.Errorf(codes.PermissionDenied, "%s requires a repository, but none was specified", method)
+	}
+	for _, repo := range repos {
+		if err := c.Check(ctx, a, scope, mp.Permission, repo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,409 @@
+package sourcegraph
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+// serviceDescs are the descriptors of all services. It is checked
+// against the services of Client by TestServiceDescs.
+var serviceDescs = []*grpc.ServiceDesc{
+	&_AccessTokens_serviceDesc,
+	&_Accounts_serviceDesc,
+	&_AuditLog_serviceDesc,
+	&_Auth_serviceDesc,
+	&_Builds_serviceDesc,
+	&_Changesets_serviceDesc,
+	&_Defs_serviceDesc,
+	&_Deltas_serviceDesc,
+	&_Discussions_serviceDesc,
+	&_GraphUplink_serviceDesc,
+	&_Markdown_serviceDesc,
+	&_Meta_serviceDesc,
+	&_MirrorRepos_serviceDesc,
+	&_MirroredRepoSSHKeys_serviceDesc,
+	&_Notify_serviceDesc,
+	&_Orgs_serviceDesc,
+	&_People_serviceDesc,
+	&_RegisteredClients_serviceDesc,
+	&_RepoBadges_serviceDesc,
+	&_RepoStatuses_serviceDesc,
+	&_RepoTree_serviceDesc,
+	&_Repos_serviceDesc,
+	&_SavedSearches_serviceDesc,
+	&_Search_serviceDesc,
+	&_Storage_serviceDesc,
+	&_Units_serviceDesc,
+	&_UserKeys_serviceDesc,
+	&_Users_serviceDesc,
+}
+
+// serviceMethods returns all methods (e.g., "Repos.Get"), including
+// streaming methods, of the services in serviceDescs, mapped to the
+// type of their request.
+func serviceMethods() map[string]reflect.Type {
+	methods := map[string]reflect.Type{}
+	for _, desc := range serviceDescs {
+		svc := strings.TrimPrefix(desc.ServiceName, "sourcegraph.")
+		srv := reflect.TypeOf(desc.HandlerType).Elem()
+		var names []string
+		for _, m := range desc.Methods {
+			names = append(names, m.MethodName)
+		}
+		for _, s := range desc.Streams {
+			names = append(names, s.StreamName)
+		}
+		for _, name := range names {
+			m, ok := srv.MethodByName(name)
+			if !ok {
+				panic(svc + "Server has no method " + name)
+			}
+			// The request is the last parameter of unary methods
+			// (ctx, in) and the first of streaming methods (in,
+			// stream).
+			in := m.Type.In(m.Type.NumIn() - 1)
+			if in.Kind() != reflect.Ptr {
+				in = m.Type.In(0)
+			}
+			methods[svc+"."+name] = in.Elem()
+		}
+	}
+	return methods
+}
+
+func TestServiceDescs(t *testing.T) {
+	descs := map[string]bool{}
+	for _, desc := range serviceDescs {
+		descs[strings.TrimPrefix(desc.ServiceName, "sourcegraph.")] = true
+	}
+	ct := reflect.TypeOf(Client{})
+	for i := 0; i < ct.NumField(); i++ {
+		f := ct.Field(i)
+		if f.Type.Kind() == reflect.Interface && f.Type.Name() == f.Name+"Client" && !descs[f.Name] {
+			t.Errorf("service %s is missing from serviceDescs", f.Name)
+		}
+	}
+}
+
+func TestMethodPermissions_complete(t *testing.T) {
+	methods := serviceMethods()
+	for method := range methods {
+		if _, ok := MethodPermissions[method]; !ok {
+			t.Errorf("method %s has no entry in MethodPermissions", method)
+		}
+	}
+	var extra []string
+	for method := range MethodPermissions {
+		if _, ok := methods[method]; !ok {
+			extra = append(extra, method)
+		}
+	}
+	sort.Strings(extra)
+	if len(extra) > 0 {
+		t.Errorf("MethodPermissions has entries for nonexistent methods: %v", extra)
+	}
+}
+
+// fillStrings sets all string fields of v (a struct) and its nested
+// structs to s.
+func fillStrings(v reflect.Value, s string, depth int) {
+	if depth > 4 {
+		return
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Ptr:
+		if v.Type().Elem().Kind() == reflect.Struct {
+			v.Set(reflect.New(v.Type().Elem()))
+			fillStrings(v.Elem(), s, depth+1)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				fillStrings(v.Field(i), s, depth+1)
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+			fillStrings(v.Index(0), s, depth+1)
+		}
+	}
+}
+
+// TestMethodPermissions_repo checks that the request of every method
+// that requires permission on a repository can refer to a repository
+// (otherwise, CheckMethod would deny all calls).
+func TestMethodPermissions_repo(t *testing.T) {
+	for method, in := range serviceMethods() {
+		if !MethodPermissions[method].Repo {
+			continue
+		}
+		req := reflect.New(in)
+		fillStrings(req.Elem(), "r", 0)
+		if uris := requestRepoURIs(req.Interface()); len(uris) == 0 {
+			t.Errorf("%s: request %s refers to no repository", method, in)
+		}
+	}
+}
+
+func TestScopePermission(t *testing.T) {
+	tests := []struct {
+		scope []string
+		want  Permission
+	}{
+		{nil, AdminPermission},
+		{[]string{}, NoPermission},
+		{[]string{"other"}, NoPermission},
+		{[]string{ReadScope}, ReadPermission},
+		{[]string{ReadScope, WriteScope}, WritePermission},
+		{[]string{AdminScope, ReadScope}, AdminPermission},
+	}
+	for _, test := range tests {
+		if got := ScopePermission(test.scope); got != test.want {
+			t.Errorf("%q: got %s, want %s", test.scope, got, test.want)
+		}
+	}
+}
+
+// newTestPermissionChecker returns a checker whose users have the
+// given permissions on all clients, and on the repositories in repos.
+func newTestPermissionChecker(users map[int32]*UserPermissions, repos map[string]*RepoPermissions) *PermissionChecker {
+	return &PermissionChecker{
+		UserPermissions: func(ctx context.Context, a *AuthInfo) (*UserPermissions, error) {
+			return users[a.UID], nil
+		},
+		RepoPermissions: func(ctx context.Context, a *AuthInfo, repo string) (*RepoPermissions, error) {
+			return repos[repo], nil
+		},
+	}
+}
+
+func TestPermissionChecker_Check(t *testing.T) {
+	c := newTestPermissionChecker(
+		map[int32]*UserPermissions{
+			0: {Read: true},
+			1: {Read: true, Write: true},
+			2: {Read: true, Write: true, Admin: true},
+		},
+		map[string]*RepoPermissions{
+			"public":  {Read: true},
+			"private": {Read: true, Write: true},
+		},
+	)
+	anon := &AuthInfo{ClientID: "c"}
+	user := &AuthInfo{ClientID: "c", UID: 1}
+	admin := &AuthInfo{ClientID: "c", UID: 2}
+
+	tests := []struct {
+		a        *AuthInfo
+		scope    []string
+		perm     Permission
+		repo     string
+		wantCode codes.Code
+	}{
+		{nil, nil, NoPermission, "", codes.OK},
+		{nil, nil, ReadPermission, "", codes.OK},
+		{nil, nil, WritePermission, "", codes.Unauthenticated},
+		{anon, nil, ReadPermission, "public", codes.OK},
+		{anon, nil, WritePermission, "public", codes.PermissionDenied},
+		{user, nil, WritePermission, "private", codes.OK},
+		{user, nil, WritePermission, "public", codes.PermissionDenied},
+		{user, nil, WritePermission, "unknown", codes.PermissionDenied},
+		{user, nil, AdminPermission, "", codes.PermissionDenied},
+		{user, []string{ReadScope}, ReadPermission, "private", codes.OK},
+		{user, []string{ReadScope}, WritePermission, "private", codes.PermissionDenied},
+		{admin, nil, AdminPermission, "", codes.OK},
+		{admin, []string{WriteScope}, AdminPermission, "", codes.PermissionDenied},
+		{admin, nil, AdminPermission, "private", codes.PermissionDenied},
+	}
+	for _, test := range tests {
+		err := c.Check(context.Background(), test.a, test.scope, test.perm, test.repo)
+		if code := grpc.Code(err); code != test.wantCode {
+			t.Errorf("%+v scope %q: %s on %q: got code %s (error %v), want %s", test.a, test.scope, test.perm, test.repo, code, err, test.wantCode)
+		}
+	}
+}
+
+type testReposServer struct {
+	ReposServer
+	calls int
+}
+
+func (s *testReposServer) Get(ctx context.Context, in *RepoSpec) (*Repo, error) {
+	s.calls++
+	return &Repo{URI: in.URI}, nil
+}
+
+func (s *testReposServer) Delete(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	s.calls++
+	return &pbtypes.Void{}, nil
+}
+
+func TestAuthorizedServer(t *testing.T) {
+	c := newTestPermissionChecker(
+		map[int32]*UserPermissions{1: {Read: true, Write: true, Admin: true}},
+		map[string]*RepoPermissions{"r": {Read: true}},
+	)
+	var scope []string
+	c.Identify = func(ctx context.Context) (*AuthInfo, []string, error) {
+		return &AuthInfo{ClientID: "c", UID: 1}, scope, nil
+	}
+	backend := &testReposServer{}
+	s := &AuthorizedReposServer{ReposServer: backend, Checker: c}
+	ctx := context.Background()
+
+	if _, err := s.Get(ctx, &RepoSpec{URI: "r"}); err != nil {
+		t.Fatal(err)
+	}
+	if backend.calls != 1 {
+		t.Errorf("got %d handler calls, want 1", backend.calls)
+	}
+
+	// The handler isn't called if permission is denied.
+	if _, err := s.Delete(ctx, &RepoSpec{URI: "r"}); grpc.Code(err) != codes.PermissionDenied {
+		t.Errorf("Delete: got error %v, want PermissionDenied", err)
+	}
+	scope = []string{}
+	if _, err := s.Get(ctx, &RepoSpec{URI: "r"}); grpc.Code(err) != codes.PermissionDenied {
+		t.Errorf("Get with empty scope: got error %v, want PermissionDenied", err)
+	}
+	if backend.calls != 1 {
+		t.Errorf("got %d handler calls, want 1", backend.calls)
+	}
+
+	// Unknown methods are denied.
+	c.Methods = map[string]MethodPermission{}
+	if err := c.CheckMethod(ctx, "Repos.Get", &RepoSpec{URI: "r"}); grpc.Code(err) != codes.PermissionDenied {
+		t.Errorf("unknown method: got error %v, want PermissionDenied", err)
+	}
+}
+
+func TestPermissionChecker_Check_noUserPermissions(t *testing.T) {
+	c := &PermissionChecker{}
+	ctx := context.Background()
+
+	// Nothing grants permissions to anonymous callers.
+	for _, a := range []*AuthInfo{nil, {}} {
+		if err := c.Check(ctx, a, nil, AdminPermission, ""); grpc.Code(err) != codes.Unauthenticated {
+			t.Errorf("%+v: got error %v, want Unauthenticated", a, err)
+		}
+	}
+	if err := c.Check(ctx, &AuthInfo{UID: 1}, nil, AdminPermission, ""); err != nil {
+		t.Errorf("authenticated user: got error %v, want nil", err)
+	}
+}
+
+func TestPermissionChecker_CheckMethod_repos(t *testing.T) {
+	c := newTestPermissionChecker(
+		map[int32]*UserPermissions{1: {Read: true, Write: true}},
+		map[string]*RepoPermissions{"public": {Read: true}},
+	)
+	c.Identify = func(ctx context.Context) (*AuthInfo, []string, error) {
+		return &AuthInfo{ClientID: "c", UID: 1}, nil, nil
+	}
+	rev := func(uri string) RepoRevSpec { return RepoRevSpec{RepoSpec: RepoSpec{URI: uri}} }
+
+	tests := []struct {
+		method   string
+		arg      interface{}
+		wantCode codes.Code
+	}{
+		{"Changesets.List", &ChangesetListOp{Repo: "public"}, codes.OK},
+		{"Changesets.List", &ChangesetListOp{Repo: "private"}, codes.PermissionDenied},
+		{"Changesets.List", &ChangesetListOp{}, codes.PermissionDenied},
+		{"Deltas.Get", &DeltaSpec{Base: rev("public"), Head: rev("public")}, codes.OK},
+		{"Deltas.Get", &DeltaSpec{Base: rev("public"), Head: rev("private")}, codes.PermissionDenied},
+		{"Defs.ListRefs", &DefsListRefsOp{Def: DefSpec{Repo: "public"}, Opt: &DefListRefsOptions{Repo: "private"}}, codes.PermissionDenied},
+		{"Search.SearchText", &TextSearchOptions{RepoRev: rev("public")}, codes.OK},
+		{"Search.SearchText", &TextSearchOptions{RepoRev: rev("private")}, codes.PermissionDenied},
+		{"Search.SearchTextMulti", &MultiTextSearchOptions{RepoRevs: []RepoRevSpec{rev("public")}}, codes.OK},
+		{"Search.SearchTextMulti", &MultiTextSearchOptions{RepoRevs: []RepoRevSpec{rev("public"), rev("private")}}, codes.PermissionDenied},
+	}
+	for _, test := range tests {
+		if err := c.CheckMethod(context.Background(), test.method, test.arg); grpc.Code(err) != test.wantCode {
+			t.Errorf("%s %+v: got error %v, want code %s", test.method, test.arg, err, test.wantCode)
+		}
+	}
+}
+
+func TestPermissionChecker_CheckMethod_accessTokenScope(t *testing.T) {
+	c := newTestPermissionChecker(map[int32]*UserPermissions{1: {Read: true, Write: true}}, nil)
+	var scope []string
+	c.Identify = func(ctx context.Context) (*AuthInfo, []string, error) {
+		return &AuthInfo{ClientID: "c", UID: 1}, scope, nil
+	}
+
+	tests := []struct {
+		scope     []string
+		wantScope []string
+		wantCode  codes.Code
+	}{
+		{nil, []string{WriteScope}, codes.OK},
+		{nil, []string{AdminScope}, codes.PermissionDenied},
+		{[]string{WriteScope}, []string{ReadScope, WriteScope}, codes.OK},
+		{[]string{ReadScope}, []string{WriteScope}, codes.PermissionDenied},
+		{[]string{WriteScope}, nil, codes.PermissionDenied},
+	}
+	for _, test := range tests {
+		scope = test.scope
+		op := &AccessTokensCreateOp{User: UserSpec{UID: 1}, Name: "n", Scope: test.wantScope}
+		if err := c.CheckMethod(context.Background(), "AccessTokens.Create", op); grpc.Code(err) != test.wantCode {
+			t.Errorf("scope %v creating token with scope %v: got error %v, want code %s", test.scope, test.wantScope, err, test.wantCode)
+		}
+	}
+}
+
+type testSearchServer struct {
+	SearchServer
+	calls int
+}
+
+func (s *testSearchServer) StreamSearch(in *SearchOptions, stream Search_StreamSearchServer) error {
+	s.calls++
+	return nil
+}
+
+// testServerStream is a grpc.ServerStream with a context.
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+type testStreamSearchServer struct {
+	testServerStream
+}
+
+func (s *testStreamSearchServer) Send(*SearchEvent) error { return nil }
+
+func TestAuthorizedServer_stream(t *testing.T) {
+	c := newTestPermissionChecker(map[int32]*UserPermissions{1: {Read: true}}, nil)
+	var scope []string
+	c.Identify = func(ctx context.Context) (*AuthInfo, []string, error) {
+		return &AuthInfo{ClientID: "c", UID: 1}, scope, nil
+	}
+	backend := &testSearchServer{}
+	s := &AuthorizedSearchServer{SearchServer: backend, Checker: c}
+	stream := &testStreamSearchServer{testServerStream{ctx: context.Background()}}
+
+	if err := s.StreamSearch(&SearchOptions{}, stream); err != nil {
+		t.Fatal(err)
+	}
+	scope = []string{}
+	if err := s.StreamSearch(&SearchOptions{}, stream); grpc.Code(err) != codes.PermissionDenied {
+		t.Errorf("got error %v, want PermissionDenied", err)
+	}
+	if backend.calls != 1 {
+		t.Errorf("got %d handler calls, want 1", backend.calls)
+	}
+}
@@ -73,3 +73,23 @@ func TestProtobuf_Example(t *testing.T) {
 		t.Errorf("got %+v, want %+v", v2, v)
 	}
 }
+
+func TestProtobuf_AccessTokenRequest_RefreshToken(t *testing.T) {
+	v := &AccessTokenRequest{
+		AuthorizationGrant: &AccessTokenRequest_RefreshToken{RefreshToken: "r"},
+		TokenURL:           "u",
+	}
+	b, err := proto.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v2 := new(AccessTokenRequest)
+	if err := proto.Unmarshal(b, v2); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(v, v2) {
+		t.Errorf("got %+v, want %+v", v2, v)
+	}
+}
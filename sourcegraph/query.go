@@ -2,6 +2,10 @@ package sourcegraph
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"unicode/utf8"
 )
 
@@ -27,3 +31,297 @@ func Join(tokens []Token) RawQuery {
 	}
 	return RawQuery{Text: buf.String(), InsertionPoint: int32(ip)}
 }
+
+// tokenPrefixes are the leading characters that determine the type
+// of a token in a raw query string.
+const tokenPrefixes = `"':~/@-(`
+
+// Field prefixes of "field:val" tokens.
+const (
+	langField   = "lang:"
+	regexpField = "re:"
+)
+
+// fieldPrefix returns the field prefix (e.g., "lang:") that s starts
+// with, or "" if it doesn't start with a field prefix.
+func fieldPrefix(s string) string {
+	for _, f := range []string{langField, regexpField} {
+		if strings.HasPrefix(s, f) {
+			return f
+		}
+	}
+	return ""
+}
+
+// orOperator is the word that separates the operands of an
+// OrGroupToken.
+const orOperator = "OR"
+
+// Tokenize splits a raw query string into tokens. It is the inverse
+// of Join.
+//
+// Double-quoted strings are tokenized as Terms, single-quoted strings
+// as ExactPhraseTokens, and the ":rev", "~unit@type", "/path" and
+// "@login" forms as RevToken, UnitToken, FileToken and UserToken,
+// respectively. The "lang:name" and "re:pattern" (or `re:"pattern"`)
+// forms are tokenized as LangToken and RegexpToken. A token prefixed
+// with "-" is tokenized as a NotToken, and tokens separated by "OR"
+// (optionally surrounded by parentheses) are tokenized as an
+// OrGroupToken. All other words are tokenized as AnyToken, because
+// they can't be resolved (into a RepoToken or Term) without consulting
+// the server.
+//
+// If the query is malformed, the returned error is a TokenError
+// whose Index refers to the offending token.
+func Tokenize(q RawQuery) (Tokens, error) {
+	toks, _, err := TokenizeActive(q)
+	return toks, err
+}
+
+// TokenizeActive is like Tokenize, but it also returns the 0-indexed
+// index of the active token, which is the token that q's
+// InsertionPoint falls in (or immediately follows). If the insertion
+// point is not in any token, the returned index is -1.
+func TokenizeActive(q RawQuery) (toks Tokens, active int, err error) {
+	z := tokenizer{text: []rune(q.Text)}
+	toks, spans, err := z.sequence(false)
+	if err != nil {
+		terr := err.(*tokenizeError)
+		return nil, -1, newTokenError(len(toks)+1, terr.tok, terr.msg)
+	}
+
+	ip := int(q.InsertionPoint)
+	active = -1
+	for i, span := range spans {
+		if ip >= span[0] && ip <= span[1] {
+			active = i
+			break
+		}
+	}
+	return toks, active, nil
+}
+
+// A tokenizer splits a raw query string into tokens.
+type tokenizer struct {
+	text []rune
+	pos  int // index of the next rune to read in text
+}
+
+// A tokenizeError is an error about a malformed token.
+type tokenizeError struct {
+	tok Token
+	msg string
+}
+
+func (e *tokenizeError) Error() string { return e.msg }
+
+// sequence reads a sequence of tokens (combining tokens separated by
+// "OR" into OrGroupTokens) until the end of the text or, if inGroup,
+// until the ')' that closes the group. It returns the tokens and the
+// [start, end) span of each token in the text.
+//
+// If an error occurs, the tokens read before the offending token are
+// returned along with the error.
+func (z *tokenizer) sequence(inGroup bool) (toks Tokens, spans [][2]int, err error) {
+	for {
+		z.skipSpace()
+		if z.pos == len(z.text) {
+			if inGroup {
+				return toks, spans, &tokenizeError{msg: "unterminated group"}
+			}
+			return toks, spans, nil
+		}
+		if inGroup && z.text[z.pos] == ')' {
+			z.pos++
+			return toks, spans, nil
+		}
+
+		start := z.pos
+		tok, err := z.token(inGroup)
+		if err != nil {
+			return toks, spans, err
+		}
+
+		if tok == AnyToken(orOperator) {
+			if len(toks) == 0 {
+				return toks, spans, &tokenizeError{tok, orOperator + " must be preceded by a token"}
+			}
+			z.skipSpace()
+			if z.pos == len(z.text) || (inGroup && z.text[z.pos] == ')') {
+				return toks, spans, &tokenizeError{tok, orOperator + " must be followed by a token"}
+			}
+			operand, err := z.token(inGroup)
+			if err != nil {
+				return toks, spans, err
+			}
+			if operand == AnyToken(orOperator) {
+				return toks, spans, &tokenizeError{operand, orOperator + " must be followed by a token"}
+			}
+
+			last := len(toks) - 1
+			group, ok := toks[last].(OrGroupToken)
+			if !ok {
+				group = NewOrGroupToken(toks[last])
+			}
+			group.Operands = append(group.Operands, PBTokenWrap(operand))
+			toks[last] = group
+			spans[last][1] = z.pos
+			continue
+		}
+
+		toks = append(toks, tok)
+		spans = append(spans, [2]int{start, z.pos})
+	}
+}
+
+// token reads the token that starts at the current position.
+func (z *tokenizer) token(inGroup bool) (Token, error) {
+	start := z.pos
+	switch c := z.text[z.pos]; c {
+	case '"', '\'':
+		end := z.closingQuote(z.pos, inGroup)
+		if end == -1 {
+			s := string(z.text[start+1:])
+			if c == '"' {
+				return nil, &tokenizeError{Term(s), "unterminated quoted term"}
+			}
+			return nil, &tokenizeError{ExactPhraseToken{Phrase: s}, "unterminated exact phrase"}
+		}
+		s := string(z.text[start+1 : end])
+		z.pos = end + 1
+		if c == '"' {
+			return Term(s), nil
+		}
+		return ExactPhraseToken{Phrase: s}, nil
+
+	case '(':
+		z.pos++
+		toks, _, err := z.sequence(true)
+		if err != nil {
+			if terr := err.(*tokenizeError); terr.tok == nil {
+				terr.tok = AnyToken(z.text[start:])
+			}
+			return nil, err
+		}
+		if len(toks) == 1 {
+			if group, ok := toks[0].(OrGroupToken); ok {
+				return group, nil
+			}
+		} else if len(toks) > 1 {
+			return nil, &tokenizeError{AnyToken(z.text[start:z.pos]), "tokens in a group must be separated by " + orOperator}
+		}
+		return NewOrGroupToken(toks...), nil
+
+	case '-':
+		z.pos++
+		if z.pos == len(z.text) || z.text[z.pos] == ' ' || (inGroup && z.text[z.pos] == ')') {
+			return nil, &tokenizeError{AnyToken("-"), "'-' must be followed by a token"}
+		}
+		operand, err := z.token(inGroup)
+		if err != nil {
+			return nil, err
+		}
+		return NewNotToken(operand), nil
+	}
+
+	// A regexp pattern may be quoted (if it contains spaces).
+	if open := start + len(regexpField); z.hasPrefix(regexpField + `"`) {
+		end := z.closingQuote(open, inGroup)
+		if end == -1 {
+			return nil, &tokenizeError{RegexpToken{Pattern: string(z.text[open+1:])}, "unterminated quoted regular expression"}
+		}
+		z.pos = end + 1
+		pattern := string(z.text[open+1 : end])
+		tok, err := parseRegexpToken(pattern)
+		if err != nil {
+			return nil, &tokenizeError{RegexpToken{Pattern: pattern}, err.Error()}
+		}
+		return tok, nil
+	}
+
+	for z.pos < len(z.text) && z.text[z.pos] != ' ' && !(inGroup && z.text[z.pos] == ')') {
+		z.pos++
+	}
+	s := string(z.text[start:z.pos])
+	tok, err := parseToken(s)
+	if err != nil {
+		return nil, &tokenizeError{AnyToken(s), err.Error()}
+	}
+	return tok, nil
+}
+
+// hasPrefix reports whether the text at the current position starts
+// with prefix.
+func (z *tokenizer) hasPrefix(prefix string) bool {
+	p := []rune(prefix)
+	return len(z.text)-z.pos >= len(p) && string(z.text[z.pos:z.pos+len(p)]) == prefix
+}
+
+func (z *tokenizer) skipSpace() {
+	for z.pos < len(z.text) && z.text[z.pos] == ' ' {
+		z.pos++
+	}
+}
+
+// closingQuote returns the index of the quote that closes a quoted
+// string whose opening quote is at text[open], or -1 if there is
+// none. Only a quote that is followed by a space (or the end of the
+// text or group) closes a quoted string, because Term.Token does not
+// escape quotes.
+func (z *tokenizer) closingQuote(open int, inGroup bool) int {
+	quote := z.text[open]
+	for i := open + 1; i < len(z.text); i++ {
+		if z.text[i] == quote && (i == len(z.text)-1 || z.text[i+1] == ' ' || (inGroup && z.text[i+1] == ')')) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseToken parses an unquoted word from a raw query string.
+func parseToken(s string) (Token, error) {
+	switch s[0] {
+	case ':':
+		return RevToken{Rev: s[1:]}, nil
+	case '~':
+		name := s[1:]
+		var unitType string
+		if i := strings.LastIndex(name, "@"); i != -1 {
+			name, unitType = name[:i], name[i+1:]
+			if name == "" {
+				return nil, errors.New("unit name must precede '@' in unit token")
+			}
+			if unitType == "" {
+				return nil, errors.New("unit type must follow '@' in unit token")
+			}
+		}
+		return UnitToken{Name: name, UnitType: unitType}, nil
+	case '/':
+		return FileToken{Path: s[1:]}, nil
+	case '@':
+		return UserToken{Login: s[1:]}, nil
+	}
+	switch fieldPrefix(s) {
+	case langField:
+		return LangToken{Lang: strings.TrimPrefix(s, langField)}, nil
+	case regexpField:
+		return parseRegexpToken(strings.TrimPrefix(s, regexpField))
+	}
+	return AnyToken(s), nil
+}
+
+// parseRegexpToken returns a RegexpToken for the pattern, or an error
+// if the pattern is not a valid regular expression.
+func parseRegexpToken(pattern string) (Token, error) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, fmt.Errorf("invalid regular expression: %s", err)
+	}
+	return RegexpToken{Pattern: pattern}, nil
+}
+
+// newTokenError returns a TokenError for the token at the 1-indexed
+// index.
+func newTokenError(index int, tok Token, msg string) TokenError {
+	pbtok := PBTokenWrap(tok)
+	return TokenError{Index: int32(index), Token: &pbtok, Message: msg}
+}
@@ -0,0 +1,347 @@
+package sourcegraph
+
+import (
+	"sort"
+	"unicode"
+
+	"golang.org/x/net/context"
+)
+
+// A CompletionSource provides the candidates that Complete ranks.
+// Each method is given the (possibly empty) partial text of the token
+// being completed. It may return candidates that don't match the
+// partial text, because Complete filters and ranks the candidates
+// using FuzzyScore.
+type CompletionSource interface {
+	// Repos returns the URIs of candidate repositories.
+	Repos(ctx context.Context, partial string) ([]string, error)
+
+	// Users returns the logins of candidate users.
+	Users(ctx context.Context, partial string) ([]string, error)
+
+	// Revs returns candidate revisions (e.g., branches and tags) of
+	// the repository.
+	Revs(ctx context.Context, repo RepoSpec, partial string) ([]string, error)
+
+	// Units returns candidate source units in the repository
+	// revision.
+	Units(ctx context.Context, repoRev RepoRevSpec, partial string) ([]UnitToken, error)
+
+	// Files returns the paths of candidate files and directories in
+	// the repository revision.
+	Files(ctx context.Context, repoRev RepoRevSpec, partial string) ([]string, error)
+}
+
+// maxTokenCompletions is the maximum number of token completions
+// that Complete returns.
+const maxTokenCompletions = 20
+
+// Complete completes the token at q's InsertionPoint (see
+// TokenizeActive), using candidates from src that are ranked by
+// FuzzyScore.
+//
+// AnyTokens are completed as repositories, UserTokens as users, and
+// RevTokens, UnitTokens and FileTokens as revisions, source units and
+// files of the closest preceding repository (and revision). If there
+// is no active token, or if it is of another type, no completions are
+// returned.
+//
+// If q can't be tokenized, the returned Completions have
+// ResolutionFatal set and the tokenization error in ResolveErrors.
+func Complete(ctx context.Context, src CompletionSource, q RawQuery) (*Completions, error) {
+	toks, active, err := TokenizeActive(q)
+	if err != nil {
+		if terr, ok := err.(TokenError); ok {
+			return &Completions{ResolveErrors: []TokenError{terr}, ResolutionFatal: true}, nil
+		}
+		return nil, err
+	}
+
+	comps := &Completions{ResolvedTokens: PBTokensWrap(toks)}
+	if active == -1 {
+		return comps, nil
+	}
+
+	var cands []completion
+	switch t := toks[active].(type) {
+	case AnyToken:
+		uris, err := src.Repos(ctx, string(t))
+		if err != nil {
+			return nil, err
+		}
+		for _, uri := range uris {
+			cands = append(cands, completion{uri, RepoToken{URI: uri}})
+		}
+
+	case UserToken:
+		logins, err := src.Users(ctx, t.Login)
+		if err != nil {
+			return nil, err
+		}
+		for _, login := range logins {
+			cands = append(cands, completion{login, UserToken{Login: login}})
+		}
+
+	case RevToken:
+		repoRev, ok := precedingRepoRev(toks[:active])
+		if !ok {
+			break
+		}
+		revs, err := src.Revs(ctx, repoRev.RepoSpec, t.Rev)
+		if err != nil {
+			return nil, err
+		}
+		for _, rev := range revs {
+			cands = append(cands, completion{rev, RevToken{Rev: rev}})
+		}
+
+	case UnitToken:
+		repoRev, ok := precedingRepoRev(toks[:active])
+		if !ok {
+			break
+		}
+		units, err := src.Units(ctx, repoRev, t.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, unit := range units {
+			cands = append(cands, completion{unit.Name, unit})
+		}
+
+	case FileToken:
+		repoRev, ok := precedingRepoRev(toks[:active])
+		if !ok {
+			break
+		}
+		paths, err := src.Files(ctx, repoRev, t.Path)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			cands = append(cands, completion{path, FileToken{Path: path}})
+		}
+	}
+
+	comps.TokenCompletions = PBTokensWrap(rankCompletions(partialText(toks[active]), cands))
+	return comps, nil
+}
+
+// precedingRepoRev returns the repository (and revision, if any)
+// specified by the last RepoToken or AnyToken in toks and the
+// RevToken immediately following it.
+func precedingRepoRev(toks Tokens) (RepoRevSpec, bool) {
+	for i := len(toks) - 1; i >= 0; i-- {
+		var uri string
+		switch t := toks[i].(type) {
+		case RepoToken:
+			uri = t.URI
+		case AnyToken:
+			uri = string(t)
+		default:
+			continue
+		}
+		repoRev := RepoRevSpec{RepoSpec: RepoSpec{URI: uri}}
+		if i+1 < len(toks) {
+			if rev, ok := toks[i+1].(RevToken); ok {
+				repoRev.Rev = rev.Rev
+			}
+		}
+		return repoRev, true
+	}
+	return RepoRevSpec{}, false
+}
+
+// partialText returns the text of tok (without its prefix) that is
+// matched against completion candidates.
+func partialText(tok Token) string {
+	switch t := tok.(type) {
+	case AnyToken:
+		return string(t)
+	case UserToken:
+		return t.Login
+	case RevToken:
+		return t.Rev
+	case UnitToken:
+		return t.Name
+	case FileToken:
+		return t.Path
+	}
+	return ""
+}
+
+// A completion is a candidate token and the text that is matched
+// against the partial token.
+type completion struct {
+	text string
+	tok  Token
+}
+
+// rankCompletions returns the tokens of the candidates that match
+// partial, ordered by descending FuzzyScore (and then by ascending
+// length and alphabetically). At most maxTokenCompletions tokens are
+// returned.
+func rankCompletions(partial string, cands []completion) Tokens {
+	var matches scoredCompletions
+	seen := map[string]bool{}
+	for _, c := range cands {
+		if seen[c.tok.Token()] {
+			continue
+		}
+		seen[c.tok.Token()] = true
+		if score, ok := FuzzyScore(partial, c.text); ok {
+			matches = append(matches, scoredCompletion{c, score})
+		}
+	}
+	sort.Sort(matches)
+
+	if len(matches) > maxTokenCompletions {
+		matches = matches[:maxTokenCompletions]
+	}
+	toks := make(Tokens, len(matches))
+	for i, m := range matches {
+		toks[i] = m.tok
+	}
+	return toks
+}
+
+type scoredCompletion struct {
+	completion
+	score int
+}
+
+type scoredCompletions []scoredCompletion
+
+func (v scoredCompletions) Len() int      { return len(v) }
+func (v scoredCompletions) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
+func (v scoredCompletions) Less(i, j int) bool {
+	a, b := v[i], v[j]
+	if a.score != b.score {
+		return a.score > b.score
+	}
+	if len(a.text) != len(b.text) {
+		return len(a.text) < len(b.text)
+	}
+	return a.text < b.text
+}
+
+// Fuzzy matching scores. A match scores higher if the pattern's
+// characters are matched consecutively, at the start of the text, at
+// the start of path segments or words, or at camel-case humps, and
+// lower if there are gaps between the matched characters.
+const (
+	scoreMatch       = 16
+	bonusPrefix      = 32 // the text starts with the pattern
+	bonusSegment     = 12 // the match follows a separator (e.g., "/")
+	bonusCamel       = 10 // the match is an uppercase letter after a lowercase letter
+	bonusConsecutive = 8  // the match immediately follows the previous match
+	penaltyGap       = 1  // per unmatched character between matches
+)
+
+// FuzzyScore reports whether pattern fuzzy-matches text (i.e., if all
+// of the characters of pattern occur in text in the same order,
+// ignoring case) and, if so, returns a score that is higher for better
+// matches. An empty pattern matches any text with a score of 0.
+//
+// The score rewards matching a prefix of text, matching characters at
+// the start of path segments (e.g., "mux" in "github.com/gorilla/mux")
+// and camel-case humps (e.g., "NC" in "NewClient"), and matching
+// consecutive characters.
+func FuzzyScore(pattern, text string) (score int, ok bool) {
+	p, t := []rune(pattern), []rune(text)
+	if len(p) == 0 {
+		return 0, true
+	}
+	if len(p) > len(t) {
+		return 0, false
+	}
+
+	// best[j] is the best score of matching p[:i+1] with p[i] matched
+	// at t[j] (or none if p[:i+1] can't be matched that way).
+	const none = -1 << 30
+	best := make([]int, len(t))
+	for j := range t {
+		best[j] = none
+		if equalFold(p[0], t[j]) {
+			best[j] = scoreMatch + matchBonus(t, j)
+		}
+	}
+	for i := 1; i < len(p); i++ {
+		next := make([]int, len(t))
+		// gapMax is the max of best[k]+k*penaltyGap over k < j-1, so
+		// that the best score after a gap is gapMax-(j-1)*penaltyGap.
+		gapMax := none
+		for j := range t {
+			next[j] = none
+			if j >= 2 && best[j-2] != none && best[j-2]+(j-2)*penaltyGap > gapMax {
+				gapMax = best[j-2] + (j-2)*penaltyGap
+			}
+			if !equalFold(p[i], t[j]) {
+				continue
+			}
+			s := none
+			if j >= 1 && best[j-1] != none {
+				s = best[j-1] + bonusConsecutive
+			}
+			if gapMax != none && gapMax-(j-1)*penaltyGap > s {
+				s = gapMax - (j-1)*penaltyGap
+			}
+			if s != none {
+				next[j] = s + scoreMatch + matchBonus(t, j)
+			}
+		}
+		best = next
+	}
+
+	score = none
+	for _, s := range best {
+		if s > score {
+			score = s
+		}
+	}
+	if score == none {
+		return 0, false
+	}
+	if hasPrefixFold(t, p) {
+		score += bonusPrefix
+	}
+	return score, true
+}
+
+// matchBonus returns the bonus for matching a pattern character at
+// t[j].
+func matchBonus(t []rune, j int) int {
+	if j == 0 {
+		return bonusSegment
+	}
+	switch prev := t[j-1]; {
+	case isSegmentSeparator(prev):
+		return bonusSegment
+	case unicode.IsLower(prev) && unicode.IsUpper(t[j]):
+		return bonusCamel
+	}
+	return 0
+}
+
+func isSegmentSeparator(r rune) bool {
+	switch r {
+	case '/', '.', '-', '_', ' ', ':', '@':
+		return true
+	}
+	return false
+}
+
+func equalFold(a, b rune) bool {
+	return a == b || unicode.ToLower(a) == unicode.ToLower(b)
+}
+
+func hasPrefixFold(t, prefix []rune) bool {
+	if len(prefix) > len(t) {
+		return false
+	}
+	for i, r := range prefix {
+		if !equalFold(r, t[i]) {
+			return false
+		}
+	}
+	return true
+}
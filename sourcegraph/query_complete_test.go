@@ -0,0 +1,175 @@
+package sourcegraph
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		pattern, text string
+		wantOK        bool
+	}{
+		{"", "a", true},
+		{"a", "a", true},
+		{"A", "a", true},
+		{"mux", "github.com/gorilla/mux", true},
+		{"gm", "github.com/gorilla/mux", true},
+		{"nc", "NewClient", true},
+		{"xum", "mux", false},
+		{"muxx", "mux", false},
+		{"b", "a", false},
+	}
+	for _, test := range tests {
+		_, ok := FuzzyScore(test.pattern, test.text)
+		if ok != test.wantOK {
+			t.Errorf("FuzzyScore(%q, %q): got ok == %v, want %v", test.pattern, test.text, ok, test.wantOK)
+		}
+	}
+}
+
+func TestFuzzyScore_ranking(t *testing.T) {
+	// Each test's better text should score higher than its worse text.
+	tests := []struct {
+		pattern, better, worse string
+	}{
+		// Prefix bonus.
+		{"foo", "foobar", "barfoo"},
+		// Path segment bonus.
+		{"mux", "github.com/gorilla/mux", "github.com/gorilla/xmuxx"},
+		// Camel-case bonus.
+		{"nc", "NewClient", "Nonclient"},
+		// Consecutive bonus.
+		{"abc", "xabcx", "xaxbxc"},
+		// Gap penalty.
+		{"ab", "a-xb", "a-xxxxxb"},
+	}
+	for _, test := range tests {
+		better, ok := FuzzyScore(test.pattern, test.better)
+		if !ok {
+			t.Errorf("FuzzyScore(%q, %q): no match", test.pattern, test.better)
+			continue
+		}
+		worse, ok := FuzzyScore(test.pattern, test.worse)
+		if !ok {
+			t.Errorf("FuzzyScore(%q, %q): no match", test.pattern, test.worse)
+			continue
+		}
+		if better <= worse {
+			t.Errorf("pattern %q: got score %d for %q <= score %d for %q", test.pattern, better, test.better, worse, test.worse)
+		}
+	}
+}
+
+type completionSource struct {
+	repos, users, revs, files []string
+	units                     []UnitToken
+
+	repoRev RepoRevSpec // the last repoRev passed to Revs, Units or Files
+}
+
+func (s *completionSource) Repos(ctx context.Context, partial string) ([]string, error) {
+	return s.repos, nil
+}
+
+func (s *completionSource) Users(ctx context.Context, partial string) ([]string, error) {
+	return s.users, nil
+}
+
+func (s *completionSource) Revs(ctx context.Context, repo RepoSpec, partial string) ([]string, error) {
+	s.repoRev = RepoRevSpec{RepoSpec: repo}
+	return s.revs, nil
+}
+
+func (s *completionSource) Units(ctx context.Context, repoRev RepoRevSpec, partial string) ([]UnitToken, error) {
+	s.repoRev = repoRev
+	return s.units, nil
+}
+
+func (s *completionSource) Files(ctx context.Context, repoRev RepoRevSpec, partial string) ([]string, error) {
+	s.repoRev = repoRev
+	return s.files, nil
+}
+
+func TestComplete(t *testing.T) {
+	src := &completionSource{
+		repos: []string{"github.com/gorilla/muxer", "github.com/gorilla/mux", "github.com/golang/go", "github.com/xmux/a"},
+		users: []string{"alice", "bob", "alicia"},
+		revs:  []string{"master", "v1.0", "mybranch"},
+		units: []UnitToken{{Name: "github.com/a/b", UnitType: "GoPackage"}, {Name: "c", UnitType: "GoPackage"}},
+		files: []string{"README.md", "mux.go", "mux_test.go", "doc/mux.md"},
+	}
+
+	tests := map[string]struct {
+		q           RawQuery
+		want        Tokens
+		wantRepoRev RepoRevSpec
+	}{
+		"no active token": {
+			q: RawQuery{Text: "mux ", InsertionPoint: 4},
+		},
+		"repo": {
+			q: RawQuery{Text: "mux", InsertionPoint: 3},
+			want: Tokens{
+				RepoToken{URI: "github.com/gorilla/mux"},
+				RepoToken{URI: "github.com/gorilla/muxer"},
+				RepoToken{URI: "github.com/xmux/a"},
+			},
+		},
+		"user": {
+			q:    RawQuery{Text: "@ali", InsertionPoint: 4},
+			want: Tokens{UserToken{Login: "alice"}, UserToken{Login: "alicia"}},
+		},
+		"rev": {
+			q:           RawQuery{Text: "mux :m", InsertionPoint: 6},
+			want:        Tokens{RevToken{Rev: "master"}, RevToken{Rev: "mybranch"}},
+			wantRepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "mux"}},
+		},
+		"rev without repo": {
+			q: RawQuery{Text: ":m", InsertionPoint: 2},
+		},
+		"unit": {
+			q:           RawQuery{Text: "mux :v1 ~b", InsertionPoint: 10},
+			want:        Tokens{UnitToken{Name: "github.com/a/b", UnitType: "GoPackage"}},
+			wantRepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "mux"}, Rev: "v1"},
+		},
+		"file": {
+			q:           RawQuery{Text: "mux /mux foo", InsertionPoint: 8},
+			want:        Tokens{FileToken{Path: "mux.go"}, FileToken{Path: "mux_test.go"}, FileToken{Path: "doc/mux.md"}},
+			wantRepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "mux"}},
+		},
+	}
+	for label, test := range tests {
+		src.repoRev = RepoRevSpec{}
+		comps, err := Complete(context.Background(), src, test.q)
+		if err != nil {
+			t.Errorf("%s: Complete: %s", label, err)
+			continue
+		}
+		if comps.ResolutionFatal {
+			t.Errorf("%s: got ResolutionFatal, errors: %v", label, comps.ResolveErrors)
+			continue
+		}
+		if got := PBTokens(comps.TokenCompletions); !reflect.DeepEqual(got, test.want) && !(len(got) == 0 && len(test.want) == 0) {
+			t.Errorf("%s: got completions %v, want %v", label, got, test.want)
+		}
+		if src.repoRev != test.wantRepoRev {
+			t.Errorf("%s: got repoRev %+v, want %+v", label, src.repoRev, test.wantRepoRev)
+		}
+	}
+}
+
+func TestComplete_tokenizeError(t *testing.T) {
+	comps, err := Complete(context.Background(), &completionSource{}, RawQuery{Text: `"foo`, InsertionPoint: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !comps.ResolutionFatal {
+		t.Error("got ResolutionFatal == false, want true")
+	}
+	if len(comps.ResolveErrors) != 1 {
+		t.Errorf("got %d resolve errors, want 1", len(comps.ResolveErrors))
+	}
+}
@@ -0,0 +1,176 @@
+package sourcegraph
+
+import "strings"
+
+// Describe returns a human-readable description of the query given
+// by toks, such as "definitions named Foo in package ~net/http of
+// github.com/golang/go at master". It is used to generate the
+// Description of Suggestions.
+func Describe(toks Tokens) string {
+	var (
+		names    []string // Terms, AnyTokens and ExactPhraseTokens
+		regexps  []string
+		langs    []string
+		files    []string
+		units    []string
+		repos    []string
+		revs     []string
+		users    []string
+		groups   []string // OrGroupTokens of other than repositories
+		excludes []string
+	)
+	for _, tok := range toks {
+		switch t := tok.(type) {
+		case Term, AnyToken, ExactPhraseToken:
+			if s := describeOperand(t); s != "" {
+				names = append(names, s)
+			}
+		case RegexpToken:
+			regexps = append(regexps, describeRegexp(t.Pattern))
+		case LangToken:
+			langs = append(langs, t.Lang)
+		case FileToken:
+			files = append(files, describeOperand(t))
+		case UnitToken:
+			units = append(units, describeOperand(t))
+		case RepoToken:
+			repos = append(repos, t.URI)
+		case RevToken:
+			revs = append(revs, t.Rev)
+		case UserToken:
+			users = append(users, describeOperand(t))
+		case OrGroupToken:
+			switch {
+			case allOperands(t, isRepoToken):
+				repos = append(repos, describeOperand(t))
+			case allOperands(t, isNameToken):
+				names = append(names, describeOperand(t))
+			default:
+				groups = append(groups, describeOperand(t))
+			}
+		case NotToken:
+			excludes = append(excludes, describeOperand(t.Operand.GetQueryToken()))
+		}
+	}
+
+	var parts []string
+	switch {
+	case len(names) > 0:
+		parts = append(parts, "definitions named "+strings.Join(names, " "))
+	case len(regexps) > 0:
+		parts = append(parts, "text")
+	case len(units) > 0 || len(files) > 0 || len(langs) > 0:
+		parts = append(parts, "definitions")
+	case len(users) > 0 && len(repos) == 0:
+		parts = append(parts, "repositories")
+	default:
+		parts = append(parts, "everything")
+	}
+	if len(regexps) > 0 {
+		parts = append(parts, "matching "+strings.Join(regexps, " and "))
+	}
+	if len(groups) > 0 {
+		parts = append(parts, "matching "+strings.Join(groups, " and "))
+	}
+	if len(langs) > 0 {
+		parts = append(parts, "written in "+strings.Join(langs, " or "))
+	}
+	if len(files) > 0 {
+		parts = append(parts, "in "+strings.Join(files, " and "))
+	}
+	if len(units) > 0 {
+		parts = append(parts, "in "+strings.Join(units, " and "))
+	}
+	if len(repos) > 0 {
+		prep := "in"
+		if len(files) > 0 || len(units) > 0 {
+			prep = "of"
+		}
+		parts = append(parts, prep+" "+strings.Join(repos, " and "))
+	}
+	if len(revs) > 0 {
+		parts = append(parts, "at "+strings.Join(revs, " and "))
+	}
+	if len(users) > 0 {
+		parts = append(parts, "owned by "+strings.Join(users, " or "))
+	}
+	if len(excludes) > 0 {
+		parts = append(parts, "excluding "+strings.Join(excludes, " and "))
+	}
+	return strings.Join(parts, " ")
+}
+
+// describeOperand returns a short noun phrase that describes tok
+// (e.g., "package ~net/http" for a UnitToken).
+func describeOperand(tok Token) string {
+	switch t := tok.(type) {
+	case Term:
+		return describeWord(string(t))
+	case AnyToken:
+		return describeWord(string(t))
+	case ExactPhraseToken:
+		return `"` + t.Phrase + `"`
+	case RegexpToken:
+		return describeRegexp(t.Pattern)
+	case LangToken:
+		return t.Lang + " code"
+	case FileToken:
+		return "path " + t.Token()
+	case UnitToken:
+		if t.UnitType == "GoPackage" {
+			return "package ~" + t.Name
+		}
+		return "source unit " + t.Token()
+	case RepoToken:
+		return t.URI
+	case RevToken:
+		return "revision " + t.Rev
+	case UserToken:
+		return "@" + t.Login
+	case NotToken:
+		return "not " + describeOperand(t.Operand.GetQueryToken())
+	case OrGroupToken:
+		ops := make([]string, len(t.Operands))
+		for i, op := range t.Operands {
+			ops[i] = describeOperand(op.GetQueryToken())
+		}
+		return strings.Join(ops, " or ")
+	}
+	return ""
+}
+
+// describeWord returns s, quoted if it contains spaces.
+func describeWord(s string) string {
+	if strings.Contains(s, " ") {
+		return `"` + s + `"`
+	}
+	return s
+}
+
+func describeRegexp(pattern string) string { return "/" + pattern + "/" }
+
+// allOperands reports whether f returns true for all of the operands
+// of t.
+func allOperands(t OrGroupToken, f func(Token) bool) bool {
+	for _, op := range t.Operands {
+		if !f(op.GetQueryToken()) {
+			return false
+		}
+	}
+	return len(t.Operands) > 0
+}
+
+func isRepoToken(tok Token) bool {
+	_, ok := tok.(RepoToken)
+	return ok
+}
+
+// isNameToken reports whether tok is a Term, AnyToken or
+// ExactPhraseToken.
+func isNameToken(tok Token) bool {
+	switch tok.(type) {
+	case Term, AnyToken, ExactPhraseToken:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package sourcegraph
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+// checkGolden compares got to the contents of the golden file
+// testdata/name (or, if the -update flag is set, writes got to it).
+func checkGolden(t *testing.T, name string, got []byte) {
+	file := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := ioutil.WriteFile(file, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run with -update to update it)\n\ngot:\n%s\nwant:\n%s", file, got, want)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	var (
+		repo = RepoToken{URI: "github.com/golang/go"}
+		rev  = RevToken{Rev: "master"}
+		pkg  = UnitToken{Name: "net/http", UnitType: "GoPackage"}
+	)
+	tests := []Tokens{
+		nil,
+		{Term("Foo")},
+		{AnyToken("Foo"), Term("bar baz")},
+		{ExactPhraseToken{Phrase: "a b"}},
+		{repo},
+		{repo, rev},
+		{repo, rev, pkg, Term("Foo")},
+		{repo, UnitToken{Name: "foo", UnitType: "PipPackage"}},
+		{repo, FileToken{Path: "src/net/"}, Term("Foo")},
+		{UserToken{Login: "alice"}},
+		{UserToken{Login: "alice"}, Term("Foo")},
+		{LangToken{Lang: "go"}, Term("Foo")},
+		{LangToken{Lang: "go"}, LangToken{Lang: "python"}},
+		{repo, RegexpToken{Pattern: "Fo+"}},
+		{NewOrGroupToken(repo, RepoToken{URI: "github.com/gorilla/mux"}), Term("Foo")},
+		{NewOrGroupToken(Term("Foo"), Term("Bar"))},
+		{Term("Foo"), NewNotToken(repo)},
+		{Term("Foo"), NewNotToken(NewOrGroupToken(LangToken{Lang: "go"}, ExactPhraseToken{Phrase: "a b"}))},
+	}
+
+	var buf bytes.Buffer
+	for _, toks := range tests {
+		fmt.Fprintf(&buf, "%s\n\t%s\n", Join(toks).Text, Describe(toks))
+	}
+	checkGolden(t, "describe.golden", buf.Bytes())
+}
@@ -0,0 +1,155 @@
+package sourcegraph
+
+import (
+	"path"
+	"strings"
+
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+)
+
+// NewPlan returns a query plan that fetches the data necessary to
+// satisfy the query given by toks, which must already be resolved
+// (e.g., using Resolve).
+//
+// Each RepoToken (and its following RevToken, if any) constrains the
+// Defs and Tree searches to that repository revision. UnitTokens and
+// FileTokens constrain the Defs search, and Terms (and
+// ExactPhraseTokens) are used as the query of each search. An
+// OrGroupToken of RepoTokens constrains the searches to any of those
+// repositories. LangTokens and RegexpTokens constrain the Defs and
+// Tree searches. If the query contains no RepoTokens, the plan
+// also searches Repos (and, if the query contains Terms or UserTokens,
+// Users).
+//
+// If the query can't be planned, the returned error is a TokenError
+// whose Index refers to the offending token.
+func NewPlan(toks Tokens) (*Plan, error) {
+	var (
+		repoRevs  []string
+		terms     []string
+		owner     string
+		langs     []string
+		regexpTok *RegexpToken
+		unitTok   *UnitToken
+		fileTok   *FileToken
+	)
+	for i, tok := range toks {
+		switch t := tok.(type) {
+		case Term:
+			if t != "" {
+				terms = append(terms, string(t))
+			}
+
+		case ExactPhraseToken:
+			if t.Phrase != "" {
+				terms = append(terms, t.Phrase)
+			}
+
+		case RepoToken:
+			repoRevs = append(repoRevs, t.URI)
+
+		case OrGroupToken:
+			// Only ORed repositories (e.g., "(a OR b)") can be
+			// expressed in a plan.
+			for _, op := range t.Operands {
+				repoTok, ok := op.GetQueryToken().(RepoToken)
+				if !ok {
+					return nil, newTokenError(i+1, t, "only repositories may be ORed together")
+				}
+				repoRevs = append(repoRevs, repoTok.URI)
+			}
+
+		case RevToken:
+			var prev Token
+			if i > 0 {
+				prev = toks[i-1]
+			}
+			if _, isRepo := prev.(RepoToken); !isRepo {
+				return nil, newTokenError(i+1, t, "revision must immediately follow a repository")
+			}
+			rev := t.Rev
+			if t.Commit != nil && t.Commit.ID != "" {
+				rev = string(t.Commit.ID)
+			}
+			repoRevs[len(repoRevs)-1] += "@" + rev
+
+		case UnitToken:
+			if unitTok != nil {
+				return nil, newTokenError(i+1, t, "only one source unit may be specified")
+			}
+			unitTok = &t
+
+		case FileToken:
+			if fileTok != nil {
+				return nil, newTokenError(i+1, t, "only one file or directory may be specified")
+			}
+			fileTok = &t
+
+		case UserToken:
+			if owner != "" {
+				return nil, newTokenError(i+1, t, "only one user may be specified")
+			}
+			owner = t.Login
+
+		case LangToken:
+			langs = append(langs, t.Lang)
+
+		case RegexpToken:
+			if regexpTok != nil {
+				return nil, newTokenError(i+1, t, "only one regular expression may be specified")
+			}
+			regexpTok = &t
+
+		case NotToken:
+			return nil, newTokenError(i+1, t, "negated tokens are not supported")
+
+		default:
+			return nil, newTokenError(i+1, tok, "unresolved token (resolve the query before planning it)")
+		}
+	}
+
+	query := strings.Join(terms, " ")
+
+	plan := &Plan{
+		Defs: &DefListOptions{
+			Query:     query,
+			RepoRevs:  repoRevs,
+			Languages: langs,
+		},
+	}
+	if regexpTok != nil {
+		plan.Defs.Regexp = regexpTok.Pattern
+	}
+	if unitTok != nil {
+		plan.Defs.Unit = unitTok.Name
+		plan.Defs.UnitType = unitTok.UnitType
+	}
+	if fileTok != nil {
+		plan.Defs.FilePathPrefix = path.Clean(fileTok.Path)
+	}
+
+	if len(repoRevs) > 0 {
+		if query != "" || regexpTok != nil {
+			plan.Tree = &RepoTreeSearchOptions{
+				SearchOptions: vcs.SearchOptions{
+					Query:     query,
+					QueryType: vcs.FixedQuery,
+				},
+				Languages: langs,
+				Regexp:    plan.Defs.Regexp,
+			}
+			plan.TreeRepoRevs = repoRevs
+		}
+	} else {
+		plan.Repos = &RepoListOptions{Query: query, Owner: owner}
+		if query != "" || owner != "" {
+			userQuery := query
+			if owner != "" {
+				userQuery = owner
+			}
+			plan.Users = &UsersListOptions{Query: userQuery}
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,119 @@
+package sourcegraph
+
+import (
+	"reflect"
+	"testing"
+
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+)
+
+func TestNewPlan(t *testing.T) {
+	tests := []struct {
+		toks Tokens
+		want *Plan
+	}{
+		{
+			toks: Tokens{},
+			want: &Plan{
+				Repos: &RepoListOptions{},
+				Defs:  &DefListOptions{},
+			},
+		},
+		{
+			toks: Tokens{Term("a"), Term("b"), UserToken{Login: "u"}},
+			want: &Plan{
+				Repos: &RepoListOptions{Query: "a b", Owner: "u"},
+				Defs:  &DefListOptions{Query: "a b"},
+				Users: &UsersListOptions{Query: "u"},
+			},
+		},
+		{
+			toks: Tokens{
+				RepoToken{URI: "r"}, RevToken{Rev: "v"},
+				RepoToken{URI: "r2"}, RevToken{Rev: "v", Commit: &vcs.Commit{ID: "c"}},
+				RepoToken{URI: "r3"},
+				UnitToken{Name: "u", UnitType: "t"},
+				FileToken{Path: "a/b/"},
+				Term("q"),
+			},
+			want: &Plan{
+				Defs: &DefListOptions{
+					Query:          "q",
+					RepoRevs:       []string{"r@v", "r2@c", "r3"},
+					Unit:           "u",
+					UnitType:       "t",
+					FilePathPrefix: "a/b",
+				},
+				Tree: &RepoTreeSearchOptions{
+					SearchOptions: vcs.SearchOptions{Query: "q", QueryType: vcs.FixedQuery},
+				},
+				TreeRepoRevs: []string{"r@v", "r2@c", "r3"},
+			},
+		},
+		{
+			toks: Tokens{RepoToken{URI: "r"}},
+			want: &Plan{
+				Defs: &DefListOptions{RepoRevs: []string{"r"}},
+			},
+		},
+		{
+			toks: Tokens{RepoToken{URI: "r"}, LangToken{Lang: "go"}, RegexpToken{Pattern: "^a"}},
+			want: &Plan{
+				Defs: &DefListOptions{RepoRevs: []string{"r"}, Languages: []string{"go"}, Regexp: "^a"},
+				Tree: &RepoTreeSearchOptions{
+					SearchOptions: vcs.SearchOptions{QueryType: vcs.FixedQuery},
+					Languages:     []string{"go"},
+					Regexp:        "^a",
+				},
+				TreeRepoRevs: []string{"r"},
+			},
+		},
+		{
+			toks: Tokens{NewOrGroupToken(RepoToken{URI: "r"}, RepoToken{URI: "r2"}), ExactPhraseToken{Phrase: "a b"}},
+			want: &Plan{
+				Defs: &DefListOptions{Query: "a b", RepoRevs: []string{"r", "r2"}},
+				Tree: &RepoTreeSearchOptions{
+					SearchOptions: vcs.SearchOptions{Query: "a b", QueryType: vcs.FixedQuery},
+				},
+				TreeRepoRevs: []string{"r", "r2"},
+			},
+		},
+	}
+	for _, test := range tests {
+		plan, err := NewPlan(test.toks)
+		if err != nil {
+			t.Errorf("%v: %s", test.toks, err)
+			continue
+		}
+		if !reflect.DeepEqual(plan, test.want) {
+			t.Errorf("%v: got plan %+v, want %+v", test.toks, plan, test.want)
+		}
+	}
+}
+
+func TestNewPlan_errors(t *testing.T) {
+	tests := []struct {
+		toks      Tokens
+		wantIndex int32
+	}{
+		{Tokens{AnyToken("a")}, 1},
+		{Tokens{Term("a"), RevToken{Rev: "v"}}, 2},
+		{Tokens{RepoToken{URI: "r"}, RevToken{Rev: "v"}, RevToken{Rev: "w"}}, 3},
+		{Tokens{UnitToken{Name: "u"}, UnitToken{Name: "u"}}, 2},
+		{Tokens{FileToken{Path: "a"}, Term("t"), FileToken{Path: "b"}}, 3},
+		{Tokens{Term("t"), NewNotToken(FileToken{Path: "a"})}, 2},
+		{Tokens{RegexpToken{Pattern: "a"}, RegexpToken{Pattern: "b"}}, 2},
+		{Tokens{NewOrGroupToken(RepoToken{URI: "r"}, Term("t"))}, 1},
+	}
+	for _, test := range tests {
+		_, err := NewPlan(test.toks)
+		terr, ok := err.(TokenError)
+		if !ok {
+			t.Errorf("%v: got error %v, want TokenError", test.toks, err)
+			continue
+		}
+		if terr.Index != test.wantIndex {
+			t.Errorf("%v: got error Index %d, want %d", test.toks, terr.Index, test.wantIndex)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package sourcegraph
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// Resolve resolves tokens (typically obtained by calling Tokenize)
+// using the Sourcegraph API that c communicates with, so that each
+// token is given an unambiguous meaning.
+//
+// Each AnyToken is resolved to a RepoToken, UserToken or RevToken if
+// possible and to a Term otherwise. The Repo, User and Commit fields
+// of RepoTokens, UserTokens and RevTokens are filled in. A RevToken
+// is resolved against the closest preceding RepoToken. The operands
+// of NotTokens and OrGroupTokens are resolved in the same way.
+//
+// Tokens that are ambiguous or that refer to nonexistent repos,
+// users or revisions are reported in the returned []TokenError,
+// whose Index fields are 1-indexed positions in toks. Other API
+// errors are returned as the error.
+func Resolve(ctx context.Context, c *Client, toks Tokens) (*ResolvedQuery, []TokenError, error) {
+	var (
+		resolved = make(Tokens, len(toks))
+		errs     []TokenError
+		repo     *RepoToken // closest preceding RepoToken
+	)
+	for i, tok := range toks {
+		if anyTok, ok := tok.(AnyToken); ok {
+			var err error
+			tok, err = resolveAnyToken(ctx, c, anyTok)
+			if err != nil {
+				if terr, ok := err.(TokenError); ok {
+					terr.Index = int32(i + 1)
+					errs = append(errs, terr)
+					tok = Term(anyTok)
+				} else {
+					return nil, nil, err
+				}
+			}
+		}
+
+		var (
+			err         error
+			operandErrs []TokenError
+		)
+		switch t := tok.(type) {
+		case RepoToken:
+			if t.Repo == nil {
+				t.Repo, err = c.Repos.Get(ctx, &RepoSpec{URI: t.URI})
+				if grpc.Code(err) == codes.NotFound {
+					err = newTokenError(i+1, t, fmt.Sprintf("repository %q not found", t.URI))
+				}
+			}
+			if err == nil {
+				repo = &t
+			}
+			tok = t
+
+		case UserToken:
+			if t.User == nil && t.Login != "" {
+				t.User, err = c.Users.Get(ctx, &UserSpec{Login: t.Login})
+				if grpc.Code(err) == codes.NotFound {
+					err = newTokenError(i+1, t, fmt.Sprintf("user %q not found", t.Login))
+				}
+			}
+			tok = t
+
+		case RevToken:
+			if repo == nil {
+				err = newTokenError(i+1, t, "revision must be preceded by a repository")
+			} else if t.Commit == nil && t.Rev != "" {
+				t.Commit, err = c.Repos.GetCommit(ctx, &RepoRevSpec{RepoSpec: repo.Spec(), Rev: t.Rev})
+				if grpc.Code(err) == codes.NotFound {
+					err = newTokenError(i+1, t, fmt.Sprintf("revision %q not found in repository %q", t.Rev, repo.URI))
+				}
+			}
+			tok = t
+
+		case NotToken:
+			var operands []PBToken
+			operands, operandErrs, err = resolveOperands(ctx, c, i, []PBToken{t.Operand})
+			if err == nil {
+				t.Operand = operands[0]
+			}
+			tok = t
+
+		case OrGroupToken:
+			t.Operands, operandErrs, err = resolveOperands(ctx, c, i, t.Operands)
+			tok = t
+		}
+		errs = append(errs, operandErrs...)
+		if err != nil {
+			if terr, ok := err.(TokenError); ok {
+				errs = append(errs, terr)
+			} else {
+				return nil, nil, err
+			}
+		}
+
+		resolved[i] = tok
+	}
+	return &ResolvedQuery{Tokens: PBTokensWrap(resolved)}, errs, nil
+}
+
+// resolveOperands resolves the operands of the NotToken or
+// OrGroupToken at the 0-indexed index i in a query. Errors about the
+// operands are reported as errors about the token at index i.
+func resolveOperands(ctx context.Context, c *Client, i int, operands []PBToken) ([]PBToken, []TokenError, error) {
+	q, errs, err := Resolve(ctx, c, PBTokens(operands))
+	if err != nil {
+		return nil, nil, err
+	}
+	for j := range errs {
+		errs[j].Index = int32(i + 1)
+	}
+	return q.Tokens, errs, nil
+}
+
+// resolveAnyToken resolves an AnyToken to a RepoToken (if it
+// uniquely identifies a repository), to the token type indicated by
+// its prefix (if any), or to a Term. If it is ambiguous, a TokenError
+// is returned.
+func resolveAnyToken(ctx context.Context, c *Client, tok AnyToken) (Token, error) {
+	s := string(tok)
+	if s == "" {
+		return Term(""), nil
+	}
+	if t, err := parseToken(s); err != nil {
+		return nil, newTokenError(0, tok, err.Error())
+	} else if _, ok := t.(AnyToken); !ok {
+		return t, nil
+	}
+
+	// A token with a slash is probably a full repo URI.
+	if strings.Contains(s, "/") {
+		repo, err := c.Repos.Get(ctx, &RepoSpec{URI: s})
+		if err == nil {
+			return RepoToken{URI: repo.URI, Repo: repo}, nil
+		} else if grpc.Code(err) != codes.NotFound {
+			return nil, err
+		}
+	}
+
+	// Otherwise, look for repos whose URI ends with the token (e.g.,
+	// "mux" or "gorilla/mux" for "github.com/gorilla/mux").
+	repos, err := c.Repos.List(ctx, &RepoListOptions{Query: s})
+	if err != nil {
+		return nil, err
+	}
+	var matches []*Repo
+	for _, repo := range repos.Repos {
+		if repo.URI == s || strings.HasSuffix(repo.URI, "/"+s) {
+			matches = append(matches, repo)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Term(s), nil
+	case 1:
+		return RepoToken{URI: matches[0].URI, Repo: matches[0]}, nil
+	default:
+		uris := make([]string, len(matches))
+		for i, repo := range matches {
+			uris[i] = repo.URI
+		}
+		return nil, newTokenError(0, tok, fmt.Sprintf("ambiguous repository %q matches %s", s, strings.Join(uris, ", ")))
+	}
+}
@@ -0,0 +1,124 @@
+package sourcegraph
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+)
+
+type resolveReposClient struct {
+	ReposClient
+	repos   []*Repo
+	commits map[string]*vcs.Commit // keyed on "repo@rev"
+}
+
+func (c *resolveReposClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*Repo, error) {
+	for _, repo := range c.repos {
+		if repo.URI == in.URI {
+			return repo, nil
+		}
+	}
+	return nil, grpc.Errorf(codes.NotFound, "repo %s not found", in.URI)
+}
+
+func (c *resolveReposClient) List(ctx context.Context, in *RepoListOptions, opts ...grpc.CallOption) (*RepoList, error) {
+	return &RepoList{Repos: c.repos}, nil
+}
+
+func (c *resolveReposClient) GetCommit(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*vcs.Commit, error) {
+	if commit, ok := c.commits[in.URI+"@"+in.Rev]; ok {
+		return commit, nil
+	}
+	return nil, grpc.Errorf(codes.NotFound, "commit %s@%s not found", in.URI, in.Rev)
+}
+
+type resolveUsersClient struct {
+	UsersClient
+	users []*User
+}
+
+func (c *resolveUsersClient) Get(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*User, error) {
+	for _, user := range c.users {
+		if user.Login == in.Login {
+			return user, nil
+		}
+	}
+	return nil, grpc.Errorf(codes.NotFound, "user %s not found", in.Login)
+}
+
+func TestResolve(t *testing.T) {
+	var (
+		mux    = &Repo{URI: "github.com/gorilla/mux"}
+		foo1   = &Repo{URI: "a.com/foo"}
+		foo2   = &Repo{URI: "b.com/foo"}
+		alice  = &User{Login: "alice"}
+		commit = &vcs.Commit{ID: "c"}
+	)
+	c := &Client{
+		Repos: &resolveReposClient{
+			repos:   []*Repo{mux, foo1, foo2},
+			commits: map[string]*vcs.Commit{"github.com/gorilla/mux@v": commit},
+		},
+		Users: &resolveUsersClient{users: []*User{alice}},
+	}
+
+	tests := []struct {
+		toks       Tokens
+		wantToks   Tokens
+		wantErrIdx []int32
+	}{
+		{
+			toks:     Tokens{AnyToken("github.com/gorilla/mux"), RevToken{Rev: "v"}},
+			wantToks: Tokens{RepoToken{URI: mux.URI, Repo: mux}, RevToken{Rev: "v", Commit: commit}},
+		},
+		{
+			toks:     Tokens{AnyToken("mux"), AnyToken("bar")},
+			wantToks: Tokens{RepoToken{URI: mux.URI, Repo: mux}, Term("bar")},
+		},
+		{
+			toks:       Tokens{AnyToken("foo")},
+			wantToks:   Tokens{Term("foo")},
+			wantErrIdx: []int32{1},
+		},
+		{
+			toks:     Tokens{AnyToken("@alice"), UserToken{Login: "alice"}},
+			wantToks: Tokens{UserToken{Login: "alice", User: alice}, UserToken{Login: "alice", User: alice}},
+		},
+		{
+			toks:       Tokens{UserToken{Login: "bob"}, RevToken{Rev: "v"}, RepoToken{URI: "a.com/bar"}},
+			wantToks:   Tokens{UserToken{Login: "bob"}, RevToken{Rev: "v"}, RepoToken{URI: "a.com/bar"}},
+			wantErrIdx: []int32{1, 2, 3},
+		},
+		{
+			toks:       Tokens{NewNotToken(AnyToken("@bob")), NewOrGroupToken(AnyToken("mux"), AnyToken("bar"))},
+			wantToks:   Tokens{NewNotToken(UserToken{Login: "bob"}), NewOrGroupToken(RepoToken{URI: mux.URI, Repo: mux}, Term("bar"))},
+			wantErrIdx: []int32{1},
+		},
+		{
+			toks:       Tokens{AnyToken("mux"), RevToken{Rev: "x"}},
+			wantToks:   Tokens{RepoToken{URI: mux.URI, Repo: mux}, RevToken{Rev: "x"}},
+			wantErrIdx: []int32{2},
+		},
+	}
+	for _, test := range tests {
+		q, errs, err := Resolve(context.Background(), c, test.toks)
+		if err != nil {
+			t.Errorf("%v: %s", test.toks, err)
+			continue
+		}
+		if toks := PBTokens(q.Tokens); !reflect.DeepEqual(toks, test.wantToks) {
+			t.Errorf("%v: got tokens %v, want %v", test.toks, toks, test.wantToks)
+		}
+		var errIdx []int32
+		for _, e := range errs {
+			errIdx = append(errIdx, e.Index)
+		}
+		if !reflect.DeepEqual(errIdx, test.wantErrIdx) {
+			t.Errorf("%v: got errors %v, want errors at indexes %v", test.toks, errs, test.wantErrIdx)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package sourcegraph
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+// maxSuggestionsPerKind is the maximum number of suggestions of each
+// kind (e.g., adding a revision) that Suggest returns.
+const maxSuggestionsPerKind = 3
+
+// Suggest returns follow-up queries for the (resolved) query given by
+// toks, using candidates from src in the order that src returns them:
+//
+//   - If the query has no RepoToken, the query restricted to each of
+//     a few repositories.
+//   - If the query has a RepoToken but no RevToken, the query at a few
+//     of the repository's revisions.
+//   - If the query has a RepoToken but no UnitToken, the query
+//     narrowed to a few of the repository revision's source units.
+//   - If the query has Terms (and no RegexpToken), the query searching
+//     text matching the terms instead of definitions named by them.
+//
+// The Description of each Suggestion is generated by Describe.
+func Suggest(ctx context.Context, src CompletionSource, toks Tokens) (*SuggestionList, error) {
+	var list SuggestionList
+	add := func(toks Tokens) {
+		list.Suggestions = append(list.Suggestions, &Suggestion{
+			Query:       PBTokensWrap(toks),
+			QueryString: Join(toks).Text,
+			Description: Describe(toks),
+		})
+	}
+
+	repoIndex := -1
+	var hasRev, hasUnit, hasRegexp bool
+	for i, tok := range toks {
+		switch tok.(type) {
+		case RepoToken:
+			if repoIndex == -1 {
+				repoIndex = i
+			}
+		case RevToken:
+			hasRev = true
+		case UnitToken:
+			hasUnit = true
+		case RegexpToken:
+			hasRegexp = true
+		}
+	}
+
+	if repoIndex == -1 {
+		uris, err := src.Repos(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, uri := range firstN(uris, maxSuggestionsPerKind) {
+			add(append(Tokens{RepoToken{URI: uri}}, toks...))
+		}
+	} else {
+		repo := toks[repoIndex].(RepoToken)
+		repoRev := RepoRevSpec{RepoSpec: repo.Spec()}
+		if hasRev {
+			if repoIndex+1 < len(toks) {
+				if rev, ok := toks[repoIndex+1].(RevToken); ok {
+					repoRev.Rev = rev.Rev
+				}
+			}
+		} else {
+			revs, err := src.Revs(ctx, repo.Spec(), "")
+			if err != nil {
+				return nil, err
+			}
+			for _, rev := range firstN(revs, maxSuggestionsPerKind) {
+				withRev := make(Tokens, 0, len(toks)+1)
+				withRev = append(withRev, toks[:repoIndex+1]...)
+				withRev = append(withRev, RevToken{Rev: rev})
+				withRev = append(withRev, toks[repoIndex+1:]...)
+				add(withRev)
+			}
+		}
+
+		if !hasUnit {
+			units, err := src.Units(ctx, repoRev, "")
+			if err != nil {
+				return nil, err
+			}
+			if len(units) > maxSuggestionsPerKind {
+				units = units[:maxSuggestionsPerKind]
+			}
+			for _, unit := range units {
+				add(append(toks[:len(toks):len(toks)], unit))
+			}
+		}
+	}
+
+	if !hasRegexp {
+		if textToks := searchTextInstead(toks); textToks != nil {
+			add(textToks)
+		}
+	}
+
+	return &list, nil
+}
+
+// searchTextInstead returns toks with its Terms (and AnyTokens and
+// ExactPhraseTokens) replaced by a RegexpToken that matches them
+// literally, or nil if toks has no such tokens.
+func searchTextInstead(toks Tokens) Tokens {
+	var (
+		words []string
+		first = -1
+		rest  Tokens
+	)
+	for _, tok := range toks {
+		var word string
+		switch t := tok.(type) {
+		case Term:
+			word = string(t)
+		case AnyToken:
+			word = string(t)
+		case ExactPhraseToken:
+			word = t.Phrase
+		default:
+			rest = append(rest, tok)
+			continue
+		}
+		if word == "" {
+			continue
+		}
+		if first == -1 {
+			first = len(rest)
+		}
+		words = append(words, word)
+	}
+	if len(words) == 0 {
+		return nil
+	}
+
+	re := RegexpToken{Pattern: regexp.QuoteMeta(strings.Join(words, " "))}
+	textToks := make(Tokens, 0, len(rest)+1)
+	textToks = append(textToks, rest[:first]...)
+	textToks = append(textToks, re)
+	textToks = append(textToks, rest[first:]...)
+	return textToks
+}
+
+func firstN(s []string, n int) []string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,44 @@
+package sourcegraph
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestSuggest(t *testing.T) {
+	src := &completionSource{
+		repos: []string{"github.com/golang/go", "github.com/gorilla/mux", "github.com/a/b", "github.com/c/d"},
+		revs:  []string{"master", "release-branch.go1.5"},
+		units: []UnitToken{{Name: "net/http", UnitType: "GoPackage"}, {Name: "fmt", UnitType: "GoPackage"}},
+	}
+	var (
+		repo = RepoToken{URI: "github.com/golang/go"}
+		rev  = RevToken{Rev: "master"}
+		pkg  = UnitToken{Name: "net/http", UnitType: "GoPackage"}
+	)
+	tests := []Tokens{
+		nil,
+		{Term("Foo")},
+		{repo},
+		{repo, Term("Foo")},
+		{repo, rev, Term("Foo")},
+		{repo, rev, pkg, Term("Foo"), ExactPhraseToken{Phrase: "a b"}},
+		{repo, rev, pkg, RegexpToken{Pattern: "Fo+"}},
+	}
+
+	var buf bytes.Buffer
+	for _, toks := range tests {
+		list, err := Suggest(context.Background(), src, toks)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&buf, "%s\n", Join(toks).Text)
+		for _, s := range list.Suggestions {
+			fmt.Fprintf(&buf, "\t%s\n\t\t%s\n", s.QueryString, s.Description)
+		}
+	}
+	checkGolden(t, "suggest.golden", buf.Bytes())
+}
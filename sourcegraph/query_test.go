@@ -1,6 +1,9 @@
 package sourcegraph
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestJoin(t *testing.T) {
 	tests := []struct {
@@ -23,3 +26,154 @@ func TestJoin(t *testing.T) {
 		}
 	}
 }
+
+func TestTokenize(t *testing.T) {
+	tests := map[string]Tokens{
+		"":            nil,
+		"  ":          nil,
+		"a":           Tokens{AnyToken("a")},
+		"a  b":        Tokens{AnyToken("a"), AnyToken("b")},
+		`"a b"`:       Tokens{Term("a b")},
+		`"a"`:         Tokens{Term("a")},
+		`""`:          Tokens{Term("")},
+		`"a "b" c`:    Tokens{Term(`a "b`), AnyToken("c")},
+		`a"b`:         Tokens{AnyToken(`a"b`)},
+		":v":          Tokens{RevToken{Rev: "v"}},
+		":":           Tokens{RevToken{}},
+		"~u":          Tokens{UnitToken{Name: "u"}},
+		"~u@t":        Tokens{UnitToken{Name: "u", UnitType: "t"}},
+		"~a@b@t":      Tokens{UnitToken{Name: "a@b", UnitType: "t"}},
+		"/p/q":        Tokens{FileToken{Path: "p/q"}},
+		"@u":          Tokens{UserToken{Login: "u"}},
+		"'a b'":       Tokens{ExactPhraseToken{Phrase: "a b"}},
+		"-/vendor":    Tokens{NewNotToken(FileToken{Path: "vendor"})},
+		`-"a b"`:      Tokens{NewNotToken(Term("a b"))},
+		"a OR b":      Tokens{NewOrGroupToken(AnyToken("a"), AnyToken("b"))},
+		"a OR b OR c": Tokens{NewOrGroupToken(AnyToken("a"), AnyToken("b"), AnyToken("c"))},
+		"x a OR b y":  Tokens{AnyToken("x"), NewOrGroupToken(AnyToken("a"), AnyToken("b")), AnyToken("y")},
+		"(a OR :v)":   Tokens{NewOrGroupToken(AnyToken("a"), RevToken{Rev: "v"})},
+		"(a)":         Tokens{NewOrGroupToken(AnyToken("a"))},
+		"()":          Tokens{NewOrGroupToken()},
+		"lang:go":     Tokens{LangToken{Lang: "go"}},
+		"re:^a.*b$":   Tokens{RegexpToken{Pattern: "^a.*b$"}},
+		`re:"a b"`:    Tokens{RegexpToken{Pattern: "a b"}},
+		`(re:"a\)" OR lang:go)`: Tokens{
+			NewOrGroupToken(RegexpToken{Pattern: `a\)`}, LangToken{Lang: "go"}),
+		},
+		"-(a OR 'b c') d": Tokens{
+			NewNotToken(NewOrGroupToken(AnyToken("a"), ExactPhraseToken{Phrase: "b c"})),
+			AnyToken("d"),
+		},
+		"r :v ~u@t /p @u": Tokens{
+			AnyToken("r"),
+			RevToken{Rev: "v"},
+			UnitToken{Name: "u", UnitType: "t"},
+			FileToken{Path: "p"},
+			UserToken{Login: "u"},
+		},
+	}
+	for text, want := range tests {
+		toks, err := Tokenize(RawQuery{Text: text})
+		if err != nil {
+			t.Errorf("%q: %s", text, err)
+			continue
+		}
+		if !reflect.DeepEqual(toks, want) {
+			t.Errorf("%q: got %#v, want %#v", text, toks, want)
+		}
+	}
+}
+
+func TestTokenize_Join(t *testing.T) {
+	tests := []Tokens{
+		{Term("a b"), Term(":a"), Term("@a b"), Term(`"a`), Term(`a "b`)},
+		{AnyToken("r"), RevToken{Rev: "v"}, UnitToken{Name: "u"}, UnitToken{Name: "u", UnitType: "t"}},
+		{FileToken{Path: "p/q"}, UserToken{Login: "u"}},
+		{LangToken{Lang: "go"}, RegexpToken{Pattern: `a\s+b`}, RegexpToken{Pattern: "a b"}, RegexpToken{Pattern: `"a`}},
+		{NewOrGroupToken(RegexpToken{Pattern: `a\)`}), Term("lang:go"), Term("re:a")},
+		{Term("OR"), Term("-a"), Term("'a"), Term("(a"), Term("a)"), ExactPhraseToken{Phrase: "a b"}},
+		{
+			NewNotToken(FileToken{Path: "vendor"}),
+			NewOrGroupToken(AnyToken("a"), NewNotToken(Term("b c")), NewOrGroupToken(Term("d)"))),
+		},
+	}
+	for _, tokens := range tests {
+		toks, err := Tokenize(Join(tokens))
+		if err != nil {
+			t.Errorf("%v: %s", tokens, err)
+			continue
+		}
+		if !reflect.DeepEqual(toks, tokens) {
+			t.Errorf("got %#v, want %#v", toks, tokens)
+		}
+	}
+}
+
+func TestTokenizeActive(t *testing.T) {
+	tests := []struct {
+		q    RawQuery
+		want int
+	}{
+		{RawQuery{Text: "", InsertionPoint: 0}, -1},
+		{RawQuery{Text: "a b", InsertionPoint: 0}, 0},
+		{RawQuery{Text: "a b", InsertionPoint: 1}, 0},
+		{RawQuery{Text: "a b", InsertionPoint: 2}, 1},
+		{RawQuery{Text: "a b", InsertionPoint: 3}, 1},
+		{RawQuery{Text: "a b", InsertionPoint: 4}, -1},
+		{RawQuery{Text: "a  b", InsertionPoint: 2}, -1},
+		{RawQuery{Text: `"é b" c`, InsertionPoint: 5}, 0},
+		{RawQuery{Text: `"é b" c`, InsertionPoint: 6}, 1},
+		{RawQuery{Text: "a OR b c", InsertionPoint: 5}, 0},
+		{RawQuery{Text: "a OR b c", InsertionPoint: 7}, 1},
+	}
+	for _, test := range tests {
+		_, active, err := TokenizeActive(test.q)
+		if err != nil {
+			t.Errorf("%+v: %s", test.q, err)
+			continue
+		}
+		if active != test.want {
+			t.Errorf("%+v: got active token %d, want %d", test.q, active, test.want)
+		}
+	}
+}
+
+func TestTokenize_errors(t *testing.T) {
+	tests := map[string]struct {
+		index int32
+		token Token
+	}{
+		`"a`:       {1, Term("a")},
+		`a "b c`:   {2, Term("b c")},
+		`a b ~@t`:  {3, AnyToken("~@t")},
+		`a ~u@`:    {2, AnyToken("~u@")},
+		`a 'b`:     {2, ExactPhraseToken{Phrase: "b"}},
+		`a -`:      {2, AnyToken("-")},
+		`OR a`:     {1, AnyToken("OR")},
+		`a OR`:     {2, AnyToken("OR")},
+		`a (b`:     {2, AnyToken("(b")},
+		`(a b) c`:  {1, AnyToken("(a b)")},
+		`(a ~@t)`:  {1, AnyToken("~@t")},
+		`re:(`:     {1, AnyToken("re:(")},
+		`re:"a`:    {1, RegexpToken{Pattern: "a"}},
+		`re:"(" a`: {1, RegexpToken{Pattern: "("}},
+	}
+	for text, want := range tests {
+		toks, err := Tokenize(RawQuery{Text: text})
+		if err == nil {
+			t.Errorf("%q: got tokens %v, want error", text, toks)
+			continue
+		}
+		terr, ok := err.(TokenError)
+		if !ok {
+			t.Errorf("%q: got error %T, want TokenError", text, err)
+			continue
+		}
+		if terr.Index != want.index {
+			t.Errorf("%q: got error Index %d, want %d", text, terr.Index, want.index)
+		}
+		if tok := terr.Token.GetQueryToken(); !reflect.DeepEqual(tok, want.token) {
+			t.Errorf("%q: got error Token %#v, want %#v", text, tok, want.token)
+		}
+	}
+}
@@ -20,7 +20,9 @@ type Token interface {
 type Term string
 
 func (t Term) Token() string {
-	if strings.Contains(string(t), " ") {
+	// Quote the term if it would otherwise be split into multiple
+	// tokens or tokenized as a non-Term token (e.g., ":foo" or "OR").
+	if strings.ContainsAny(string(t), " )") || (len(t) > 0 && strings.IndexByte(tokenPrefixes, t[0]) != -1) || t == orOperator || fieldPrefix(string(t)) != "" {
 		return `"` + string(t) + `"`
 	}
 	return string(t)
@@ -55,6 +57,37 @@ func (t FileToken) Token() string { return "/" + path.Clean(t.Path) }
 
 func (t UserToken) Token() string { return "@" + t.Login }
 
+// NewNotToken returns a NotToken that negates tok.
+func NewNotToken(tok Token) NotToken {
+	return NotToken{Operand: PBTokenWrap(tok)}
+}
+
+func (t NotToken) Token() string { return "-" + t.Operand.GetQueryToken().Token() }
+
+// NewOrGroupToken returns an OrGroupToken whose operands are toks.
+func NewOrGroupToken(toks ...Token) OrGroupToken {
+	return OrGroupToken{Operands: PBTokensWrap(toks)}
+}
+
+func (t OrGroupToken) Token() string {
+	ops := make([]string, len(t.Operands))
+	for i, op := range t.Operands {
+		ops[i] = op.GetQueryToken().Token()
+	}
+	return "(" + strings.Join(ops, " "+orOperator+" ") + ")"
+}
+
+func (t ExactPhraseToken) Token() string { return "'" + t.Phrase + "'" }
+
+func (t LangToken) Token() string { return langField + t.Lang }
+
+func (t RegexpToken) Token() string {
+	if strings.ContainsAny(t.Pattern, " )") || strings.HasPrefix(t.Pattern, `"`) {
+		return regexpField + `"` + t.Pattern + `"`
+	}
+	return regexpField + t.Pattern
+}
+
 // Tokens wraps a list of tokens and adds some helper methods. It also
 // serializes to JSON with "Type" fields added to each token and
 // deserializes that same JSON back into a typed list of tokens.
@@ -147,6 +180,16 @@ func (t *jsonToken) UnmarshalJSON(b []byte) error {
 		t.Token = &FileToken{}
 	case "UserToken":
 		t.Token = &UserToken{}
+	case "NotToken":
+		t.Token = &NotToken{}
+	case "OrGroupToken":
+		t.Token = &OrGroupToken{}
+	case "ExactPhraseToken":
+		t.Token = &ExactPhraseToken{}
+	case "LangToken":
+		t.Token = &LangToken{}
+	case "RegexpToken":
+		t.Token = &RegexpToken{}
 	default:
 		return fmt.Errorf("unmarshal Tokens: unrecognized Type %q", typ.Type)
 	}
@@ -191,6 +234,16 @@ func (t *PBToken) GetQueryToken() Token {
 		return *userToken
 	} else if unitToken := t.GetUnitToken(); unitToken != nil {
 		return *unitToken
+	} else if notToken := t.GetNotToken(); notToken != nil {
+		return *notToken
+	} else if orGroupToken := t.GetOrGroupToken(); orGroupToken != nil {
+		return *orGroupToken
+	} else if exactPhraseToken := t.GetExactPhraseToken(); exactPhraseToken != nil {
+		return *exactPhraseToken
+	} else if langToken := t.GetLangToken(); langToken != nil {
+		return *langToken
+	} else if regexpToken := t.GetRegexpToken(); regexpToken != nil {
+		return *regexpToken
 	} else {
 		// empty
 		return Term("")
@@ -216,6 +269,16 @@ func PBTokenWrap(t Token) PBToken {
 		pbToken = &PBToken_UserToken{UserToken: &t}
 	case UnitToken:
 		pbToken = &PBToken_UnitToken{UnitToken: &t}
+	case NotToken:
+		pbToken = &PBToken_NotToken{NotToken: &t}
+	case OrGroupToken:
+		pbToken = &PBToken_OrGroupToken{OrGroupToken: &t}
+	case ExactPhraseToken:
+		pbToken = &PBToken_ExactPhraseToken{ExactPhraseToken: &t}
+	case LangToken:
+		pbToken = &PBToken_LangToken{LangToken: &t}
+	case RegexpToken:
+		pbToken = &PBToken_RegexpToken{RegexpToken: &t}
 	case *RepoToken:
 		pbToken = &PBToken_RepoToken{RepoToken: t}
 	case *RevToken:
@@ -226,6 +289,16 @@ func PBTokenWrap(t Token) PBToken {
 		pbToken = &PBToken_UserToken{UserToken: t}
 	case *UnitToken:
 		pbToken = &PBToken_UnitToken{UnitToken: t}
+	case *NotToken:
+		pbToken = &PBToken_NotToken{NotToken: t}
+	case *OrGroupToken:
+		pbToken = &PBToken_OrGroupToken{OrGroupToken: t}
+	case *ExactPhraseToken:
+		pbToken = &PBToken_ExactPhraseToken{ExactPhraseToken: t}
+	case *LangToken:
+		pbToken = &PBToken_LangToken{LangToken: t}
+	case *RegexpToken:
+		pbToken = &PBToken_RegexpToken{RegexpToken: t}
 	default:
 		// empty
 	}
@@ -15,6 +15,11 @@ func TestTokens_JSON(t *testing.T) {
 		RevToken{Rev: "v"},
 		FileToken{Path: "p"},
 		UserToken{Login: "u"},
+		NewNotToken(FileToken{Path: "p"}),
+		NewOrGroupToken(RepoToken{URI: "r"}, Term("t")),
+		ExactPhraseToken{Phrase: "a b"},
+		LangToken{Lang: "go"},
+		RegexpToken{Pattern: "^a"},
 	}
 
 	b, err := json.MarshalIndent(tokens, "", "  ")
@@ -50,6 +55,38 @@ func TestTokens_JSON(t *testing.T) {
   {
     "login": "u",
     "Type": "UserToken"
+  },
+  {
+    "operand": {
+      "path": "p",
+      "Type": "FileToken"
+    },
+    "Type": "NotToken"
+  },
+  {
+    "operands": [
+      {
+        "uri": "r",
+        "Type": "RepoToken"
+      },
+      {
+        "String": "t",
+        "Type": "Term"
+      }
+    ],
+    "Type": "OrGroupToken"
+  },
+  {
+    "phrase": "a b",
+    "Type": "ExactPhraseToken"
+  },
+  {
+    "lang": "go",
+    "Type": "LangToken"
+  },
+  {
+    "pattern": "^a",
+    "Type": "RegexpToken"
   }
 ]`
 	if string(b) != wantJSON {
@@ -95,6 +132,12 @@ func TestPBToken_Token(t *testing.T) {
 		{PBTokenWrap(&RevToken{Rev: "v"}), RevToken{Rev: "v"}},
 		{PBTokenWrap(&FileToken{Path: "p"}), FileToken{Path: "p"}},
 		{PBTokenWrap(&UserToken{Login: "u"}), UserToken{Login: "u"}},
+		{PBTokenWrap(NewNotToken(Term("t"))), NewNotToken(Term("t"))},
+		{PBTokenWrap(NewOrGroupToken(Term("t"))), NewOrGroupToken(Term("t"))},
+		{PBTokenWrap(ExactPhraseToken{Phrase: "p"}), ExactPhraseToken{Phrase: "p"}},
+		{PBTokenWrap(&ExactPhraseToken{Phrase: "p"}), ExactPhraseToken{Phrase: "p"}},
+		{PBTokenWrap(LangToken{Lang: "go"}), LangToken{Lang: "go"}},
+		{PBTokenWrap(&RegexpToken{Pattern: "^a"}), RegexpToken{Pattern: "^a"}},
 
 		{PBTokenWrap(nil), Term("")},
 		{PBTokenWrap(Term("")), Term("")},
@@ -119,6 +162,11 @@ func TestPBTokens_JSON(t *testing.T) {
 		PBTokenWrap(&RevToken{Rev: "v"}),
 		PBTokenWrap(&FileToken{Path: "p"}),
 		PBTokenWrap(&UserToken{Login: "u"}),
+		PBTokenWrap(NewNotToken(UserToken{Login: "u"})),
+		PBTokenWrap(NewOrGroupToken(Term("a"), Term("b"))),
+		PBTokenWrap(ExactPhraseToken{Phrase: "p"}),
+		PBTokenWrap(LangToken{Lang: "go"}),
+		PBTokenWrap(RegexpToken{Pattern: "^a"}),
 	}
 
 	b, err := json.Marshal(pbtoks)
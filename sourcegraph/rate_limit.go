@@ -0,0 +1,345 @@
+package sourcegraph
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// RateLimits limit the rate and concurrency of API client calls, so
+// that clients (such as indexing jobs) don't overwhelm the server.
+// Calls wait until they are allowed by the rate limit of their method
+// (or service) and until fewer than MaxInFlight calls are in
+// progress. A streaming call is in progress until its stream ends (or
+// its context is done).
+//
+// When a call fails with codes.ResourceExhausted, subsequent calls to
+// the same method back off (wait) for an exponentially increasing
+// delay, and the call is retried if the method is idempotent (see
+// IsIdempotent). Clients constructed with NewClientFromContext that
+// also have a RetryPolicy don't retry such calls again.
+//
+// Use WithRateLimits or ClientOptions.RateLimits to make clients use
+// rate limits. RateLimits may be shared by multiple clients, which
+// then share the limits. A RateLimits must not be modified after it is
+// first used.
+type RateLimits struct {
+	// Rates maps a method (e.g., "Defs.ListRefs") or service (e.g.,
+	// "RepoTree") to its rate limit. A method's rate limit takes
+	// precedence over its service's; the methods of a service share
+	// the service's rate limit. Calls to methods with no rate limit
+	// aren't rate limited.
+	Rates map[string]Rate
+
+	// MaxInFlight is the maximum number of calls (to all methods) in
+	// progress at once. If zero, the number is not limited.
+	MaxInFlight int
+
+	// InitialBackoff is the delay after the first call to a method
+	// that fails with codes.ResourceExhausted. It doubles after each
+	// consecutive such failure. If zero, 1 second is used.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay after a call fails with
+	// codes.ResourceExhausted. If zero, 30 seconds is used.
+	MaxBackoff time.Duration
+
+	// MaxRetries is the maximum number of times a call to an
+	// idempotent method that fails with codes.ResourceExhausted is
+	// retried. If zero, 3 is used; if negative, such calls are not
+	// retried (but later calls still back off).
+	MaxRetries int
+
+	mu       sync.Mutex
+	buckets  map[string]*tokenBucket // keyed on Rates key
+	backoffs map[string]*backoff     // keyed on method
+	inFlight chan struct{}           // semaphore (nil if MaxInFlight is zero)
+}
+
+// A Rate is the rate limit of a method or service. Calls are allowed
+// at an average rate of PerSecond calls per second, with bursts of up
+// to Burst calls.
+type Rate struct {
+	PerSecond float64
+	Burst     int // if less than 1, 1 is used
+}
+
+// WithRateLimits returns a copy of the parent context whose API
+// clients (constructed using NewClientFromContext) are limited by
+// limits.
+func WithRateLimits(parent context.Context, limits *RateLimits) context.Context {
+	return context.WithValue(parent, rateLimitsKey, limits)
+}
+
+// rateLimitsFromContext returns the rate limits previously set in the
+// context by WithRateLimits, or nil if none were set.
+func rateLimitsFromContext(ctx context.Context) *RateLimits {
+	limits, _ := ctx.Value(rateLimitsKey).(*RateLimits)
+	return limits
+}
+
+// do calls call when it is allowed by the limits, and retries it if
+// it fails with codes.ResourceExhausted and method is idempotent. The
+// method is the name of the RPC method (e.g., "Repos.Get").
+func (l *RateLimits) do(ctx context.Context, method string, call func() error) error {
+	if l == nil {
+		return call()
+	}
+
+	for retries := 0; ; retries++ {
+		if err := l.wait(ctx, method); err != nil {
+			return err
+		}
+		release, err := l.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		err = call()
+		release()
+
+		if !l.record(method, err) || retries >= l.maxRetries() || !IsIdempotent(method) {
+			return err
+		}
+	}
+}
+
+// startStream blocks until a streaming call to method is allowed by
+// the limits. The call holds its in-flight slot until the returned
+// func is called with the stream's final error (nil if it ended with
+// io.EOF) or until ctx is done, whichever comes first. Streaming calls
+// aren't retried.
+func (l *RateLimits) startStream(ctx context.Context, method string) (end func(error), err error) {
+	if l == nil {
+		return func(error) {}, nil
+	}
+	if err := l.wait(ctx, method); err != nil {
+		return nil, err
+	}
+	release, err := l.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var once sync.Once
+	done := make(chan struct{})
+	end = func(err error) {
+		once.Do(func() {
+			close(done)
+			release()
+			l.record(method, err)
+		})
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			end(ctx.Err())
+		case <-done:
+		}
+	}()
+	return end, nil
+}
+
+// record updates method's backoff after a call to it ended with err,
+// and reports whether err is codes.ResourceExhausted.
+func (l *RateLimits) record(method string, err error) (exhausted bool) {
+	if grpc.Code(err) != codes.ResourceExhausted {
+		l.backoff(method).reset()
+		return false
+	}
+	l.backoff(method).fail(l.initialBackoff(), l.maxBackoff())
+	return true
+}
+
+// wait blocks until a call to method is allowed by its backoff and
+// rate limit.
+func (l *RateLimits) wait(ctx context.Context, method string) error {
+	delay := l.backoff(method).remaining()
+	b := l.bucket(method)
+	if b != nil {
+		if d := b.reserve(time.Now()); d > delay {
+			delay = d
+		}
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(delay)
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		t.Stop()
+		if b != nil {
+			b.cancel()
+		}
+		return ctx.Err()
+	}
+}
+
+// acquire blocks until fewer than MaxInFlight calls are in progress.
+// The returned func must be called when the call is done.
+func (l *RateLimits) acquire(ctx context.Context) (release func(), err error) {
+	if l.MaxInFlight <= 0 {
+		return func() {}, nil
+	}
+	l.mu.Lock()
+	if l.inFlight == nil {
+		l.inFlight = make(chan struct{}, l.MaxInFlight)
+	}
+	sem := l.inFlight
+	l.mu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// bucket returns the token bucket for method's rate limit, or nil if
+// it has no rate limit.
+func (l *RateLimits) bucket(method string) *tokenBucket {
+	key := method
+	rate, ok := l.Rates[key]
+	if !ok {
+		if i := strings.Index(method, "."); i != -1 {
+			key = method[:i]
+			rate, ok = l.Rates[key]
+		}
+	}
+	if !ok {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.buckets == nil {
+		l.buckets = map[string]*tokenBucket{}
+	}
+	b := l.buckets[key]
+	if b == nil {
+		b = newTokenBucket(rate, time.Now())
+		l.buckets[key] = b
+	}
+	return b
+}
+
+func (l *RateLimits) backoff(method string) *backoff {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.backoffs == nil {
+		l.backoffs = map[string]*backoff{}
+	}
+	b := l.backoffs[method]
+	if b == nil {
+		b = new(backoff)
+		l.backoffs[method] = b
+	}
+	return b
+}
+
+func (l *RateLimits) initialBackoff() time.Duration {
+	if l.InitialBackoff == 0 {
+		return time.Second
+	}
+	return l.InitialBackoff
+}
+
+func (l *RateLimits) maxBackoff() time.Duration {
+	if l.MaxBackoff == 0 {
+		return 30 * time.Second
+	}
+	return l.MaxBackoff
+}
+
+func (l *RateLimits) maxRetries() int {
+	if l.MaxRetries == 0 {
+		return 3
+	}
+	return l.MaxRetries
+}
+
+// A tokenBucket implements a Rate. Each call takes a token from the
+// bucket, which is refilled at the rate's PerSecond rate.
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64 // tokens per second
+	capacity float64
+	tokens   float64 // negative if calls are waiting for tokens
+	last     time.Time
+}
+
+func newTokenBucket(rate Rate, now time.Time) *tokenBucket {
+	capacity := float64(rate.Burst)
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &tokenBucket{rate: rate.PerSecond, capacity: capacity, tokens: capacity, last: now}
+}
+
+// reserve takes a token from the bucket and returns how long the
+// caller must wait until the token is available.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rate <= 0 {
+		return 0
+	}
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that wasn't used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens++
+}
+
+// backoff is the backoff state of a method after calls to it failed
+// with codes.ResourceExhausted.
+type backoff struct {
+	mu    sync.Mutex
+	delay time.Duration // the delay after the last failure (0 if the last call succeeded)
+	until time.Time
+}
+
+func (b *backoff) remaining() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.until.Sub(time.Now())
+}
+
+func (b *backoff) fail(initial, max time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.delay == 0 {
+		b.delay = initial
+	} else {
+		b.delay *= 2
+	}
+	if b.delay > max {
+		b.delay = max
+	}
+	b.until = time.Now().Add(b.delay)
+}
+
+func (b *backoff) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.delay = 0
+}
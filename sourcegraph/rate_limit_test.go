@@ -0,0 +1,230 @@
+package sourcegraph
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRateLimits_rate(t *testing.T) {
+	l := &RateLimits{Rates: map[string]Rate{"Repos": {PerSecond: 20, Burst: 2}}}
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := l.do(context.Background(), "Repos.Get", func() error { return nil }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first 2 calls are a burst, and the next 2 wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("4 calls took %s, want at least 100ms", elapsed)
+	}
+
+	// Calls to other services aren't limited.
+	start = time.Now()
+	for i := 0; i < 10; i++ {
+		l.do(context.Background(), "Defs.Get", func() error { return nil })
+	}
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("unlimited calls took %s", elapsed)
+	}
+}
+
+func TestRateLimits_methodRate(t *testing.T) {
+	l := &RateLimits{Rates: map[string]Rate{
+		"Repos":     {PerSecond: 1},
+		"Repos.Get": {PerSecond: 1000, Burst: 10},
+	}}
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		l.do(context.Background(), "Repos.Get", func() error { return nil })
+	}
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("calls took %s, want method rate to take precedence", elapsed)
+	}
+}
+
+func TestRateLimits_cancel(t *testing.T) {
+	l := &RateLimits{Rates: map[string]Rate{"Repos.Get": {PerSecond: 0.1}}}
+	l.do(context.Background(), "Repos.Get", func() error { return nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	called := false
+	err := l.do(ctx, "Repos.Get", func() error { called = true; return nil })
+	if err != context.DeadlineExceeded {
+		t.Errorf("got err %v, want %v", err, context.DeadlineExceeded)
+	}
+	if called {
+		t.Error("call was made after the context was done")
+	}
+}
+
+func TestRateLimits_MaxInFlight(t *testing.T) {
+	l := &RateLimits{MaxInFlight: 2}
+
+	var (
+		mu                sync.Mutex
+		inFlight, maxSeen int
+		wg                sync.WaitGroup
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.do(context.Background(), "Repos.Get", func() error {
+				mu.Lock()
+				inFlight++
+				if inFlight > maxSeen {
+					maxSeen = inFlight
+				}
+				mu.Unlock()
+				time.Sleep(5 * time.Millisecond)
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+	if maxSeen != 2 {
+		t.Errorf("got max %d calls in flight, want 2", maxSeen)
+	}
+}
+
+func TestRateLimits_resourceExhausted(t *testing.T) {
+	l := &RateLimits{InitialBackoff: 20 * time.Millisecond, MaxRetries: 2}
+	exhausted := grpc.Errorf(codes.ResourceExhausted, "x")
+
+	// The call is retried after backing off.
+	calls := 0
+	start := time.Now()
+	err := l.do(context.Background(), "Repos.Get", func() error {
+		calls++
+		if calls == 1 {
+			return exhausted
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("calls took %s, want at least the backoff", elapsed)
+	}
+
+	// After MaxRetries, the error is returned.
+	calls = 0
+	err = l.do(context.Background(), "Repos.Get", func() error {
+		calls++
+		return exhausted
+	})
+	if grpc.Code(err) != codes.ResourceExhausted {
+		t.Errorf("got err %v, want ResourceExhausted", err)
+	}
+	if calls != 3 {
+		t.Errorf("got %d calls, want 3", calls)
+	}
+
+	// Other calls to the method back off, too.
+	start = time.Now()
+	l.do(context.Background(), "Repos.Get", func() error { return nil })
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("call took %s, want it to back off", elapsed)
+	}
+}
+
+func TestRateLimits_resourceExhausted_nonIdempotent(t *testing.T) {
+	l := &RateLimits{InitialBackoff: 20 * time.Millisecond}
+	exhausted := grpc.Errorf(codes.ResourceExhausted, "x")
+
+	// Calls to non-idempotent methods aren't retried.
+	calls := 0
+	err := l.do(context.Background(), "Repos.Create", func() error {
+		calls++
+		return exhausted
+	})
+	if err != exhausted {
+		t.Errorf("got err %v, want %v", err, exhausted)
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+
+	// But later calls to the method still back off.
+	start := time.Now()
+	l.do(context.Background(), "Repos.Create", func() error { return nil })
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("call took %s, want it to back off", elapsed)
+	}
+}
+
+func TestRateLimitedClient(t *testing.T) {
+	exhausted := grpc.Errorf(codes.ResourceExhausted, "x")
+	c := &RateLimitedReposClient{errReposClient{err: exhausted}, &RateLimits{InitialBackoff: time.Millisecond, MaxRetries: -1}}
+	if _, err := c.Get(context.Background(), &RepoSpec{}); err != exhausted {
+		t.Errorf("got err %v, want %v", err, exhausted)
+	}
+
+	// Nil Limits don't limit calls.
+	c.Limits = nil
+	if _, err := c.Get(context.Background(), &RepoSpec{}); err != exhausted {
+		t.Errorf("got err %v, want %v", err, exhausted)
+	}
+}
+
+func TestRateLimitedClient_stream(t *testing.T) {
+	c := &RateLimitedSearchClient{&testSearchClient{events: []*SearchEvent{{}}, err: io.EOF}, &RateLimits{MaxInFlight: 1}}
+	startStream := func(ctx context.Context) (Search_StreamSearchClient, error) {
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		return c.StreamSearch(ctx, &SearchOptions{})
+	}
+
+	// The stream holds its in-flight slot until it ends.
+	stream, err := c.StreamSearch(context.Background(), &SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := startStream(context.Background()); err != context.DeadlineExceeded {
+		t.Errorf("got err %v while another stream is in flight, want %v", err, context.DeadlineExceeded)
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := startStream(context.Background()); err != nil {
+		t.Errorf("got err %v after the stream ended", err)
+	}
+
+	// A stream whose context is done releases its slot.
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := c.StreamSearch(ctx, &SearchOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if _, err := startStream(context.Background()); err != nil {
+		t.Errorf("got err %v after the stream's context was canceled", err)
+	}
+
+	// Opening a stream takes a token from the rate limit.
+	c.Limits = &RateLimits{Rates: map[string]Rate{"Search.StreamSearch": {PerSecond: 0.1}}}
+	if _, err := startStream(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := startStream(context.Background()); err != context.DeadlineExceeded {
+		t.Errorf("got err %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,1448 @@
+// GENERATED CODE - DO NOT EDIT!
+//
+// Generated by:
+//
+//   go run gen/wrappers.go -kind ratelimit -o rate_limited_grpc.pb.go sourcegraph.pb.go
+//
+// Called via:
+//
+//   go generate
+//
+
+package sourcegraph
+
+import (
+	"io"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+	"sourcegraph.com/sourcegraph/srclib/unit"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+type RateLimitedAccessTokensClient struct {
+	AccessTokensClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedAccessTokensClient) Create(ctx context.Context, in *AccessTokensCreateOp, opts ...grpc.CallOption) (*NewAccessToken, error) {
+	var result *NewAccessToken
+	err := s.Limits.do(ctx, "AccessTokens.Create", func() error {
+		var err error
+		result, err = s.AccessTokensClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedAccessTokensClient) List(ctx context.Context, in *AccessTokensListOptions, opts ...grpc.CallOption) (*AccessTokenList, error) {
+	var result *AccessTokenList
+	err := s.Limits.do(ctx, "AccessTokens.List", func() error {
+		var err error
+		result, err = s.AccessTokensClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedAccessTokensClient) Revoke(ctx context.Context, in *AccessTokenSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "AccessTokens.Revoke", func() error {
+		var err error
+		result, err = s.AccessTokensClient.Revoke(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedAccountsClient struct {
+	AccountsClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedAccountsClient) Create(ctx context.Context, in *NewAccount, opts ...grpc.CallOption) (*UserSpec, error) {
+	var result *UserSpec
+	err := s.Limits.do(ctx, "Accounts.Create", func() error {
+		var err error
+		result, err = s.AccountsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedAccountsClient) RequestPasswordReset(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (*User, error) {
+	var result *User
+	err := s.Limits.do(ctx, "Accounts.RequestPasswordReset", func() error {
+		var err error
+		result, err = s.AccountsClient.RequestPasswordReset(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedAccountsClient) ResetPassword(ctx context.Context, in *NewPassword, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "Accounts.ResetPassword", func() error {
+		var err error
+		result, err = s.AccountsClient.ResetPassword(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedAccountsClient) Update(ctx context.Context, in *User, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "Accounts.Update", func() error {
+		var err error
+		result, err = s.AccountsClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedAuditLogClient struct {
+	AuditLogClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedAuditLogClient) List(ctx context.Context, in *AuditEventListOptions, opts ...grpc.CallOption) (*AuditEventList, error) {
+	var result *AuditEventList
+	err := s.Limits.do(ctx, "AuditLog.List", func() error {
+		var err error
+		result, err = s.AuditLogClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedAuthClient struct {
+	AuthClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedAuthClient) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest, opts ...grpc.CallOption) (*AuthorizationCode, error) {
+	var result *AuthorizationCode
+	err := s.Limits.do(ctx, "Auth.GetAuthorizationCode", func() error {
+		var err error
+		result, err = s.AuthClient.GetAuthorizationCode(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedAuthClient) GetAccessToken(ctx context.Context, in *AccessTokenRequest, opts ...grpc.CallOption) (*AccessTokenResponse, error) {
+	var result *AccessTokenResponse
+	err := s.Limits.do(ctx, "Auth.GetAccessToken", func() error {
+		var err error
+		result, err = s.AuthClient.GetAccessToken(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedAuthClient) Identify(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*AuthInfo, error) {
+	var result *AuthInfo
+	err := s.Limits.do(ctx, "Auth.Identify", func() error {
+		var err error
+		result, err = s.AuthClient.Identify(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedAuthClient) GetPermissions(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*UserPermissions, error) {
+	var result *UserPermissions
+	err := s.Limits.do(ctx, "Auth.GetPermissions", func() error {
+		var err error
+		result, err = s.AuthClient.GetPermissions(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedBuildsClient struct {
+	BuildsClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedBuildsClient) Get(ctx context.Context, in *BuildSpec, opts ...grpc.CallOption) (*Build, error) {
+	var result *Build
+	err := s.Limits.do(ctx, "Builds.Get", func() error {
+		var err error
+		result, err = s.BuildsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedBuildsClient) GetRepoBuildInfo(ctx context.Context, in *BuildsGetRepoBuildInfoOp, opts ...grpc.CallOption) (*RepoBuildInfo, error) {
+	var result *RepoBuildInfo
+	err := s.Limits.do(ctx, "Builds.GetRepoBuildInfo", func() error {
+		var err error
+		result, err = s.BuildsClient.GetRepoBuildInfo(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedBuildsClient) List(ctx context.Context, in *BuildListOptions, opts ...grpc.CallOption) (*BuildList, error) {
+	var result *BuildList
+	err := s.Limits.do(ctx, "Builds.List", func() error {
+		var err error
+		result, err = s.BuildsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedBuildsClient) Create(ctx context.Context, in *BuildsCreateOp, opts ...grpc.CallOption) (*Build, error) {
+	var result *Build
+	err := s.Limits.do(ctx, "Builds.Create", func() error {
+		var err error
+		result, err = s.BuildsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedBuildsClient) Update(ctx context.Context, in *BuildsUpdateOp, opts ...grpc.CallOption) (*Build, error) {
+	var result *Build
+	err := s.Limits.do(ctx, "Builds.Update", func() error {
+		var err error
+		result, err = s.BuildsClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedBuildsClient) ListBuildTasks(ctx context.Context, in *BuildsListBuildTasksOp, opts ...grpc.CallOption) (*BuildTaskList, error) {
+	var result *BuildTaskList
+	err := s.Limits.do(ctx, "Builds.ListBuildTasks", func() error {
+		var err error
+		result, err = s.BuildsClient.ListBuildTasks(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedBuildsClient) CreateTasks(ctx context.Context, in *BuildsCreateTasksOp, opts ...grpc.CallOption) (*BuildTaskList, error) {
+	var result *BuildTaskList
+	err := s.Limits.do(ctx, "Builds.CreateTasks", func() error {
+		var err error
+		result, err = s.BuildsClient.CreateTasks(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedBuildsClient) UpdateTask(ctx context.Context, in *BuildsUpdateTaskOp, opts ...grpc.CallOption) (*BuildTask, error) {
+	var result *BuildTask
+	err := s.Limits.do(ctx, "Builds.UpdateTask", func() error {
+		var err error
+		result, err = s.BuildsClient.UpdateTask(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedBuildsClient) GetLog(ctx context.Context, in *BuildsGetLogOp, opts ...grpc.CallOption) (*LogEntries, error) {
+	var result *LogEntries
+	err := s.Limits.do(ctx, "Builds.GetLog", func() error {
+		var err error
+		result, err = s.BuildsClient.GetLog(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedBuildsClient) GetTaskLog(ctx context.Context, in *BuildsGetTaskLogOp, opts ...grpc.CallOption) (*LogEntries, error) {
+	var result *LogEntries
+	err := s.Limits.do(ctx, "Builds.GetTaskLog", func() error {
+		var err error
+		result, err = s.BuildsClient.GetTaskLog(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedBuildsClient) DequeueNext(ctx context.Context, in *BuildsDequeueNextOp, opts ...grpc.CallOption) (*Build, error) {
+	var result *Build
+	err := s.Limits.do(ctx, "Builds.DequeueNext", func() error {
+		var err error
+		result, err = s.BuildsClient.DequeueNext(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedChangesetsClient struct {
+	ChangesetsClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedChangesetsClient) Create(ctx context.Context, in *ChangesetCreateOp, opts ...grpc.CallOption) (*Changeset, error) {
+	var result *Changeset
+	err := s.Limits.do(ctx, "Changesets.Create", func() error {
+		var err error
+		result, err = s.ChangesetsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedChangesetsClient) Get(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (*Changeset, error) {
+	var result *Changeset
+	err := s.Limits.do(ctx, "Changesets.Get", func() error {
+		var err error
+		result, err = s.ChangesetsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedChangesetsClient) List(ctx context.Context, in *ChangesetListOp, opts ...grpc.CallOption) (*ChangesetList, error) {
+	var result *ChangesetList
+	err := s.Limits.do(ctx, "Changesets.List", func() error {
+		var err error
+		result, err = s.ChangesetsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedChangesetsClient) Update(ctx context.Context, in *ChangesetUpdateOp, opts ...grpc.CallOption) (*ChangesetEvent, error) {
+	var result *ChangesetEvent
+	err := s.Limits.do(ctx, "Changesets.Update", func() error {
+		var err error
+		result, err = s.ChangesetsClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedChangesetsClient) Merge(ctx context.Context, in *ChangesetMergeOp, opts ...grpc.CallOption) (*ChangesetEvent, error) {
+	var result *ChangesetEvent
+	err := s.Limits.do(ctx, "Changesets.Merge", func() error {
+		var err error
+		result, err = s.ChangesetsClient.Merge(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedChangesetsClient) UpdateAffected(ctx context.Context, in *ChangesetUpdateAffectedOp, opts ...grpc.CallOption) (*ChangesetEventList, error) {
+	var result *ChangesetEventList
+	err := s.Limits.do(ctx, "Changesets.UpdateAffected", func() error {
+		var err error
+		result, err = s.ChangesetsClient.UpdateAffected(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedChangesetsClient) CreateReview(ctx context.Context, in *ChangesetCreateReviewOp, opts ...grpc.CallOption) (*ChangesetReview, error) {
+	var result *ChangesetReview
+	err := s.Limits.do(ctx, "Changesets.CreateReview", func() error {
+		var err error
+		result, err = s.ChangesetsClient.CreateReview(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedChangesetsClient) ListReviews(ctx context.Context, in *ChangesetListReviewsOp, opts ...grpc.CallOption) (*ChangesetReviewList, error) {
+	var result *ChangesetReviewList
+	err := s.Limits.do(ctx, "Changesets.ListReviews", func() error {
+		var err error
+		result, err = s.ChangesetsClient.ListReviews(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedChangesetsClient) ListEvents(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (*ChangesetEventList, error) {
+	var result *ChangesetEventList
+	err := s.Limits.do(ctx, "Changesets.ListEvents", func() error {
+		var err error
+		result, err = s.ChangesetsClient.ListEvents(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedDefsClient struct {
+	DefsClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedDefsClient) Get(ctx context.Context, in *DefsGetOp, opts ...grpc.CallOption) (*Def, error) {
+	var result *Def
+	err := s.Limits.do(ctx, "Defs.Get", func() error {
+		var err error
+		result, err = s.DefsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDefsClient) List(ctx context.Context, in *DefListOptions, opts ...grpc.CallOption) (*DefList, error) {
+	var result *DefList
+	err := s.Limits.do(ctx, "Defs.List", func() error {
+		var err error
+		result, err = s.DefsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDefsClient) ListRefs(ctx context.Context, in *DefsListRefsOp, opts ...grpc.CallOption) (*RefList, error) {
+	var result *RefList
+	err := s.Limits.do(ctx, "Defs.ListRefs", func() error {
+		var err error
+		result, err = s.DefsClient.ListRefs(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDefsClient) ListExamples(ctx context.Context, in *DefsListExamplesOp, opts ...grpc.CallOption) (*ExampleList, error) {
+	var result *ExampleList
+	err := s.Limits.do(ctx, "Defs.ListExamples", func() error {
+		var err error
+		result, err = s.DefsClient.ListExamples(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDefsClient) ListAuthors(ctx context.Context, in *DefsListAuthorsOp, opts ...grpc.CallOption) (*DefAuthorList, error) {
+	var result *DefAuthorList
+	err := s.Limits.do(ctx, "Defs.ListAuthors", func() error {
+		var err error
+		result, err = s.DefsClient.ListAuthors(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDefsClient) ListClients(ctx context.Context, in *DefsListClientsOp, opts ...grpc.CallOption) (*DefClientList, error) {
+	var result *DefClientList
+	err := s.Limits.do(ctx, "Defs.ListClients", func() error {
+		var err error
+		result, err = s.DefsClient.ListClients(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedDeltasClient struct {
+	DeltasClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedDeltasClient) Get(ctx context.Context, in *DeltaSpec, opts ...grpc.CallOption) (*Delta, error) {
+	var result *Delta
+	err := s.Limits.do(ctx, "Deltas.Get", func() error {
+		var err error
+		result, err = s.DeltasClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDeltasClient) ListUnits(ctx context.Context, in *DeltasListUnitsOp, opts ...grpc.CallOption) (*UnitDeltaList, error) {
+	var result *UnitDeltaList
+	err := s.Limits.do(ctx, "Deltas.ListUnits", func() error {
+		var err error
+		result, err = s.DeltasClient.ListUnits(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDeltasClient) ListDefs(ctx context.Context, in *DeltasListDefsOp, opts ...grpc.CallOption) (*DeltaDefs, error) {
+	var result *DeltaDefs
+	err := s.Limits.do(ctx, "Deltas.ListDefs", func() error {
+		var err error
+		result, err = s.DeltasClient.ListDefs(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDeltasClient) ListFiles(ctx context.Context, in *DeltasListFilesOp, opts ...grpc.CallOption) (*DeltaFiles, error) {
+	var result *DeltaFiles
+	err := s.Limits.do(ctx, "Deltas.ListFiles", func() error {
+		var err error
+		result, err = s.DeltasClient.ListFiles(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDeltasClient) ListAffectedAuthors(ctx context.Context, in *DeltasListAffectedAuthorsOp, opts ...grpc.CallOption) (*DeltaAffectedPersonList, error) {
+	var result *DeltaAffectedPersonList
+	err := s.Limits.do(ctx, "Deltas.ListAffectedAuthors", func() error {
+		var err error
+		result, err = s.DeltasClient.ListAffectedAuthors(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDeltasClient) ListAffectedClients(ctx context.Context, in *DeltasListAffectedClientsOp, opts ...grpc.CallOption) (*DeltaAffectedPersonList, error) {
+	var result *DeltaAffectedPersonList
+	err := s.Limits.do(ctx, "Deltas.ListAffectedClients", func() error {
+		var err error
+		result, err = s.DeltasClient.ListAffectedClients(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedDiscussionsClient struct {
+	DiscussionsClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedDiscussionsClient) Create(ctx context.Context, in *Discussion, opts ...grpc.CallOption) (*Discussion, error) {
+	var result *Discussion
+	err := s.Limits.do(ctx, "Discussions.Create", func() error {
+		var err error
+		result, err = s.DiscussionsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDiscussionsClient) Get(ctx context.Context, in *DiscussionSpec, opts ...grpc.CallOption) (*Discussion, error) {
+	var result *Discussion
+	err := s.Limits.do(ctx, "Discussions.Get", func() error {
+		var err error
+		result, err = s.DiscussionsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDiscussionsClient) List(ctx context.Context, in *DiscussionListOp, opts ...grpc.CallOption) (*DiscussionList, error) {
+	var result *DiscussionList
+	err := s.Limits.do(ctx, "Discussions.List", func() error {
+		var err error
+		result, err = s.DiscussionsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDiscussionsClient) CreateComment(ctx context.Context, in *DiscussionCommentCreateOp, opts ...grpc.CallOption) (*DiscussionComment, error) {
+	var result *DiscussionComment
+	err := s.Limits.do(ctx, "Discussions.CreateComment", func() error {
+		var err error
+		result, err = s.DiscussionsClient.CreateComment(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedDiscussionsClient) UpdateRating(ctx context.Context, in *DiscussionRatingUpdateOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "Discussions.UpdateRating", func() error {
+		var err error
+		result, err = s.DiscussionsClient.UpdateRating(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedGraphUplinkClient struct {
+	GraphUplinkClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedGraphUplinkClient) Push(ctx context.Context, in *MetricsSnapshot, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "GraphUplink.Push", func() error {
+		var err error
+		result, err = s.GraphUplinkClient.Push(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedGraphUplinkClient) PushEvents(ctx context.Context, in *UserEventList, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "GraphUplink.PushEvents", func() error {
+		var err error
+		result, err = s.GraphUplinkClient.PushEvents(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedMarkdownClient struct {
+	MarkdownClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedMarkdownClient) Render(ctx context.Context, in *MarkdownRenderOp, opts ...grpc.CallOption) (*MarkdownData, error) {
+	var result *MarkdownData
+	err := s.Limits.do(ctx, "Markdown.Render", func() error {
+		var err error
+		result, err = s.MarkdownClient.Render(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedMetaClient struct {
+	MetaClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedMetaClient) Status(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerStatus, error) {
+	var result *ServerStatus
+	err := s.Limits.do(ctx, "Meta.Status", func() error {
+		var err error
+		result, err = s.MetaClient.Status(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedMetaClient) Config(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerConfig, error) {
+	var result *ServerConfig
+	err := s.Limits.do(ctx, "Meta.Config", func() error {
+		var err error
+		result, err = s.MetaClient.Config(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedMetaClient) PubKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerPubKey, error) {
+	var result *ServerPubKey
+	err := s.Limits.do(ctx, "Meta.PubKey", func() error {
+		var err error
+		result, err = s.MetaClient.PubKey(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedMirrorReposClient struct {
+	MirrorReposClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedMirrorReposClient) RefreshVCS(ctx context.Context, in *MirrorReposRefreshVCSOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "MirrorRepos.RefreshVCS", func() error {
+		var err error
+		result, err = s.MirrorReposClient.RefreshVCS(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedMirroredRepoSSHKeysClient struct {
+	MirroredRepoSSHKeysClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedMirroredRepoSSHKeysClient) Create(ctx context.Context, in *MirroredRepoSSHKeysCreateOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "MirroredRepoSSHKeys.Create", func() error {
+		var err error
+		result, err = s.MirroredRepoSSHKeysClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedMirroredRepoSSHKeysClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*SSHPrivateKey, error) {
+	var result *SSHPrivateKey
+	err := s.Limits.do(ctx, "MirroredRepoSSHKeys.Get", func() error {
+		var err error
+		result, err = s.MirroredRepoSSHKeysClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedMirroredRepoSSHKeysClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "MirroredRepoSSHKeys.Delete", func() error {
+		var err error
+		result, err = s.MirroredRepoSSHKeysClient.Delete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedNotifyClient struct {
+	NotifyClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedNotifyClient) GenericEvent(ctx context.Context, in *NotifyGenericEvent, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "Notify.GenericEvent", func() error {
+		var err error
+		result, err = s.NotifyClient.GenericEvent(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedOrgsClient struct {
+	OrgsClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedOrgsClient) Get(ctx context.Context, in *OrgSpec, opts ...grpc.CallOption) (*Org, error) {
+	var result *Org
+	err := s.Limits.do(ctx, "Orgs.Get", func() error {
+		var err error
+		result, err = s.OrgsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedOrgsClient) List(ctx context.Context, in *OrgsListOp, opts ...grpc.CallOption) (*OrgList, error) {
+	var result *OrgList
+	err := s.Limits.do(ctx, "Orgs.List", func() error {
+		var err error
+		result, err = s.OrgsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedOrgsClient) ListMembers(ctx context.Context, in *OrgsListMembersOp, opts ...grpc.CallOption) (*UserList, error) {
+	var result *UserList
+	err := s.Limits.do(ctx, "Orgs.ListMembers", func() error {
+		var err error
+		result, err = s.OrgsClient.ListMembers(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedPeopleClient struct {
+	PeopleClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedPeopleClient) Get(ctx context.Context, in *PersonSpec, opts ...grpc.CallOption) (*Person, error) {
+	var result *Person
+	err := s.Limits.do(ctx, "People.Get", func() error {
+		var err error
+		result, err = s.PeopleClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedRegisteredClientsClient struct {
+	RegisteredClientsClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedRegisteredClientsClient) Get(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	var result *RegisteredClient
+	err := s.Limits.do(ctx, "RegisteredClients.Get", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRegisteredClientsClient) GetCurrent(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	var result *RegisteredClient
+	err := s.Limits.do(ctx, "RegisteredClients.GetCurrent", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.GetCurrent(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRegisteredClientsClient) Create(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	var result *RegisteredClient
+	err := s.Limits.do(ctx, "RegisteredClients.Create", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRegisteredClientsClient) Update(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "RegisteredClients.Update", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRegisteredClientsClient) Delete(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "RegisteredClients.Delete", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.Delete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRegisteredClientsClient) List(ctx context.Context, in *RegisteredClientListOptions, opts ...grpc.CallOption) (*RegisteredClientList, error) {
+	var result *RegisteredClientList
+	err := s.Limits.do(ctx, "RegisteredClients.List", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRegisteredClientsClient) GetUserPermissions(ctx context.Context, in *UserPermissionsOptions, opts ...grpc.CallOption) (*UserPermissions, error) {
+	var result *UserPermissions
+	err := s.Limits.do(ctx, "RegisteredClients.GetUserPermissions", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.GetUserPermissions(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRegisteredClientsClient) SetUserPermissions(ctx context.Context, in *UserPermissions, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "RegisteredClients.SetUserPermissions", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.SetUserPermissions(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRegisteredClientsClient) ListUserPermissions(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*UserPermissionsList, error) {
+	var result *UserPermissionsList
+	err := s.Limits.do(ctx, "RegisteredClients.ListUserPermissions", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.ListUserPermissions(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedRepoBadgesClient struct {
+	RepoBadgesClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedRepoBadgesClient) ListBadges(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*BadgeList, error) {
+	var result *BadgeList
+	err := s.Limits.do(ctx, "RepoBadges.ListBadges", func() error {
+		var err error
+		result, err = s.RepoBadgesClient.ListBadges(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRepoBadgesClient) ListCounters(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*CounterList, error) {
+	var result *CounterList
+	err := s.Limits.do(ctx, "RepoBadges.ListCounters", func() error {
+		var err error
+		result, err = s.RepoBadgesClient.ListCounters(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRepoBadgesClient) RecordHit(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "RepoBadges.RecordHit", func() error {
+		var err error
+		result, err = s.RepoBadgesClient.RecordHit(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRepoBadgesClient) CountHits(ctx context.Context, in *RepoBadgesCountHitsOp, opts ...grpc.CallOption) (*RepoBadgesCountHitsResult, error) {
+	var result *RepoBadgesCountHitsResult
+	err := s.Limits.do(ctx, "RepoBadges.CountHits", func() error {
+		var err error
+		result, err = s.RepoBadgesClient.CountHits(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedRepoStatusesClient struct {
+	RepoStatusesClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedRepoStatusesClient) GetCombined(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*CombinedStatus, error) {
+	var result *CombinedStatus
+	err := s.Limits.do(ctx, "RepoStatuses.GetCombined", func() error {
+		var err error
+		result, err = s.RepoStatusesClient.GetCombined(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRepoStatusesClient) Create(ctx context.Context, in *RepoStatusesCreateOp, opts ...grpc.CallOption) (*RepoStatus, error) {
+	var result *RepoStatus
+	err := s.Limits.do(ctx, "RepoStatuses.Create", func() error {
+		var err error
+		result, err = s.RepoStatusesClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedRepoTreeClient struct {
+	RepoTreeClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedRepoTreeClient) Get(ctx context.Context, in *RepoTreeGetOp, opts ...grpc.CallOption) (*TreeEntry, error) {
+	var result *TreeEntry
+	err := s.Limits.do(ctx, "RepoTree.Get", func() error {
+		var err error
+		result, err = s.RepoTreeClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRepoTreeClient) Search(ctx context.Context, in *RepoTreeSearchOp, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	var result *VCSSearchResultList
+	err := s.Limits.do(ctx, "RepoTree.Search", func() error {
+		var err error
+		result, err = s.RepoTreeClient.Search(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedRepoTreeClient) List(ctx context.Context, in *RepoTreeListOp, opts ...grpc.CallOption) (*RepoTreeListResult, error) {
+	var result *RepoTreeListResult
+	err := s.Limits.do(ctx, "RepoTree.List", func() error {
+		var err error
+		result, err = s.RepoTreeClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedReposClient struct {
+	ReposClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedReposClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*Repo, error) {
+	var result *Repo
+	err := s.Limits.do(ctx, "Repos.Get", func() error {
+		var err error
+		result, err = s.ReposClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) List(ctx context.Context, in *RepoListOptions, opts ...grpc.CallOption) (*RepoList, error) {
+	var result *RepoList
+	err := s.Limits.do(ctx, "Repos.List", func() error {
+		var err error
+		result, err = s.ReposClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) Create(ctx context.Context, in *ReposCreateOp, opts ...grpc.CallOption) (*Repo, error) {
+	var result *Repo
+	err := s.Limits.do(ctx, "Repos.Create", func() error {
+		var err error
+		result, err = s.ReposClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) Update(ctx context.Context, in *ReposUpdateOp, opts ...grpc.CallOption) (*Repo, error) {
+	var result *Repo
+	err := s.Limits.do(ctx, "Repos.Update", func() error {
+		var err error
+		result, err = s.ReposClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "Repos.Delete", func() error {
+		var err error
+		result, err = s.ReposClient.Delete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) GetReadme(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*Readme, error) {
+	var result *Readme
+	err := s.Limits.do(ctx, "Repos.GetReadme", func() error {
+		var err error
+		result, err = s.ReposClient.GetReadme(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) Enable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "Repos.Enable", func() error {
+		var err error
+		result, err = s.ReposClient.Enable(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) Disable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "Repos.Disable", func() error {
+		var err error
+		result, err = s.ReposClient.Disable(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) GetConfig(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*RepoConfig, error) {
+	var result *RepoConfig
+	err := s.Limits.do(ctx, "Repos.GetConfig", func() error {
+		var err error
+		result, err = s.ReposClient.GetConfig(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) GetCommit(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*vcs.Commit, error) {
+	var result *vcs.Commit
+	err := s.Limits.do(ctx, "Repos.GetCommit", func() error {
+		var err error
+		result, err = s.ReposClient.GetCommit(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) ListCommits(ctx context.Context, in *ReposListCommitsOp, opts ...grpc.CallOption) (*CommitList, error) {
+	var result *CommitList
+	err := s.Limits.do(ctx, "Repos.ListCommits", func() error {
+		var err error
+		result, err = s.ReposClient.ListCommits(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) ListBranches(ctx context.Context, in *ReposListBranchesOp, opts ...grpc.CallOption) (*BranchList, error) {
+	var result *BranchList
+	err := s.Limits.do(ctx, "Repos.ListBranches", func() error {
+		var err error
+		result, err = s.ReposClient.ListBranches(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) ListTags(ctx context.Context, in *ReposListTagsOp, opts ...grpc.CallOption) (*TagList, error) {
+	var result *TagList
+	err := s.Limits.do(ctx, "Repos.ListTags", func() error {
+		var err error
+		result, err = s.ReposClient.ListTags(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedReposClient) ListCommitters(ctx context.Context, in *ReposListCommittersOp, opts ...grpc.CallOption) (*CommitterList, error) {
+	var result *CommitterList
+	err := s.Limits.do(ctx, "Repos.ListCommitters", func() error {
+		var err error
+		result, err = s.ReposClient.ListCommitters(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedSavedSearchesClient struct {
+	SavedSearchesClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedSavedSearchesClient) Create(ctx context.Context, in *SavedSearchesCreateOp, opts ...grpc.CallOption) (*SavedSearch, error) {
+	var result *SavedSearch
+	err := s.Limits.do(ctx, "SavedSearches.Create", func() error {
+		var err error
+		result, err = s.SavedSearchesClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedSavedSearchesClient) List(ctx context.Context, in *SavedSearchListOptions, opts ...grpc.CallOption) (*SavedSearchList, error) {
+	var result *SavedSearchList
+	err := s.Limits.do(ctx, "SavedSearches.List", func() error {
+		var err error
+		result, err = s.SavedSearchesClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedSavedSearchesClient) Delete(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "SavedSearches.Delete", func() error {
+		var err error
+		result, err = s.SavedSearchesClient.Delete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedSavedSearchesClient) Run(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*SavedSearchRunResult, error) {
+	var result *SavedSearchRunResult
+	err := s.Limits.do(ctx, "SavedSearches.Run", func() error {
+		var err error
+		result, err = s.SavedSearchesClient.Run(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedSearchClient struct {
+	SearchClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedSearchClient) Search(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (*SearchResults, error) {
+	var result *SearchResults
+	err := s.Limits.do(ctx, "Search.Search", func() error {
+		var err error
+		result, err = s.SearchClient.Search(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedSearchClient) SearchTokens(ctx context.Context, in *TokenSearchOptions, opts ...grpc.CallOption) (*DefList, error) {
+	var result *DefList
+	err := s.Limits.do(ctx, "Search.SearchTokens", func() error {
+		var err error
+		result, err = s.SearchClient.SearchTokens(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedSearchClient) SearchText(ctx context.Context, in *TextSearchOptions, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	var result *VCSSearchResultList
+	err := s.Limits.do(ctx, "Search.SearchText", func() error {
+		var err error
+		result, err = s.SearchClient.SearchText(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedSearchClient) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions, opts ...grpc.CallOption) (*MultiTextSearchResults, error) {
+	var result *MultiTextSearchResults
+	err := s.Limits.do(ctx, "Search.SearchTextMulti", func() error {
+		var err error
+		result, err = s.SearchClient.SearchTextMulti(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedSearchClient) Complete(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*Completions, error) {
+	var result *Completions
+	err := s.Limits.do(ctx, "Search.Complete", func() error {
+		var err error
+		result, err = s.SearchClient.Complete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedSearchClient) Suggest(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*SuggestionList, error) {
+	var result *SuggestionList
+	err := s.Limits.do(ctx, "Search.Suggest", func() error {
+		var err error
+		result, err = s.SearchClient.Suggest(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedSearchClient) StreamSearch(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (Search_StreamSearchClient, error) {
+	end, err := s.Limits.startStream(ctx, "Search.StreamSearch")
+	if err != nil {
+		return nil, err
+	}
+	stream, err := s.SearchClient.StreamSearch(ctx, in, opts...)
+	if err != nil {
+		end(err)
+		return nil, err
+	}
+	return &rateLimitedSearchStreamSearchClient{stream, end}, nil
+}
+
+// rateLimitedSearchStreamSearchClient holds the call's in-flight slot
+// until the stream ends.
+type rateLimitedSearchStreamSearchClient struct {
+	Search_StreamSearchClient
+	end func(error)
+}
+
+func (x *rateLimitedSearchStreamSearchClient) Recv() (*SearchEvent, error) {
+	m, err := x.Search_StreamSearchClient.Recv()
+	if err != nil && x.end != nil {
+		if err == io.EOF {
+			x.end(nil)
+		} else {
+			x.end(err)
+		}
+		x.end = nil
+	}
+	return m, err
+}
+
+type RateLimitedStorageClient struct {
+	StorageClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedStorageClient) Create(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	var result *StorageError
+	err := s.Limits.do(ctx, "Storage.Create", func() error {
+		var err error
+		result, err = s.StorageClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedStorageClient) RemoveAll(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	var result *StorageError
+	err := s.Limits.do(ctx, "Storage.RemoveAll", func() error {
+		var err error
+		result, err = s.StorageClient.RemoveAll(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedStorageClient) Read(ctx context.Context, in *StorageReadOp, opts ...grpc.CallOption) (*StorageRead, error) {
+	var result *StorageRead
+	err := s.Limits.do(ctx, "Storage.Read", func() error {
+		var err error
+		result, err = s.StorageClient.Read(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedStorageClient) Write(ctx context.Context, in *StorageWriteOp, opts ...grpc.CallOption) (*StorageWrite, error) {
+	var result *StorageWrite
+	err := s.Limits.do(ctx, "Storage.Write", func() error {
+		var err error
+		result, err = s.StorageClient.Write(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedStorageClient) Stat(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageStat, error) {
+	var result *StorageStat
+	err := s.Limits.do(ctx, "Storage.Stat", func() error {
+		var err error
+		result, err = s.StorageClient.Stat(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedStorageClient) ReadDir(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageReadDir, error) {
+	var result *StorageReadDir
+	err := s.Limits.do(ctx, "Storage.ReadDir", func() error {
+		var err error
+		result, err = s.StorageClient.ReadDir(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedStorageClient) Close(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	var result *StorageError
+	err := s.Limits.do(ctx, "Storage.Close", func() error {
+		var err error
+		result, err = s.StorageClient.Close(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedUnitsClient struct {
+	UnitsClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedUnitsClient) Get(ctx context.Context, in *UnitSpec, opts ...grpc.CallOption) (*unit.RepoSourceUnit, error) {
+	var result *unit.RepoSourceUnit
+	err := s.Limits.do(ctx, "Units.Get", func() error {
+		var err error
+		result, err = s.UnitsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedUnitsClient) List(ctx context.Context, in *UnitListOptions, opts ...grpc.CallOption) (*RepoSourceUnitList, error) {
+	var result *RepoSourceUnitList
+	err := s.Limits.do(ctx, "Units.List", func() error {
+		var err error
+		result, err = s.UnitsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedUserKeysClient struct {
+	UserKeysClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedUserKeysClient) AddKey(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "UserKeys.AddKey", func() error {
+		var err error
+		result, err = s.UserKeysClient.AddKey(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedUserKeysClient) LookupUser(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (*UserSpec, error) {
+	var result *UserSpec
+	err := s.Limits.do(ctx, "UserKeys.LookupUser", func() error {
+		var err error
+		result, err = s.UserKeysClient.LookupUser(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedUserKeysClient) DeleteKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Limits.do(ctx, "UserKeys.DeleteKey", func() error {
+		var err error
+		result, err = s.UserKeysClient.DeleteKey(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RateLimitedUsersClient struct {
+	UsersClient
+	Limits *RateLimits
+}
+
+func (s *RateLimitedUsersClient) Get(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*User, error) {
+	var result *User
+	err := s.Limits.do(ctx, "Users.Get", func() error {
+		var err error
+		result, err = s.UsersClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedUsersClient) GetWithEmail(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (*User, error) {
+	var result *User
+	err := s.Limits.do(ctx, "Users.GetWithEmail", func() error {
+		var err error
+		result, err = s.UsersClient.GetWithEmail(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedUsersClient) ListEmails(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*EmailAddrList, error) {
+	var result *EmailAddrList
+	err := s.Limits.do(ctx, "Users.ListEmails", func() error {
+		var err error
+		result, err = s.UsersClient.ListEmails(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RateLimitedUsersClient) List(ctx context.Context, in *UsersListOptions, opts ...grpc.CallOption) (*UserList, error) {
+	var result *UserList
+	err := s.Limits.do(ctx, "Users.List", func() error {
+		var err error
+		result, err = s.UsersClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+// rateLimitClient wraps each of c's services in a RateLimited*Client
+// that limits calls according to limits.
+func rateLimitClient(c *Client, limits *RateLimits) {
+	c.AccessTokens = &RateLimitedAccessTokensClient{c.AccessTokens, limits}
+	c.Accounts = &RateLimitedAccountsClient{c.Accounts, limits}
+	c.AuditLog = &RateLimitedAuditLogClient{c.AuditLog, limits}
+	c.Auth = &RateLimitedAuthClient{c.Auth, limits}
+	c.Builds = &RateLimitedBuildsClient{c.Builds, limits}
+	c.Changesets = &RateLimitedChangesetsClient{c.Changesets, limits}
+	c.Defs = &RateLimitedDefsClient{c.Defs, limits}
+	c.Deltas = &RateLimitedDeltasClient{c.Deltas, limits}
+	c.Discussions = &RateLimitedDiscussionsClient{c.Discussions, limits}
+	c.GraphUplink = &RateLimitedGraphUplinkClient{c.GraphUplink, limits}
+	c.Markdown = &RateLimitedMarkdownClient{c.Markdown, limits}
+	c.Meta = &RateLimitedMetaClient{c.Meta, limits}
+	c.MirrorRepos = &RateLimitedMirrorReposClient{c.MirrorRepos, limits}
+	c.MirroredRepoSSHKeys = &RateLimitedMirroredRepoSSHKeysClient{c.MirroredRepoSSHKeys, limits}
+	c.Notify = &RateLimitedNotifyClient{c.Notify, limits}
+	c.Orgs = &RateLimitedOrgsClient{c.Orgs, limits}
+	c.People = &RateLimitedPeopleClient{c.People, limits}
+	c.RegisteredClients = &RateLimitedRegisteredClientsClient{c.RegisteredClients, limits}
+	c.RepoBadges = &RateLimitedRepoBadgesClient{c.RepoBadges, limits}
+	c.RepoStatuses = &RateLimitedRepoStatusesClient{c.RepoStatuses, limits}
+	c.RepoTree = &RateLimitedRepoTreeClient{c.RepoTree, limits}
+	c.Repos = &RateLimitedReposClient{c.Repos, limits}
+	c.SavedSearches = &RateLimitedSavedSearchesClient{c.SavedSearches, limits}
+	c.Search = &RateLimitedSearchClient{c.Search, limits}
+	c.Storage = &RateLimitedStorageClient{c.Storage, limits}
+	c.Units = &RateLimitedUnitsClient{c.Units, limits}
+	c.UserKeys = &RateLimitedUserKeysClient{c.UserKeys, limits}
+	c.Users = &RateLimitedUsersClient{c.Users, limits}
+}
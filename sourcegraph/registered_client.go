@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"encoding/base64"
 	"errors"
+	"net"
+	"net/url"
+	"path"
 )
 
 // Spec returns c's RegisteredClientSpec.
@@ -11,6 +14,46 @@ func (c *RegisteredClient) Spec() RegisteredClientSpec {
 	return RegisteredClientSpec{ID: c.ID}
 }
 
+// ValidRedirectURI reports whether uri is one of c's RedirectURIs.
+// As recommended for native apps by RFC 8252 (section 7.3), the port
+// of a loopback redirect URI (e.g., "http://127.0.0.1/callback") is
+// ignored, because native apps listen on whatever port is available.
+func (c *RegisteredClient) ValidRedirectURI(uri string) bool {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	for _, r := range c.RedirectURIs {
+		if r == uri {
+			return true
+		}
+		ru, err := url.Parse(r)
+		if err != nil || !isLoopbackURL(ru) || !isLoopbackURL(u) {
+			continue
+		}
+		if ru.Scheme == u.Scheme && ru.Hostname() == u.Hostname() && cleanURLPath(ru.Path) == cleanURLPath(u.Path) && ru.RawQuery == u.RawQuery {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanURLPath returns the shortest equivalent of the URL path p, in
+// which the empty path is "/" (as it is in the request).
+func cleanURLPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	return path.Clean(p)
+}
+
+// isLoopbackURL reports whether u is an http URL whose host is a
+// loopback IP address.
+func isLoopbackURL(u *url.URL) bool {
+	ip := net.ParseIP(u.Hostname())
+	return u.Scheme == "http" && ip != nil && ip.IsLoopback()
+}
+
 // MarshalText implements encoding.TextMarshaler.
 func (c *RegisteredClientCredentials) MarshalText() ([]byte, error) {
 	b64 := base64.StdEncoding
@@ -36,3 +36,34 @@ func TestRegisteredClientCredentials_TextMarshalerUnmarshaler(t *testing.T) {
 		}
 	}
 }
+
+func TestRegisteredClient_ValidRedirectURI(t *testing.T) {
+	c := &RegisteredClient{RedirectURIs: []string{
+		"https://example.com/callback",
+		"http://127.0.0.1/oauth2/callback",
+		"http://[::1]:1234/cb",
+		"http://127.0.0.2:8080",
+	}}
+	tests := map[string]bool{
+		"https://example.com/callback":            true,
+		"https://example.com/callback?x=y":        false,
+		"https://example.com:8443/callback":       false,
+		"http://example.com/callback":             false,
+		"http://127.0.0.1/oauth2/callback":        true,
+		"http://127.0.0.1:53682/oauth2/callback":  true,
+		"http://127.0.0.1:53682/other":            false,
+		"https://127.0.0.1:53682/oauth2/callback": false,
+		"http://localhost:53682/oauth2/callback":  false,
+		"http://[::1]:5678/cb":                    true,
+		"http://127.0.0.1:5678/cb":                false,
+		"http://127.0.0.2:5678/":                  true,
+		"http://127.0.0.2:5678":                   true,
+		"http://127.0.0.2:5678/cb":                false,
+		"":                                        false,
+	}
+	for uri, want := range tests {
+		if got := c.ValidRedirectURI(uri); got != want {
+			t.Errorf("%q: got %v, want %v", uri, got, want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package sourcegraph
 
 import (
 	"net/url"
+	"reflect"
 
 	"strings"
 
@@ -119,3 +120,101 @@ func UnmarshalRepoRevSpec(routeVars map[string]string) (RepoRevSpec, error) {
 	}
 	return rrspec, err
 }
+
+// requestRepoURI returns the URI of the (first) repository that an
+// API request (such as a *RepoRevSpec, *DefSpec or
+// *ReposListCommitsOp) refers to, or "" if it doesn't refer to a
+// repository. See requestRepoURIs.
+func requestRepoURI(req interface{}) string {
+	if uris := requestRepoURIs(req); len(uris) > 0 {
+		return uris[0]
+	}
+	return ""
+}
+
+// requestRepoURIs returns the URIs of all repositories that an API
+// request refers to (e.g., both the base and head repositories of a
+// *DeltaSpec), without duplicates. The repositories are found by
+// looking (up to a few levels deep) for RepoSpecs in the request's
+// *Spec and *Options fields (and in each element of slices of them), and for Repo string fields in the request
+// itself (e.g., ChangesetListOp.Repo), in a *Spec (e.g., DefSpec.Repo)
+// or in *Options (e.g., DefListRefsOptions.Repo). A StorageName is
+// treated like a *Spec.
+func requestRepoURIs(req interface{}) []string {
+	var uris []string
+	specRepoURIs(reflect.ValueOf(req), 0, func(uri string) {
+		if uri == "" {
+			return
+		}
+		for _, u := range uris {
+			if u == uri {
+				return
+			}
+		}
+		uris = append(uris, uri)
+	})
+	return uris
+}
+
+var (
+	repoSpecType    = reflect.TypeOf(RepoSpec{})
+	storageNameType = reflect.TypeOf(StorageName{})
+)
+
+// isSpecType reports whether t is a type whose fields may refer to a
+// repository.
+func isSpecType(t reflect.Type) bool {
+	name := t.Name()
+	return strings.HasSuffix(name, "Spec") || strings.HasSuffix(name, "Options") || t == storageNameType
+}
+
+func specRepoURIs(v reflect.Value, depth int, add func(uri string)) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct || depth > 3 {
+		return
+	}
+	t := v.Type()
+	if t == repoSpecType {
+		add(v.Interface().(RepoSpec).URI)
+		return
+	}
+	isSpec := isSpecType(t)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		if (isSpec || depth == 0) && f.Name == "Repo" && f.Type.Kind() == reflect.String {
+			add(v.Field(i).String())
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Slice {
+			// Check every element (e.g., of
+			// MultiTextSearchOptions.RepoRevs).
+			if et := derefType(ft.Elem()); et.Kind() == reflect.Struct && isSpecType(et) {
+				for j := 0; j < v.Field(i).Len(); j++ {
+					specRepoURIs(v.Field(i).Index(j), depth+1, add)
+				}
+			}
+			continue
+		}
+		if ft = derefType(ft); ft.Kind() == reflect.Struct && isSpecType(ft) {
+			specRepoURIs(v.Field(i), depth+1, add)
+		}
+	}
+}
+
+// derefType returns the type that t points to, if t is a pointer
+// type, and otherwise t.
+func derefType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
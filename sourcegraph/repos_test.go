@@ -1,6 +1,9 @@
 package sourcegraph
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 const commitID = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
 
@@ -25,3 +28,50 @@ func TestRepo_GitHubHTMLURL(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestRepoURI(t *testing.T) {
+	tests := []struct {
+		req  interface{}
+		want string
+	}{
+		{&RepoSpec{URI: "r"}, "r"},
+		{&RepoRevSpec{RepoSpec: RepoSpec{URI: "r"}, Rev: "v"}, "r"},
+		{&DefSpec{Repo: "r", Path: "p"}, "r"},
+		{&UnitSpec{RepoRevSpec: RepoRevSpec{RepoSpec: RepoSpec{URI: "r"}}}, "r"},
+		{&TreeEntrySpec{RepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "r"}}}, "r"},
+		{&BuildSpec{Repo: RepoSpec{URI: "r"}}, "r"},
+		{&ReposListCommitsOp{Repo: RepoSpec{URI: "r"}}, "r"},
+		{&StorageName{AppName: "a", Repo: "r"}, "r"},
+		{&StorageReadOp{Name: StorageName{Repo: "r"}}, "r"},
+		{&ChangesetListOp{Repo: "r"}, "r"},
+		{&UserSpec{Login: "u"}, ""},
+		{(*RepoSpec)(nil), ""},
+		{nil, ""},
+	}
+	for _, test := range tests {
+		if got := requestRepoURI(test.req); got != test.want {
+			t.Errorf("%#v: got %q, want %q", test.req, got, test.want)
+		}
+	}
+}
+
+func TestRequestRepoURIs(t *testing.T) {
+	rev := func(uri string) RepoRevSpec { return RepoRevSpec{RepoSpec: RepoSpec{URI: uri}} }
+	tests := []struct {
+		req  interface{}
+		want []string
+	}{
+		{&DeltaSpec{Base: rev("b"), Head: rev("h")}, []string{"b", "h"}},
+		{&DeltaSpec{Base: rev("r"), Head: rev("r")}, []string{"r"}},
+		{&DeltasListUnitsOp{Ds: DeltaSpec{Base: rev("b"), Head: rev("h")}}, []string{"b", "h"}},
+		{&ChangesetListOp{Repo: "r"}, []string{"r"}},
+		{&DefsListRefsOp{Def: DefSpec{Repo: "r"}, Opt: &DefListRefsOptions{Repo: "other"}}, []string{"r", "other"}},
+		{&MultiTextSearchOptions{RepoRevs: []RepoRevSpec{rev("a"), rev("b"), rev("a")}}, []string{"a", "b"}},
+		{&UserSpec{Login: "u"}, nil},
+	}
+	for _, test := range tests {
+		if got := requestRepoURIs(test.req); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%#v: got %q, want %q", test.req, got, test.want)
+		}
+	}
+}
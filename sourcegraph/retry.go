@@ -0,0 +1,244 @@
+package sourcegraph
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// A RetryPolicy specifies how API client calls that fail with
+// transient errors are retried. Calls are retried only if they are
+// idempotent, and each retry is delayed by an exponential backoff
+// (with jitter).
+//
+// Use WithRetryPolicy to make clients constructed with
+// NewClientFromContext use a retry policy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of each call
+	// (including the first). If it is 1 or less, calls are not
+	// retried.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay before a retry. If zero, the
+	// delay is not limited.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor by which the delay increases after each
+	// retry. If zero, 2 is used.
+	Multiplier float64
+
+	// Jitter is the fraction (between 0 and 1) by which each delay is
+	// randomly increased or decreased, to avoid many clients retrying
+	// in lockstep.
+	Jitter float64
+
+	// Codes are the gRPC error codes of calls that are retried. If
+	// nil, only calls that fail with codes.Unavailable are retried.
+	Codes []codes.Code
+
+	// Idempotent reports whether the method (e.g., "Repos.Get") is
+	// idempotent and may therefore be retried. If nil, IsIdempotent
+	// is used.
+	Idempotent func(method string) bool
+
+	// Budget, if set, limits the number of retries when many calls
+	// are failing. It may be shared by multiple policies.
+	Budget *RetryBudget
+}
+
+// DefaultRetryPolicy is a RetryPolicy suitable for most clients. It
+// retries idempotent calls that fail with codes.Unavailable (e.g.,
+// while the server is restarting) up to 3 times.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// do calls call, retrying it according to the policy if it fails.
+// The method is the name of the RPC method (e.g., "Repos.Get").
+func (p *RetryPolicy) do(ctx context.Context, method string, call func() error) error {
+	if p == nil || p.MaxAttempts <= 1 || !p.idempotent(method) {
+		return call()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := call()
+		if err == nil {
+			p.Budget.success()
+			return nil
+		}
+		if !p.retryable(err) {
+			return err
+		}
+		if !p.Budget.failure() || attempt >= p.MaxAttempts {
+			return err
+		}
+
+		t := time.NewTimer(p.backoff(attempt))
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+func (p *RetryPolicy) idempotent(method string) bool {
+	if p.Idempotent != nil {
+		return p.Idempotent(method)
+	}
+	return IsIdempotent(method)
+}
+
+// retryable reports whether err's code is one of the policy's Codes.
+func (p *RetryPolicy) retryable(err error) bool {
+	code := grpc.Code(err)
+	if p.Codes == nil {
+		return code == codes.Unavailable
+	}
+	for _, c := range p.Codes {
+		if code == c {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutCode returns a copy of the policy that doesn't retry calls
+// that fail with code (or p itself, if it doesn't retry them).
+func (p *RetryPolicy) withoutCode(code codes.Code) *RetryPolicy {
+	var keep []codes.Code
+	for _, c := range p.Codes {
+		if c != code {
+			keep = append(keep, c)
+		}
+	}
+	if len(keep) == len(p.Codes) {
+		return p
+	}
+	cpy := *p
+	cpy.Codes = keep
+	if cpy.Codes == nil {
+		cpy.Codes = []codes.Code{} // nil means the default codes
+	}
+	return &cpy
+}
+
+// backoff returns the delay before the retry that follows the given
+// (1-based) attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	mult := p.Multiplier
+	if mult == 0 {
+		mult = 2
+	}
+	d := float64(p.InitialBackoff) * math.Pow(mult, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	if d < 0 {
+		return 0
+	}
+	return time.Duration(d)
+}
+
+// idempotentMethodPrefixes are the prefixes of the names of methods
+// that only read data (e.g., "Get" and "List"), which IsIdempotent
+// reports as idempotent.
+var idempotentMethodPrefixes = []string{
+	"Complete",
+	"Config",
+	"Count",
+	"Get",
+	"Identify",
+	"List",
+	"Lookup",
+	"PubKey",
+	"Read",
+	"Render",
+	"Search",
+	"Stat",
+	"Status",
+	"Suggest",
+}
+
+// nonIdempotentMethods are methods whose names have one of the
+// idempotentMethodPrefixes but that have side effects.
+var nonIdempotentMethods = map[string]bool{
+	"Auth.GetAccessToken":       true, // consumes the authorization code
+	"Auth.GetAuthorizationCode": true, // issues a new code
+}
+
+// IsIdempotent reports whether calling method (e.g., "Repos.Get") more
+// than once has the same effect as calling it once. Methods that only
+// read data (e.g., Get, List and Search methods) are idempotent;
+// methods that create or modify data (e.g., Create, Update and Merge
+// methods) are not.
+func IsIdempotent(method string) bool {
+	if nonIdempotentMethods[method] {
+		return false
+	}
+	name := method[strings.Index(method, ".")+1:]
+	for _, prefix := range idempotentMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// A RetryBudget limits retries when many calls are failing (e.g.,
+// because the server is overloaded), so that retries don't make
+// matters worse. Each failed call consumes a token and each
+// successful call adds a fraction of a token. Retries are allowed
+// only while more than half of the tokens remain.
+//
+// A RetryBudget is safe for concurrent use.
+type RetryBudget struct {
+	mu     sync.Mutex
+	tokens float64
+	max    float64
+	ratio  float64
+}
+
+// NewRetryBudget returns a retry budget with maxTokens tokens, which
+// are replenished at tokenRatio tokens per successful call.
+func NewRetryBudget(maxTokens, tokenRatio float64) *RetryBudget {
+	return &RetryBudget{tokens: maxTokens, max: maxTokens, ratio: tokenRatio}
+}
+
+func (b *RetryBudget) success() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.tokens+b.ratio, b.max)
+}
+
+// failure records a failed call and reports whether it may be
+// retried.
+func (b *RetryBudget) failure() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Max(b.tokens-1, 0)
+	return b.tokens > b.max/2
+}
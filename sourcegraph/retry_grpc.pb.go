@@ -0,0 +1,1413 @@
+// GENERATED CODE - DO NOT EDIT!
+//
+// Generated by:
+//
+//   go run gen/wrappers.go -kind retry -o retry_grpc.pb.go sourcegraph.pb.go
+//
+// Called via:
+//
+//   go generate
+//
+
+package sourcegraph
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+	"sourcegraph.com/sourcegraph/srclib/unit"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+type RetryAccessTokensClient struct {
+	AccessTokensClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryAccessTokensClient) Create(ctx context.Context, in *AccessTokensCreateOp, opts ...grpc.CallOption) (*NewAccessToken, error) {
+	var result *NewAccessToken
+	err := s.Policy.do(ctx, "AccessTokens.Create", func() error {
+		var err error
+		result, err = s.AccessTokensClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryAccessTokensClient) List(ctx context.Context, in *AccessTokensListOptions, opts ...grpc.CallOption) (*AccessTokenList, error) {
+	var result *AccessTokenList
+	err := s.Policy.do(ctx, "AccessTokens.List", func() error {
+		var err error
+		result, err = s.AccessTokensClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryAccessTokensClient) Revoke(ctx context.Context, in *AccessTokenSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "AccessTokens.Revoke", func() error {
+		var err error
+		result, err = s.AccessTokensClient.Revoke(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryAccountsClient struct {
+	AccountsClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryAccountsClient) Create(ctx context.Context, in *NewAccount, opts ...grpc.CallOption) (*UserSpec, error) {
+	var result *UserSpec
+	err := s.Policy.do(ctx, "Accounts.Create", func() error {
+		var err error
+		result, err = s.AccountsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryAccountsClient) RequestPasswordReset(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (*User, error) {
+	var result *User
+	err := s.Policy.do(ctx, "Accounts.RequestPasswordReset", func() error {
+		var err error
+		result, err = s.AccountsClient.RequestPasswordReset(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryAccountsClient) ResetPassword(ctx context.Context, in *NewPassword, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "Accounts.ResetPassword", func() error {
+		var err error
+		result, err = s.AccountsClient.ResetPassword(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryAccountsClient) Update(ctx context.Context, in *User, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "Accounts.Update", func() error {
+		var err error
+		result, err = s.AccountsClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryAuditLogClient struct {
+	AuditLogClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryAuditLogClient) List(ctx context.Context, in *AuditEventListOptions, opts ...grpc.CallOption) (*AuditEventList, error) {
+	var result *AuditEventList
+	err := s.Policy.do(ctx, "AuditLog.List", func() error {
+		var err error
+		result, err = s.AuditLogClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryAuthClient struct {
+	AuthClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryAuthClient) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest, opts ...grpc.CallOption) (*AuthorizationCode, error) {
+	var result *AuthorizationCode
+	err := s.Policy.do(ctx, "Auth.GetAuthorizationCode", func() error {
+		var err error
+		result, err = s.AuthClient.GetAuthorizationCode(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryAuthClient) GetAccessToken(ctx context.Context, in *AccessTokenRequest, opts ...grpc.CallOption) (*AccessTokenResponse, error) {
+	var result *AccessTokenResponse
+	err := s.Policy.do(ctx, "Auth.GetAccessToken", func() error {
+		var err error
+		result, err = s.AuthClient.GetAccessToken(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryAuthClient) Identify(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*AuthInfo, error) {
+	var result *AuthInfo
+	err := s.Policy.do(ctx, "Auth.Identify", func() error {
+		var err error
+		result, err = s.AuthClient.Identify(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryAuthClient) GetPermissions(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*UserPermissions, error) {
+	var result *UserPermissions
+	err := s.Policy.do(ctx, "Auth.GetPermissions", func() error {
+		var err error
+		result, err = s.AuthClient.GetPermissions(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryBuildsClient struct {
+	BuildsClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryBuildsClient) Get(ctx context.Context, in *BuildSpec, opts ...grpc.CallOption) (*Build, error) {
+	var result *Build
+	err := s.Policy.do(ctx, "Builds.Get", func() error {
+		var err error
+		result, err = s.BuildsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryBuildsClient) GetRepoBuildInfo(ctx context.Context, in *BuildsGetRepoBuildInfoOp, opts ...grpc.CallOption) (*RepoBuildInfo, error) {
+	var result *RepoBuildInfo
+	err := s.Policy.do(ctx, "Builds.GetRepoBuildInfo", func() error {
+		var err error
+		result, err = s.BuildsClient.GetRepoBuildInfo(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryBuildsClient) List(ctx context.Context, in *BuildListOptions, opts ...grpc.CallOption) (*BuildList, error) {
+	var result *BuildList
+	err := s.Policy.do(ctx, "Builds.List", func() error {
+		var err error
+		result, err = s.BuildsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryBuildsClient) Create(ctx context.Context, in *BuildsCreateOp, opts ...grpc.CallOption) (*Build, error) {
+	var result *Build
+	err := s.Policy.do(ctx, "Builds.Create", func() error {
+		var err error
+		result, err = s.BuildsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryBuildsClient) Update(ctx context.Context, in *BuildsUpdateOp, opts ...grpc.CallOption) (*Build, error) {
+	var result *Build
+	err := s.Policy.do(ctx, "Builds.Update", func() error {
+		var err error
+		result, err = s.BuildsClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryBuildsClient) ListBuildTasks(ctx context.Context, in *BuildsListBuildTasksOp, opts ...grpc.CallOption) (*BuildTaskList, error) {
+	var result *BuildTaskList
+	err := s.Policy.do(ctx, "Builds.ListBuildTasks", func() error {
+		var err error
+		result, err = s.BuildsClient.ListBuildTasks(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryBuildsClient) CreateTasks(ctx context.Context, in *BuildsCreateTasksOp, opts ...grpc.CallOption) (*BuildTaskList, error) {
+	var result *BuildTaskList
+	err := s.Policy.do(ctx, "Builds.CreateTasks", func() error {
+		var err error
+		result, err = s.BuildsClient.CreateTasks(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryBuildsClient) UpdateTask(ctx context.Context, in *BuildsUpdateTaskOp, opts ...grpc.CallOption) (*BuildTask, error) {
+	var result *BuildTask
+	err := s.Policy.do(ctx, "Builds.UpdateTask", func() error {
+		var err error
+		result, err = s.BuildsClient.UpdateTask(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryBuildsClient) GetLog(ctx context.Context, in *BuildsGetLogOp, opts ...grpc.CallOption) (*LogEntries, error) {
+	var result *LogEntries
+	err := s.Policy.do(ctx, "Builds.GetLog", func() error {
+		var err error
+		result, err = s.BuildsClient.GetLog(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryBuildsClient) GetTaskLog(ctx context.Context, in *BuildsGetTaskLogOp, opts ...grpc.CallOption) (*LogEntries, error) {
+	var result *LogEntries
+	err := s.Policy.do(ctx, "Builds.GetTaskLog", func() error {
+		var err error
+		result, err = s.BuildsClient.GetTaskLog(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryBuildsClient) DequeueNext(ctx context.Context, in *BuildsDequeueNextOp, opts ...grpc.CallOption) (*Build, error) {
+	var result *Build
+	err := s.Policy.do(ctx, "Builds.DequeueNext", func() error {
+		var err error
+		result, err = s.BuildsClient.DequeueNext(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryChangesetsClient struct {
+	ChangesetsClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryChangesetsClient) Create(ctx context.Context, in *ChangesetCreateOp, opts ...grpc.CallOption) (*Changeset, error) {
+	var result *Changeset
+	err := s.Policy.do(ctx, "Changesets.Create", func() error {
+		var err error
+		result, err = s.ChangesetsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryChangesetsClient) Get(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (*Changeset, error) {
+	var result *Changeset
+	err := s.Policy.do(ctx, "Changesets.Get", func() error {
+		var err error
+		result, err = s.ChangesetsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryChangesetsClient) List(ctx context.Context, in *ChangesetListOp, opts ...grpc.CallOption) (*ChangesetList, error) {
+	var result *ChangesetList
+	err := s.Policy.do(ctx, "Changesets.List", func() error {
+		var err error
+		result, err = s.ChangesetsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryChangesetsClient) Update(ctx context.Context, in *ChangesetUpdateOp, opts ...grpc.CallOption) (*ChangesetEvent, error) {
+	var result *ChangesetEvent
+	err := s.Policy.do(ctx, "Changesets.Update", func() error {
+		var err error
+		result, err = s.ChangesetsClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryChangesetsClient) Merge(ctx context.Context, in *ChangesetMergeOp, opts ...grpc.CallOption) (*ChangesetEvent, error) {
+	var result *ChangesetEvent
+	err := s.Policy.do(ctx, "Changesets.Merge", func() error {
+		var err error
+		result, err = s.ChangesetsClient.Merge(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryChangesetsClient) UpdateAffected(ctx context.Context, in *ChangesetUpdateAffectedOp, opts ...grpc.CallOption) (*ChangesetEventList, error) {
+	var result *ChangesetEventList
+	err := s.Policy.do(ctx, "Changesets.UpdateAffected", func() error {
+		var err error
+		result, err = s.ChangesetsClient.UpdateAffected(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryChangesetsClient) CreateReview(ctx context.Context, in *ChangesetCreateReviewOp, opts ...grpc.CallOption) (*ChangesetReview, error) {
+	var result *ChangesetReview
+	err := s.Policy.do(ctx, "Changesets.CreateReview", func() error {
+		var err error
+		result, err = s.ChangesetsClient.CreateReview(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryChangesetsClient) ListReviews(ctx context.Context, in *ChangesetListReviewsOp, opts ...grpc.CallOption) (*ChangesetReviewList, error) {
+	var result *ChangesetReviewList
+	err := s.Policy.do(ctx, "Changesets.ListReviews", func() error {
+		var err error
+		result, err = s.ChangesetsClient.ListReviews(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryChangesetsClient) ListEvents(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (*ChangesetEventList, error) {
+	var result *ChangesetEventList
+	err := s.Policy.do(ctx, "Changesets.ListEvents", func() error {
+		var err error
+		result, err = s.ChangesetsClient.ListEvents(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryDefsClient struct {
+	DefsClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryDefsClient) Get(ctx context.Context, in *DefsGetOp, opts ...grpc.CallOption) (*Def, error) {
+	var result *Def
+	err := s.Policy.do(ctx, "Defs.Get", func() error {
+		var err error
+		result, err = s.DefsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDefsClient) List(ctx context.Context, in *DefListOptions, opts ...grpc.CallOption) (*DefList, error) {
+	var result *DefList
+	err := s.Policy.do(ctx, "Defs.List", func() error {
+		var err error
+		result, err = s.DefsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDefsClient) ListRefs(ctx context.Context, in *DefsListRefsOp, opts ...grpc.CallOption) (*RefList, error) {
+	var result *RefList
+	err := s.Policy.do(ctx, "Defs.ListRefs", func() error {
+		var err error
+		result, err = s.DefsClient.ListRefs(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDefsClient) ListExamples(ctx context.Context, in *DefsListExamplesOp, opts ...grpc.CallOption) (*ExampleList, error) {
+	var result *ExampleList
+	err := s.Policy.do(ctx, "Defs.ListExamples", func() error {
+		var err error
+		result, err = s.DefsClient.ListExamples(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDefsClient) ListAuthors(ctx context.Context, in *DefsListAuthorsOp, opts ...grpc.CallOption) (*DefAuthorList, error) {
+	var result *DefAuthorList
+	err := s.Policy.do(ctx, "Defs.ListAuthors", func() error {
+		var err error
+		result, err = s.DefsClient.ListAuthors(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDefsClient) ListClients(ctx context.Context, in *DefsListClientsOp, opts ...grpc.CallOption) (*DefClientList, error) {
+	var result *DefClientList
+	err := s.Policy.do(ctx, "Defs.ListClients", func() error {
+		var err error
+		result, err = s.DefsClient.ListClients(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryDeltasClient struct {
+	DeltasClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryDeltasClient) Get(ctx context.Context, in *DeltaSpec, opts ...grpc.CallOption) (*Delta, error) {
+	var result *Delta
+	err := s.Policy.do(ctx, "Deltas.Get", func() error {
+		var err error
+		result, err = s.DeltasClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDeltasClient) ListUnits(ctx context.Context, in *DeltasListUnitsOp, opts ...grpc.CallOption) (*UnitDeltaList, error) {
+	var result *UnitDeltaList
+	err := s.Policy.do(ctx, "Deltas.ListUnits", func() error {
+		var err error
+		result, err = s.DeltasClient.ListUnits(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDeltasClient) ListDefs(ctx context.Context, in *DeltasListDefsOp, opts ...grpc.CallOption) (*DeltaDefs, error) {
+	var result *DeltaDefs
+	err := s.Policy.do(ctx, "Deltas.ListDefs", func() error {
+		var err error
+		result, err = s.DeltasClient.ListDefs(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDeltasClient) ListFiles(ctx context.Context, in *DeltasListFilesOp, opts ...grpc.CallOption) (*DeltaFiles, error) {
+	var result *DeltaFiles
+	err := s.Policy.do(ctx, "Deltas.ListFiles", func() error {
+		var err error
+		result, err = s.DeltasClient.ListFiles(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDeltasClient) ListAffectedAuthors(ctx context.Context, in *DeltasListAffectedAuthorsOp, opts ...grpc.CallOption) (*DeltaAffectedPersonList, error) {
+	var result *DeltaAffectedPersonList
+	err := s.Policy.do(ctx, "Deltas.ListAffectedAuthors", func() error {
+		var err error
+		result, err = s.DeltasClient.ListAffectedAuthors(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDeltasClient) ListAffectedClients(ctx context.Context, in *DeltasListAffectedClientsOp, opts ...grpc.CallOption) (*DeltaAffectedPersonList, error) {
+	var result *DeltaAffectedPersonList
+	err := s.Policy.do(ctx, "Deltas.ListAffectedClients", func() error {
+		var err error
+		result, err = s.DeltasClient.ListAffectedClients(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryDiscussionsClient struct {
+	DiscussionsClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryDiscussionsClient) Create(ctx context.Context, in *Discussion, opts ...grpc.CallOption) (*Discussion, error) {
+	var result *Discussion
+	err := s.Policy.do(ctx, "Discussions.Create", func() error {
+		var err error
+		result, err = s.DiscussionsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDiscussionsClient) Get(ctx context.Context, in *DiscussionSpec, opts ...grpc.CallOption) (*Discussion, error) {
+	var result *Discussion
+	err := s.Policy.do(ctx, "Discussions.Get", func() error {
+		var err error
+		result, err = s.DiscussionsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDiscussionsClient) List(ctx context.Context, in *DiscussionListOp, opts ...grpc.CallOption) (*DiscussionList, error) {
+	var result *DiscussionList
+	err := s.Policy.do(ctx, "Discussions.List", func() error {
+		var err error
+		result, err = s.DiscussionsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDiscussionsClient) CreateComment(ctx context.Context, in *DiscussionCommentCreateOp, opts ...grpc.CallOption) (*DiscussionComment, error) {
+	var result *DiscussionComment
+	err := s.Policy.do(ctx, "Discussions.CreateComment", func() error {
+		var err error
+		result, err = s.DiscussionsClient.CreateComment(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryDiscussionsClient) UpdateRating(ctx context.Context, in *DiscussionRatingUpdateOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "Discussions.UpdateRating", func() error {
+		var err error
+		result, err = s.DiscussionsClient.UpdateRating(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryGraphUplinkClient struct {
+	GraphUplinkClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryGraphUplinkClient) Push(ctx context.Context, in *MetricsSnapshot, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "GraphUplink.Push", func() error {
+		var err error
+		result, err = s.GraphUplinkClient.Push(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryGraphUplinkClient) PushEvents(ctx context.Context, in *UserEventList, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "GraphUplink.PushEvents", func() error {
+		var err error
+		result, err = s.GraphUplinkClient.PushEvents(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryMarkdownClient struct {
+	MarkdownClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryMarkdownClient) Render(ctx context.Context, in *MarkdownRenderOp, opts ...grpc.CallOption) (*MarkdownData, error) {
+	var result *MarkdownData
+	err := s.Policy.do(ctx, "Markdown.Render", func() error {
+		var err error
+		result, err = s.MarkdownClient.Render(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryMetaClient struct {
+	MetaClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryMetaClient) Status(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerStatus, error) {
+	var result *ServerStatus
+	err := s.Policy.do(ctx, "Meta.Status", func() error {
+		var err error
+		result, err = s.MetaClient.Status(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryMetaClient) Config(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerConfig, error) {
+	var result *ServerConfig
+	err := s.Policy.do(ctx, "Meta.Config", func() error {
+		var err error
+		result, err = s.MetaClient.Config(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryMetaClient) PubKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerPubKey, error) {
+	var result *ServerPubKey
+	err := s.Policy.do(ctx, "Meta.PubKey", func() error {
+		var err error
+		result, err = s.MetaClient.PubKey(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryMirrorReposClient struct {
+	MirrorReposClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryMirrorReposClient) RefreshVCS(ctx context.Context, in *MirrorReposRefreshVCSOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "MirrorRepos.RefreshVCS", func() error {
+		var err error
+		result, err = s.MirrorReposClient.RefreshVCS(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryMirroredRepoSSHKeysClient struct {
+	MirroredRepoSSHKeysClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryMirroredRepoSSHKeysClient) Create(ctx context.Context, in *MirroredRepoSSHKeysCreateOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "MirroredRepoSSHKeys.Create", func() error {
+		var err error
+		result, err = s.MirroredRepoSSHKeysClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryMirroredRepoSSHKeysClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*SSHPrivateKey, error) {
+	var result *SSHPrivateKey
+	err := s.Policy.do(ctx, "MirroredRepoSSHKeys.Get", func() error {
+		var err error
+		result, err = s.MirroredRepoSSHKeysClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryMirroredRepoSSHKeysClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "MirroredRepoSSHKeys.Delete", func() error {
+		var err error
+		result, err = s.MirroredRepoSSHKeysClient.Delete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryNotifyClient struct {
+	NotifyClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryNotifyClient) GenericEvent(ctx context.Context, in *NotifyGenericEvent, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "Notify.GenericEvent", func() error {
+		var err error
+		result, err = s.NotifyClient.GenericEvent(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryOrgsClient struct {
+	OrgsClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryOrgsClient) Get(ctx context.Context, in *OrgSpec, opts ...grpc.CallOption) (*Org, error) {
+	var result *Org
+	err := s.Policy.do(ctx, "Orgs.Get", func() error {
+		var err error
+		result, err = s.OrgsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryOrgsClient) List(ctx context.Context, in *OrgsListOp, opts ...grpc.CallOption) (*OrgList, error) {
+	var result *OrgList
+	err := s.Policy.do(ctx, "Orgs.List", func() error {
+		var err error
+		result, err = s.OrgsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryOrgsClient) ListMembers(ctx context.Context, in *OrgsListMembersOp, opts ...grpc.CallOption) (*UserList, error) {
+	var result *UserList
+	err := s.Policy.do(ctx, "Orgs.ListMembers", func() error {
+		var err error
+		result, err = s.OrgsClient.ListMembers(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryPeopleClient struct {
+	PeopleClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryPeopleClient) Get(ctx context.Context, in *PersonSpec, opts ...grpc.CallOption) (*Person, error) {
+	var result *Person
+	err := s.Policy.do(ctx, "People.Get", func() error {
+		var err error
+		result, err = s.PeopleClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryRegisteredClientsClient struct {
+	RegisteredClientsClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryRegisteredClientsClient) Get(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	var result *RegisteredClient
+	err := s.Policy.do(ctx, "RegisteredClients.Get", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRegisteredClientsClient) GetCurrent(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	var result *RegisteredClient
+	err := s.Policy.do(ctx, "RegisteredClients.GetCurrent", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.GetCurrent(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRegisteredClientsClient) Create(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	var result *RegisteredClient
+	err := s.Policy.do(ctx, "RegisteredClients.Create", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRegisteredClientsClient) Update(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "RegisteredClients.Update", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRegisteredClientsClient) Delete(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "RegisteredClients.Delete", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.Delete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRegisteredClientsClient) List(ctx context.Context, in *RegisteredClientListOptions, opts ...grpc.CallOption) (*RegisteredClientList, error) {
+	var result *RegisteredClientList
+	err := s.Policy.do(ctx, "RegisteredClients.List", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRegisteredClientsClient) GetUserPermissions(ctx context.Context, in *UserPermissionsOptions, opts ...grpc.CallOption) (*UserPermissions, error) {
+	var result *UserPermissions
+	err := s.Policy.do(ctx, "RegisteredClients.GetUserPermissions", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.GetUserPermissions(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRegisteredClientsClient) SetUserPermissions(ctx context.Context, in *UserPermissions, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "RegisteredClients.SetUserPermissions", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.SetUserPermissions(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRegisteredClientsClient) ListUserPermissions(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*UserPermissionsList, error) {
+	var result *UserPermissionsList
+	err := s.Policy.do(ctx, "RegisteredClients.ListUserPermissions", func() error {
+		var err error
+		result, err = s.RegisteredClientsClient.ListUserPermissions(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryRepoBadgesClient struct {
+	RepoBadgesClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryRepoBadgesClient) ListBadges(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*BadgeList, error) {
+	var result *BadgeList
+	err := s.Policy.do(ctx, "RepoBadges.ListBadges", func() error {
+		var err error
+		result, err = s.RepoBadgesClient.ListBadges(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRepoBadgesClient) ListCounters(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*CounterList, error) {
+	var result *CounterList
+	err := s.Policy.do(ctx, "RepoBadges.ListCounters", func() error {
+		var err error
+		result, err = s.RepoBadgesClient.ListCounters(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRepoBadgesClient) RecordHit(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "RepoBadges.RecordHit", func() error {
+		var err error
+		result, err = s.RepoBadgesClient.RecordHit(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRepoBadgesClient) CountHits(ctx context.Context, in *RepoBadgesCountHitsOp, opts ...grpc.CallOption) (*RepoBadgesCountHitsResult, error) {
+	var result *RepoBadgesCountHitsResult
+	err := s.Policy.do(ctx, "RepoBadges.CountHits", func() error {
+		var err error
+		result, err = s.RepoBadgesClient.CountHits(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryRepoStatusesClient struct {
+	RepoStatusesClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryRepoStatusesClient) GetCombined(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*CombinedStatus, error) {
+	var result *CombinedStatus
+	err := s.Policy.do(ctx, "RepoStatuses.GetCombined", func() error {
+		var err error
+		result, err = s.RepoStatusesClient.GetCombined(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRepoStatusesClient) Create(ctx context.Context, in *RepoStatusesCreateOp, opts ...grpc.CallOption) (*RepoStatus, error) {
+	var result *RepoStatus
+	err := s.Policy.do(ctx, "RepoStatuses.Create", func() error {
+		var err error
+		result, err = s.RepoStatusesClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryRepoTreeClient struct {
+	RepoTreeClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryRepoTreeClient) Get(ctx context.Context, in *RepoTreeGetOp, opts ...grpc.CallOption) (*TreeEntry, error) {
+	var result *TreeEntry
+	err := s.Policy.do(ctx, "RepoTree.Get", func() error {
+		var err error
+		result, err = s.RepoTreeClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRepoTreeClient) Search(ctx context.Context, in *RepoTreeSearchOp, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	var result *VCSSearchResultList
+	err := s.Policy.do(ctx, "RepoTree.Search", func() error {
+		var err error
+		result, err = s.RepoTreeClient.Search(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryRepoTreeClient) List(ctx context.Context, in *RepoTreeListOp, opts ...grpc.CallOption) (*RepoTreeListResult, error) {
+	var result *RepoTreeListResult
+	err := s.Policy.do(ctx, "RepoTree.List", func() error {
+		var err error
+		result, err = s.RepoTreeClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryReposClient struct {
+	ReposClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryReposClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*Repo, error) {
+	var result *Repo
+	err := s.Policy.do(ctx, "Repos.Get", func() error {
+		var err error
+		result, err = s.ReposClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) List(ctx context.Context, in *RepoListOptions, opts ...grpc.CallOption) (*RepoList, error) {
+	var result *RepoList
+	err := s.Policy.do(ctx, "Repos.List", func() error {
+		var err error
+		result, err = s.ReposClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) Create(ctx context.Context, in *ReposCreateOp, opts ...grpc.CallOption) (*Repo, error) {
+	var result *Repo
+	err := s.Policy.do(ctx, "Repos.Create", func() error {
+		var err error
+		result, err = s.ReposClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) Update(ctx context.Context, in *ReposUpdateOp, opts ...grpc.CallOption) (*Repo, error) {
+	var result *Repo
+	err := s.Policy.do(ctx, "Repos.Update", func() error {
+		var err error
+		result, err = s.ReposClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "Repos.Delete", func() error {
+		var err error
+		result, err = s.ReposClient.Delete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) GetReadme(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*Readme, error) {
+	var result *Readme
+	err := s.Policy.do(ctx, "Repos.GetReadme", func() error {
+		var err error
+		result, err = s.ReposClient.GetReadme(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) Enable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "Repos.Enable", func() error {
+		var err error
+		result, err = s.ReposClient.Enable(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) Disable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "Repos.Disable", func() error {
+		var err error
+		result, err = s.ReposClient.Disable(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) GetConfig(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*RepoConfig, error) {
+	var result *RepoConfig
+	err := s.Policy.do(ctx, "Repos.GetConfig", func() error {
+		var err error
+		result, err = s.ReposClient.GetConfig(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) GetCommit(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*vcs.Commit, error) {
+	var result *vcs.Commit
+	err := s.Policy.do(ctx, "Repos.GetCommit", func() error {
+		var err error
+		result, err = s.ReposClient.GetCommit(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) ListCommits(ctx context.Context, in *ReposListCommitsOp, opts ...grpc.CallOption) (*CommitList, error) {
+	var result *CommitList
+	err := s.Policy.do(ctx, "Repos.ListCommits", func() error {
+		var err error
+		result, err = s.ReposClient.ListCommits(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) ListBranches(ctx context.Context, in *ReposListBranchesOp, opts ...grpc.CallOption) (*BranchList, error) {
+	var result *BranchList
+	err := s.Policy.do(ctx, "Repos.ListBranches", func() error {
+		var err error
+		result, err = s.ReposClient.ListBranches(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) ListTags(ctx context.Context, in *ReposListTagsOp, opts ...grpc.CallOption) (*TagList, error) {
+	var result *TagList
+	err := s.Policy.do(ctx, "Repos.ListTags", func() error {
+		var err error
+		result, err = s.ReposClient.ListTags(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryReposClient) ListCommitters(ctx context.Context, in *ReposListCommittersOp, opts ...grpc.CallOption) (*CommitterList, error) {
+	var result *CommitterList
+	err := s.Policy.do(ctx, "Repos.ListCommitters", func() error {
+		var err error
+		result, err = s.ReposClient.ListCommitters(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetrySavedSearchesClient struct {
+	SavedSearchesClient
+	Policy *RetryPolicy
+}
+
+func (s *RetrySavedSearchesClient) Create(ctx context.Context, in *SavedSearchesCreateOp, opts ...grpc.CallOption) (*SavedSearch, error) {
+	var result *SavedSearch
+	err := s.Policy.do(ctx, "SavedSearches.Create", func() error {
+		var err error
+		result, err = s.SavedSearchesClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetrySavedSearchesClient) List(ctx context.Context, in *SavedSearchListOptions, opts ...grpc.CallOption) (*SavedSearchList, error) {
+	var result *SavedSearchList
+	err := s.Policy.do(ctx, "SavedSearches.List", func() error {
+		var err error
+		result, err = s.SavedSearchesClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetrySavedSearchesClient) Delete(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "SavedSearches.Delete", func() error {
+		var err error
+		result, err = s.SavedSearchesClient.Delete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetrySavedSearchesClient) Run(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*SavedSearchRunResult, error) {
+	var result *SavedSearchRunResult
+	err := s.Policy.do(ctx, "SavedSearches.Run", func() error {
+		var err error
+		result, err = s.SavedSearchesClient.Run(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetrySearchClient struct {
+	SearchClient
+	Policy *RetryPolicy
+}
+
+func (s *RetrySearchClient) Search(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (*SearchResults, error) {
+	var result *SearchResults
+	err := s.Policy.do(ctx, "Search.Search", func() error {
+		var err error
+		result, err = s.SearchClient.Search(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetrySearchClient) SearchTokens(ctx context.Context, in *TokenSearchOptions, opts ...grpc.CallOption) (*DefList, error) {
+	var result *DefList
+	err := s.Policy.do(ctx, "Search.SearchTokens", func() error {
+		var err error
+		result, err = s.SearchClient.SearchTokens(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetrySearchClient) SearchText(ctx context.Context, in *TextSearchOptions, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	var result *VCSSearchResultList
+	err := s.Policy.do(ctx, "Search.SearchText", func() error {
+		var err error
+		result, err = s.SearchClient.SearchText(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetrySearchClient) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions, opts ...grpc.CallOption) (*MultiTextSearchResults, error) {
+	var result *MultiTextSearchResults
+	err := s.Policy.do(ctx, "Search.SearchTextMulti", func() error {
+		var err error
+		result, err = s.SearchClient.SearchTextMulti(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetrySearchClient) Complete(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*Completions, error) {
+	var result *Completions
+	err := s.Policy.do(ctx, "Search.Complete", func() error {
+		var err error
+		result, err = s.SearchClient.Complete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetrySearchClient) Suggest(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*SuggestionList, error) {
+	var result *SuggestionList
+	err := s.Policy.do(ctx, "Search.Suggest", func() error {
+		var err error
+		result, err = s.SearchClient.Suggest(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryStorageClient struct {
+	StorageClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryStorageClient) Create(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	var result *StorageError
+	err := s.Policy.do(ctx, "Storage.Create", func() error {
+		var err error
+		result, err = s.StorageClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryStorageClient) RemoveAll(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	var result *StorageError
+	err := s.Policy.do(ctx, "Storage.RemoveAll", func() error {
+		var err error
+		result, err = s.StorageClient.RemoveAll(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryStorageClient) Read(ctx context.Context, in *StorageReadOp, opts ...grpc.CallOption) (*StorageRead, error) {
+	var result *StorageRead
+	err := s.Policy.do(ctx, "Storage.Read", func() error {
+		var err error
+		result, err = s.StorageClient.Read(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryStorageClient) Write(ctx context.Context, in *StorageWriteOp, opts ...grpc.CallOption) (*StorageWrite, error) {
+	var result *StorageWrite
+	err := s.Policy.do(ctx, "Storage.Write", func() error {
+		var err error
+		result, err = s.StorageClient.Write(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryStorageClient) Stat(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageStat, error) {
+	var result *StorageStat
+	err := s.Policy.do(ctx, "Storage.Stat", func() error {
+		var err error
+		result, err = s.StorageClient.Stat(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryStorageClient) ReadDir(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageReadDir, error) {
+	var result *StorageReadDir
+	err := s.Policy.do(ctx, "Storage.ReadDir", func() error {
+		var err error
+		result, err = s.StorageClient.ReadDir(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryStorageClient) Close(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	var result *StorageError
+	err := s.Policy.do(ctx, "Storage.Close", func() error {
+		var err error
+		result, err = s.StorageClient.Close(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryUnitsClient struct {
+	UnitsClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryUnitsClient) Get(ctx context.Context, in *UnitSpec, opts ...grpc.CallOption) (*unit.RepoSourceUnit, error) {
+	var result *unit.RepoSourceUnit
+	err := s.Policy.do(ctx, "Units.Get", func() error {
+		var err error
+		result, err = s.UnitsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryUnitsClient) List(ctx context.Context, in *UnitListOptions, opts ...grpc.CallOption) (*RepoSourceUnitList, error) {
+	var result *RepoSourceUnitList
+	err := s.Policy.do(ctx, "Units.List", func() error {
+		var err error
+		result, err = s.UnitsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryUserKeysClient struct {
+	UserKeysClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryUserKeysClient) AddKey(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "UserKeys.AddKey", func() error {
+		var err error
+		result, err = s.UserKeysClient.AddKey(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryUserKeysClient) LookupUser(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (*UserSpec, error) {
+	var result *UserSpec
+	err := s.Policy.do(ctx, "UserKeys.LookupUser", func() error {
+		var err error
+		result, err = s.UserKeysClient.LookupUser(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryUserKeysClient) DeleteKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := s.Policy.do(ctx, "UserKeys.DeleteKey", func() error {
+		var err error
+		result, err = s.UserKeysClient.DeleteKey(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type RetryUsersClient struct {
+	UsersClient
+	Policy *RetryPolicy
+}
+
+func (s *RetryUsersClient) Get(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*User, error) {
+	var result *User
+	err := s.Policy.do(ctx, "Users.Get", func() error {
+		var err error
+		result, err = s.UsersClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryUsersClient) GetWithEmail(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (*User, error) {
+	var result *User
+	err := s.Policy.do(ctx, "Users.GetWithEmail", func() error {
+		var err error
+		result, err = s.UsersClient.GetWithEmail(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryUsersClient) ListEmails(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*EmailAddrList, error) {
+	var result *EmailAddrList
+	err := s.Policy.do(ctx, "Users.ListEmails", func() error {
+		var err error
+		result, err = s.UsersClient.ListEmails(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *RetryUsersClient) List(ctx context.Context, in *UsersListOptions, opts ...grpc.CallOption) (*UserList, error) {
+	var result *UserList
+	err := s.Policy.do(ctx, "Users.List", func() error {
+		var err error
+		result, err = s.UsersClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+// retryClient wraps each of c's services in a Retry*Client that
+// retries calls according to policy.
+func retryClient(c *Client, policy *RetryPolicy) {
+	c.AccessTokens = &RetryAccessTokensClient{c.AccessTokens, policy}
+	c.Accounts = &RetryAccountsClient{c.Accounts, policy}
+	c.AuditLog = &RetryAuditLogClient{c.AuditLog, policy}
+	c.Auth = &RetryAuthClient{c.Auth, policy}
+	c.Builds = &RetryBuildsClient{c.Builds, policy}
+	c.Changesets = &RetryChangesetsClient{c.Changesets, policy}
+	c.Defs = &RetryDefsClient{c.Defs, policy}
+	c.Deltas = &RetryDeltasClient{c.Deltas, policy}
+	c.Discussions = &RetryDiscussionsClient{c.Discussions, policy}
+	c.GraphUplink = &RetryGraphUplinkClient{c.GraphUplink, policy}
+	c.Markdown = &RetryMarkdownClient{c.Markdown, policy}
+	c.Meta = &RetryMetaClient{c.Meta, policy}
+	c.MirrorRepos = &RetryMirrorReposClient{c.MirrorRepos, policy}
+	c.MirroredRepoSSHKeys = &RetryMirroredRepoSSHKeysClient{c.MirroredRepoSSHKeys, policy}
+	c.Notify = &RetryNotifyClient{c.Notify, policy}
+	c.Orgs = &RetryOrgsClient{c.Orgs, policy}
+	c.People = &RetryPeopleClient{c.People, policy}
+	c.RegisteredClients = &RetryRegisteredClientsClient{c.RegisteredClients, policy}
+	c.RepoBadges = &RetryRepoBadgesClient{c.RepoBadges, policy}
+	c.RepoStatuses = &RetryRepoStatusesClient{c.RepoStatuses, policy}
+	c.RepoTree = &RetryRepoTreeClient{c.RepoTree, policy}
+	c.Repos = &RetryReposClient{c.Repos, policy}
+	c.SavedSearches = &RetrySavedSearchesClient{c.SavedSearches, policy}
+	c.Search = &RetrySearchClient{c.Search, policy}
+	c.Storage = &RetryStorageClient{c.Storage, policy}
+	c.Units = &RetryUnitsClient{c.Units, policy}
+	c.UserKeys = &RetryUserKeysClient{c.UserKeys, policy}
+	c.Users = &RetryUsersClient{c.Users, policy}
+}
@@ -0,0 +1,205 @@
+package sourcegraph
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func TestIsIdempotent(t *testing.T) {
+	tests := map[string]bool{
+		"Repos.Get":                 true,
+		"Repos.ListCommits":         true,
+		"Defs.ListRefs":             true,
+		"Search.SearchTextMulti":    true,
+		"Storage.ReadDir":           true,
+		"Meta.Status":               true,
+		"Repos.Create":              false,
+		"Repos.Update":              false,
+		"Changesets.Merge":          false,
+		"Builds.DequeueNext":        false,
+		"RepoBadges.RecordHit":      false,
+		"Auth.GetAccessToken":       false,
+		"Auth.GetAuthorizationCode": false,
+	}
+	for method, want := range tests {
+		if got := IsIdempotent(method); got != want {
+			t.Errorf("%s: got IsIdempotent == %v, want %v", method, got, want)
+		}
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, want := range want {
+		if got := p.backoff(i + 1); got != want {
+			t.Errorf("attempt %d: got backoff %s, want %s", i+1, got, want)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(1); got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("got jittered backoff %s, want between 50ms and 150ms", got)
+		}
+	}
+}
+
+// flakyCall returns a func that fails with err the first n times it
+// is called and then succeeds. The number of calls is stored in
+// *calls.
+func flakyCall(n int, err error, calls *int) func() error {
+	return func() error {
+		*calls++
+		if *calls <= n {
+			return err
+		}
+		return nil
+	}
+}
+
+func TestRetryPolicy_do(t *testing.T) {
+	ctx := context.Background()
+	unavailable := grpc.Errorf(codes.Unavailable, "server restarting")
+	p := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	tests := map[string]struct {
+		method    string
+		fails     int
+		err       error
+		wantCalls int
+		wantErr   bool
+	}{
+		"success":           {"Repos.Get", 0, nil, 1, false},
+		"retried":           {"Repos.Get", 2, unavailable, 3, false},
+		"too many attempts": {"Repos.Get", 3, unavailable, 3, true},
+		"not idempotent":    {"Repos.Create", 1, unavailable, 1, true},
+		"other code":        {"Repos.Get", 1, grpc.Errorf(codes.NotFound, "x"), 1, true},
+		"non-gRPC error":    {"Repos.Get", 1, errors.New("x"), 1, true},
+	}
+	for label, test := range tests {
+		var calls int
+		err := p.do(ctx, test.method, flakyCall(test.fails, test.err, &calls))
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Errorf("%s: got error %v, want error: %v", label, err, test.wantErr)
+		}
+		if calls != test.wantCalls {
+			t.Errorf("%s: got %d calls, want %d", label, calls, test.wantCalls)
+		}
+	}
+}
+
+func TestRetryPolicy_do_nil(t *testing.T) {
+	var p *RetryPolicy
+	var calls int
+	if err := p.do(context.Background(), "Repos.Get", flakyCall(1, grpc.Errorf(codes.Unavailable, ""), &calls)); err == nil {
+		t.Error("got err == nil, want non-nil")
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}
+
+func TestRetryPolicy_do_contextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour}
+
+	var calls int
+	call := func() error {
+		calls++
+		cancel()
+		return grpc.Errorf(codes.Unavailable, "")
+	}
+	if err := p.do(ctx, "Repos.Get", call); err != context.Canceled {
+		t.Errorf("got err %v, want %v", err, context.Canceled)
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}
+
+func TestRetryPolicy_withoutCode(t *testing.T) {
+	p := &RetryPolicy{Codes: []codes.Code{codes.Unavailable, codes.ResourceExhausted}}
+	exhausted := grpc.Errorf(codes.ResourceExhausted, "")
+	if p2 := p.withoutCode(codes.ResourceExhausted); p2.retryable(exhausted) || !p2.retryable(grpc.Errorf(codes.Unavailable, "")) {
+		t.Errorf("got codes %v, want only Unavailable", p2.Codes)
+	}
+	if !p.retryable(exhausted) {
+		t.Error("original policy was modified")
+	}
+
+	// Removing the only code retries no calls (not the default codes).
+	p = &RetryPolicy{Codes: []codes.Code{codes.ResourceExhausted}}
+	if p2 := p.withoutCode(codes.ResourceExhausted); p2.retryable(grpc.Errorf(codes.Unavailable, "")) {
+		t.Errorf("got codes %v, want none", p2.Codes)
+	}
+
+	p = &RetryPolicy{}
+	if p2 := p.withoutCode(codes.ResourceExhausted); p2 != p {
+		t.Error("got a copy of a policy that doesn't retry the code")
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	b := NewRetryBudget(4, 0.5)
+	p := &RetryPolicy{MaxAttempts: 10, Budget: b}
+	unavailable := grpc.Errorf(codes.Unavailable, "")
+
+	// The first call fails twice (tokens 4 -> 3 -> 2) and then stops
+	// retrying, because only half of the tokens remain.
+	var calls int
+	if err := p.do(context.Background(), "Repos.Get", flakyCall(100, unavailable, &calls)); err == nil {
+		t.Fatal("got err == nil, want non-nil")
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+
+	// Successful calls replenish the budget (tokens 2 -> 4).
+	for i := 0; i < 4; i++ {
+		if err := p.do(context.Background(), "Repos.Get", func() error { return nil }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	calls = 0
+	if err := p.do(context.Background(), "Repos.Get", flakyCall(1, unavailable, &calls)); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls, want 2", calls)
+	}
+}
+
+type flakyReposClient struct {
+	ReposClient
+	fails, calls int
+}
+
+func (c *flakyReposClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*Repo, error) {
+	c.calls++
+	if c.calls <= c.fails {
+		return nil, grpc.Errorf(codes.Unavailable, "")
+	}
+	return &Repo{URI: in.URI}, nil
+}
+
+func TestRetryReposClient(t *testing.T) {
+	flaky := &flakyReposClient{fails: 1}
+	c := &RetryReposClient{flaky, &RetryPolicy{MaxAttempts: 2}}
+
+	repo, err := c.Get(context.Background(), &RepoSpec{URI: "r"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.URI != "r" {
+		t.Errorf("got repo URI %q, want %q", repo.URI, "r")
+	}
+	if flaky.calls != 2 {
+		t.Errorf("got %d calls, want 2", flaky.calls)
+	}
+}
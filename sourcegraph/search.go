@@ -1,6 +1,181 @@
 package sourcegraph
 
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
 // Empty is whether there are no search results for any result type.
 func (r *SearchResults) Empty() bool {
 	return len(r.Defs) == 0 && len(r.People) == 0 && len(r.Repos) == 0 && len(r.Tree) == 0
 }
+
+// DiffSearchResults returns the results in cur that are not in prev
+// (e.g., to report the new results of a saved search). If prev is nil,
+// all results in cur are new.
+//
+// Defs are compared by their DefKey (ignoring the CommitID), people by
+// their login (or email), repos by their URI, and tree results by
+// their repository, file and matched text (ignoring line numbers,
+// which change as files are edited).
+func DiffSearchResults(prev, cur *SearchResults) *SearchResults {
+	var diff SearchResults
+	if cur == nil {
+		return &diff
+	}
+	if prev == nil {
+		prev = &SearchResults{}
+	}
+
+	seen := map[string]bool{}
+	for _, def := range prev.Defs {
+		seen[defResultKey(def)] = true
+	}
+	for _, person := range prev.People {
+		seen[personResultKey(person)] = true
+	}
+	for _, repo := range prev.Repos {
+		seen[repoResultKey(repo)] = true
+	}
+	for _, res := range prev.Tree {
+		seen[treeResultKey(res)] = true
+	}
+
+	for _, def := range cur.Defs {
+		if !seen[defResultKey(def)] {
+			diff.Defs = append(diff.Defs, def)
+		}
+	}
+	for _, person := range cur.People {
+		if !seen[personResultKey(person)] {
+			diff.People = append(diff.People, person)
+		}
+	}
+	for _, repo := range cur.Repos {
+		if !seen[repoResultKey(repo)] {
+			diff.Repos = append(diff.Repos, repo)
+		}
+	}
+	for _, res := range cur.Tree {
+		if !seen[treeResultKey(res)] {
+			diff.Tree = append(diff.Tree, res)
+		}
+	}
+	return &diff
+}
+
+// The *ResultKey funcs return keys that identify search results for
+// DiffSearchResults. Each key is prefixed with the result type so
+// that results of different types never collide.
+
+func defResultKey(def *Def) string {
+	k := def.DefKey
+	return strings.Join([]string{"def", k.Repo, k.UnitType, k.Unit, k.Path}, "\x00")
+}
+
+func personResultKey(person *Person) string {
+	if person.Login != "" {
+		return "person\x00login\x00" + person.Login
+	}
+	return "person\x00email\x00" + person.Email
+}
+
+func repoResultKey(repo *Repo) string { return "repo\x00" + repo.URI }
+
+func treeResultKey(res *RepoTreeSearchResult) string {
+	return strings.Join([]string{"tree", res.RepoRev.URI, res.File, string(res.Match)}, "\x00")
+}
+
+// StreamSearch starts a streaming search (using Search.StreamSearch)
+// and returns a SearchStream that iterates over its events. The
+// search is canceled when ctx is canceled or the stream is closed.
+func (c *Client) StreamSearch(ctx context.Context, opt *SearchOptions) (*SearchStream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.Search.StreamSearch(ctx, opt)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &SearchStream{ctx: ctx, cancel: cancel, stream: stream}, nil
+}
+
+// A SearchStream iterates over the events of a streaming search. Its
+// usage is similar to that of bufio.Scanner:
+//
+//	stream, err := c.StreamSearch(ctx, opt)
+//	if err != nil {
+//		return err
+//	}
+//	defer stream.Close()
+//	for stream.Next() {
+//		ev := stream.Event()
+//		// ...
+//	}
+//	if err := stream.Err(); err != nil {
+//		return err
+//	}
+type SearchStream struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	stream Search_StreamSearchClient
+
+	event   *SearchEvent
+	summary *SearchSummary
+	err     error
+	done    bool
+}
+
+// Next advances the stream to the next event, which is then
+// available through Event. It returns false when the stream ends,
+// an error occurs or the stream is closed.
+func (s *SearchStream) Next() bool {
+	if s.done {
+		return false
+	}
+	ev, err := s.stream.Recv()
+	if err != nil {
+		if err != io.EOF {
+			// Report cancellation of the caller's context as such,
+			// not as the gRPC error it caused.
+			if ctxErr := s.ctx.Err(); ctxErr != nil {
+				err = ctxErr
+			}
+			s.err = err
+		}
+		s.Close()
+		return false
+	}
+	s.event = ev
+	if summary := ev.GetSummary(); summary != nil {
+		s.summary = summary
+	}
+	return true
+}
+
+// Event returns the most recent event read by Next.
+func (s *SearchStream) Event() *SearchEvent {
+	return s.event
+}
+
+// Summary returns the summary of the search, or nil if it has not
+// (yet) been received. The summary is the last event of a stream
+// that completes successfully.
+func (s *SearchStream) Summary() *SearchSummary {
+	return s.summary
+}
+
+// Err returns the first error that occurred while reading the
+// stream. It returns nil if the stream ended normally or was closed.
+func (s *SearchStream) Err() error {
+	return s.err
+}
+
+// Close cancels the search and releases its resources. It is safe to
+// call Close more than once.
+func (s *SearchStream) Close() {
+	s.done = true
+	s.event = nil
+	s.cancel()
+}
@@ -0,0 +1,103 @@
+package sourcegraph
+
+import (
+	"sync"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+)
+
+// defaultSearchTextConcurrency is the maximum number of repositories
+// that are searched concurrently if MultiTextSearchOptions doesn't
+// specify a limit.
+const defaultSearchTextConcurrency = 8
+
+// SearchTextMulti searches the content of files in multiple
+// repositories using Search.SearchTextMulti. If the server does not
+// implement that method, it falls back to calling Search.SearchText
+// for each repository revision (with at most opt.Concurrency calls in
+// flight) and merging the results.
+func (c *Client) SearchTextMulti(ctx context.Context, opt *MultiTextSearchOptions) (*MultiTextSearchResults, error) {
+	res, err := c.Search.SearchTextMulti(ctx, opt)
+	if grpc.Code(err) == codes.Unimplemented {
+		return searchTextEachRepo(ctx, c, opt)
+	}
+	return res, err
+}
+
+// searchTextEachRepo implements SearchTextMulti on top of the
+// per-repository Search.SearchText method. Errors that occur while
+// searching a repository revision are reported in the RepoErrors of
+// the results, which are in the order of the repository revisions.
+func searchTextEachRepo(ctx context.Context, c *Client, opt *MultiTextSearchOptions) (*MultiTextSearchResults, error) {
+	repoRevs := opt.RepoRevs
+	if len(repoRevs) == 0 && opt.Repos != nil {
+		repos, err := c.Repos.List(ctx, opt.Repos)
+		if err != nil {
+			return nil, err
+		}
+		for _, repo := range repos.Repos {
+			repoRevs = append(repoRevs, RepoRevSpec{RepoSpec: repo.RepoSpec(), Rev: repo.DefaultBranch})
+		}
+	}
+
+	concurrency := int(opt.Concurrency)
+	if concurrency <= 0 {
+		concurrency = defaultSearchTextConcurrency
+	}
+
+	var (
+		results = make([][]*vcs.SearchResult, len(repoRevs))
+		errs    = make([]error, len(repoRevs))
+		sem     = make(chan struct{}, concurrency)
+		wg      sync.WaitGroup
+	)
+	for i, repoRev := range repoRevs {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(i int, repoRev RepoRevSpec) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			res, err := c.Search.SearchText(ctx, &TextSearchOptions{
+				Query:       opt.Query,
+				RepoRev:     repoRev,
+				ListOptions: opt.ListOptions,
+				Languages:   opt.Languages,
+				Regexp:      opt.Regexp,
+			})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = res.SearchResults
+		}(i, repoRev)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	merged := &MultiTextSearchResults{}
+	for i, repoRev := range repoRevs {
+		if err := errs[i]; err != nil {
+			merged.RepoErrors = append(merged.RepoErrors, RepoSearchError{
+				RepoRev: repoRev,
+				Code:    uint32(grpc.Code(err)),
+				Message: grpc.ErrorDesc(err),
+			})
+			continue
+		}
+		for _, res := range results[i] {
+			merged.Results = append(merged.Results, &RepoTreeSearchResult{SearchResult: *res, RepoRev: repoRev})
+		}
+	}
+	return merged, nil
+}
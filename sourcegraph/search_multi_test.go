@@ -0,0 +1,144 @@
+package sourcegraph
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+)
+
+type searchTextClient struct {
+	SearchClient
+
+	mu              sync.Mutex
+	inFlight, maxIn int
+	results         map[string][]*vcs.SearchResult // keyed on "repo@rev"
+	started         chan struct{}                  // if non-nil, receives when a search starts
+	release         chan struct{}                  // if non-nil, searches block until it is closed
+}
+
+func (c *searchTextClient) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions, opts ...grpc.CallOption) (*MultiTextSearchResults, error) {
+	return nil, grpc.Errorf(codes.Unimplemented, "SearchTextMulti is not implemented")
+}
+
+func (c *searchTextClient) SearchText(ctx context.Context, in *TextSearchOptions, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	c.mu.Lock()
+	c.inFlight++
+	if c.inFlight > c.maxIn {
+		c.maxIn = c.inFlight
+	}
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.inFlight--
+		c.mu.Unlock()
+	}()
+
+	if c.started != nil {
+		c.started <- struct{}{}
+	}
+	if c.release != nil {
+		<-c.release
+	}
+
+	res, ok := c.results[in.RepoRev.URI+"@"+in.RepoRev.Rev]
+	if !ok {
+		return nil, grpc.Errorf(codes.NotFound, "repo %s not found", in.RepoRev.URI)
+	}
+	return &VCSSearchResultList{SearchResults: res}, nil
+}
+
+func TestClient_SearchTextMulti_fallback(t *testing.T) {
+	var (
+		a = &vcs.SearchResult{File: "a"}
+		b = &vcs.SearchResult{File: "b"}
+	)
+	search := &searchTextClient{
+		results: map[string][]*vcs.SearchResult{
+			"r1@":  {a},
+			"r2@v": {b},
+			"r3@":  {},
+		},
+	}
+	c := &Client{Search: search}
+
+	repoRevs := []RepoRevSpec{
+		{RepoSpec: RepoSpec{URI: "r1"}},
+		{RepoSpec: RepoSpec{URI: "x"}},
+		{RepoSpec: RepoSpec{URI: "r2"}, Rev: "v"},
+		{RepoSpec: RepoSpec{URI: "r3"}},
+	}
+	res, err := c.SearchTextMulti(context.Background(), &MultiTextSearchOptions{Query: "q", RepoRevs: repoRevs, Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &MultiTextSearchResults{
+		Results: []*RepoTreeSearchResult{
+			{SearchResult: *a, RepoRev: repoRevs[0]},
+			{SearchResult: *b, RepoRev: repoRevs[2]},
+		},
+		RepoErrors: []RepoSearchError{
+			{RepoRev: repoRevs[1], Code: uint32(codes.NotFound), Message: "repo x not found"},
+		},
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("got %+v, want %+v", res, want)
+	}
+	if search.maxIn > 2 {
+		t.Errorf("got %d concurrent searches, want at most 2", search.maxIn)
+	}
+}
+
+func TestClient_SearchTextMulti_fallbackRepoList(t *testing.T) {
+	search := &searchTextClient{
+		results: map[string][]*vcs.SearchResult{
+			"a.com/foo@master": {{File: "f"}},
+		},
+	}
+	c := &Client{
+		Repos:  &resolveReposClient{repos: []*Repo{{URI: "a.com/foo", DefaultBranch: "master"}}},
+		Search: search,
+	}
+
+	res, err := c.SearchTextMulti(context.Background(), &MultiTextSearchOptions{Query: "q", Repos: &RepoListOptions{Owner: "a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*RepoTreeSearchResult{
+		{SearchResult: vcs.SearchResult{File: "f"}, RepoRev: RepoRevSpec{RepoSpec: RepoSpec{URI: "a.com/foo"}, Rev: "master"}},
+	}
+	if !reflect.DeepEqual(res.Results, want) {
+		t.Errorf("got results %+v, want %+v", res.Results, want)
+	}
+	if len(res.RepoErrors) != 0 {
+		t.Errorf("got repo errors %+v, want none", res.RepoErrors)
+	}
+}
+
+func TestClient_SearchTextMulti_fallbackCanceled(t *testing.T) {
+	search := &searchTextClient{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	c := &Client{Search: search}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := c.SearchTextMulti(ctx, &MultiTextSearchOptions{
+			RepoRevs:    []RepoRevSpec{{RepoSpec: RepoSpec{URI: "r1"}}, {RepoSpec: RepoSpec{URI: "r2"}}},
+			Concurrency: 1,
+		})
+		done <- err
+	}()
+	<-search.started
+	cancel()
+	close(search.release)
+	if err := <-done; err != context.Canceled {
+		t.Errorf("got err %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,157 @@
+package sourcegraph
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+	"sourcegraph.com/sourcegraph/srclib/graph"
+)
+
+type streamSearchClient struct {
+	SearchClient
+	events []*SearchEvent
+	block  bool // block after sending events until ctx is done
+}
+
+func (c *streamSearchClient) StreamSearch(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (Search_StreamSearchClient, error) {
+	return &fakeSearchStream{ctx: ctx, events: c.events, block: c.block}, nil
+}
+
+type fakeSearchStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events []*SearchEvent
+	block  bool
+}
+
+func (s *fakeSearchStream) Recv() (*SearchEvent, error) {
+	if len(s.events) > 0 {
+		ev := s.events[0]
+		s.events = s.events[1:]
+		return ev, nil
+	}
+	if s.block {
+		<-s.ctx.Done()
+		return nil, grpc.Errorf(codes.Canceled, "%s", s.ctx.Err())
+	}
+	if err := s.ctx.Err(); err != nil {
+		return nil, grpc.Errorf(codes.Canceled, "%s", err)
+	}
+	return nil, io.EOF
+}
+
+func TestClient_StreamSearch(t *testing.T) {
+	events := []*SearchEvent{
+		{Event: &SearchEvent_Results{&SearchResults{Repos: []*Repo{{URI: "r"}}}}},
+		{Event: &SearchEvent_Progress{&SearchProgress{ReposSearched: 1, ReposRemaining: 0}}},
+		{Event: &SearchEvent_Summary{&SearchSummary{Tips: []TokenError{{Message: "m"}}}}},
+	}
+	c := &Client{Search: &streamSearchClient{events: events}}
+
+	stream, err := c.StreamSearch(context.Background(), &SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	var got []*SearchEvent
+	for stream.Next() {
+		got = append(got, stream.Event())
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, events) {
+		t.Errorf("got events %v, want %v", got, events)
+	}
+	if want := events[2].GetSummary(); stream.Summary() != want {
+		t.Errorf("got summary %v, want %v", stream.Summary(), want)
+	}
+	if stream.Next() {
+		t.Error("got Next == true after end of stream")
+	}
+}
+
+func TestClient_StreamSearch_canceled(t *testing.T) {
+	events := []*SearchEvent{
+		{Event: &SearchEvent_Progress{&SearchProgress{ReposSearched: 1, ReposRemaining: 2}}},
+	}
+	c := &Client{Search: &streamSearchClient{events: events, block: true}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := c.StreamSearch(ctx, &SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	if !stream.Next() {
+		t.Fatalf("got Next == false, want true (err: %v)", stream.Err())
+	}
+	cancel()
+	if stream.Next() {
+		t.Fatal("got Next == true after cancellation")
+	}
+	if err := stream.Err(); err != context.Canceled {
+		t.Errorf("got err %v, want %v", err, context.Canceled)
+	}
+	if stream.Summary() != nil {
+		t.Errorf("got summary %v, want nil", stream.Summary())
+	}
+}
+
+func TestClient_StreamSearch_close(t *testing.T) {
+	c := &Client{Search: &streamSearchClient{block: true}}
+
+	stream, err := c.StreamSearch(context.Background(), &SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Close()
+	if stream.Next() {
+		t.Fatal("got Next == true after Close")
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("got err %v, want nil", err)
+	}
+}
+
+func TestDiffSearchResults(t *testing.T) {
+	def := func(path, commitID string) *Def {
+		return &Def{Def: graph.Def{DefKey: graph.DefKey{Repo: "r", CommitID: commitID, UnitType: "t", Unit: "u", Path: path}}}
+	}
+	tree := func(file string, line uint32, match string) *RepoTreeSearchResult {
+		return &RepoTreeSearchResult{
+			SearchResult: vcs.SearchResult{File: file, StartLine: line, EndLine: line, Match: []byte(match)},
+			RepoRev:      RepoRevSpec{RepoSpec: RepoSpec{URI: "r"}},
+		}
+	}
+	prev := &SearchResults{
+		Defs:   []*Def{def("a", "c1")},
+		People: []*Person{{PersonSpec: PersonSpec{Login: "alice"}}, {PersonSpec: PersonSpec{Email: "bob@example.com"}}},
+		Repos:  []*Repo{{URI: "r1"}},
+		Tree:   []*RepoTreeSearchResult{tree("f", 1, "Foo()")},
+	}
+	cur := &SearchResults{
+		Defs:   []*Def{def("a", "c2"), def("b", "c2")},
+		People: []*Person{{PersonSpec: PersonSpec{Login: "alice"}}, {PersonSpec: PersonSpec{Email: "bob@example.com"}}, {PersonSpec: PersonSpec{Login: "carol"}}},
+		Repos:  []*Repo{{URI: "r1"}, {URI: "r2"}},
+		Tree:   []*RepoTreeSearchResult{tree("f", 2, "Foo()"), tree("f", 3, "x := Foo()"), tree("g", 1, "Foo()")},
+	}
+	want := &SearchResults{
+		Defs:   []*Def{cur.Defs[1]},
+		People: []*Person{cur.People[2]},
+		Repos:  []*Repo{cur.Repos[1]},
+		Tree:   []*RepoTreeSearchResult{cur.Tree[1], cur.Tree[2]},
+	}
+	if diff := DiffSearchResults(prev, cur); !reflect.DeepEqual(diff, want) {
+		t.Errorf("got %+v, want %+v", diff, want)
+	}
+
+	if diff := DiffSearchResults(nil, cur); !reflect.DeepEqual(diff, cur) {
+		t.Errorf("with nil prev: got %+v, want %+v", diff, cur)
+	}
+}
@@ -188,8 +188,14 @@ It has these top-level messages:
 	VCSSearchResultList
 	TokenSearchOptions
 	TextSearchOptions
+	MultiTextSearchOptions
+	MultiTextSearchResults
+	RepoSearchError
 	SearchOptions
 	SearchResults
+	SearchEvent
+	SearchProgress
+	SearchSummary
 	SuggestionList
 	SourceCode
 	SourceCodeLine
@@ -202,6 +208,13 @@ It has these top-level messages:
 	RepoSourceUnitList
 	DefAuthorList
 	DefClientList
+	SavedSearch
+	SavedSearchNotify
+	SavedSearchSpec
+	SavedSearchesCreateOp
+	SavedSearchListOptions
+	SavedSearchList
+	SavedSearchRunResult
 	Checklist
 	FileToken
 	Plan
@@ -212,6 +225,11 @@ It has these top-level messages:
 	Suggestion
 	UnitToken
 	UserToken
+	NotToken
+	OrGroupToken
+	ExactPhraseToken
+	LangToken
+	RegexpToken
 	TokenError
 	PBToken
 	ServerStatus
@@ -229,6 +247,15 @@ It has these top-level messages:
 	UserEvent
 	UserEventList
 	NotifyGenericEvent
+	AuditEvent
+	AuditEventListOptions
+	AuditEventList
+	AccessToken
+	AccessTokenSpec
+	AccessTokensCreateOp
+	NewAccessToken
+	AccessTokensListOptions
+	AccessTokenList
 */
 package sourcegraph
 
@@ -1984,6 +2011,12 @@ type AuthorizationCodeRequest struct {
 	Scope        []string `protobuf:"bytes,4,rep,name=scope" json:"scope,omitempty"`
 	// UID is the UID of the user who will be presented with the code.
 	UID int32 `protobuf:"varint,5,opt,name=uid,proto3" json:"uid,omitempty"`
+	// CodeChallenge and CodeChallengeMethod are the PKCE code
+	// challenge (and its method, "S256" or "plain") that the code
+	// verifier must match when the code is exchanged. See
+	// https://tools.ietf.org/html/rfc7636#section-4.3.
+	CodeChallenge       string `protobuf:"bytes,6,opt,name=code_challenge,proto3" json:"code_challenge,omitempty"`
+	CodeChallengeMethod string `protobuf:"bytes,7,opt,name=code_challenge_method,proto3" json:"code_challenge_method,omitempty"`
 }
 
 func (m *AuthorizationCodeRequest) Reset()         { *m = AuthorizationCodeRequest{} }
@@ -2000,6 +2033,10 @@ func (*AuthorizationCodeRequest) ProtoMessage()    {}
 type AuthorizationCode struct {
 	Code        string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	RedirectURI string `protobuf:"bytes,2,opt,name=redirect_uri,proto3" json:"redirect_uri,omitempty"`
+	// CodeVerifier is the PKCE code verifier, which must match the
+	// code challenge of the authorization request. See
+	// https://tools.ietf.org/html/rfc7636#section-4.5.
+	CodeVerifier string `protobuf:"bytes,3,opt,name=code_verifier,proto3" json:"code_verifier,omitempty"`
 }
 
 func (m *AuthorizationCode) Reset()         { *m = AuthorizationCode{} }
@@ -2043,6 +2080,7 @@ type AccessTokenRequest struct {
 	//	*AccessTokenRequest_AuthorizationCode
 	//	*AccessTokenRequest_ResourceOwnerPassword
 	//	*AccessTokenRequest_BearerJWT
+	//	*AccessTokenRequest_RefreshToken
 	AuthorizationGrant isAccessTokenRequest_AuthorizationGrant `protobuf_oneof:"authorization_grant"`
 	// TokenURL is the token endpoint URL on the OAuth2 authorization
 	// server that the client is requesting an access token from.
@@ -2067,10 +2105,14 @@ type AccessTokenRequest_ResourceOwnerPassword struct {
 type AccessTokenRequest_BearerJWT struct {
 	BearerJWT *BearerJWT `protobuf:"bytes,3,opt,name=bearer_jwt,oneof"`
 }
+type AccessTokenRequest_RefreshToken struct {
+	RefreshToken string `protobuf:"bytes,4,opt,name=refresh_token,proto3,oneof"`
+}
 
 func (*AccessTokenRequest_AuthorizationCode) isAccessTokenRequest_AuthorizationGrant()     {}
 func (*AccessTokenRequest_ResourceOwnerPassword) isAccessTokenRequest_AuthorizationGrant() {}
 func (*AccessTokenRequest_BearerJWT) isAccessTokenRequest_AuthorizationGrant()             {}
+func (*AccessTokenRequest_RefreshToken) isAccessTokenRequest_AuthorizationGrant()          {}
 
 func (m *AccessTokenRequest) GetAuthorizationGrant() isAccessTokenRequest_AuthorizationGrant {
 	if m != nil {
@@ -2100,12 +2142,20 @@ func (m *AccessTokenRequest) GetBearerJWT() *BearerJWT {
 	return nil
 }
 
+func (m *AccessTokenRequest) GetRefreshToken() string {
+	if x, ok := m.GetAuthorizationGrant().(*AccessTokenRequest_RefreshToken); ok {
+		return x.RefreshToken
+	}
+	return ""
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*AccessTokenRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), []interface{}) {
 	return _AccessTokenRequest_OneofMarshaler, _AccessTokenRequest_OneofUnmarshaler, []interface{}{
 		(*AccessTokenRequest_AuthorizationCode)(nil),
 		(*AccessTokenRequest_ResourceOwnerPassword)(nil),
 		(*AccessTokenRequest_BearerJWT)(nil),
+		(*AccessTokenRequest_RefreshToken)(nil),
 	}
 }
 
@@ -2128,6 +2178,9 @@ func _AccessTokenRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) erro
 		if err := b.EncodeMessage(x.BearerJWT); err != nil {
 			return err
 		}
+	case *AccessTokenRequest_RefreshToken:
+		_ = b.EncodeVarint(4<<3 | proto.WireBytes)
+		_ = b.EncodeStringBytes(x.RefreshToken)
 	case nil:
 	default:
 		return fmt.Errorf("AccessTokenRequest.AuthorizationGrant has unexpected type %T", x)
@@ -2162,6 +2215,13 @@ func _AccessTokenRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *p
 		err := b.DecodeMessage(msg)
 		m.AuthorizationGrant = &AccessTokenRequest_BearerJWT{msg}
 		return true, err
+	case 4: // authorization_grant.refresh_token
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.AuthorizationGrant = &AccessTokenRequest_RefreshToken{x}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -2198,6 +2258,10 @@ type AuthInfo struct {
 	Domain string `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
 	// Login is the login of the currently authenticated user (if any).
 	Login string `protobuf:"bytes,4,opt,name=login,proto3" json:"login,omitempty"`
+	// AccessTokenID is the ID of the personal access token (see
+	// AccessToken) that the request was authenticated with, or 0 if
+	// it was not authenticated with a personal access token.
+	AccessTokenID int64 `protobuf:"varint,5,opt,name=access_token_id,proto3" json:"access_token_id,omitempty"`
 }
 
 func (m *AuthInfo) Reset()         { *m = AuthInfo{} }
@@ -2380,6 +2444,12 @@ type DefListOptions struct {
 	Direction string `protobuf:"bytes,19,opt,name=direction,proto3" json:"direction,omitempty" url:",omitempty"`
 	// Paging
 	ListOptions `protobuf:"bytes,20,opt,name=list_options,embedded=list_options" json:"list_options"`
+	// Languages, if specified, will restrict the results to only defs written in
+	// one of the specified programming languages (e.g., "go").
+	Languages []string `protobuf:"bytes,21,rep,name=languages" json:"languages,omitempty" url:",omitempty,comma"`
+	// Regexp, if specified, will restrict the results to only defs whose names
+	// match the specified regular expression.
+	Regexp string `protobuf:"bytes,22,opt,name=regexp,proto3" json:"regexp,omitempty" url:",omitempty"`
 }
 
 func (m *DefListOptions) Reset()         { *m = DefListOptions{} }
@@ -2845,6 +2915,12 @@ func (*RepoTreeGetOptions) ProtoMessage()    {}
 type RepoTreeSearchOptions struct {
 	vcs.SearchOptions `protobuf:"bytes,1,opt,name=search_options,embedded=search_options" json:"search_options"`
 	Formatted         bool `protobuf:"varint,2,opt,name=formatted,proto3" json:"formatted,omitempty"`
+	// Languages, if specified, will restrict the results to only files written in
+	// one of the specified programming languages (e.g., "go").
+	Languages []string `protobuf:"bytes,3,rep,name=languages" json:"languages,omitempty"`
+	// Regexp, if specified, will restrict the results to only lines that match the
+	// specified regular expression.
+	Regexp string `protobuf:"bytes,4,opt,name=regexp,proto3" json:"regexp,omitempty"`
 }
 
 func (m *RepoTreeSearchOptions) Reset()         { *m = RepoTreeSearchOptions{} }
@@ -2919,12 +2995,71 @@ type TextSearchOptions struct {
 	Query       string      `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty" url:"q" schema:"q"`
 	RepoRev     RepoRevSpec `protobuf:"bytes,2,opt,name=repo_rev" json:"repo_rev"`
 	ListOptions `protobuf:"bytes,3,opt,name=list_options,embedded=list_options" json:"list_options"`
+	// Languages, if specified, will restrict the results to only files written in
+	// one of the specified programming languages (e.g., "go").
+	Languages []string `protobuf:"bytes,4,rep,name=languages" json:"languages,omitempty" url:",omitempty,comma"`
+	// Regexp, if specified, will restrict the results to only lines that match the
+	// specified regular expression.
+	Regexp string `protobuf:"bytes,5,opt,name=regexp,proto3" json:"regexp,omitempty" url:",omitempty"`
 }
 
 func (m *TextSearchOptions) Reset()         { *m = TextSearchOptions{} }
 func (m *TextSearchOptions) String() string { return proto.CompactTextString(m) }
 func (*TextSearchOptions) ProtoMessage()    {}
 
+// MultiTextSearchOptions specifies a text search across multiple
+// repositories.
+type MultiTextSearchOptions struct {
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty" url:"q" schema:"q"`
+	// RepoRevs are the repository revisions to search.
+	RepoRevs []RepoRevSpec `protobuf:"bytes,2,rep,name=repo_revs" json:"repo_revs"`
+	// Repos, if specified and RepoRevs is empty, selects the
+	// repositories to search (at their default branches).
+	Repos *RepoListOptions `protobuf:"bytes,3,opt,name=repos" json:"repos,omitempty"`
+	// ListOptions applies to the results of each repository.
+	ListOptions `protobuf:"bytes,4,opt,name=list_options,embedded=list_options" json:"list_options"`
+	// Languages, if specified, will restrict the results to only files written in
+	// one of the specified programming languages (e.g., "go").
+	Languages []string `protobuf:"bytes,5,rep,name=languages" json:"languages,omitempty" url:",omitempty,comma"`
+	// Regexp, if specified, will restrict the results to only lines that match the
+	// specified regular expression.
+	Regexp string `protobuf:"bytes,6,opt,name=regexp,proto3" json:"regexp,omitempty" url:",omitempty"`
+	// Concurrency is the maximum number of repositories to search
+	// concurrently. If zero, a default limit is used.
+	Concurrency int32 `protobuf:"varint,7,opt,name=concurrency,proto3" json:"concurrency,omitempty" url:",omitempty"`
+}
+
+func (m *MultiTextSearchOptions) Reset()         { *m = MultiTextSearchOptions{} }
+func (m *MultiTextSearchOptions) String() string { return proto.CompactTextString(m) }
+func (*MultiTextSearchOptions) ProtoMessage()    {}
+
+// MultiTextSearchResults holds the results of a text search across
+// multiple repositories.
+type MultiTextSearchResults struct {
+	Results []*RepoTreeSearchResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+	// RepoErrors holds an error for each repository revision that
+	// could not be searched. The results of the other repository
+	// revisions are still returned.
+	RepoErrors []RepoSearchError `protobuf:"bytes,2,rep,name=repo_errors" json:"repo_errors"`
+}
+
+func (m *MultiTextSearchResults) Reset()         { *m = MultiTextSearchResults{} }
+func (m *MultiTextSearchResults) String() string { return proto.CompactTextString(m) }
+func (*MultiTextSearchResults) ProtoMessage()    {}
+
+// A RepoSearchError describes why a repository revision could not be
+// searched.
+type RepoSearchError struct {
+	RepoRev RepoRevSpec `protobuf:"bytes,1,opt,name=repo_rev" json:"repo_rev"`
+	// Code is the gRPC status code of the error.
+	Code    uint32 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (m *RepoSearchError) Reset()         { *m = RepoSearchError{} }
+func (m *RepoSearchError) String() string { return proto.CompactTextString(m) }
+func (*RepoSearchError) ProtoMessage()    {}
+
 // Deprecated.
 type SearchOptions struct {
 	Query       string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty" url:"q" schema:"q"`
@@ -2967,6 +3102,162 @@ func (m *SearchResults) Reset()         { *m = SearchResults{} }
 func (m *SearchResults) String() string { return proto.CompactTextString(m) }
 func (*SearchResults) ProtoMessage()    {}
 
+// A SearchEvent is sent by StreamSearch. Exactly one field is set.
+type SearchEvent struct {
+	// Types that are valid to be assigned to Event:
+	//	*SearchEvent_Results
+	//	*SearchEvent_Progress
+	//	*SearchEvent_Summary
+	Event isSearchEvent_Event `protobuf_oneof:"event"`
+}
+
+func (m *SearchEvent) Reset()         { *m = SearchEvent{} }
+func (m *SearchEvent) String() string { return proto.CompactTextString(m) }
+func (*SearchEvent) ProtoMessage()    {}
+
+type isSearchEvent_Event interface {
+	isSearchEvent_Event()
+}
+
+type SearchEvent_Results struct {
+	Results *SearchResults `protobuf:"bytes,1,opt,name=results,oneof"`
+}
+type SearchEvent_Progress struct {
+	Progress *SearchProgress `protobuf:"bytes,2,opt,name=progress,oneof"`
+}
+type SearchEvent_Summary struct {
+	Summary *SearchSummary `protobuf:"bytes,3,opt,name=summary,oneof"`
+}
+
+func (*SearchEvent_Results) isSearchEvent_Event()  {}
+func (*SearchEvent_Progress) isSearchEvent_Event() {}
+func (*SearchEvent_Summary) isSearchEvent_Event()  {}
+
+func (m *SearchEvent) GetEvent() isSearchEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (m *SearchEvent) GetResults() *SearchResults {
+	if x, ok := m.GetEvent().(*SearchEvent_Results); ok {
+		return x.Results
+	}
+	return nil
+}
+
+func (m *SearchEvent) GetProgress() *SearchProgress {
+	if x, ok := m.GetEvent().(*SearchEvent_Progress); ok {
+		return x.Progress
+	}
+	return nil
+}
+
+func (m *SearchEvent) GetSummary() *SearchSummary {
+	if x, ok := m.GetEvent().(*SearchEvent_Summary); ok {
+		return x.Summary
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*SearchEvent) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), []interface{}) {
+	return _SearchEvent_OneofMarshaler, _SearchEvent_OneofUnmarshaler, []interface{}{
+		(*SearchEvent_Results)(nil),
+		(*SearchEvent_Progress)(nil),
+		(*SearchEvent_Summary)(nil),
+	}
+}
+
+func _SearchEvent_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*SearchEvent)
+	// event
+	switch x := m.Event.(type) {
+	case *SearchEvent_Results:
+		_ = b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Results); err != nil {
+			return err
+		}
+	case *SearchEvent_Progress:
+		_ = b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Progress); err != nil {
+			return err
+		}
+	case *SearchEvent_Summary:
+		_ = b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Summary); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("SearchEvent.Event has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _SearchEvent_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*SearchEvent)
+	switch tag {
+	case 1: // event.results
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SearchResults)
+		err := b.DecodeMessage(msg)
+		m.Event = &SearchEvent_Results{msg}
+		return true, err
+	case 2: // event.progress
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SearchProgress)
+		err := b.DecodeMessage(msg)
+		m.Event = &SearchEvent_Progress{msg}
+		return true, err
+	case 3: // event.summary
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(SearchSummary)
+		err := b.DecodeMessage(msg)
+		m.Event = &SearchEvent_Summary{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+// SearchProgress describes the progress of a streaming search.
+type SearchProgress struct {
+	// ReposSearched is the number of repositories that have been
+	// searched so far.
+	ReposSearched int32 `protobuf:"varint,1,opt,name=repos_searched,proto3" json:"repos_searched,omitempty"`
+	// ReposRemaining is the number of repositories that remain to be
+	// searched.
+	ReposRemaining int32 `protobuf:"varint,2,opt,name=repos_remaining,proto3" json:"repos_remaining,omitempty"`
+}
+
+func (m *SearchProgress) Reset()         { *m = SearchProgress{} }
+func (m *SearchProgress) String() string { return proto.CompactTextString(m) }
+func (*SearchProgress) ProtoMessage()    {}
+
+// SearchSummary describes a completed streaming search. Its fields
+// have the same meaning as the corresponding fields of SearchResults.
+type SearchSummary struct {
+	RawQuery       RawQuery     `protobuf:"bytes,1,opt,name=raw_query" json:"raw_query"`
+	Tokens         []PBToken    `protobuf:"bytes,2,rep,name=tokens" json:"tokens"`
+	Plan           *Plan        `protobuf:"bytes,3,opt,name=plan" json:"plan,omitempty"`
+	ResolvedTokens []PBToken    `protobuf:"bytes,4,rep,name=resolved_tokens" json:"resolved_tokens"`
+	ResolveErrors  []TokenError `protobuf:"bytes,5,rep,name=resolve_errors" json:"resolve_errors"`
+	Tips           []TokenError `protobuf:"bytes,6,rep,name=tips" json:"tips"`
+	Canceled       bool         `protobuf:"varint,7,opt,name=canceled,proto3" json:"canceled,omitempty"`
+}
+
+func (m *SearchSummary) Reset()         { *m = SearchSummary{} }
+func (m *SearchSummary) String() string { return proto.CompactTextString(m) }
+func (*SearchSummary) ProtoMessage()    {}
+
 type SuggestionList struct {
 	Suggestions []*Suggestion `protobuf:"bytes,1,rep,name=suggestions" json:"suggestions,omitempty"`
 }
@@ -3129,6 +3420,100 @@ func (m *DefClientList) Reset()         { *m = DefClientList{} }
 func (m *DefClientList) String() string { return proto.CompactTextString(m) }
 func (*DefClientList) ProtoMessage()    {}
 
+// A SavedSearch is a query that is saved so that it can be run again
+// later (e.g., to be notified of new results).
+type SavedSearch struct {
+	// ID uniquely identifies the saved search.
+	ID int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name is a human-readable name for the saved search.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Query is the saved query.
+	Query RawQuery `protobuf:"bytes,3,opt,name=query" json:"query"`
+	// Owner is the user or organization that owns the saved search.
+	Owner UserSpec `protobuf:"bytes,4,opt,name=owner" json:"owner"`
+	// Notify, if set, specifies how the owner is notified when a run of
+	// the saved search finds new results.
+	Notify *SavedSearchNotify `protobuf:"bytes,5,opt,name=notify" json:"notify,omitempty"`
+	// CreatedAt is when the saved search was created.
+	CreatedAt *pbtypes.Timestamp `protobuf:"bytes,6,opt,name=created_at" json:"created_at,omitempty"`
+	// LastRunAt is when the saved search was last run, if ever.
+	LastRunAt *pbtypes.Timestamp `protobuf:"bytes,7,opt,name=last_run_at" json:"last_run_at,omitempty"`
+}
+
+func (m *SavedSearch) Reset()         { *m = SavedSearch{} }
+func (m *SavedSearch) String() string { return proto.CompactTextString(m) }
+func (*SavedSearch) ProtoMessage()    {}
+
+// SavedSearchNotify specifies how the owner of a saved search is
+// notified of new results.
+type SavedSearchNotify struct {
+	// Email, if true, notifies the owner by email.
+	Email bool `protobuf:"varint,1,opt,name=email,proto3" json:"email,omitempty"`
+	// WebhookURL, if set, is sent the SavedSearchRunResult of each run
+	// that finds new results (in a POST request).
+	WebhookURL string `protobuf:"bytes,2,opt,name=webhook_url,proto3" json:"webhook_url,omitempty"`
+}
+
+func (m *SavedSearchNotify) Reset()         { *m = SavedSearchNotify{} }
+func (m *SavedSearchNotify) String() string { return proto.CompactTextString(m) }
+func (*SavedSearchNotify) ProtoMessage()    {}
+
+// SavedSearchSpec specifies a saved search.
+type SavedSearchSpec struct {
+	ID int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *SavedSearchSpec) Reset()         { *m = SavedSearchSpec{} }
+func (m *SavedSearchSpec) String() string { return proto.CompactTextString(m) }
+func (*SavedSearchSpec) ProtoMessage()    {}
+
+type SavedSearchesCreateOp struct {
+	Name   string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Query  RawQuery           `protobuf:"bytes,2,opt,name=query" json:"query"`
+	Owner  UserSpec           `protobuf:"bytes,3,opt,name=owner" json:"owner"`
+	Notify *SavedSearchNotify `protobuf:"bytes,4,opt,name=notify" json:"notify,omitempty"`
+}
+
+func (m *SavedSearchesCreateOp) Reset()         { *m = SavedSearchesCreateOp{} }
+func (m *SavedSearchesCreateOp) String() string { return proto.CompactTextString(m) }
+func (*SavedSearchesCreateOp) ProtoMessage()    {}
+
+type SavedSearchListOptions struct {
+	// Owner, if set, restricts the list to saved searches owned by
+	// this user or organization.
+	Owner       UserSpec `protobuf:"bytes,1,opt,name=owner" json:"owner"`
+	ListOptions `protobuf:"bytes,2,opt,name=list_options,embedded=list_options" json:"list_options"`
+}
+
+func (m *SavedSearchListOptions) Reset()         { *m = SavedSearchListOptions{} }
+func (m *SavedSearchListOptions) String() string { return proto.CompactTextString(m) }
+func (*SavedSearchListOptions) ProtoMessage()    {}
+
+type SavedSearchList struct {
+	SavedSearches []*SavedSearch `protobuf:"bytes,1,rep,name=saved_searches" json:"saved_searches,omitempty"`
+}
+
+func (m *SavedSearchList) Reset()         { *m = SavedSearchList{} }
+func (m *SavedSearchList) String() string { return proto.CompactTextString(m) }
+func (*SavedSearchList) ProtoMessage()    {}
+
+// SavedSearchRunResult is the result of running a saved search.
+type SavedSearchRunResult struct {
+	// SavedSearch is the saved search that was run (with LastRunAt
+	// updated).
+	SavedSearch *SavedSearch `protobuf:"bytes,1,opt,name=saved_search" json:"saved_search,omitempty"`
+	// Results are all of the results of the run.
+	Results *SearchResults `protobuf:"bytes,2,opt,name=results" json:"results,omitempty"`
+	// NewResults are the results of the run that were not results of
+	// the previous run (as computed by DiffSearchResults). If the
+	// saved search had not been run before, all results are new.
+	NewResults *SearchResults `protobuf:"bytes,3,opt,name=new_results" json:"new_results,omitempty"`
+}
+
+func (m *SavedSearchRunResult) Reset()         { *m = SavedSearchRunResult{} }
+func (m *SavedSearchRunResult) String() string { return proto.CompactTextString(m) }
+func (*SavedSearchRunResult) ProtoMessage()    {}
+
 type Checklist struct {
 	// number of tasks to be done (unchecked)
 	Todo int32 `protobuf:"varint,1,opt,name=todo,proto3" json:"todo,omitempty"`
@@ -3261,6 +3646,59 @@ func (m *UserToken) Reset()         { *m = UserToken{} }
 func (m *UserToken) String() string { return proto.CompactTextString(m) }
 func (*UserToken) ProtoMessage()    {}
 
+// A NotToken excludes results that match its operand. It consists of the
+// string "-" followed by the operand token (e.g., "-/vendor").
+type NotToken struct {
+	Operand PBToken `protobuf:"bytes,1,opt,name=operand" json:"operand"`
+}
+
+func (m *NotToken) Reset()         { *m = NotToken{} }
+func (m *NotToken) String() string { return proto.CompactTextString(m) }
+func (*NotToken) ProtoMessage()    {}
+
+// An OrGroupToken matches results that match any of its operands. It consists of
+// the operand tokens separated by "OR" and surrounded by parentheses (e.g., "(a
+// OR b)").
+type OrGroupToken struct {
+	Operands []PBToken `protobuf:"bytes,1,rep,name=operands" json:"operands"`
+}
+
+func (m *OrGroupToken) Reset()         { *m = OrGroupToken{} }
+func (m *OrGroupToken) String() string { return proto.CompactTextString(m) }
+func (*OrGroupToken) ProtoMessage()    {}
+
+// An ExactPhraseToken matches the phrase exactly, including whitespace and
+// punctuation. It consists of the phrase surrounded by single quotes (e.g., "'a
+// b'").
+type ExactPhraseToken struct {
+	Phrase string `protobuf:"bytes,1,opt,name=phrase,proto3" json:"phrase,omitempty"`
+}
+
+func (m *ExactPhraseToken) Reset()         { *m = ExactPhraseToken{} }
+func (m *ExactPhraseToken) String() string { return proto.CompactTextString(m) }
+func (*ExactPhraseToken) ProtoMessage()    {}
+
+// A LangToken restricts results to those written in a programming language. It
+// consists of the string "lang:" followed by the language name (e.g., "lang:go").
+type LangToken struct {
+	Lang string `protobuf:"bytes,1,opt,name=lang,proto3" json:"lang,omitempty"`
+}
+
+func (m *LangToken) Reset()         { *m = LangToken{} }
+func (m *LangToken) String() string { return proto.CompactTextString(m) }
+func (*LangToken) ProtoMessage()    {}
+
+// A RegexpToken matches results against a regular expression. It consists of the
+// string "re:" followed by the pattern (e.g., "re:^func\s"), which is quoted if it
+// contains whitespace.
+type RegexpToken struct {
+	Pattern string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+}
+
+func (m *RegexpToken) Reset()         { *m = RegexpToken{} }
+func (m *RegexpToken) String() string { return proto.CompactTextString(m) }
+func (*RegexpToken) ProtoMessage()    {}
+
 // A TokenError is an error about a specific token.
 type TokenError struct {
 	// Index is the 1-indexed index of the token that caused the error (0 means not
@@ -3289,6 +3727,11 @@ type PBToken struct {
 	//	*PBToken_UnitToken
 	//	*PBToken_FileToken
 	//	*PBToken_UserToken
+	//	*PBToken_NotToken
+	//	*PBToken_OrGroupToken
+	//	*PBToken_ExactPhraseToken
+	//	*PBToken_LangToken
+	//	*PBToken_RegexpToken
 	Token isPBToken_Token `protobuf_oneof:"token"`
 }
 
@@ -3321,14 +3764,34 @@ type PBToken_FileToken struct {
 type PBToken_UserToken struct {
 	UserToken *UserToken `protobuf:"bytes,7,opt,name=user_token,oneof"`
 }
+type PBToken_NotToken struct {
+	NotToken *NotToken `protobuf:"bytes,8,opt,name=not_token,oneof"`
+}
+type PBToken_OrGroupToken struct {
+	OrGroupToken *OrGroupToken `protobuf:"bytes,9,opt,name=or_group_token,oneof"`
+}
+type PBToken_ExactPhraseToken struct {
+	ExactPhraseToken *ExactPhraseToken `protobuf:"bytes,10,opt,name=exact_phrase_token,oneof"`
+}
+type PBToken_LangToken struct {
+	LangToken *LangToken `protobuf:"bytes,11,opt,name=lang_token,oneof"`
+}
+type PBToken_RegexpToken struct {
+	RegexpToken *RegexpToken `protobuf:"bytes,12,opt,name=regexp_token,oneof"`
+}
 
-func (*PBToken_Term) isPBToken_Token()      {}
-func (*PBToken_AnyToken) isPBToken_Token()  {}
-func (*PBToken_RepoToken) isPBToken_Token() {}
-func (*PBToken_RevToken) isPBToken_Token()  {}
-func (*PBToken_UnitToken) isPBToken_Token() {}
-func (*PBToken_FileToken) isPBToken_Token() {}
-func (*PBToken_UserToken) isPBToken_Token() {}
+func (*PBToken_Term) isPBToken_Token()             {}
+func (*PBToken_AnyToken) isPBToken_Token()         {}
+func (*PBToken_RepoToken) isPBToken_Token()        {}
+func (*PBToken_RevToken) isPBToken_Token()         {}
+func (*PBToken_UnitToken) isPBToken_Token()        {}
+func (*PBToken_FileToken) isPBToken_Token()        {}
+func (*PBToken_UserToken) isPBToken_Token()        {}
+func (*PBToken_NotToken) isPBToken_Token()         {}
+func (*PBToken_OrGroupToken) isPBToken_Token()     {}
+func (*PBToken_ExactPhraseToken) isPBToken_Token() {}
+func (*PBToken_LangToken) isPBToken_Token()        {}
+func (*PBToken_RegexpToken) isPBToken_Token()      {}
 
 func (m *PBToken) GetToken() isPBToken_Token {
 	if m != nil {
@@ -3386,6 +3849,41 @@ func (m *PBToken) GetUserToken() *UserToken {
 	return nil
 }
 
+func (m *PBToken) GetNotToken() *NotToken {
+	if x, ok := m.GetToken().(*PBToken_NotToken); ok {
+		return x.NotToken
+	}
+	return nil
+}
+
+func (m *PBToken) GetOrGroupToken() *OrGroupToken {
+	if x, ok := m.GetToken().(*PBToken_OrGroupToken); ok {
+		return x.OrGroupToken
+	}
+	return nil
+}
+
+func (m *PBToken) GetExactPhraseToken() *ExactPhraseToken {
+	if x, ok := m.GetToken().(*PBToken_ExactPhraseToken); ok {
+		return x.ExactPhraseToken
+	}
+	return nil
+}
+
+func (m *PBToken) GetLangToken() *LangToken {
+	if x, ok := m.GetToken().(*PBToken_LangToken); ok {
+		return x.LangToken
+	}
+	return nil
+}
+
+func (m *PBToken) GetRegexpToken() *RegexpToken {
+	if x, ok := m.GetToken().(*PBToken_RegexpToken); ok {
+		return x.RegexpToken
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*PBToken) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), []interface{}) {
 	return _PBToken_OneofMarshaler, _PBToken_OneofUnmarshaler, []interface{}{
@@ -3396,6 +3894,11 @@ func (*PBToken) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error
 		(*PBToken_UnitToken)(nil),
 		(*PBToken_FileToken)(nil),
 		(*PBToken_UserToken)(nil),
+		(*PBToken_NotToken)(nil),
+		(*PBToken_OrGroupToken)(nil),
+		(*PBToken_ExactPhraseToken)(nil),
+		(*PBToken_LangToken)(nil),
+		(*PBToken_RegexpToken)(nil),
 	}
 }
 
@@ -3434,6 +3937,31 @@ func _PBToken_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.UserToken); err != nil {
 			return err
 		}
+	case *PBToken_NotToken:
+		_ = b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.NotToken); err != nil {
+			return err
+		}
+	case *PBToken_OrGroupToken:
+		_ = b.EncodeVarint(9<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.OrGroupToken); err != nil {
+			return err
+		}
+	case *PBToken_ExactPhraseToken:
+		_ = b.EncodeVarint(10<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ExactPhraseToken); err != nil {
+			return err
+		}
+	case *PBToken_LangToken:
+		_ = b.EncodeVarint(11<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LangToken); err != nil {
+			return err
+		}
+	case *PBToken_RegexpToken:
+		_ = b.EncodeVarint(12<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.RegexpToken); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("PBToken.Token has unexpected type %T", x)
@@ -3498,6 +4026,46 @@ func _PBToken_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer
 		err := b.DecodeMessage(msg)
 		m.Token = &PBToken_UserToken{msg}
 		return true, err
+	case 8: // token.not_token
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(NotToken)
+		err := b.DecodeMessage(msg)
+		m.Token = &PBToken_NotToken{msg}
+		return true, err
+	case 9: // token.or_group_token
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(OrGroupToken)
+		err := b.DecodeMessage(msg)
+		m.Token = &PBToken_OrGroupToken{msg}
+		return true, err
+	case 10: // token.exact_phrase_token
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ExactPhraseToken)
+		err := b.DecodeMessage(msg)
+		m.Token = &PBToken_ExactPhraseToken{msg}
+		return true, err
+	case 11: // token.lang_token
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LangToken)
+		err := b.DecodeMessage(msg)
+		m.Token = &PBToken_LangToken{msg}
+		return true, err
+	case 12: // token.regexp_token
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RegexpToken)
+		err := b.DecodeMessage(msg)
+		m.Token = &PBToken_RegexpToken{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -3758,6 +4326,162 @@ func (m *NotifyGenericEvent) Reset()         { *m = NotifyGenericEvent{} }
 func (m *NotifyGenericEvent) String() string { return proto.CompactTextString(m) }
 func (*NotifyGenericEvent) ProtoMessage()    {}
 
+// AuditEvent is a record of an administrative or security-sensitive
+// operation (such as deleting a repository or setting a user's
+// permissions on a client).
+type AuditEvent struct {
+	// ID uniquely identifies the event. It is assigned when the event
+	// is recorded.
+	ID int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Actor is the user who performed the operation (if any).
+	Actor UserSpec `protobuf:"bytes,2,opt,name=actor" json:"actor"`
+	// ClientID is the ID of the registered client that performed the
+	// operation (if any).
+	ClientID string `protobuf:"bytes,3,opt,name=client_id,proto3" json:"client_id,omitempty"`
+	// Method is the RPC method of the operation (e.g., "Repos.Delete").
+	Method string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	// Target is what the operation acted on: the URI of the
+	// repository that the request refers to, or else the request
+	// encoded as JSON (with secrets redacted).
+	Target string `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
+	// Time is when the operation was performed.
+	Time pbtypes.Timestamp `protobuf:"bytes,6,opt,name=time" json:"time"`
+	// Result is the gRPC status code of the operation (e.g., "OK" or
+	// "PermissionDenied").
+	Result string `protobuf:"bytes,7,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (m *AuditEvent) Reset()         { *m = AuditEvent{} }
+func (m *AuditEvent) String() string { return proto.CompactTextString(m) }
+func (*AuditEvent) ProtoMessage()    {}
+
+type AuditEventListOptions struct {
+	// Method, if set, restricts the list to events of this RPC method.
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// Actor, if set, restricts the list to events whose actor has
+	// this UID (or, if UID is not set, this login).
+	Actor UserSpec `protobuf:"bytes,2,opt,name=actor" json:"actor"`
+	// ClientID, if set, restricts the list to events performed by
+	// this client.
+	ClientID string `protobuf:"bytes,3,opt,name=client_id,proto3" json:"client_id,omitempty"`
+	// Target, if set, restricts the list to events with this target.
+	Target string `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	// Since and Until, if set, restrict the list to events that
+	// occurred at or after Since and before Until.
+	Since       *pbtypes.Timestamp `protobuf:"bytes,5,opt,name=since" json:"since,omitempty"`
+	Until       *pbtypes.Timestamp `protobuf:"bytes,6,opt,name=until" json:"until,omitempty"`
+	ListOptions `protobuf:"bytes,7,opt,name=list_options,embedded=list_options" json:"list_options"`
+}
+
+func (m *AuditEventListOptions) Reset()         { *m = AuditEventListOptions{} }
+func (m *AuditEventListOptions) String() string { return proto.CompactTextString(m) }
+func (*AuditEventListOptions) ProtoMessage()    {}
+
+type AuditEventList struct {
+	// Events are the events, most recent first.
+	Events       []*AuditEvent `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
+	ListResponse `protobuf:"bytes,2,opt,name=list_response,embedded=list_response" json:"list_response"`
+}
+
+func (m *AuditEventList) Reset()         { *m = AuditEventList{} }
+func (m *AuditEventList) String() string { return proto.CompactTextString(m) }
+func (*AuditEventList) ProtoMessage()    {}
+
+// AccessToken is a personal access token: a named, long-lived access
+// token that a user creates for use by scripts and bots (e.g., CI
+// builds), instead of obtaining one using an OAuth2 grant. Only a
+// hash of the token itself is stored; the token is returned once,
+// when it is created.
+type AccessToken struct {
+	// ID uniquely identifies the token. It is assigned when the token
+	// is created.
+	ID int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// User is the user that the token authenticates as.
+	User UserSpec `protobuf:"bytes,2,opt,name=user" json:"user"`
+	// Name describes the token (e.g., "CI bot"). It is unique among
+	// the user's tokens.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Scope limits the access granted by the token (see ReadScope,
+	// WriteScope and AdminScope).
+	Scope []string `protobuf:"bytes,4,rep,name=scope" json:"scope,omitempty"`
+	// CreatedAt is when the token was created.
+	CreatedAt pbtypes.Timestamp `protobuf:"bytes,5,opt,name=created_at" json:"created_at"`
+	// ExpiresAt is when the token expires. If not set, it never
+	// expires.
+	ExpiresAt *pbtypes.Timestamp `protobuf:"bytes,6,opt,name=expires_at" json:"expires_at,omitempty"`
+	// LastUsedAt is when the token was last used to authenticate a
+	// request (if ever).
+	LastUsedAt *pbtypes.Timestamp `protobuf:"bytes,7,opt,name=last_used_at" json:"last_used_at,omitempty"`
+	// TokenHash is the SHA-256 hash of the token (see
+	// HashAccessToken). It is never returned to API clients.
+	TokenHash []byte `protobuf:"bytes,8,opt,name=token_hash,proto3" json:"token_hash,omitempty"`
+}
+
+func (m *AccessToken) Reset()         { *m = AccessToken{} }
+func (m *AccessToken) String() string { return proto.CompactTextString(m) }
+func (*AccessToken) ProtoMessage()    {}
+
+// AccessTokenSpec specifies a personal access token.
+type AccessTokenSpec struct {
+	// ID is the token's ID.
+	ID int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// User is the user that the token belongs to.
+	User UserSpec `protobuf:"bytes,2,opt,name=user" json:"user"`
+}
+
+func (m *AccessTokenSpec) Reset()         { *m = AccessTokenSpec{} }
+func (m *AccessTokenSpec) String() string { return proto.CompactTextString(m) }
+func (*AccessTokenSpec) ProtoMessage()    {}
+
+type AccessTokensCreateOp struct {
+	// User is the user to create the token for.
+	User UserSpec `protobuf:"bytes,1,opt,name=user" json:"user"`
+	// Name describes the token. It must be unique among the user's
+	// tokens.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Scope limits the access granted by the token.
+	Scope []string `protobuf:"bytes,3,rep,name=scope" json:"scope,omitempty"`
+	// ExpiresAt, if set, is when the token expires.
+	ExpiresAt *pbtypes.Timestamp `protobuf:"bytes,4,opt,name=expires_at" json:"expires_at,omitempty"`
+}
+
+func (m *AccessTokensCreateOp) Reset()         { *m = AccessTokensCreateOp{} }
+func (m *AccessTokensCreateOp) String() string { return proto.CompactTextString(m) }
+func (*AccessTokensCreateOp) ProtoMessage()    {}
+
+// NewAccessToken is a newly created personal access token.
+type NewAccessToken struct {
+	// AccessToken describes the token.
+	AccessToken AccessToken `protobuf:"bytes,1,opt,name=access_token" json:"access_token"`
+	// Token is the token itself. It is only returned when the token
+	// is created, and it can't be retrieved later.
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (m *NewAccessToken) Reset()         { *m = NewAccessToken{} }
+func (m *NewAccessToken) String() string { return proto.CompactTextString(m) }
+func (*NewAccessToken) ProtoMessage()    {}
+
+type AccessTokensListOptions struct {
+	// User is the user whose tokens are listed.
+	User        UserSpec `protobuf:"bytes,1,opt,name=user" json:"user"`
+	ListOptions `protobuf:"bytes,2,opt,name=list_options,embedded=list_options" json:"list_options"`
+}
+
+func (m *AccessTokensListOptions) Reset()         { *m = AccessTokensListOptions{} }
+func (m *AccessTokensListOptions) String() string { return proto.CompactTextString(m) }
+func (*AccessTokensListOptions) ProtoMessage()    {}
+
+type AccessTokenList struct {
+	// Tokens are the tokens, in the order they were created.
+	Tokens       []*AccessToken `protobuf:"bytes,1,rep,name=tokens" json:"tokens,omitempty"`
+	ListResponse `protobuf:"bytes,2,opt,name=list_response,embedded=list_response" json:"list_response"`
+}
+
+func (m *AccessTokenList) Reset()         { *m = AccessTokenList{} }
+func (m *AccessTokenList) String() string { return proto.CompactTextString(m) }
+func (*AccessTokenList) ProtoMessage()    {}
+
 func init() {
 	proto.RegisterEnum("sourcegraph.DiscussionListOrder", DiscussionListOrder_name, DiscussionListOrder_value)
 	proto.RegisterEnum("sourcegraph.RegisteredClientType", RegisteredClientType_name, RegisteredClientType_value)
@@ -7072,10 +7796,18 @@ type SearchClient interface {
 	// Search searches the full index.
 	// Deprecated: use one of the more specific search methods below.
 	Search(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (*SearchResults, error)
+	// StreamSearch searches the full index, sending results as they
+	// are found. The stream contains SearchEvents with chunks of results
+	// and progress information, followed by a final summary.
+	StreamSearch(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (Search_StreamSearchClient, error)
 	// SearchTokens searches the index of tokens.
 	SearchTokens(ctx context.Context, in *TokenSearchOptions, opts ...grpc.CallOption) (*DefList, error)
 	// SearchText searches the content of files in the repo tree.
 	SearchText(ctx context.Context, in *TextSearchOptions, opts ...grpc.CallOption) (*VCSSearchResultList, error)
+	// SearchTextMulti searches the content of files in the trees of
+	// multiple repositories. Repositories that can't be searched are
+	// reported in the RepoErrors of the results.
+	SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions, opts ...grpc.CallOption) (*MultiTextSearchResults, error)
 	// Complete completes the token at the RawQuery's InsertionPoint.
 	Complete(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*Completions, error)
 	// Suggest suggests queries given an existing query. It can be called with an empty
@@ -7101,6 +7833,38 @@ func (c *searchClient) Search(ctx context.Context, in *SearchOptions, opts ...gr
 	return out, nil
 }
 
+func (c *searchClient) StreamSearch(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (Search_StreamSearchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Search_serviceDesc.Streams[0], c.cc, "/sourcegraph.Search/StreamSearch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &searchStreamSearchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Search_StreamSearchClient interface {
+	Recv() (*SearchEvent, error)
+	grpc.ClientStream
+}
+
+type searchStreamSearchClient struct {
+	grpc.ClientStream
+}
+
+func (x *searchStreamSearchClient) Recv() (*SearchEvent, error) {
+	m := new(SearchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *searchClient) SearchTokens(ctx context.Context, in *TokenSearchOptions, opts ...grpc.CallOption) (*DefList, error) {
 	out := new(DefList)
 	err := grpc.Invoke(ctx, "/sourcegraph.Search/SearchTokens", in, out, c.cc, opts...)
@@ -7119,6 +7883,15 @@ func (c *searchClient) SearchText(ctx context.Context, in *TextSearchOptions, op
 	return out, nil
 }
 
+func (c *searchClient) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions, opts ...grpc.CallOption) (*MultiTextSearchResults, error) {
+	out := new(MultiTextSearchResults)
+	err := grpc.Invoke(ctx, "/sourcegraph.Search/SearchTextMulti", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *searchClient) Complete(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*Completions, error) {
 	out := new(Completions)
 	err := grpc.Invoke(ctx, "/sourcegraph.Search/Complete", in, out, c.cc, opts...)
@@ -7143,10 +7916,18 @@ type SearchServer interface {
 	// Search searches the full index.
 	// Deprecated: use one of the more specific search methods below.
 	Search(context.Context, *SearchOptions) (*SearchResults, error)
+	// StreamSearch searches the full index, sending results as they
+	// are found. The stream contains SearchEvents with chunks of results
+	// and progress information, followed by a final summary.
+	StreamSearch(*SearchOptions, Search_StreamSearchServer) error
 	// SearchTokens searches the index of tokens.
 	SearchTokens(context.Context, *TokenSearchOptions) (*DefList, error)
 	// SearchText searches the content of files in the repo tree.
 	SearchText(context.Context, *TextSearchOptions) (*VCSSearchResultList, error)
+	// SearchTextMulti searches the content of files in the trees of
+	// multiple repositories. Repositories that can't be searched are
+	// reported in the RepoErrors of the results.
+	SearchTextMulti(context.Context, *MultiTextSearchOptions) (*MultiTextSearchResults, error)
 	// Complete completes the token at the RawQuery's InsertionPoint.
 	Complete(context.Context, *RawQuery) (*Completions, error)
 	// Suggest suggests queries given an existing query. It can be called with an empty
@@ -7171,6 +7952,27 @@ func _Search_Search_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return out, nil
 }
 
+func _Search_StreamSearch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SearchOptions)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SearchServer).StreamSearch(m, &searchStreamSearchServer{stream})
+}
+
+type Search_StreamSearchServer interface {
+	Send(*SearchEvent) error
+	grpc.ServerStream
+}
+
+type searchStreamSearchServer struct {
+	grpc.ServerStream
+}
+
+func (x *searchStreamSearchServer) Send(m *SearchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Search_SearchTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
 	in := new(TokenSearchOptions)
 	if err := dec(in); err != nil {
@@ -7195,6 +7997,18 @@ func _Search_SearchText_Handler(srv interface{}, ctx context.Context, dec func(i
 	return out, nil
 }
 
+func _Search_SearchTextMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(MultiTextSearchOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(SearchServer).SearchTextMulti(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func _Search_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
 	in := new(RawQuery)
 	if err := dec(in); err != nil {
@@ -7235,6 +8049,10 @@ var _Search_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SearchText",
 			Handler:    _Search_SearchText_Handler,
 		},
+		{
+			MethodName: "SearchTextMulti",
+			Handler:    _Search_SearchTextMulti_Handler,
+		},
 		{
 			MethodName: "Complete",
 			Handler:    _Search_Complete_Handler,
@@ -7244,6 +8062,164 @@ var _Search_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Search_Suggest_Handler,
 		},
 	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSearch",
+			Handler:       _Search_StreamSearch_Handler,
+			ServerStreams: true,
+		},
+	},
+}
+
+// Client API for SavedSearches service
+
+type SavedSearchesClient interface {
+	// Create saves a query.
+	Create(ctx context.Context, in *SavedSearchesCreateOp, opts ...grpc.CallOption) (*SavedSearch, error)
+	// List lists saved searches.
+	List(ctx context.Context, in *SavedSearchListOptions, opts ...grpc.CallOption) (*SavedSearchList, error)
+	// Delete deletes a saved search.
+	Delete(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*pbtypes1.Void, error)
+	// Run runs a saved search and reports the results that are new since
+	// the previous run (e.g., newly introduced usages of a deprecated def).
+	// If the search has notification settings and there are new results,
+	// the owner is notified.
+	Run(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*SavedSearchRunResult, error)
+}
+
+type savedSearchesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSavedSearchesClient(cc *grpc.ClientConn) SavedSearchesClient {
+	return &savedSearchesClient{cc}
+}
+
+func (c *savedSearchesClient) Create(ctx context.Context, in *SavedSearchesCreateOp, opts ...grpc.CallOption) (*SavedSearch, error) {
+	out := new(SavedSearch)
+	err := grpc.Invoke(ctx, "/sourcegraph.SavedSearches/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *savedSearchesClient) List(ctx context.Context, in *SavedSearchListOptions, opts ...grpc.CallOption) (*SavedSearchList, error) {
+	out := new(SavedSearchList)
+	err := grpc.Invoke(ctx, "/sourcegraph.SavedSearches/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *savedSearchesClient) Delete(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*pbtypes1.Void, error) {
+	out := new(pbtypes1.Void)
+	err := grpc.Invoke(ctx, "/sourcegraph.SavedSearches/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *savedSearchesClient) Run(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*SavedSearchRunResult, error) {
+	out := new(SavedSearchRunResult)
+	err := grpc.Invoke(ctx, "/sourcegraph.SavedSearches/Run", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for SavedSearches service
+
+type SavedSearchesServer interface {
+	// Create saves a query.
+	Create(context.Context, *SavedSearchesCreateOp) (*SavedSearch, error)
+	// List lists saved searches.
+	List(context.Context, *SavedSearchListOptions) (*SavedSearchList, error)
+	// Delete deletes a saved search.
+	Delete(context.Context, *SavedSearchSpec) (*pbtypes1.Void, error)
+	// Run runs a saved search and reports the results that are new since
+	// the previous run (e.g., newly introduced usages of a deprecated def).
+	// If the search has notification settings and there are new results,
+	// the owner is notified.
+	Run(context.Context, *SavedSearchSpec) (*SavedSearchRunResult, error)
+}
+
+func RegisterSavedSearchesServer(s *grpc.Server, srv SavedSearchesServer) {
+	s.RegisterService(&_SavedSearches_serviceDesc, srv)
+}
+
+func _SavedSearches_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(SavedSearchesCreateOp)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(SavedSearchesServer).Create(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _SavedSearches_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(SavedSearchListOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(SavedSearchesServer).List(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _SavedSearches_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(SavedSearchSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(SavedSearchesServer).Delete(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _SavedSearches_Run_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(SavedSearchSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(SavedSearchesServer).Run(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+var _SavedSearches_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sourcegraph.SavedSearches",
+	HandlerType: (*SavedSearchesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _SavedSearches_Create_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _SavedSearches_List_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _SavedSearches_Delete_Handler,
+		},
+		{
+			MethodName: "Run",
+			Handler:    _SavedSearches_Run_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{},
 }
 
@@ -7903,3 +8879,187 @@ var _Notify_serviceDesc = grpc.ServiceDesc{
 	},
 	Streams: []grpc.StreamDesc{},
 }
+
+// Client API for AuditLog service
+
+type AuditLogClient interface {
+	// List lists audit events, most recent first.
+	List(ctx context.Context, in *AuditEventListOptions, opts ...grpc.CallOption) (*AuditEventList, error)
+}
+
+type auditLogClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAuditLogClient(cc *grpc.ClientConn) AuditLogClient {
+	return &auditLogClient{cc}
+}
+
+func (c *auditLogClient) List(ctx context.Context, in *AuditEventListOptions, opts ...grpc.CallOption) (*AuditEventList, error) {
+	out := new(AuditEventList)
+	err := grpc.Invoke(ctx, "/sourcegraph.AuditLog/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for AuditLog service
+
+type AuditLogServer interface {
+	// List lists audit events, most recent first.
+	List(context.Context, *AuditEventListOptions) (*AuditEventList, error)
+}
+
+func RegisterAuditLogServer(s *grpc.Server, srv AuditLogServer) {
+	s.RegisterService(&_AuditLog_serviceDesc, srv)
+}
+
+func _AuditLog_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(AuditEventListOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(AuditLogServer).List(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+var _AuditLog_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sourcegraph.AuditLog",
+	HandlerType: (*AuditLogServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _AuditLog_List_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// Client API for AccessTokens service
+
+type AccessTokensClient interface {
+	// Create creates a personal access token for a user. The
+	// response contains the token itself, which can't be retrieved
+	// later.
+	Create(ctx context.Context, in *AccessTokensCreateOp, opts ...grpc.CallOption) (*NewAccessToken, error)
+	// List lists a user's personal access tokens (without the tokens
+	// themselves).
+	List(ctx context.Context, in *AccessTokensListOptions, opts ...grpc.CallOption) (*AccessTokenList, error)
+	// Revoke revokes a personal access token. Requests authenticated
+	// with the token will no longer succeed.
+	Revoke(ctx context.Context, in *AccessTokenSpec, opts ...grpc.CallOption) (*pbtypes1.Void, error)
+}
+
+type accessTokensClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAccessTokensClient(cc *grpc.ClientConn) AccessTokensClient {
+	return &accessTokensClient{cc}
+}
+
+func (c *accessTokensClient) Create(ctx context.Context, in *AccessTokensCreateOp, opts ...grpc.CallOption) (*NewAccessToken, error) {
+	out := new(NewAccessToken)
+	err := grpc.Invoke(ctx, "/sourcegraph.AccessTokens/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessTokensClient) List(ctx context.Context, in *AccessTokensListOptions, opts ...grpc.CallOption) (*AccessTokenList, error) {
+	out := new(AccessTokenList)
+	err := grpc.Invoke(ctx, "/sourcegraph.AccessTokens/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessTokensClient) Revoke(ctx context.Context, in *AccessTokenSpec, opts ...grpc.CallOption) (*pbtypes1.Void, error) {
+	out := new(pbtypes1.Void)
+	err := grpc.Invoke(ctx, "/sourcegraph.AccessTokens/Revoke", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for AccessTokens service
+
+type AccessTokensServer interface {
+	// Create creates a personal access token for a user. The
+	// response contains the token itself, which can't be retrieved
+	// later.
+	Create(context.Context, *AccessTokensCreateOp) (*NewAccessToken, error)
+	// List lists a user's personal access tokens (without the tokens
+	// themselves).
+	List(context.Context, *AccessTokensListOptions) (*AccessTokenList, error)
+	// Revoke revokes a personal access token. Requests authenticated
+	// with the token will no longer succeed.
+	Revoke(context.Context, *AccessTokenSpec) (*pbtypes1.Void, error)
+}
+
+func RegisterAccessTokensServer(s *grpc.Server, srv AccessTokensServer) {
+	s.RegisterService(&_AccessTokens_serviceDesc, srv)
+}
+
+func _AccessTokens_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(AccessTokensCreateOp)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(AccessTokensServer).Create(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _AccessTokens_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(AccessTokensListOptions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(AccessTokensServer).List(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func _AccessTokens_Revoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error) (interface{}, error) {
+	in := new(AccessTokenSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	out, err := srv.(AccessTokensServer).Revoke(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+var _AccessTokens_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sourcegraph.AccessTokens",
+	HandlerType: (*AccessTokensServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _AccessTokens_Create_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _AccessTokens_List_Handler,
+		},
+		{
+			MethodName: "Revoke",
+			Handler:    _AccessTokens_Revoke_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
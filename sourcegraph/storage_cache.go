@@ -0,0 +1,184 @@
+package sourcegraph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+	"sourcegraph.com/sourcegraph/grpccache"
+)
+
+// A StorageCache caches API responses in a CacheStorage (such as an
+// in-memory LRUCacheStorage or a persistent DiskCacheStorage). Like
+// grpccache.Cache, it only caches responses for as long as the server
+// allows, as indicated by the cache control trailers that the
+// Cached*Server wrappers set, and only caches the responses of
+// idempotent methods (see IsIdempotent).
+//
+// API clients use a StorageCache if it is set in ClientOptions or in
+// the context (using WithStorageCache).
+type StorageCache struct {
+	// Storage stores the cached responses.
+	Storage CacheStorage
+
+	// KeyPart, if set, returns a string that is added to the cache
+	// key of each call, to partition the cache. If nil,
+	// CredentialsCacheKeyPart is used, so that cached responses
+	// obtained using one user's credentials are never returned to
+	// another user.
+	KeyPart func(ctx context.Context) string
+
+	hits, misses int64 // accessed atomically
+}
+
+// CacheStats are statistics about a StorageCache.
+type CacheStats struct {
+	Hits   int64 // number of calls whose response was in the cache
+	Misses int64 // number of calls whose response was not in the cache
+}
+
+// NewStorageCache returns a new cache that stores responses in
+// storage.
+func NewStorageCache(storage CacheStorage) *StorageCache {
+	return &StorageCache{Storage: storage}
+}
+
+// WithStorageCache returns a copy of the parent context whose API
+// clients (constructed using NewClientFromContext) cache API
+// responses in cache.
+func WithStorageCache(parent context.Context, cache *StorageCache) context.Context {
+	return context.WithValue(parent, storageCacheKey, cache)
+}
+
+// storageCacheFromContext returns the cache previously set in the
+// context by WithStorageCache, or nil if none was set.
+func storageCacheFromContext(ctx context.Context) *StorageCache {
+	cache, _ := ctx.Value(storageCacheKey).(*StorageCache)
+	return cache
+}
+
+// key returns the storage key for a call to method with arg. It
+// consists of the method name and a hash of the key part and the
+// marshaled request.
+func (c *StorageCache) key(ctx context.Context, method string, arg proto.Message) (string, error) {
+	data, err := proto.Marshal(arg)
+	if err != nil {
+		return "", err
+	}
+	keyPart := c.KeyPart
+	if keyPart == nil {
+		keyPart = CredentialsCacheKeyPart
+	}
+	h := sha256.New()
+	h.Write([]byte(keyPart(ctx)))
+	h.Write([]byte{0})
+	h.Write(data)
+	return method + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Get looks up the cached response to a call to method with arg. If
+// there is an unexpired cached response, it is unmarshaled into result
+// and Get returns true.
+func (c *StorageCache) Get(ctx context.Context, method string, arg, result proto.Message) (cached bool, err error) {
+	key, err := c.key(ctx, method, arg)
+	if err != nil {
+		return false, err
+	}
+	e, ok := c.Storage.Get(key)
+	if ok && !time.Now().Before(e.Expires) {
+		c.Storage.Delete(key)
+		ok = false
+	}
+	if ok {
+		if err := proto.Unmarshal(e.Data, result); err != nil {
+			// Treat corrupt entries as missing.
+			c.Storage.Delete(key)
+			ok = false
+		}
+	}
+	if !ok {
+		atomic.AddInt64(&c.misses, 1)
+		return false, nil
+	}
+	atomic.AddInt64(&c.hits, 1)
+	SpanFromContext(ctx).SetTag(CacheHitTag, "true")
+	return true, nil
+}
+
+// Store caches the response (result) to a call to method with arg, if
+// the server allowed it to be cached (in the call's trailer).
+func (c *StorageCache) Store(ctx context.Context, method string, arg, result proto.Message, trailer metadata.MD) error {
+	maxAge := cacheControlMaxAge(trailer)
+	if maxAge <= 0 {
+		return nil
+	}
+	key, err := c.key(ctx, method, arg)
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return c.Storage.Set(key, &CacheEntry{
+		Method:  method,
+		Repo:    requestRepoURI(arg),
+		Data:    data,
+		Expires: time.Now().Add(maxAge),
+	})
+}
+
+// InvalidateRepo deletes all cached responses to requests that refer
+// to the repository with the given URI (e.g., after a push to it). It
+// returns the number of deleted responses.
+func (c *StorageCache) InvalidateRepo(uri string) (int, error) {
+	var keys []string
+	err := c.Storage.Walk(func(key string, e *CacheEntry) error {
+		if e.Repo == uri {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for i, key := range keys {
+		if err := c.Storage.Delete(key); err != nil {
+			return i, err
+		}
+	}
+	return len(keys), nil
+}
+
+// Stats returns statistics about the cache.
+func (c *StorageCache) Stats() CacheStats {
+	return CacheStats{
+		Hits:   atomic.LoadInt64(&c.hits),
+		Misses: atomic.LoadInt64(&c.misses),
+	}
+}
+
+// cacheControlTrailerKey is the trailer key of the cache control
+// set by the Cached*Server wrappers (see
+// grpccache.Internal_SetCacheControlTrailer).
+const cacheControlTrailerKey = "cache-control"
+
+// cacheControlMaxAge returns how long the response may be cached,
+// according to the JSON-encoded grpccache.CacheControl in the
+// trailer. It returns 0 if the response may not be cached.
+func cacheControlMaxAge(trailer metadata.MD) time.Duration {
+	v := trailer[cacheControlTrailerKey]
+	if len(v) == 0 {
+		return 0
+	}
+	var cc grpccache.CacheControl
+	if err := json.Unmarshal([]byte(v[len(v)-1]), &cc); err != nil {
+		return 0
+	}
+	return cc.MaxAge
+}
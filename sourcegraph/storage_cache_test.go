@@ -0,0 +1,249 @@
+package sourcegraph
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"sourcegraph.com/sourcegraph/grpccache"
+)
+
+func TestStorageCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewStorageCache(NewLRUCacheStorage(0, 0))
+	arg := &RepoSpec{URI: "r"}
+	maxAge := cacheControlTrailer(time.Minute)
+
+	var repo Repo
+	if cached, err := c.Get(ctx, "Repos.Get", arg, &repo); err != nil || cached {
+		t.Fatalf("got cached == %v (err %v) before Store, want false", cached, err)
+	}
+
+	// Responses are only stored if the server allows it.
+	if err := c.Store(ctx, "Repos.Get", arg, &Repo{URI: "r"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if cached, _ := c.Get(ctx, "Repos.Get", arg, &repo); cached {
+		t.Error("got cached == true without cache-control trailer")
+	}
+
+	if err := c.Store(ctx, "Repos.Get", arg, &Repo{URI: "r"}, maxAge); err != nil {
+		t.Fatal(err)
+	}
+	if cached, err := c.Get(ctx, "Repos.Get", arg, &repo); err != nil || !cached {
+		t.Fatalf("got cached == %v (err %v) after Store, want true", cached, err)
+	}
+	if repo.URI != "r" {
+		t.Errorf("got cached repo URI %q, want %q", repo.URI, "r")
+	}
+
+	// Other methods, args and credentials don't share the response.
+	if cached, _ := c.Get(ctx, "Repos.Resolve", arg, &repo); cached {
+		t.Error("got cached == true for different method")
+	}
+	if cached, _ := c.Get(ctx, "Repos.Get", &RepoSpec{URI: "r2"}, &repo); cached {
+		t.Error("got cached == true for different arg")
+	}
+	if cached, _ := c.Get(WithClientMetadata(ctx, map[string]string{"authorization": "x"}), "Repos.Get", arg, &repo); cached {
+		t.Error("got cached == true for different credentials")
+	}
+
+	if got, want := c.Stats(), (CacheStats{Hits: 1, Misses: 5}); got != want {
+		t.Errorf("got stats %+v, want %+v", got, want)
+	}
+}
+
+func TestStorageCache_expired(t *testing.T) {
+	ctx := context.Background()
+	s := NewLRUCacheStorage(0, 0)
+	c := NewStorageCache(s)
+	arg := &RepoSpec{URI: "r"}
+	if err := c.Store(ctx, "Repos.Get", arg, &Repo{}, cacheControlTrailer(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	s.Walk(func(key string, e *CacheEntry) error {
+		e.Expires = time.Now().Add(-time.Second)
+		return nil
+	})
+	if cached, _ := c.Get(ctx, "Repos.Get", arg, &Repo{}); cached {
+		t.Error("got cached == true for expired response")
+	}
+	if got := s.Len(); got != 0 {
+		t.Errorf("got %d stored entries, want expired entry to be deleted", got)
+	}
+}
+
+func TestStorageCache_InvalidateRepo(t *testing.T) {
+	ctx := context.Background()
+	c := NewStorageCache(NewLRUCacheStorage(0, 0))
+	maxAge := cacheControlTrailer(time.Minute)
+	for _, arg := range []proto.Message{
+		&RepoRevSpec{RepoSpec: RepoSpec{URI: "r"}, Rev: "v"},
+		&DefSpec{Repo: "r", Path: "p"},
+		&RepoSpec{URI: "r2"},
+	} {
+		if err := c.Store(ctx, "M", arg, &Repo{}, maxAge); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n, err := c.InvalidateRepo("r")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("got %d invalidated responses, want 2", n)
+	}
+	if cached, _ := c.Get(ctx, "M", &DefSpec{Repo: "r", Path: "p"}, &Repo{}); cached {
+		t.Error("got cached == true for invalidated response")
+	}
+	if cached, _ := c.Get(ctx, "M", &RepoSpec{URI: "r2"}, &Repo{}); !cached {
+		t.Error("got cached == false for response of other repo")
+	}
+}
+
+func TestCacheControlMaxAge(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":                       0,
+		`{"MaxAge":60000000000}`: time.Minute,
+		`{"MaxAge":0}`:           0,
+		`{}`:                     0,
+		"max-age=60":             0,
+		`{"MaxAge":"60s"}`:       0,
+	}
+	for v, want := range tests {
+		if got := cacheControlMaxAge(metadata.MD{"cache-control": []string{v}}); got != want {
+			t.Errorf("%q: got %s, want %s", v, got, want)
+		}
+	}
+	if got := cacheControlMaxAge(nil); got != 0 {
+		t.Errorf("no trailer: got %s, want 0", got)
+	}
+}
+
+// cacheControlTrailer returns the trailer that the Cached*Server
+// wrappers set to allow a response to be cached for maxAge.
+func cacheControlTrailer(maxAge time.Duration) metadata.MD {
+	b, err := json.Marshal(grpccache.CacheControl{MaxAge: maxAge})
+	if err != nil {
+		panic(err)
+	}
+	return metadata.MD{"cache-control": []string{string(b)}}
+}
+
+// cacheControlReposServer is a ReposServer whose Get and Create allow
+// their responses to be cached for a minute.
+type cacheControlReposServer struct {
+	ReposServer
+	calls int32 // accessed atomically
+}
+
+func (s *cacheControlReposServer) Get(ctx context.Context, in *RepoSpec) (*Repo, error) {
+	atomic.AddInt32(&s.calls, 1)
+	if err := grpccache.SetCacheControl(ctx, grpccache.CacheControl{MaxAge: time.Minute}); err != nil {
+		return nil, err
+	}
+	return &Repo{URI: in.URI}, nil
+}
+
+func (s *cacheControlReposServer) Create(ctx context.Context, in *ReposCreateOp) (*Repo, error) {
+	atomic.AddInt32(&s.calls, 1)
+	if err := grpccache.SetCacheControl(ctx, grpccache.CacheControl{MaxAge: time.Minute}); err != nil {
+		return nil, err
+	}
+	return &Repo{URI: in.URI}, nil
+}
+
+// testStorageCacheClient starts a server for repos (wrapped in a
+// CachedReposServer) and returns a client that caches its responses
+// in cache. The caller must call done when finished.
+func testStorageCacheClient(t *testing.T, repos ReposServer, cache *StorageCache) (c *Client, ctx context.Context, done func()) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	RegisterReposServer(s, &CachedReposServer{repos})
+	go s.Serve(l)
+
+	pool := &ConnPool{}
+	ctx = WithConnPool(context.Background(), pool)
+	ctx = WithGRPCEndpoint(ctx, &url.URL{Host: l.Addr().String()})
+	ctx = WithStorageCache(ctx, cache)
+	return NewClientFromContext(ctx), ctx, func() {
+		pool.Close()
+		s.Stop()
+	}
+}
+
+func TestStorageCache_server(t *testing.T) {
+	repos := &cacheControlReposServer{}
+	cache := NewStorageCache(NewLRUCacheStorage(0, 0))
+	c, ctx, done := testStorageCacheClient(t, repos, cache)
+	defer done()
+
+	for i := 0; i < 2; i++ {
+		repo, err := c.Repos.Get(ctx, &RepoSpec{URI: "r"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if repo.URI != "r" {
+			t.Errorf("got repo URI %q, want %q", repo.URI, "r")
+		}
+	}
+	if calls := atomic.LoadInt32(&repos.calls); calls != 1 {
+		t.Errorf("got %d calls to the server, want 1 (the second response is cached)", calls)
+	}
+	if got, want := cache.Stats(), (CacheStats{Hits: 1, Misses: 1}); got != want {
+		t.Errorf("got stats %+v, want %+v", got, want)
+	}
+
+	// Responses of non-idempotent methods are never cached.
+	for i := 0; i < 2; i++ {
+		if _, err := c.Repos.Create(ctx, &ReposCreateOp{URI: "r2"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls := atomic.LoadInt32(&repos.calls); calls != 3 {
+		t.Errorf("got %d calls to the server, want 3 (Repos.Create isn't cached)", calls)
+	}
+}
+
+// errorCacheStorage is a CacheStorage that fails to store entries.
+type errorCacheStorage struct{ CacheStorage }
+
+func (errorCacheStorage) Set(string, *CacheEntry) error { return errors.New("x") }
+
+func TestStorageCache_storeError(t *testing.T) {
+	repos := &cacheControlReposServer{}
+	c, ctx, done := testStorageCacheClient(t, repos, NewStorageCache(errorCacheStorage{NewLRUCacheStorage(0, 0)}))
+	defer done()
+
+	// The call succeeded, so failing to cache its response isn't an
+	// error.
+	repo, err := c.Repos.Get(ctx, &RepoSpec{URI: "r"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.URI != "r" {
+		t.Errorf("got repo URI %q, want %q", repo.URI, "r")
+	}
+}
+
+func TestWithStorageCache(t *testing.T) {
+	if got := storageCacheFromContext(context.Background()); got != nil {
+		t.Errorf("got cache %p, want nil", got)
+	}
+	cache := &StorageCache{}
+	if got := storageCacheFromContext(WithStorageCache(context.Background(), cache)); got != cache {
+		t.Errorf("got cache %p, want %p", got, cache)
+	}
+}
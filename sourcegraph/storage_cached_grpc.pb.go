@@ -0,0 +1,3369 @@
+// GENERATED CODE - DO NOT EDIT!
+//
+// Generated by:
+//
+//   go run gen/wrappers.go -kind storagecache -o storage_cached_grpc.pb.go sourcegraph.pb.go
+//
+// Called via:
+//
+//   go generate
+//
+
+package sourcegraph
+
+import (
+	"log"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+	"sourcegraph.com/sourcegraph/srclib/unit"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+type StorageCachedAccessTokensClient struct {
+	AccessTokensClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedAccessTokensClient) Create(ctx context.Context, in *AccessTokensCreateOp, opts ...grpc.CallOption) (*NewAccessToken, error) {
+	if s.Cache == nil || !IsIdempotent("AccessTokens.Create") {
+		return s.AccessTokensClient.Create(ctx, in, opts...)
+	}
+
+	var cachedResult NewAccessToken
+	cached, err := s.Cache.Get(ctx, "AccessTokens.Create", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AccessTokensClient.Create(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "AccessTokens.Create", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "AccessTokens.Create", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedAccessTokensClient) List(ctx context.Context, in *AccessTokensListOptions, opts ...grpc.CallOption) (*AccessTokenList, error) {
+	if s.Cache == nil || !IsIdempotent("AccessTokens.List") {
+		return s.AccessTokensClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult AccessTokenList
+	cached, err := s.Cache.Get(ctx, "AccessTokens.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AccessTokensClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "AccessTokens.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "AccessTokens.List", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedAccessTokensClient) Revoke(ctx context.Context, in *AccessTokenSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("AccessTokens.Revoke") {
+		return s.AccessTokensClient.Revoke(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "AccessTokens.Revoke", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AccessTokensClient.Revoke(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "AccessTokens.Revoke", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "AccessTokens.Revoke", err)
+	}
+	return result, nil
+}
+
+type StorageCachedAccountsClient struct {
+	AccountsClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedAccountsClient) Create(ctx context.Context, in *NewAccount, opts ...grpc.CallOption) (*UserSpec, error) {
+	if s.Cache == nil || !IsIdempotent("Accounts.Create") {
+		return s.AccountsClient.Create(ctx, in, opts...)
+	}
+
+	var cachedResult UserSpec
+	cached, err := s.Cache.Get(ctx, "Accounts.Create", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AccountsClient.Create(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Accounts.Create", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Accounts.Create", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedAccountsClient) RequestPasswordReset(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (*User, error) {
+	if s.Cache == nil || !IsIdempotent("Accounts.RequestPasswordReset") {
+		return s.AccountsClient.RequestPasswordReset(ctx, in, opts...)
+	}
+
+	var cachedResult User
+	cached, err := s.Cache.Get(ctx, "Accounts.RequestPasswordReset", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AccountsClient.RequestPasswordReset(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Accounts.RequestPasswordReset", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Accounts.RequestPasswordReset", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedAccountsClient) ResetPassword(ctx context.Context, in *NewPassword, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("Accounts.ResetPassword") {
+		return s.AccountsClient.ResetPassword(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "Accounts.ResetPassword", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AccountsClient.ResetPassword(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Accounts.ResetPassword", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Accounts.ResetPassword", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedAccountsClient) Update(ctx context.Context, in *User, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("Accounts.Update") {
+		return s.AccountsClient.Update(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "Accounts.Update", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AccountsClient.Update(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Accounts.Update", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Accounts.Update", err)
+	}
+	return result, nil
+}
+
+type StorageCachedAuditLogClient struct {
+	AuditLogClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedAuditLogClient) List(ctx context.Context, in *AuditEventListOptions, opts ...grpc.CallOption) (*AuditEventList, error) {
+	if s.Cache == nil || !IsIdempotent("AuditLog.List") {
+		return s.AuditLogClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult AuditEventList
+	cached, err := s.Cache.Get(ctx, "AuditLog.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AuditLogClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "AuditLog.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "AuditLog.List", err)
+	}
+	return result, nil
+}
+
+type StorageCachedAuthClient struct {
+	AuthClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedAuthClient) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest, opts ...grpc.CallOption) (*AuthorizationCode, error) {
+	if s.Cache == nil || !IsIdempotent("Auth.GetAuthorizationCode") {
+		return s.AuthClient.GetAuthorizationCode(ctx, in, opts...)
+	}
+
+	var cachedResult AuthorizationCode
+	cached, err := s.Cache.Get(ctx, "Auth.GetAuthorizationCode", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AuthClient.GetAuthorizationCode(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Auth.GetAuthorizationCode", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Auth.GetAuthorizationCode", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedAuthClient) GetAccessToken(ctx context.Context, in *AccessTokenRequest, opts ...grpc.CallOption) (*AccessTokenResponse, error) {
+	if s.Cache == nil || !IsIdempotent("Auth.GetAccessToken") {
+		return s.AuthClient.GetAccessToken(ctx, in, opts...)
+	}
+
+	var cachedResult AccessTokenResponse
+	cached, err := s.Cache.Get(ctx, "Auth.GetAccessToken", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AuthClient.GetAccessToken(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Auth.GetAccessToken", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Auth.GetAccessToken", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedAuthClient) Identify(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*AuthInfo, error) {
+	if s.Cache == nil || !IsIdempotent("Auth.Identify") {
+		return s.AuthClient.Identify(ctx, in, opts...)
+	}
+
+	var cachedResult AuthInfo
+	cached, err := s.Cache.Get(ctx, "Auth.Identify", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AuthClient.Identify(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Auth.Identify", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Auth.Identify", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedAuthClient) GetPermissions(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*UserPermissions, error) {
+	if s.Cache == nil || !IsIdempotent("Auth.GetPermissions") {
+		return s.AuthClient.GetPermissions(ctx, in, opts...)
+	}
+
+	var cachedResult UserPermissions
+	cached, err := s.Cache.Get(ctx, "Auth.GetPermissions", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.AuthClient.GetPermissions(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Auth.GetPermissions", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Auth.GetPermissions", err)
+	}
+	return result, nil
+}
+
+type StorageCachedBuildsClient struct {
+	BuildsClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedBuildsClient) Get(ctx context.Context, in *BuildSpec, opts ...grpc.CallOption) (*Build, error) {
+	if s.Cache == nil || !IsIdempotent("Builds.Get") {
+		return s.BuildsClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult Build
+	cached, err := s.Cache.Get(ctx, "Builds.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.BuildsClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Builds.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Builds.Get", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedBuildsClient) GetRepoBuildInfo(ctx context.Context, in *BuildsGetRepoBuildInfoOp, opts ...grpc.CallOption) (*RepoBuildInfo, error) {
+	if s.Cache == nil || !IsIdempotent("Builds.GetRepoBuildInfo") {
+		return s.BuildsClient.GetRepoBuildInfo(ctx, in, opts...)
+	}
+
+	var cachedResult RepoBuildInfo
+	cached, err := s.Cache.Get(ctx, "Builds.GetRepoBuildInfo", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.BuildsClient.GetRepoBuildInfo(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Builds.GetRepoBuildInfo", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Builds.GetRepoBuildInfo", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedBuildsClient) List(ctx context.Context, in *BuildListOptions, opts ...grpc.CallOption) (*BuildList, error) {
+	if s.Cache == nil || !IsIdempotent("Builds.List") {
+		return s.BuildsClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult BuildList
+	cached, err := s.Cache.Get(ctx, "Builds.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.BuildsClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Builds.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Builds.List", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedBuildsClient) Create(ctx context.Context, in *BuildsCreateOp, opts ...grpc.CallOption) (*Build, error) {
+	if s.Cache == nil || !IsIdempotent("Builds.Create") {
+		return s.BuildsClient.Create(ctx, in, opts...)
+	}
+
+	var cachedResult Build
+	cached, err := s.Cache.Get(ctx, "Builds.Create", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.BuildsClient.Create(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Builds.Create", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Builds.Create", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedBuildsClient) Update(ctx context.Context, in *BuildsUpdateOp, opts ...grpc.CallOption) (*Build, error) {
+	if s.Cache == nil || !IsIdempotent("Builds.Update") {
+		return s.BuildsClient.Update(ctx, in, opts...)
+	}
+
+	var cachedResult Build
+	cached, err := s.Cache.Get(ctx, "Builds.Update", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.BuildsClient.Update(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Builds.Update", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Builds.Update", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedBuildsClient) ListBuildTasks(ctx context.Context, in *BuildsListBuildTasksOp, opts ...grpc.CallOption) (*BuildTaskList, error) {
+	if s.Cache == nil || !IsIdempotent("Builds.ListBuildTasks") {
+		return s.BuildsClient.ListBuildTasks(ctx, in, opts...)
+	}
+
+	var cachedResult BuildTaskList
+	cached, err := s.Cache.Get(ctx, "Builds.ListBuildTasks", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.BuildsClient.ListBuildTasks(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Builds.ListBuildTasks", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Builds.ListBuildTasks", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedBuildsClient) CreateTasks(ctx context.Context, in *BuildsCreateTasksOp, opts ...grpc.CallOption) (*BuildTaskList, error) {
+	if s.Cache == nil || !IsIdempotent("Builds.CreateTasks") {
+		return s.BuildsClient.CreateTasks(ctx, in, opts...)
+	}
+
+	var cachedResult BuildTaskList
+	cached, err := s.Cache.Get(ctx, "Builds.CreateTasks", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.BuildsClient.CreateTasks(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Builds.CreateTasks", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Builds.CreateTasks", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedBuildsClient) UpdateTask(ctx context.Context, in *BuildsUpdateTaskOp, opts ...grpc.CallOption) (*BuildTask, error) {
+	if s.Cache == nil || !IsIdempotent("Builds.UpdateTask") {
+		return s.BuildsClient.UpdateTask(ctx, in, opts...)
+	}
+
+	var cachedResult BuildTask
+	cached, err := s.Cache.Get(ctx, "Builds.UpdateTask", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.BuildsClient.UpdateTask(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Builds.UpdateTask", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Builds.UpdateTask", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedBuildsClient) GetLog(ctx context.Context, in *BuildsGetLogOp, opts ...grpc.CallOption) (*LogEntries, error) {
+	if s.Cache == nil || !IsIdempotent("Builds.GetLog") {
+		return s.BuildsClient.GetLog(ctx, in, opts...)
+	}
+
+	var cachedResult LogEntries
+	cached, err := s.Cache.Get(ctx, "Builds.GetLog", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.BuildsClient.GetLog(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Builds.GetLog", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Builds.GetLog", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedBuildsClient) GetTaskLog(ctx context.Context, in *BuildsGetTaskLogOp, opts ...grpc.CallOption) (*LogEntries, error) {
+	if s.Cache == nil || !IsIdempotent("Builds.GetTaskLog") {
+		return s.BuildsClient.GetTaskLog(ctx, in, opts...)
+	}
+
+	var cachedResult LogEntries
+	cached, err := s.Cache.Get(ctx, "Builds.GetTaskLog", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.BuildsClient.GetTaskLog(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Builds.GetTaskLog", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Builds.GetTaskLog", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedBuildsClient) DequeueNext(ctx context.Context, in *BuildsDequeueNextOp, opts ...grpc.CallOption) (*Build, error) {
+	if s.Cache == nil || !IsIdempotent("Builds.DequeueNext") {
+		return s.BuildsClient.DequeueNext(ctx, in, opts...)
+	}
+
+	var cachedResult Build
+	cached, err := s.Cache.Get(ctx, "Builds.DequeueNext", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.BuildsClient.DequeueNext(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Builds.DequeueNext", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Builds.DequeueNext", err)
+	}
+	return result, nil
+}
+
+type StorageCachedChangesetsClient struct {
+	ChangesetsClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedChangesetsClient) Create(ctx context.Context, in *ChangesetCreateOp, opts ...grpc.CallOption) (*Changeset, error) {
+	if s.Cache == nil || !IsIdempotent("Changesets.Create") {
+		return s.ChangesetsClient.Create(ctx, in, opts...)
+	}
+
+	var cachedResult Changeset
+	cached, err := s.Cache.Get(ctx, "Changesets.Create", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ChangesetsClient.Create(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Changesets.Create", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Changesets.Create", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedChangesetsClient) Get(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (*Changeset, error) {
+	if s.Cache == nil || !IsIdempotent("Changesets.Get") {
+		return s.ChangesetsClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult Changeset
+	cached, err := s.Cache.Get(ctx, "Changesets.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ChangesetsClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Changesets.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Changesets.Get", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedChangesetsClient) List(ctx context.Context, in *ChangesetListOp, opts ...grpc.CallOption) (*ChangesetList, error) {
+	if s.Cache == nil || !IsIdempotent("Changesets.List") {
+		return s.ChangesetsClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult ChangesetList
+	cached, err := s.Cache.Get(ctx, "Changesets.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ChangesetsClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Changesets.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Changesets.List", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedChangesetsClient) Update(ctx context.Context, in *ChangesetUpdateOp, opts ...grpc.CallOption) (*ChangesetEvent, error) {
+	if s.Cache == nil || !IsIdempotent("Changesets.Update") {
+		return s.ChangesetsClient.Update(ctx, in, opts...)
+	}
+
+	var cachedResult ChangesetEvent
+	cached, err := s.Cache.Get(ctx, "Changesets.Update", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ChangesetsClient.Update(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Changesets.Update", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Changesets.Update", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedChangesetsClient) Merge(ctx context.Context, in *ChangesetMergeOp, opts ...grpc.CallOption) (*ChangesetEvent, error) {
+	if s.Cache == nil || !IsIdempotent("Changesets.Merge") {
+		return s.ChangesetsClient.Merge(ctx, in, opts...)
+	}
+
+	var cachedResult ChangesetEvent
+	cached, err := s.Cache.Get(ctx, "Changesets.Merge", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ChangesetsClient.Merge(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Changesets.Merge", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Changesets.Merge", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedChangesetsClient) UpdateAffected(ctx context.Context, in *ChangesetUpdateAffectedOp, opts ...grpc.CallOption) (*ChangesetEventList, error) {
+	if s.Cache == nil || !IsIdempotent("Changesets.UpdateAffected") {
+		return s.ChangesetsClient.UpdateAffected(ctx, in, opts...)
+	}
+
+	var cachedResult ChangesetEventList
+	cached, err := s.Cache.Get(ctx, "Changesets.UpdateAffected", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ChangesetsClient.UpdateAffected(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Changesets.UpdateAffected", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Changesets.UpdateAffected", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedChangesetsClient) CreateReview(ctx context.Context, in *ChangesetCreateReviewOp, opts ...grpc.CallOption) (*ChangesetReview, error) {
+	if s.Cache == nil || !IsIdempotent("Changesets.CreateReview") {
+		return s.ChangesetsClient.CreateReview(ctx, in, opts...)
+	}
+
+	var cachedResult ChangesetReview
+	cached, err := s.Cache.Get(ctx, "Changesets.CreateReview", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ChangesetsClient.CreateReview(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Changesets.CreateReview", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Changesets.CreateReview", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedChangesetsClient) ListReviews(ctx context.Context, in *ChangesetListReviewsOp, opts ...grpc.CallOption) (*ChangesetReviewList, error) {
+	if s.Cache == nil || !IsIdempotent("Changesets.ListReviews") {
+		return s.ChangesetsClient.ListReviews(ctx, in, opts...)
+	}
+
+	var cachedResult ChangesetReviewList
+	cached, err := s.Cache.Get(ctx, "Changesets.ListReviews", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ChangesetsClient.ListReviews(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Changesets.ListReviews", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Changesets.ListReviews", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedChangesetsClient) ListEvents(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (*ChangesetEventList, error) {
+	if s.Cache == nil || !IsIdempotent("Changesets.ListEvents") {
+		return s.ChangesetsClient.ListEvents(ctx, in, opts...)
+	}
+
+	var cachedResult ChangesetEventList
+	cached, err := s.Cache.Get(ctx, "Changesets.ListEvents", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ChangesetsClient.ListEvents(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Changesets.ListEvents", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Changesets.ListEvents", err)
+	}
+	return result, nil
+}
+
+type StorageCachedDefsClient struct {
+	DefsClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedDefsClient) Get(ctx context.Context, in *DefsGetOp, opts ...grpc.CallOption) (*Def, error) {
+	if s.Cache == nil || !IsIdempotent("Defs.Get") {
+		return s.DefsClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult Def
+	cached, err := s.Cache.Get(ctx, "Defs.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DefsClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Defs.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Defs.Get", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDefsClient) List(ctx context.Context, in *DefListOptions, opts ...grpc.CallOption) (*DefList, error) {
+	if s.Cache == nil || !IsIdempotent("Defs.List") {
+		return s.DefsClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult DefList
+	cached, err := s.Cache.Get(ctx, "Defs.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DefsClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Defs.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Defs.List", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDefsClient) ListRefs(ctx context.Context, in *DefsListRefsOp, opts ...grpc.CallOption) (*RefList, error) {
+	if s.Cache == nil || !IsIdempotent("Defs.ListRefs") {
+		return s.DefsClient.ListRefs(ctx, in, opts...)
+	}
+
+	var cachedResult RefList
+	cached, err := s.Cache.Get(ctx, "Defs.ListRefs", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DefsClient.ListRefs(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Defs.ListRefs", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Defs.ListRefs", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDefsClient) ListExamples(ctx context.Context, in *DefsListExamplesOp, opts ...grpc.CallOption) (*ExampleList, error) {
+	if s.Cache == nil || !IsIdempotent("Defs.ListExamples") {
+		return s.DefsClient.ListExamples(ctx, in, opts...)
+	}
+
+	var cachedResult ExampleList
+	cached, err := s.Cache.Get(ctx, "Defs.ListExamples", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DefsClient.ListExamples(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Defs.ListExamples", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Defs.ListExamples", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDefsClient) ListAuthors(ctx context.Context, in *DefsListAuthorsOp, opts ...grpc.CallOption) (*DefAuthorList, error) {
+	if s.Cache == nil || !IsIdempotent("Defs.ListAuthors") {
+		return s.DefsClient.ListAuthors(ctx, in, opts...)
+	}
+
+	var cachedResult DefAuthorList
+	cached, err := s.Cache.Get(ctx, "Defs.ListAuthors", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DefsClient.ListAuthors(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Defs.ListAuthors", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Defs.ListAuthors", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDefsClient) ListClients(ctx context.Context, in *DefsListClientsOp, opts ...grpc.CallOption) (*DefClientList, error) {
+	if s.Cache == nil || !IsIdempotent("Defs.ListClients") {
+		return s.DefsClient.ListClients(ctx, in, opts...)
+	}
+
+	var cachedResult DefClientList
+	cached, err := s.Cache.Get(ctx, "Defs.ListClients", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DefsClient.ListClients(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Defs.ListClients", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Defs.ListClients", err)
+	}
+	return result, nil
+}
+
+type StorageCachedDeltasClient struct {
+	DeltasClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedDeltasClient) Get(ctx context.Context, in *DeltaSpec, opts ...grpc.CallOption) (*Delta, error) {
+	if s.Cache == nil || !IsIdempotent("Deltas.Get") {
+		return s.DeltasClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult Delta
+	cached, err := s.Cache.Get(ctx, "Deltas.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DeltasClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Deltas.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Deltas.Get", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDeltasClient) ListUnits(ctx context.Context, in *DeltasListUnitsOp, opts ...grpc.CallOption) (*UnitDeltaList, error) {
+	if s.Cache == nil || !IsIdempotent("Deltas.ListUnits") {
+		return s.DeltasClient.ListUnits(ctx, in, opts...)
+	}
+
+	var cachedResult UnitDeltaList
+	cached, err := s.Cache.Get(ctx, "Deltas.ListUnits", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DeltasClient.ListUnits(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Deltas.ListUnits", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Deltas.ListUnits", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDeltasClient) ListDefs(ctx context.Context, in *DeltasListDefsOp, opts ...grpc.CallOption) (*DeltaDefs, error) {
+	if s.Cache == nil || !IsIdempotent("Deltas.ListDefs") {
+		return s.DeltasClient.ListDefs(ctx, in, opts...)
+	}
+
+	var cachedResult DeltaDefs
+	cached, err := s.Cache.Get(ctx, "Deltas.ListDefs", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DeltasClient.ListDefs(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Deltas.ListDefs", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Deltas.ListDefs", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDeltasClient) ListFiles(ctx context.Context, in *DeltasListFilesOp, opts ...grpc.CallOption) (*DeltaFiles, error) {
+	if s.Cache == nil || !IsIdempotent("Deltas.ListFiles") {
+		return s.DeltasClient.ListFiles(ctx, in, opts...)
+	}
+
+	var cachedResult DeltaFiles
+	cached, err := s.Cache.Get(ctx, "Deltas.ListFiles", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DeltasClient.ListFiles(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Deltas.ListFiles", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Deltas.ListFiles", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDeltasClient) ListAffectedAuthors(ctx context.Context, in *DeltasListAffectedAuthorsOp, opts ...grpc.CallOption) (*DeltaAffectedPersonList, error) {
+	if s.Cache == nil || !IsIdempotent("Deltas.ListAffectedAuthors") {
+		return s.DeltasClient.ListAffectedAuthors(ctx, in, opts...)
+	}
+
+	var cachedResult DeltaAffectedPersonList
+	cached, err := s.Cache.Get(ctx, "Deltas.ListAffectedAuthors", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DeltasClient.ListAffectedAuthors(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Deltas.ListAffectedAuthors", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Deltas.ListAffectedAuthors", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDeltasClient) ListAffectedClients(ctx context.Context, in *DeltasListAffectedClientsOp, opts ...grpc.CallOption) (*DeltaAffectedPersonList, error) {
+	if s.Cache == nil || !IsIdempotent("Deltas.ListAffectedClients") {
+		return s.DeltasClient.ListAffectedClients(ctx, in, opts...)
+	}
+
+	var cachedResult DeltaAffectedPersonList
+	cached, err := s.Cache.Get(ctx, "Deltas.ListAffectedClients", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DeltasClient.ListAffectedClients(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Deltas.ListAffectedClients", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Deltas.ListAffectedClients", err)
+	}
+	return result, nil
+}
+
+type StorageCachedDiscussionsClient struct {
+	DiscussionsClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedDiscussionsClient) Create(ctx context.Context, in *Discussion, opts ...grpc.CallOption) (*Discussion, error) {
+	if s.Cache == nil || !IsIdempotent("Discussions.Create") {
+		return s.DiscussionsClient.Create(ctx, in, opts...)
+	}
+
+	var cachedResult Discussion
+	cached, err := s.Cache.Get(ctx, "Discussions.Create", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DiscussionsClient.Create(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Discussions.Create", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Discussions.Create", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDiscussionsClient) Get(ctx context.Context, in *DiscussionSpec, opts ...grpc.CallOption) (*Discussion, error) {
+	if s.Cache == nil || !IsIdempotent("Discussions.Get") {
+		return s.DiscussionsClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult Discussion
+	cached, err := s.Cache.Get(ctx, "Discussions.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DiscussionsClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Discussions.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Discussions.Get", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDiscussionsClient) List(ctx context.Context, in *DiscussionListOp, opts ...grpc.CallOption) (*DiscussionList, error) {
+	if s.Cache == nil || !IsIdempotent("Discussions.List") {
+		return s.DiscussionsClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult DiscussionList
+	cached, err := s.Cache.Get(ctx, "Discussions.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DiscussionsClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Discussions.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Discussions.List", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDiscussionsClient) CreateComment(ctx context.Context, in *DiscussionCommentCreateOp, opts ...grpc.CallOption) (*DiscussionComment, error) {
+	if s.Cache == nil || !IsIdempotent("Discussions.CreateComment") {
+		return s.DiscussionsClient.CreateComment(ctx, in, opts...)
+	}
+
+	var cachedResult DiscussionComment
+	cached, err := s.Cache.Get(ctx, "Discussions.CreateComment", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DiscussionsClient.CreateComment(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Discussions.CreateComment", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Discussions.CreateComment", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedDiscussionsClient) UpdateRating(ctx context.Context, in *DiscussionRatingUpdateOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("Discussions.UpdateRating") {
+		return s.DiscussionsClient.UpdateRating(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "Discussions.UpdateRating", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.DiscussionsClient.UpdateRating(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Discussions.UpdateRating", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Discussions.UpdateRating", err)
+	}
+	return result, nil
+}
+
+type StorageCachedGraphUplinkClient struct {
+	GraphUplinkClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedGraphUplinkClient) Push(ctx context.Context, in *MetricsSnapshot, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("GraphUplink.Push") {
+		return s.GraphUplinkClient.Push(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "GraphUplink.Push", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.GraphUplinkClient.Push(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "GraphUplink.Push", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "GraphUplink.Push", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedGraphUplinkClient) PushEvents(ctx context.Context, in *UserEventList, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("GraphUplink.PushEvents") {
+		return s.GraphUplinkClient.PushEvents(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "GraphUplink.PushEvents", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.GraphUplinkClient.PushEvents(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "GraphUplink.PushEvents", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "GraphUplink.PushEvents", err)
+	}
+	return result, nil
+}
+
+type StorageCachedMarkdownClient struct {
+	MarkdownClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedMarkdownClient) Render(ctx context.Context, in *MarkdownRenderOp, opts ...grpc.CallOption) (*MarkdownData, error) {
+	if s.Cache == nil || !IsIdempotent("Markdown.Render") {
+		return s.MarkdownClient.Render(ctx, in, opts...)
+	}
+
+	var cachedResult MarkdownData
+	cached, err := s.Cache.Get(ctx, "Markdown.Render", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.MarkdownClient.Render(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Markdown.Render", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Markdown.Render", err)
+	}
+	return result, nil
+}
+
+type StorageCachedMetaClient struct {
+	MetaClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedMetaClient) Status(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerStatus, error) {
+	if s.Cache == nil || !IsIdempotent("Meta.Status") {
+		return s.MetaClient.Status(ctx, in, opts...)
+	}
+
+	var cachedResult ServerStatus
+	cached, err := s.Cache.Get(ctx, "Meta.Status", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.MetaClient.Status(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Meta.Status", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Meta.Status", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedMetaClient) Config(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerConfig, error) {
+	if s.Cache == nil || !IsIdempotent("Meta.Config") {
+		return s.MetaClient.Config(ctx, in, opts...)
+	}
+
+	var cachedResult ServerConfig
+	cached, err := s.Cache.Get(ctx, "Meta.Config", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.MetaClient.Config(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Meta.Config", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Meta.Config", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedMetaClient) PubKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerPubKey, error) {
+	if s.Cache == nil || !IsIdempotent("Meta.PubKey") {
+		return s.MetaClient.PubKey(ctx, in, opts...)
+	}
+
+	var cachedResult ServerPubKey
+	cached, err := s.Cache.Get(ctx, "Meta.PubKey", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.MetaClient.PubKey(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Meta.PubKey", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Meta.PubKey", err)
+	}
+	return result, nil
+}
+
+type StorageCachedMirrorReposClient struct {
+	MirrorReposClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedMirrorReposClient) RefreshVCS(ctx context.Context, in *MirrorReposRefreshVCSOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("MirrorRepos.RefreshVCS") {
+		return s.MirrorReposClient.RefreshVCS(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "MirrorRepos.RefreshVCS", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.MirrorReposClient.RefreshVCS(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "MirrorRepos.RefreshVCS", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "MirrorRepos.RefreshVCS", err)
+	}
+	return result, nil
+}
+
+type StorageCachedMirroredRepoSSHKeysClient struct {
+	MirroredRepoSSHKeysClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedMirroredRepoSSHKeysClient) Create(ctx context.Context, in *MirroredRepoSSHKeysCreateOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("MirroredRepoSSHKeys.Create") {
+		return s.MirroredRepoSSHKeysClient.Create(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "MirroredRepoSSHKeys.Create", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.MirroredRepoSSHKeysClient.Create(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "MirroredRepoSSHKeys.Create", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "MirroredRepoSSHKeys.Create", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedMirroredRepoSSHKeysClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*SSHPrivateKey, error) {
+	if s.Cache == nil || !IsIdempotent("MirroredRepoSSHKeys.Get") {
+		return s.MirroredRepoSSHKeysClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult SSHPrivateKey
+	cached, err := s.Cache.Get(ctx, "MirroredRepoSSHKeys.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.MirroredRepoSSHKeysClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "MirroredRepoSSHKeys.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "MirroredRepoSSHKeys.Get", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedMirroredRepoSSHKeysClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("MirroredRepoSSHKeys.Delete") {
+		return s.MirroredRepoSSHKeysClient.Delete(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "MirroredRepoSSHKeys.Delete", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.MirroredRepoSSHKeysClient.Delete(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "MirroredRepoSSHKeys.Delete", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "MirroredRepoSSHKeys.Delete", err)
+	}
+	return result, nil
+}
+
+type StorageCachedNotifyClient struct {
+	NotifyClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedNotifyClient) GenericEvent(ctx context.Context, in *NotifyGenericEvent, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("Notify.GenericEvent") {
+		return s.NotifyClient.GenericEvent(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "Notify.GenericEvent", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.NotifyClient.GenericEvent(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Notify.GenericEvent", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Notify.GenericEvent", err)
+	}
+	return result, nil
+}
+
+type StorageCachedOrgsClient struct {
+	OrgsClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedOrgsClient) Get(ctx context.Context, in *OrgSpec, opts ...grpc.CallOption) (*Org, error) {
+	if s.Cache == nil || !IsIdempotent("Orgs.Get") {
+		return s.OrgsClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult Org
+	cached, err := s.Cache.Get(ctx, "Orgs.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.OrgsClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Orgs.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Orgs.Get", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedOrgsClient) List(ctx context.Context, in *OrgsListOp, opts ...grpc.CallOption) (*OrgList, error) {
+	if s.Cache == nil || !IsIdempotent("Orgs.List") {
+		return s.OrgsClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult OrgList
+	cached, err := s.Cache.Get(ctx, "Orgs.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.OrgsClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Orgs.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Orgs.List", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedOrgsClient) ListMembers(ctx context.Context, in *OrgsListMembersOp, opts ...grpc.CallOption) (*UserList, error) {
+	if s.Cache == nil || !IsIdempotent("Orgs.ListMembers") {
+		return s.OrgsClient.ListMembers(ctx, in, opts...)
+	}
+
+	var cachedResult UserList
+	cached, err := s.Cache.Get(ctx, "Orgs.ListMembers", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.OrgsClient.ListMembers(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Orgs.ListMembers", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Orgs.ListMembers", err)
+	}
+	return result, nil
+}
+
+type StorageCachedPeopleClient struct {
+	PeopleClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedPeopleClient) Get(ctx context.Context, in *PersonSpec, opts ...grpc.CallOption) (*Person, error) {
+	if s.Cache == nil || !IsIdempotent("People.Get") {
+		return s.PeopleClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult Person
+	cached, err := s.Cache.Get(ctx, "People.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.PeopleClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "People.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "People.Get", err)
+	}
+	return result, nil
+}
+
+type StorageCachedRegisteredClientsClient struct {
+	RegisteredClientsClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedRegisteredClientsClient) Get(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	if s.Cache == nil || !IsIdempotent("RegisteredClients.Get") {
+		return s.RegisteredClientsClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult RegisteredClient
+	cached, err := s.Cache.Get(ctx, "RegisteredClients.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RegisteredClientsClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RegisteredClients.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RegisteredClients.Get", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRegisteredClientsClient) GetCurrent(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	if s.Cache == nil || !IsIdempotent("RegisteredClients.GetCurrent") {
+		return s.RegisteredClientsClient.GetCurrent(ctx, in, opts...)
+	}
+
+	var cachedResult RegisteredClient
+	cached, err := s.Cache.Get(ctx, "RegisteredClients.GetCurrent", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RegisteredClientsClient.GetCurrent(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RegisteredClients.GetCurrent", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RegisteredClients.GetCurrent", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRegisteredClientsClient) Create(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	if s.Cache == nil || !IsIdempotent("RegisteredClients.Create") {
+		return s.RegisteredClientsClient.Create(ctx, in, opts...)
+	}
+
+	var cachedResult RegisteredClient
+	cached, err := s.Cache.Get(ctx, "RegisteredClients.Create", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RegisteredClientsClient.Create(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RegisteredClients.Create", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RegisteredClients.Create", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRegisteredClientsClient) Update(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("RegisteredClients.Update") {
+		return s.RegisteredClientsClient.Update(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "RegisteredClients.Update", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RegisteredClientsClient.Update(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RegisteredClients.Update", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RegisteredClients.Update", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRegisteredClientsClient) Delete(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("RegisteredClients.Delete") {
+		return s.RegisteredClientsClient.Delete(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "RegisteredClients.Delete", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RegisteredClientsClient.Delete(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RegisteredClients.Delete", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RegisteredClients.Delete", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRegisteredClientsClient) List(ctx context.Context, in *RegisteredClientListOptions, opts ...grpc.CallOption) (*RegisteredClientList, error) {
+	if s.Cache == nil || !IsIdempotent("RegisteredClients.List") {
+		return s.RegisteredClientsClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult RegisteredClientList
+	cached, err := s.Cache.Get(ctx, "RegisteredClients.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RegisteredClientsClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RegisteredClients.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RegisteredClients.List", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRegisteredClientsClient) GetUserPermissions(ctx context.Context, in *UserPermissionsOptions, opts ...grpc.CallOption) (*UserPermissions, error) {
+	if s.Cache == nil || !IsIdempotent("RegisteredClients.GetUserPermissions") {
+		return s.RegisteredClientsClient.GetUserPermissions(ctx, in, opts...)
+	}
+
+	var cachedResult UserPermissions
+	cached, err := s.Cache.Get(ctx, "RegisteredClients.GetUserPermissions", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RegisteredClientsClient.GetUserPermissions(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RegisteredClients.GetUserPermissions", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RegisteredClients.GetUserPermissions", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRegisteredClientsClient) SetUserPermissions(ctx context.Context, in *UserPermissions, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("RegisteredClients.SetUserPermissions") {
+		return s.RegisteredClientsClient.SetUserPermissions(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "RegisteredClients.SetUserPermissions", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RegisteredClientsClient.SetUserPermissions(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RegisteredClients.SetUserPermissions", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RegisteredClients.SetUserPermissions", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRegisteredClientsClient) ListUserPermissions(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*UserPermissionsList, error) {
+	if s.Cache == nil || !IsIdempotent("RegisteredClients.ListUserPermissions") {
+		return s.RegisteredClientsClient.ListUserPermissions(ctx, in, opts...)
+	}
+
+	var cachedResult UserPermissionsList
+	cached, err := s.Cache.Get(ctx, "RegisteredClients.ListUserPermissions", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RegisteredClientsClient.ListUserPermissions(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RegisteredClients.ListUserPermissions", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RegisteredClients.ListUserPermissions", err)
+	}
+	return result, nil
+}
+
+type StorageCachedRepoBadgesClient struct {
+	RepoBadgesClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedRepoBadgesClient) ListBadges(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*BadgeList, error) {
+	if s.Cache == nil || !IsIdempotent("RepoBadges.ListBadges") {
+		return s.RepoBadgesClient.ListBadges(ctx, in, opts...)
+	}
+
+	var cachedResult BadgeList
+	cached, err := s.Cache.Get(ctx, "RepoBadges.ListBadges", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RepoBadgesClient.ListBadges(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RepoBadges.ListBadges", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RepoBadges.ListBadges", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRepoBadgesClient) ListCounters(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*CounterList, error) {
+	if s.Cache == nil || !IsIdempotent("RepoBadges.ListCounters") {
+		return s.RepoBadgesClient.ListCounters(ctx, in, opts...)
+	}
+
+	var cachedResult CounterList
+	cached, err := s.Cache.Get(ctx, "RepoBadges.ListCounters", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RepoBadgesClient.ListCounters(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RepoBadges.ListCounters", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RepoBadges.ListCounters", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRepoBadgesClient) RecordHit(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("RepoBadges.RecordHit") {
+		return s.RepoBadgesClient.RecordHit(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "RepoBadges.RecordHit", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RepoBadgesClient.RecordHit(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RepoBadges.RecordHit", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RepoBadges.RecordHit", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRepoBadgesClient) CountHits(ctx context.Context, in *RepoBadgesCountHitsOp, opts ...grpc.CallOption) (*RepoBadgesCountHitsResult, error) {
+	if s.Cache == nil || !IsIdempotent("RepoBadges.CountHits") {
+		return s.RepoBadgesClient.CountHits(ctx, in, opts...)
+	}
+
+	var cachedResult RepoBadgesCountHitsResult
+	cached, err := s.Cache.Get(ctx, "RepoBadges.CountHits", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RepoBadgesClient.CountHits(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RepoBadges.CountHits", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RepoBadges.CountHits", err)
+	}
+	return result, nil
+}
+
+type StorageCachedRepoStatusesClient struct {
+	RepoStatusesClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedRepoStatusesClient) GetCombined(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*CombinedStatus, error) {
+	if s.Cache == nil || !IsIdempotent("RepoStatuses.GetCombined") {
+		return s.RepoStatusesClient.GetCombined(ctx, in, opts...)
+	}
+
+	var cachedResult CombinedStatus
+	cached, err := s.Cache.Get(ctx, "RepoStatuses.GetCombined", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RepoStatusesClient.GetCombined(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RepoStatuses.GetCombined", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RepoStatuses.GetCombined", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRepoStatusesClient) Create(ctx context.Context, in *RepoStatusesCreateOp, opts ...grpc.CallOption) (*RepoStatus, error) {
+	if s.Cache == nil || !IsIdempotent("RepoStatuses.Create") {
+		return s.RepoStatusesClient.Create(ctx, in, opts...)
+	}
+
+	var cachedResult RepoStatus
+	cached, err := s.Cache.Get(ctx, "RepoStatuses.Create", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RepoStatusesClient.Create(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RepoStatuses.Create", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RepoStatuses.Create", err)
+	}
+	return result, nil
+}
+
+type StorageCachedRepoTreeClient struct {
+	RepoTreeClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedRepoTreeClient) Get(ctx context.Context, in *RepoTreeGetOp, opts ...grpc.CallOption) (*TreeEntry, error) {
+	if s.Cache == nil || !IsIdempotent("RepoTree.Get") {
+		return s.RepoTreeClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult TreeEntry
+	cached, err := s.Cache.Get(ctx, "RepoTree.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RepoTreeClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RepoTree.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RepoTree.Get", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRepoTreeClient) Search(ctx context.Context, in *RepoTreeSearchOp, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	if s.Cache == nil || !IsIdempotent("RepoTree.Search") {
+		return s.RepoTreeClient.Search(ctx, in, opts...)
+	}
+
+	var cachedResult VCSSearchResultList
+	cached, err := s.Cache.Get(ctx, "RepoTree.Search", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RepoTreeClient.Search(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RepoTree.Search", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RepoTree.Search", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedRepoTreeClient) List(ctx context.Context, in *RepoTreeListOp, opts ...grpc.CallOption) (*RepoTreeListResult, error) {
+	if s.Cache == nil || !IsIdempotent("RepoTree.List") {
+		return s.RepoTreeClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult RepoTreeListResult
+	cached, err := s.Cache.Get(ctx, "RepoTree.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.RepoTreeClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "RepoTree.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "RepoTree.List", err)
+	}
+	return result, nil
+}
+
+type StorageCachedReposClient struct {
+	ReposClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedReposClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*Repo, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.Get") {
+		return s.ReposClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult Repo
+	cached, err := s.Cache.Get(ctx, "Repos.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.Get", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) List(ctx context.Context, in *RepoListOptions, opts ...grpc.CallOption) (*RepoList, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.List") {
+		return s.ReposClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult RepoList
+	cached, err := s.Cache.Get(ctx, "Repos.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.List", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) Create(ctx context.Context, in *ReposCreateOp, opts ...grpc.CallOption) (*Repo, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.Create") {
+		return s.ReposClient.Create(ctx, in, opts...)
+	}
+
+	var cachedResult Repo
+	cached, err := s.Cache.Get(ctx, "Repos.Create", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.Create(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.Create", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.Create", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) Update(ctx context.Context, in *ReposUpdateOp, opts ...grpc.CallOption) (*Repo, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.Update") {
+		return s.ReposClient.Update(ctx, in, opts...)
+	}
+
+	var cachedResult Repo
+	cached, err := s.Cache.Get(ctx, "Repos.Update", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.Update(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.Update", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.Update", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.Delete") {
+		return s.ReposClient.Delete(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "Repos.Delete", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.Delete(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.Delete", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.Delete", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) GetReadme(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*Readme, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.GetReadme") {
+		return s.ReposClient.GetReadme(ctx, in, opts...)
+	}
+
+	var cachedResult Readme
+	cached, err := s.Cache.Get(ctx, "Repos.GetReadme", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.GetReadme(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.GetReadme", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.GetReadme", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) Enable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.Enable") {
+		return s.ReposClient.Enable(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "Repos.Enable", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.Enable(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.Enable", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.Enable", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) Disable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.Disable") {
+		return s.ReposClient.Disable(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "Repos.Disable", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.Disable(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.Disable", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.Disable", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) GetConfig(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*RepoConfig, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.GetConfig") {
+		return s.ReposClient.GetConfig(ctx, in, opts...)
+	}
+
+	var cachedResult RepoConfig
+	cached, err := s.Cache.Get(ctx, "Repos.GetConfig", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.GetConfig(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.GetConfig", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.GetConfig", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) GetCommit(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*vcs.Commit, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.GetCommit") {
+		return s.ReposClient.GetCommit(ctx, in, opts...)
+	}
+
+	var cachedResult vcs.Commit
+	cached, err := s.Cache.Get(ctx, "Repos.GetCommit", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.GetCommit(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.GetCommit", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.GetCommit", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) ListCommits(ctx context.Context, in *ReposListCommitsOp, opts ...grpc.CallOption) (*CommitList, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.ListCommits") {
+		return s.ReposClient.ListCommits(ctx, in, opts...)
+	}
+
+	var cachedResult CommitList
+	cached, err := s.Cache.Get(ctx, "Repos.ListCommits", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.ListCommits(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.ListCommits", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.ListCommits", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) ListBranches(ctx context.Context, in *ReposListBranchesOp, opts ...grpc.CallOption) (*BranchList, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.ListBranches") {
+		return s.ReposClient.ListBranches(ctx, in, opts...)
+	}
+
+	var cachedResult BranchList
+	cached, err := s.Cache.Get(ctx, "Repos.ListBranches", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.ListBranches(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.ListBranches", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.ListBranches", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) ListTags(ctx context.Context, in *ReposListTagsOp, opts ...grpc.CallOption) (*TagList, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.ListTags") {
+		return s.ReposClient.ListTags(ctx, in, opts...)
+	}
+
+	var cachedResult TagList
+	cached, err := s.Cache.Get(ctx, "Repos.ListTags", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.ListTags(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.ListTags", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.ListTags", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedReposClient) ListCommitters(ctx context.Context, in *ReposListCommittersOp, opts ...grpc.CallOption) (*CommitterList, error) {
+	if s.Cache == nil || !IsIdempotent("Repos.ListCommitters") {
+		return s.ReposClient.ListCommitters(ctx, in, opts...)
+	}
+
+	var cachedResult CommitterList
+	cached, err := s.Cache.Get(ctx, "Repos.ListCommitters", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.ReposClient.ListCommitters(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Repos.ListCommitters", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Repos.ListCommitters", err)
+	}
+	return result, nil
+}
+
+type StorageCachedSavedSearchesClient struct {
+	SavedSearchesClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedSavedSearchesClient) Create(ctx context.Context, in *SavedSearchesCreateOp, opts ...grpc.CallOption) (*SavedSearch, error) {
+	if s.Cache == nil || !IsIdempotent("SavedSearches.Create") {
+		return s.SavedSearchesClient.Create(ctx, in, opts...)
+	}
+
+	var cachedResult SavedSearch
+	cached, err := s.Cache.Get(ctx, "SavedSearches.Create", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SavedSearchesClient.Create(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "SavedSearches.Create", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "SavedSearches.Create", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedSavedSearchesClient) List(ctx context.Context, in *SavedSearchListOptions, opts ...grpc.CallOption) (*SavedSearchList, error) {
+	if s.Cache == nil || !IsIdempotent("SavedSearches.List") {
+		return s.SavedSearchesClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult SavedSearchList
+	cached, err := s.Cache.Get(ctx, "SavedSearches.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SavedSearchesClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "SavedSearches.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "SavedSearches.List", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedSavedSearchesClient) Delete(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("SavedSearches.Delete") {
+		return s.SavedSearchesClient.Delete(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "SavedSearches.Delete", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SavedSearchesClient.Delete(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "SavedSearches.Delete", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "SavedSearches.Delete", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedSavedSearchesClient) Run(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*SavedSearchRunResult, error) {
+	if s.Cache == nil || !IsIdempotent("SavedSearches.Run") {
+		return s.SavedSearchesClient.Run(ctx, in, opts...)
+	}
+
+	var cachedResult SavedSearchRunResult
+	cached, err := s.Cache.Get(ctx, "SavedSearches.Run", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SavedSearchesClient.Run(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "SavedSearches.Run", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "SavedSearches.Run", err)
+	}
+	return result, nil
+}
+
+type StorageCachedSearchClient struct {
+	SearchClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedSearchClient) Search(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (*SearchResults, error) {
+	if s.Cache == nil || !IsIdempotent("Search.Search") {
+		return s.SearchClient.Search(ctx, in, opts...)
+	}
+
+	var cachedResult SearchResults
+	cached, err := s.Cache.Get(ctx, "Search.Search", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SearchClient.Search(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Search.Search", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Search.Search", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedSearchClient) SearchTokens(ctx context.Context, in *TokenSearchOptions, opts ...grpc.CallOption) (*DefList, error) {
+	if s.Cache == nil || !IsIdempotent("Search.SearchTokens") {
+		return s.SearchClient.SearchTokens(ctx, in, opts...)
+	}
+
+	var cachedResult DefList
+	cached, err := s.Cache.Get(ctx, "Search.SearchTokens", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SearchClient.SearchTokens(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Search.SearchTokens", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Search.SearchTokens", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedSearchClient) SearchText(ctx context.Context, in *TextSearchOptions, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	if s.Cache == nil || !IsIdempotent("Search.SearchText") {
+		return s.SearchClient.SearchText(ctx, in, opts...)
+	}
+
+	var cachedResult VCSSearchResultList
+	cached, err := s.Cache.Get(ctx, "Search.SearchText", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SearchClient.SearchText(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Search.SearchText", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Search.SearchText", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedSearchClient) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions, opts ...grpc.CallOption) (*MultiTextSearchResults, error) {
+	if s.Cache == nil || !IsIdempotent("Search.SearchTextMulti") {
+		return s.SearchClient.SearchTextMulti(ctx, in, opts...)
+	}
+
+	var cachedResult MultiTextSearchResults
+	cached, err := s.Cache.Get(ctx, "Search.SearchTextMulti", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SearchClient.SearchTextMulti(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Search.SearchTextMulti", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Search.SearchTextMulti", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedSearchClient) Complete(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*Completions, error) {
+	if s.Cache == nil || !IsIdempotent("Search.Complete") {
+		return s.SearchClient.Complete(ctx, in, opts...)
+	}
+
+	var cachedResult Completions
+	cached, err := s.Cache.Get(ctx, "Search.Complete", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SearchClient.Complete(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Search.Complete", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Search.Complete", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedSearchClient) Suggest(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*SuggestionList, error) {
+	if s.Cache == nil || !IsIdempotent("Search.Suggest") {
+		return s.SearchClient.Suggest(ctx, in, opts...)
+	}
+
+	var cachedResult SuggestionList
+	cached, err := s.Cache.Get(ctx, "Search.Suggest", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.SearchClient.Suggest(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Search.Suggest", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Search.Suggest", err)
+	}
+	return result, nil
+}
+
+type StorageCachedStorageClient struct {
+	StorageClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedStorageClient) Create(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	if s.Cache == nil || !IsIdempotent("Storage.Create") {
+		return s.StorageClient.Create(ctx, in, opts...)
+	}
+
+	var cachedResult StorageError
+	cached, err := s.Cache.Get(ctx, "Storage.Create", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.StorageClient.Create(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Storage.Create", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Storage.Create", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedStorageClient) RemoveAll(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	if s.Cache == nil || !IsIdempotent("Storage.RemoveAll") {
+		return s.StorageClient.RemoveAll(ctx, in, opts...)
+	}
+
+	var cachedResult StorageError
+	cached, err := s.Cache.Get(ctx, "Storage.RemoveAll", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.StorageClient.RemoveAll(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Storage.RemoveAll", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Storage.RemoveAll", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedStorageClient) Read(ctx context.Context, in *StorageReadOp, opts ...grpc.CallOption) (*StorageRead, error) {
+	if s.Cache == nil || !IsIdempotent("Storage.Read") {
+		return s.StorageClient.Read(ctx, in, opts...)
+	}
+
+	var cachedResult StorageRead
+	cached, err := s.Cache.Get(ctx, "Storage.Read", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.StorageClient.Read(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Storage.Read", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Storage.Read", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedStorageClient) Write(ctx context.Context, in *StorageWriteOp, opts ...grpc.CallOption) (*StorageWrite, error) {
+	if s.Cache == nil || !IsIdempotent("Storage.Write") {
+		return s.StorageClient.Write(ctx, in, opts...)
+	}
+
+	var cachedResult StorageWrite
+	cached, err := s.Cache.Get(ctx, "Storage.Write", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.StorageClient.Write(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Storage.Write", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Storage.Write", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedStorageClient) Stat(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageStat, error) {
+	if s.Cache == nil || !IsIdempotent("Storage.Stat") {
+		return s.StorageClient.Stat(ctx, in, opts...)
+	}
+
+	var cachedResult StorageStat
+	cached, err := s.Cache.Get(ctx, "Storage.Stat", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.StorageClient.Stat(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Storage.Stat", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Storage.Stat", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedStorageClient) ReadDir(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageReadDir, error) {
+	if s.Cache == nil || !IsIdempotent("Storage.ReadDir") {
+		return s.StorageClient.ReadDir(ctx, in, opts...)
+	}
+
+	var cachedResult StorageReadDir
+	cached, err := s.Cache.Get(ctx, "Storage.ReadDir", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.StorageClient.ReadDir(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Storage.ReadDir", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Storage.ReadDir", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedStorageClient) Close(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	if s.Cache == nil || !IsIdempotent("Storage.Close") {
+		return s.StorageClient.Close(ctx, in, opts...)
+	}
+
+	var cachedResult StorageError
+	cached, err := s.Cache.Get(ctx, "Storage.Close", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.StorageClient.Close(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Storage.Close", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Storage.Close", err)
+	}
+	return result, nil
+}
+
+type StorageCachedUnitsClient struct {
+	UnitsClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedUnitsClient) Get(ctx context.Context, in *UnitSpec, opts ...grpc.CallOption) (*unit.RepoSourceUnit, error) {
+	if s.Cache == nil || !IsIdempotent("Units.Get") {
+		return s.UnitsClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult unit.RepoSourceUnit
+	cached, err := s.Cache.Get(ctx, "Units.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.UnitsClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Units.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Units.Get", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedUnitsClient) List(ctx context.Context, in *UnitListOptions, opts ...grpc.CallOption) (*RepoSourceUnitList, error) {
+	if s.Cache == nil || !IsIdempotent("Units.List") {
+		return s.UnitsClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult RepoSourceUnitList
+	cached, err := s.Cache.Get(ctx, "Units.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.UnitsClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Units.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Units.List", err)
+	}
+	return result, nil
+}
+
+type StorageCachedUserKeysClient struct {
+	UserKeysClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedUserKeysClient) AddKey(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("UserKeys.AddKey") {
+		return s.UserKeysClient.AddKey(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "UserKeys.AddKey", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.UserKeysClient.AddKey(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "UserKeys.AddKey", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "UserKeys.AddKey", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedUserKeysClient) LookupUser(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (*UserSpec, error) {
+	if s.Cache == nil || !IsIdempotent("UserKeys.LookupUser") {
+		return s.UserKeysClient.LookupUser(ctx, in, opts...)
+	}
+
+	var cachedResult UserSpec
+	cached, err := s.Cache.Get(ctx, "UserKeys.LookupUser", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.UserKeysClient.LookupUser(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "UserKeys.LookupUser", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "UserKeys.LookupUser", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedUserKeysClient) DeleteKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	if s.Cache == nil || !IsIdempotent("UserKeys.DeleteKey") {
+		return s.UserKeysClient.DeleteKey(ctx, in, opts...)
+	}
+
+	var cachedResult pbtypes.Void
+	cached, err := s.Cache.Get(ctx, "UserKeys.DeleteKey", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.UserKeysClient.DeleteKey(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "UserKeys.DeleteKey", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "UserKeys.DeleteKey", err)
+	}
+	return result, nil
+}
+
+type StorageCachedUsersClient struct {
+	UsersClient
+	Cache *StorageCache
+}
+
+func (s *StorageCachedUsersClient) Get(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*User, error) {
+	if s.Cache == nil || !IsIdempotent("Users.Get") {
+		return s.UsersClient.Get(ctx, in, opts...)
+	}
+
+	var cachedResult User
+	cached, err := s.Cache.Get(ctx, "Users.Get", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.UsersClient.Get(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Users.Get", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Users.Get", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedUsersClient) GetWithEmail(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (*User, error) {
+	if s.Cache == nil || !IsIdempotent("Users.GetWithEmail") {
+		return s.UsersClient.GetWithEmail(ctx, in, opts...)
+	}
+
+	var cachedResult User
+	cached, err := s.Cache.Get(ctx, "Users.GetWithEmail", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.UsersClient.GetWithEmail(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Users.GetWithEmail", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Users.GetWithEmail", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedUsersClient) ListEmails(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*EmailAddrList, error) {
+	if s.Cache == nil || !IsIdempotent("Users.ListEmails") {
+		return s.UsersClient.ListEmails(ctx, in, opts...)
+	}
+
+	var cachedResult EmailAddrList
+	cached, err := s.Cache.Get(ctx, "Users.ListEmails", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.UsersClient.ListEmails(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Users.ListEmails", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Users.ListEmails", err)
+	}
+	return result, nil
+}
+
+func (s *StorageCachedUsersClient) List(ctx context.Context, in *UsersListOptions, opts ...grpc.CallOption) (*UserList, error) {
+	if s.Cache == nil || !IsIdempotent("Users.List") {
+		return s.UsersClient.List(ctx, in, opts...)
+	}
+
+	var cachedResult UserList
+	cached, err := s.Cache.Get(ctx, "Users.List", in, &cachedResult)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return &cachedResult, nil
+	}
+
+	var trailer metadata.MD
+
+	result, err := s.UsersClient.List(ctx, in, append(opts, grpc.Trailer(&trailer))...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Cache.Store(ctx, "Users.List", in, result, trailer); err != nil {
+		log.Printf("Warning: caching response to %s: %s", "Users.List", err)
+	}
+	return result, nil
+}
+
+// storageCacheClient wraps each of c's services in a
+// StorageCached*Client that caches responses in cache. Only the
+// responses of idempotent methods (see IsIdempotent) are cached.
+func storageCacheClient(c *Client, cache *StorageCache) {
+	c.AccessTokens = &StorageCachedAccessTokensClient{c.AccessTokens, cache}
+	c.Accounts = &StorageCachedAccountsClient{c.Accounts, cache}
+	c.AuditLog = &StorageCachedAuditLogClient{c.AuditLog, cache}
+	c.Auth = &StorageCachedAuthClient{c.Auth, cache}
+	c.Builds = &StorageCachedBuildsClient{c.Builds, cache}
+	c.Changesets = &StorageCachedChangesetsClient{c.Changesets, cache}
+	c.Defs = &StorageCachedDefsClient{c.Defs, cache}
+	c.Deltas = &StorageCachedDeltasClient{c.Deltas, cache}
+	c.Discussions = &StorageCachedDiscussionsClient{c.Discussions, cache}
+	c.GraphUplink = &StorageCachedGraphUplinkClient{c.GraphUplink, cache}
+	c.Markdown = &StorageCachedMarkdownClient{c.Markdown, cache}
+	c.Meta = &StorageCachedMetaClient{c.Meta, cache}
+	c.MirrorRepos = &StorageCachedMirrorReposClient{c.MirrorRepos, cache}
+	c.MirroredRepoSSHKeys = &StorageCachedMirroredRepoSSHKeysClient{c.MirroredRepoSSHKeys, cache}
+	c.Notify = &StorageCachedNotifyClient{c.Notify, cache}
+	c.Orgs = &StorageCachedOrgsClient{c.Orgs, cache}
+	c.People = &StorageCachedPeopleClient{c.People, cache}
+	c.RegisteredClients = &StorageCachedRegisteredClientsClient{c.RegisteredClients, cache}
+	c.RepoBadges = &StorageCachedRepoBadgesClient{c.RepoBadges, cache}
+	c.RepoStatuses = &StorageCachedRepoStatusesClient{c.RepoStatuses, cache}
+	c.RepoTree = &StorageCachedRepoTreeClient{c.RepoTree, cache}
+	c.Repos = &StorageCachedReposClient{c.Repos, cache}
+	c.SavedSearches = &StorageCachedSavedSearchesClient{c.SavedSearches, cache}
+	c.Search = &StorageCachedSearchClient{c.Search, cache}
+	c.Storage = &StorageCachedStorageClient{c.Storage, cache}
+	c.Units = &StorageCachedUnitsClient{c.Units, cache}
+	c.UserKeys = &StorageCachedUserKeysClient{c.UserKeys, cache}
+	c.Users = &StorageCachedUsersClient{c.Users, cache}
+}
@@ -0,0 +1,162 @@
+package sourcegraph
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+// tlsOptions are the TLS options for connections to "https" gRPC
+// endpoints, which are set in a context using WithTLSConfig,
+// WithRootCAs, etc.
+type tlsOptions struct {
+	config     *tls.Config // base config (may be nil)
+	rootCAs    *x509.CertPool
+	clientCert *tls.Certificate
+	serverName string
+
+	insecureSkipVerifyLocalhost bool
+}
+
+func tlsOptionsFromContext(ctx context.Context) tlsOptions {
+	opts, _ := ctx.Value(tlsOptionsKey).(tlsOptions)
+	return opts
+}
+
+// withTLSOptions returns a copy of parent whose TLS options are the
+// parent's TLS options modified by f.
+func withTLSOptions(parent context.Context, f func(*tlsOptions)) context.Context {
+	opts := tlsOptionsFromContext(parent)
+	f(&opts)
+	return context.WithValue(parent, tlsOptionsKey, opts)
+}
+
+// WithTLSConfig returns a copy of the parent context whose API clients
+// (constructed using NewClientFromContext) use config as the base TLS
+// configuration when communicating with an "https" gRPC endpoint.
+// The options set by WithRootCAs, WithClientCertificate and
+// WithServerNameOverride take precedence over the corresponding
+// fields of config. Config must not be modified after it is passed to
+// WithTLSConfig.
+//
+// If config has a VerifyPeerCertificate, VerifyConnection or
+// GetClientCertificate callback, each client dials its own connection
+// (instead of using the connection pool), which is closed by
+// Client.Close.
+func WithTLSConfig(parent context.Context, config *tls.Config) context.Context {
+	return withTLSOptions(parent, func(opts *tlsOptions) { opts.config = config })
+}
+
+// WithRootCAs returns a copy of the parent context whose API clients
+// (constructed using NewClientFromContext) verify the certificates of
+// "https" gRPC endpoints using the given root certificate
+// authorities, instead of the system's.
+func WithRootCAs(parent context.Context, rootCAs *x509.CertPool) context.Context {
+	return withTLSOptions(parent, func(opts *tlsOptions) { opts.rootCAs = rootCAs })
+}
+
+// WithClientCertificate returns a copy of the parent context whose API
+// clients (constructed using NewClientFromContext) present cert to
+// "https" gRPC endpoints that require client certificates (i.e., mutual
+// TLS).
+func WithClientCertificate(parent context.Context, cert tls.Certificate) context.Context {
+	return withTLSOptions(parent, func(opts *tlsOptions) { opts.clientCert = &cert })
+}
+
+// WithServerNameOverride returns a copy of the parent context whose API
+// clients (constructed using NewClientFromContext) verify that the
+// certificates of "https" gRPC endpoints are valid for serverName,
+// instead of for the endpoint's host. It is useful when the endpoint
+// is addressed by an IP address or an internal hostname.
+func WithServerNameOverride(parent context.Context, serverName string) context.Context {
+	return withTLSOptions(parent, func(opts *tlsOptions) { opts.serverName = serverName })
+}
+
+// WithInsecureSkipVerifyLocalhost returns a copy of the parent context
+// whose API clients (constructed using NewClientFromContext) don't
+// verify the certificates of "https" gRPC endpoints whose host is
+// "localhost". It is intended for development servers that use
+// self-signed certificates.
+func WithInsecureSkipVerifyLocalhost(parent context.Context) context.Context {
+	return withTLSOptions(parent, func(opts *tlsOptions) { opts.insecureSkipVerifyLocalhost = true })
+}
+
+// tlsConfigFromContext returns the TLS configuration for connections
+// to the gRPC endpoint in ctx, or nil if it isn't an "https" endpoint.
+func tlsConfigFromContext(ctx context.Context, endpoint *url.URL) *tls.Config {
+	if endpoint.Scheme != "https" {
+		return nil
+	}
+
+	opts := tlsOptionsFromContext(ctx)
+	var config *tls.Config
+	if opts.config != nil {
+		config = opts.config.Clone()
+	} else {
+		config = new(tls.Config)
+	}
+
+	host, _, _ := net.SplitHostPort(hostWithExplicitPort(endpoint))
+	if opts.rootCAs != nil {
+		config.RootCAs = opts.rootCAs
+	}
+	if opts.clientCert != nil {
+		config.Certificates = []tls.Certificate{*opts.clientCert}
+	}
+	if opts.serverName != "" {
+		config.ServerName = opts.serverName
+	}
+	if config.ServerName == "" {
+		config.ServerName = host
+	}
+	if host == "localhost" && opts.insecureSkipVerifyLocalhost {
+		config.InsecureSkipVerify = true
+	}
+	return config
+}
+
+// tlsIdentity returns a string that identifies the server
+// verification settings, client certificates and protocol settings
+// (versions, cipher suites and application protocols) of config, so
+// that connections using different TLS settings aren't shared in the
+// connection pool. Root CAs are identified by the *x509.CertPool, not
+// by its contents.
+func tlsIdentity(config *tls.Config) string {
+	if config == nil {
+		return ""
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%q %v %p\n", config.ServerName, config.InsecureSkipVerify, config.RootCAs)
+	fmt.Fprintf(h, "%x %x %x %q\n", config.MinVersion, config.MaxVersion, config.CipherSuites, config.NextProtos)
+	for _, cert := range config.Certificates {
+		for _, der := range cert.Certificate {
+			h.Write(der)
+		}
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// poolableTLSConfig reports whether connections that use config may
+// be pooled. Connections whose config has peer verification or client
+// certificate callbacks aren't pooled, because tlsIdentity can't
+// identify what the callbacks do (e.g., two closures of the same func
+// may verify different things).
+func poolableTLSConfig(config *tls.Config) bool {
+	return config == nil || (config.VerifyPeerCertificate == nil && config.VerifyConnection == nil && config.GetClientCertificate == nil)
+}
+
+// connKey returns the key of the pooled connection to target that
+// uses config (which is nil for insecure connections).
+func connKey(target string, config *tls.Config) string {
+	if config == nil {
+		return target
+	}
+	return target + " tls:" + tlsIdentity(config)
+}
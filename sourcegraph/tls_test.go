@@ -0,0 +1,345 @@
+package sourcegraph
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// newTestCert returns a self-signed certificate for hosts and a
+// certificate pool that contains it.
+func newTestCert(t *testing.T, hosts ...string) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: hosts[0]},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, host)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pool
+}
+
+// startTLSServer starts a gRPC server that uses config and returns
+// its address.
+func startTLSServer(t *testing.T, config *tls.Config) (addr string, stop func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))
+	go s.Serve(l)
+	return l.Addr().String(), s.Stop
+}
+
+func TestTLSConfigFromContext(t *testing.T) {
+	clientCert, rootCAs := newTestCert(t, "client")
+	base := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: "base.example.com"}
+
+	tests := map[string]struct {
+		endpoint string
+		ctx      func(context.Context) context.Context
+
+		wantNil                bool
+		wantServerName         string
+		wantInsecureSkipVerify bool
+		wantRootCAs            *x509.CertPool
+		wantCerts              int
+		wantMinVersion         uint16
+	}{
+		"http": {
+			endpoint: "http://example.com",
+			wantNil:  true,
+		},
+		"default": {
+			endpoint:       "https://example.com",
+			wantServerName: "example.com",
+		},
+		"localhost": {
+			endpoint:       "https://localhost:3100",
+			wantServerName: "localhost",
+		},
+		"localhost opt-in": {
+			endpoint:               "https://localhost:3100",
+			ctx:                    WithInsecureSkipVerifyLocalhost,
+			wantServerName:         "localhost",
+			wantInsecureSkipVerify: true,
+		},
+		"opt-in doesn't affect other hosts": {
+			endpoint:       "https://example.com",
+			ctx:            WithInsecureSkipVerifyLocalhost,
+			wantServerName: "example.com",
+		},
+		"options": {
+			endpoint: "https://10.0.0.1",
+			ctx: func(ctx context.Context) context.Context {
+				ctx = WithRootCAs(ctx, rootCAs)
+				ctx = WithClientCertificate(ctx, clientCert)
+				return WithServerNameOverride(ctx, "sourcegraph.internal")
+			},
+			wantServerName: "sourcegraph.internal",
+			wantRootCAs:    rootCAs,
+			wantCerts:      1,
+		},
+		"base config": {
+			endpoint: "https://example.com",
+			ctx: func(ctx context.Context) context.Context {
+				return WithTLSConfig(ctx, base)
+			},
+			wantServerName: "base.example.com",
+			wantMinVersion: tls.VersionTLS12,
+		},
+		"options override base config": {
+			endpoint: "https://example.com",
+			ctx: func(ctx context.Context) context.Context {
+				ctx = WithTLSConfig(ctx, base)
+				return WithServerNameOverride(ctx, "sourcegraph.internal")
+			},
+			wantServerName: "sourcegraph.internal",
+			wantMinVersion: tls.VersionTLS12,
+		},
+	}
+	for label, test := range tests {
+		ctx := context.Background()
+		if test.ctx != nil {
+			ctx = test.ctx(ctx)
+		}
+		endpoint, err := url.Parse(test.endpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		config := tlsConfigFromContext(ctx, endpoint)
+		if test.wantNil {
+			if config != nil {
+				t.Errorf("%s: got config %+v, want nil", label, config)
+			}
+			continue
+		}
+		if config.ServerName != test.wantServerName {
+			t.Errorf("%s: got ServerName %q, want %q", label, config.ServerName, test.wantServerName)
+		}
+		if config.InsecureSkipVerify != test.wantInsecureSkipVerify {
+			t.Errorf("%s: got InsecureSkipVerify %v, want %v", label, config.InsecureSkipVerify, test.wantInsecureSkipVerify)
+		}
+		if config.RootCAs != test.wantRootCAs {
+			t.Errorf("%s: got RootCAs %p, want %p", label, config.RootCAs, test.wantRootCAs)
+		}
+		if len(config.Certificates) != test.wantCerts {
+			t.Errorf("%s: got %d certificates, want %d", label, len(config.Certificates), test.wantCerts)
+		}
+		if config.MinVersion != test.wantMinVersion {
+			t.Errorf("%s: got MinVersion %x, want %x", label, config.MinVersion, test.wantMinVersion)
+		}
+	}
+	if base.ServerName != "base.example.com" {
+		t.Errorf("base config was modified")
+	}
+}
+
+func TestConnKey(t *testing.T) {
+	cert1, _ := newTestCert(t, "client1")
+	cert2, _ := newTestCert(t, "client2")
+	endpoint := &url.URL{Scheme: "https", Host: "example.com"}
+	key := func(ctx context.Context) string {
+		return connKey(hostWithExplicitPort(endpoint), tlsConfigFromContext(ctx, endpoint))
+	}
+
+	ctx1 := WithClientCertificate(context.Background(), cert1)
+	ctx2 := WithClientCertificate(context.Background(), cert2)
+	if key(ctx1) != key(WithClientCertificate(context.Background(), cert1)) {
+		t.Error("got different keys for the same client certificate")
+	}
+	if key(ctx1) == key(ctx2) {
+		t.Error("got the same key for different client certificates")
+	}
+	if key(ctx1) == key(context.Background()) {
+		t.Error("got the same key with and without a client certificate")
+	}
+	if key(context.Background()) == key(WithServerNameOverride(context.Background(), "other.example.com")) {
+		t.Error("got the same key for different server names")
+	}
+	for _, config := range []*tls.Config{
+		{MinVersion: tls.VersionTLS12},
+		{MaxVersion: tls.VersionTLS12},
+		{CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}},
+	} {
+		if key(context.Background()) == key(WithTLSConfig(context.Background(), config)) {
+			t.Errorf("got the same key with and without TLS config %+v", config)
+		}
+	}
+
+	if got, want := connKey("example.com:80", nil), "example.com:80"; got != want {
+		t.Errorf("got insecure key %q, want %q", got, want)
+	}
+}
+
+func TestNewClientFromContextE_tlsCallbacks(t *testing.T) {
+	serverCert, rootCAs := newTestCert(t, "127.0.0.1")
+	addr, stop := startTLSServer(t, &tls.Config{Certificates: []tls.Certificate{serverCert}})
+	defer stop()
+
+	pool := &ConnPool{}
+	defer pool.Close()
+	var (
+		mu       sync.Mutex
+		verified int
+	)
+	ctx := WithConnPool(context.Background(), pool)
+	ctx = WithGRPCEndpoint(ctx, &url.URL{Scheme: "https", Host: addr})
+	ctx = WithTLSConfig(ctx, &tls.Config{
+		RootCAs: rootCAs,
+		VerifyPeerCertificate: func([][]byte, [][]*x509.Certificate) error {
+			mu.Lock()
+			defer mu.Unlock()
+			verified++
+			return nil
+		},
+	})
+
+	// Connections whose TLS config has callbacks aren't pooled.
+	var clients []*Client
+	for i := 0; i < 2; i++ {
+		c, err := NewClientFromContextE(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clients = append(clients, c)
+	}
+	if clients[0].Conn == clients[1].Conn {
+		t.Error("got the same connection for both clients, want unpooled connections")
+	}
+	if stats := pool.Stats(); len(stats) != 0 {
+		t.Errorf("got pool stats %+v, want none", stats)
+	}
+	mu.Lock()
+	if verified != 2 {
+		t.Errorf("got %d verified connections, want 2", verified)
+	}
+	mu.Unlock()
+
+	// Close closes the unpooled connection.
+	clients[0].Close()
+	if state := clients[0].Conn.State(); state != grpc.Shutdown {
+		t.Errorf("got state %s after Close, want %s", state, grpc.Shutdown)
+	}
+	clients[1].Close()
+}
+
+func TestNewClientFromContextE_tls(t *testing.T) {
+	defer func(d time.Duration) { tlsProbeTimeout = d }(tlsProbeTimeout)
+	tlsProbeTimeout = 500 * time.Millisecond
+
+	serverCert, rootCAs := newTestCert(t, "127.0.0.1", "sourcegraph.internal")
+	clientCert, clientCAs := newTestCert(t, "client")
+
+	addr, stop := startTLSServer(t, &tls.Config{Certificates: []tls.Certificate{serverCert}})
+	defer stop()
+	mtlsAddr, stop := startTLSServer(t, &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	})
+	defer stop()
+
+	tests := map[string]struct {
+		addr       string
+		ctx        func(context.Context) context.Context
+		wantTLSErr bool
+	}{
+		"untrusted": {
+			addr:       addr,
+			wantTLSErr: true,
+		},
+		"root CAs": {
+			addr: addr,
+			ctx: func(ctx context.Context) context.Context {
+				return WithRootCAs(ctx, rootCAs)
+			},
+		},
+		"server name override": {
+			addr: addr,
+			ctx: func(ctx context.Context) context.Context {
+				return WithServerNameOverride(WithRootCAs(ctx, rootCAs), "sourcegraph.internal")
+			},
+		},
+		"wrong server name": {
+			addr: addr,
+			ctx: func(ctx context.Context) context.Context {
+				return WithServerNameOverride(WithRootCAs(ctx, rootCAs), "example.com")
+			},
+			wantTLSErr: true,
+		},
+		"base config": {
+			addr: addr,
+			ctx: func(ctx context.Context) context.Context {
+				return WithTLSConfig(ctx, &tls.Config{RootCAs: rootCAs})
+			},
+		},
+		"mTLS": {
+			addr: mtlsAddr,
+			ctx: func(ctx context.Context) context.Context {
+				return WithClientCertificate(WithRootCAs(ctx, rootCAs), clientCert)
+			},
+		},
+		"mTLS without client certificate": {
+			addr: mtlsAddr,
+			ctx: func(ctx context.Context) context.Context {
+				return WithRootCAs(ctx, rootCAs)
+			},
+			wantTLSErr: true,
+		},
+	}
+	for label, test := range tests {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		ctx = WithGRPCEndpoint(ctx, &url.URL{Scheme: "https", Host: test.addr})
+		if test.ctx != nil {
+			ctx = test.ctx(ctx)
+		}
+		_, err := NewClientFromContextE(ctx)
+		cancel()
+		if test.wantTLSErr {
+			if _, ok := err.(*TLSError); !ok {
+				t.Errorf("%s: got err %v (%T), want *TLSError", label, err, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: %s", label, err)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package sourcegraph
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// A Tracer records a span for each API call made by (or handled by)
+// the Traced*Client (or Traced*Server) wrappers, and for spans started
+// with StartSpan. Finished spans are passed to the Exporter.
+//
+// The trace context is propagated from clients to servers in the
+// trace-id and span-id client metadata (see WithClientMetadata), so
+// that the spans of a server's calls are children of the client's
+// span.
+type Tracer struct {
+	Exporter SpanExporter
+}
+
+// A SpanExporter receives finished spans.
+type SpanExporter interface {
+	ExportSpan(*Span)
+}
+
+// A Span records an operation, such as an API call.
+type Span struct {
+	TraceID  string // the ID of the trace that the span belongs to
+	SpanID   string // the ID of the span
+	ParentID string // the ID of the parent span ("" for root spans)
+
+	Name  string // the name of the operation (e.g., "Repos.Get")
+	Start time.Time
+	End   time.Time
+
+	mu     sync.Mutex
+	tags   map[string]string
+	tracer *Tracer
+}
+
+// Tag keys set on API call spans.
+const (
+	SpanKindTag = "span.kind" // "client" or "server"
+	ServiceTag  = "service"   // e.g., "Repos"
+	MethodTag   = "method"    // e.g., "Get"
+	RepoTag     = "repo"      // the URI of the repository that the request refers to (if any)
+	CodeTag     = "code"      // the gRPC status code (e.g., "OK")
+	CacheHitTag = "cache.hit" // "true" if the response was obtained from a cache (instead of the server)
+)
+
+// Metadata keys that carry the trace context.
+const (
+	traceIDMetadataKey = "trace-id"
+	spanIDMetadataKey  = "span-id"
+)
+
+// WithTracer returns a copy of the parent context whose API clients
+// (constructed using NewClientFromContext) record spans with tracer.
+func WithTracer(parent context.Context, tracer *Tracer) context.Context {
+	return context.WithValue(parent, tracerKey, tracer)
+}
+
+// tracerFromContext returns the tracer previously set in the context
+// by WithTracer, or nil if none was set.
+func tracerFromContext(ctx context.Context) *Tracer {
+	tracer, _ := ctx.Value(tracerKey).(*Tracer)
+	return tracer
+}
+
+// SpanFromContext returns the current span (if any) in the context.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey).(*Span)
+	return span
+}
+
+// StartSpan starts a span that is a child of the current span in ctx
+// (if any). The returned context's current span is the new span. The
+// caller must call Finish on the span when the operation is done.
+func (t *Tracer) StartSpan(ctx context.Context, name string) (*Span, context.Context) {
+	span := &Span{
+		SpanID: newTraceID(),
+		Name:   name,
+		Start:  time.Now(),
+		tracer: t,
+	}
+	if parent := SpanFromContext(ctx); parent != nil {
+		span.TraceID = parent.TraceID
+		span.ParentID = parent.SpanID
+	} else {
+		span.TraceID = newTraceID()
+	}
+	return span, context.WithValue(ctx, spanKey, span)
+}
+
+// startClientSpan starts a span for a client's call to method (e.g.,
+// "Repos.Get") with arg, and adds the trace context to the returned
+// context's client metadata. It returns a nil span if t is nil.
+func (t *Tracer) startClientSpan(ctx context.Context, method string, arg interface{}) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+	span, ctx := t.StartSpan(ctx, method)
+	span.setCallTags("client", method, arg)
+
+	md := map[string]string{}
+	for k, v := range clientMetadataFromContext(ctx) {
+		md[k] = v
+	}
+	md[traceIDMetadataKey] = span.TraceID
+	md[spanIDMetadataKey] = span.SpanID
+	return WithClientMetadata(ctx, md), span
+}
+
+// startServerSpan starts a span for a server's handling of a call to
+// method (e.g., "Repos.Get") with arg. If the call's metadata has a
+// trace context, the span is a child of the client's span. It returns
+// a nil span if t is nil.
+func (t *Tracer) startServerSpan(ctx context.Context, method string, arg interface{}) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+	var traceID, parentID string
+	if md, ok := metadata.FromContext(ctx); ok {
+		traceID, parentID = firstMetadataValue(md, traceIDMetadataKey), firstMetadataValue(md, spanIDMetadataKey)
+	}
+	span, ctx := t.StartSpan(ctx, method)
+	if traceID != "" && parentID != "" {
+		span.TraceID, span.ParentID = traceID, parentID
+	}
+	span.setCallTags("server", method, arg)
+	return ctx, span
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	if v := md[key]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (s *Span) setCallTags(kind, method string, arg interface{}) {
+	s.SetTag(SpanKindTag, kind)
+	if i := strings.Index(method, "."); i != -1 {
+		s.SetTag(ServiceTag, method[:i])
+		s.SetTag(MethodTag, method[i+1:])
+	}
+	if repo := requestRepoURI(arg); repo != "" {
+		s.SetTag(RepoTag, repo)
+	}
+	s.SetTag(CacheHitTag, "false")
+}
+
+// finishCall sets the span's status code tag to err's code and
+// finishes the span. It does nothing if s is nil.
+func (s *Span) finishCall(err error) {
+	if s == nil {
+		return
+	}
+	s.SetTag(CodeTag, grpc.Code(err).String())
+	s.Finish()
+}
+
+// traceCacheHit calls call, which calls an API method through a
+// Cached*Client wrapper. If the call succeeds without reaching the
+// client beneath the wrapper (see traceCacheMiss), the current span
+// in ctx is tagged as a cache hit.
+func traceCacheHit(ctx context.Context, call func(context.Context) error) error {
+	span := SpanFromContext(ctx)
+	if span == nil {
+		return call(ctx)
+	}
+	var missed bool
+	err := call(context.WithValue(ctx, cacheMissKey, &missed))
+	if err == nil && !missed {
+		span.SetTag(CacheHitTag, "true")
+	}
+	return err
+}
+
+// traceCacheMiss records that a call made by traceCacheHit was not
+// answered by the cache.
+func traceCacheMiss(ctx context.Context) {
+	if missed, ok := ctx.Value(cacheMissKey).(*bool); ok {
+		*missed = true
+	}
+}
+
+// SetTag sets a tag on the span. It does nothing if s is nil.
+func (s *Span) SetTag(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tags == nil {
+		s.tags = map[string]string{}
+	}
+	s.tags[key] = value
+}
+
+// Tag returns the value of the span's tag with the given key.
+func (s *Span) Tag(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tags[key]
+}
+
+// Tags returns a copy of the span's tags.
+func (s *Span) Tags() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tags := make(map[string]string, len(s.tags))
+	for k, v := range s.tags {
+		tags[k] = v
+	}
+	return tags
+}
+
+// Finish records the end time of the span and exports it.
+func (s *Span) Finish() {
+	s.End = time.Now()
+	if s.tracer != nil && s.tracer.Exporter != nil {
+		s.tracer.Exporter.ExportSpan(s)
+	}
+}
+
+func newTraceID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// InMemoryExporter is a SpanExporter that keeps finished spans in
+// memory. It is intended for tests.
+type InMemoryExporter struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// ExportSpan implements SpanExporter.
+func (e *InMemoryExporter) ExportSpan(span *Span) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, span)
+}
+
+// Spans returns the finished spans, ordered by start time.
+func (e *InMemoryExporter) Spans() []*Span {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	spans := make([]*Span, len(e.spans))
+	copy(spans, e.spans)
+	sort.Stable(spansByStart(spans))
+	return spans
+}
+
+// Roots returns the finished spans that have no parent.
+func (e *InMemoryExporter) Roots() []*Span {
+	var roots []*Span
+	for _, span := range e.Spans() {
+		if span.ParentID == "" {
+			roots = append(roots, span)
+		}
+	}
+	return roots
+}
+
+// Children returns the finished spans that are children of parent.
+func (e *InMemoryExporter) Children(parent *Span) []*Span {
+	var children []*Span
+	for _, span := range e.Spans() {
+		if span.TraceID == parent.TraceID && span.ParentID == parent.SpanID {
+			children = append(children, span)
+		}
+	}
+	return children
+}
+
+// Reset discards all finished spans.
+func (e *InMemoryExporter) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = nil
+}
+
+type spansByStart []*Span
+
+func (v spansByStart) Len() int           { return len(v) }
+func (v spansByStart) Less(i, j int) bool { return v[i].Start.Before(v[j].Start) }
+func (v spansByStart) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
@@ -0,0 +1,267 @@
+package sourcegraph
+
+import (
+	"io"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"sourcegraph.com/sourcegraph/grpccache"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+type statusMetaServer struct{ MetaServer }
+
+func (statusMetaServer) Status(context.Context, *pbtypes.Void) (*ServerStatus, error) {
+	return &ServerStatus{}, nil
+}
+
+func TestTracer_clientServer(t *testing.T) {
+	exporter := &InMemoryExporter{}
+	tracer := &Tracer{Exporter: exporter}
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	RegisterMetaServer(s, &TracedMetaServer{statusMetaServer{}, tracer})
+	go s.Serve(l)
+	defer s.Stop()
+
+	pool := &ConnPool{}
+	defer pool.Close()
+	ctx := WithConnPool(context.Background(), pool)
+	ctx = WithGRPCEndpoint(ctx, &url.URL{Host: l.Addr().String()})
+	ctx = WithTracer(ctx, tracer)
+
+	root, ctx := tracer.StartSpan(ctx, "root")
+	c := NewClientFromContext(ctx)
+	if _, err := c.Meta.Status(ctx, &pbtypes.Void{}); err != nil {
+		t.Fatal(err)
+	}
+	root.Finish()
+
+	if roots := exporter.Roots(); len(roots) != 1 || roots[0] != root {
+		t.Fatalf("got root spans %v, want [root]", roots)
+	}
+	clientSpans := exporter.Children(root)
+	if len(clientSpans) != 1 {
+		t.Fatalf("got %d client spans, want 1", len(clientSpans))
+	}
+	want := map[string]string{SpanKindTag: "client", ServiceTag: "Meta", MethodTag: "Status", CodeTag: "OK", CacheHitTag: "false"}
+	if got := clientSpans[0].Tags(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got client span tags %v, want %v", got, want)
+	}
+	serverSpans := exporter.Children(clientSpans[0])
+	if len(serverSpans) != 1 {
+		t.Fatalf("got %d server spans, want 1", len(serverSpans))
+	}
+	if got := serverSpans[0].Tag(SpanKindTag); got != "server" {
+		t.Errorf("got server span kind %q, want %q", got, "server")
+	}
+}
+
+type errReposClient struct {
+	ReposClient
+	err error
+}
+
+func (c errReposClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*Repo, error) {
+	return nil, c.err
+}
+
+func TestTracedClient(t *testing.T) {
+	exporter := &InMemoryExporter{}
+	c := &TracedReposClient{errReposClient{err: grpc.Errorf(codes.NotFound, "x")}, &Tracer{Exporter: exporter}}
+	c.Get(context.Background(), &RepoSpec{URI: "r"})
+
+	spans := exporter.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if got, want := spans[0].Tag(RepoTag), "r"; got != want {
+		t.Errorf("got repo tag %q, want %q", got, want)
+	}
+	if got, want := spans[0].Tag(CodeTag), "NotFound"; got != want {
+		t.Errorf("got code tag %q, want %q", got, want)
+	}
+
+	// A nil Tracer records nothing.
+	exporter.Reset()
+	c.Tracer = nil
+	c.Get(context.Background(), &RepoSpec{URI: "r"})
+	if spans := exporter.Spans(); len(spans) != 0 {
+		t.Errorf("got %d spans with nil Tracer, want 0", len(spans))
+	}
+}
+
+func TestTracer_startServerSpan(t *testing.T) {
+	tracer := &Tracer{}
+	ctx := metadata.NewContext(context.Background(), metadata.MD{"trace-id": []string{"t"}, "span-id": []string{"p"}})
+	_, span := tracer.startServerSpan(ctx, "Repos.Get", &RepoSpec{})
+	if span.TraceID != "t" || span.ParentID != "p" {
+		t.Errorf("got trace ID %q and parent ID %q, want %q and %q", span.TraceID, span.ParentID, "t", "p")
+	}
+
+	_, span = tracer.startServerSpan(context.Background(), "Repos.Get", &RepoSpec{})
+	if span.TraceID == "" || span.ParentID != "" {
+		t.Errorf("got trace ID %q and parent ID %q, want new trace", span.TraceID, span.ParentID)
+	}
+}
+
+func TestTracer_cacheHit(t *testing.T) {
+	tracer := &Tracer{}
+	cache := NewStorageCache(NewLRUCacheStorage(0, 0))
+	arg := &RepoSpec{URI: "r"}
+	if err := cache.Store(context.Background(), "Repos.Get", arg, &Repo{}, cacheControlTrailer(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The trace metadata doesn't partition the cache.
+	ctx, span := tracer.startClientSpan(context.Background(), "Repos.Get", arg)
+	if cached, _ := cache.Get(ctx, "Repos.Get", arg, &Repo{}); !cached {
+		t.Fatal("got cached == false, want true")
+	}
+	if got := span.Tag(CacheHitTag); got != "true" {
+		t.Errorf("got cache hit tag %q, want %q", got, "true")
+	}
+}
+
+func TestTracer_grpcCacheHit(t *testing.T) {
+	exporter := &InMemoryExporter{}
+	tracer := &Tracer{Exporter: exporter}
+
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	RegisterReposServer(s, &CachedReposServer{&cacheControlReposServer{}})
+	go s.Serve(l)
+	defer s.Stop()
+
+	pool := &ConnPool{}
+	defer pool.Close()
+	ctx := WithConnPool(context.Background(), pool)
+	ctx = WithGRPCEndpoint(ctx, &url.URL{Host: l.Addr().String()})
+	ctx = WithCache(ctx, &grpccache.Cache{KeyPart: CredentialsCacheKeyPart})
+	ctx = WithTracer(ctx, tracer)
+
+	c := NewClientFromContext(ctx)
+	for i := 0; i < 2; i++ {
+		if _, err := c.Repos.Get(ctx, &RepoSpec{URI: "r"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	spans := exporter.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	for i, want := range []string{"false", "true"} {
+		if got := spans[i].Tag(CacheHitTag); got != want {
+			t.Errorf("call %d: got cache hit tag %q, want %q", i, got, want)
+		}
+	}
+}
+
+type testSearchClient struct {
+	SearchClient
+	events []*SearchEvent
+	err    error // returned by Recv after the events
+}
+
+func (c *testSearchClient) StreamSearch(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (Search_StreamSearchClient, error) {
+	return &testStreamSearchClient{events: c.events, err: c.err}, nil
+}
+
+type testStreamSearchClient struct {
+	grpc.ClientStream
+	events []*SearchEvent
+	err    error
+}
+
+func (s *testStreamSearchClient) Recv() (*SearchEvent, error) {
+	if len(s.events) == 0 {
+		return nil, s.err
+	}
+	e := s.events[0]
+	s.events = s.events[1:]
+	return e, nil
+}
+
+func TestTracedClient_stream(t *testing.T) {
+	for _, test := range []struct {
+		err      error
+		wantCode string
+	}{
+		{io.EOF, "OK"},
+		{grpc.Errorf(codes.Unavailable, "x"), "Unavailable"},
+	} {
+		exporter := &InMemoryExporter{}
+		c := &TracedSearchClient{&testSearchClient{events: []*SearchEvent{{}}, err: test.err}, &Tracer{Exporter: exporter}}
+		stream, err := c.StreamSearch(context.Background(), &SearchOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The span is finished when the stream ends.
+		if _, err := stream.Recv(); err != nil {
+			t.Fatal(err)
+		}
+		if spans := exporter.Spans(); len(spans) != 0 {
+			t.Errorf("got %d spans before the stream ended, want 0", len(spans))
+		}
+		for i := 0; i < 2; i++ {
+			if _, err := stream.Recv(); err != test.err {
+				t.Errorf("got error %v, want %v", err, test.err)
+			}
+		}
+		spans := exporter.Spans()
+		if len(spans) != 1 {
+			t.Fatalf("got %d spans, want 1", len(spans))
+		}
+		if got := spans[0].Tag(CodeTag); got != test.wantCode {
+			t.Errorf("got code tag %q, want %q", got, test.wantCode)
+		}
+	}
+}
+
+type spanSearchServer struct {
+	SearchServer
+	span *Span
+}
+
+func (s *spanSearchServer) StreamSearch(in *SearchOptions, stream Search_StreamSearchServer) error {
+	s.span = SpanFromContext(stream.Context())
+	return grpc.Errorf(codes.NotFound, "x")
+}
+
+func TestTracedServer_stream(t *testing.T) {
+	exporter := &InMemoryExporter{}
+	backend := &spanSearchServer{}
+	s := &TracedSearchServer{backend, &Tracer{Exporter: exporter}}
+	stream := &testStreamSearchServer{testServerStream{ctx: context.Background()}}
+	if err := s.StreamSearch(&SearchOptions{}, stream); grpc.Code(err) != codes.NotFound {
+		t.Errorf("got error %v, want NotFound", err)
+	}
+
+	spans := exporter.Spans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if backend.span != spans[0] {
+		t.Error("got handler stream context without the call's span")
+	}
+	want := map[string]string{SpanKindTag: "server", ServiceTag: "Search", MethodTag: "StreamSearch", CodeTag: "NotFound", CacheHitTag: "false"}
+	if got := spans[0].Tags(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got span tags %v, want %v", got, want)
+	}
+}
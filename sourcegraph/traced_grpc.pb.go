@@ -0,0 +1,4211 @@
+// GENERATED CODE - DO NOT EDIT!
+//
+// Generated by:
+//
+//   go run gen/wrappers.go -kind trace -o traced_grpc.pb.go sourcegraph.pb.go
+//
+// Called via:
+//
+//   go generate
+//
+
+package sourcegraph
+
+import (
+	"io"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"sourcegraph.com/sourcegraph/go-vcs/vcs"
+	"sourcegraph.com/sourcegraph/srclib/unit"
+	"sourcegraph.com/sqs/pbtypes"
+)
+
+type TracedAccessTokensClient struct {
+	AccessTokensClient
+	Tracer *Tracer
+}
+
+func (s *TracedAccessTokensClient) Create(ctx context.Context, in *AccessTokensCreateOp, opts ...grpc.CallOption) (*NewAccessToken, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "AccessTokens.Create", in)
+	result, err := s.AccessTokensClient.Create(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAccessTokensClient) List(ctx context.Context, in *AccessTokensListOptions, opts ...grpc.CallOption) (*AccessTokenList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "AccessTokens.List", in)
+	result, err := s.AccessTokensClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAccessTokensClient) Revoke(ctx context.Context, in *AccessTokenSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "AccessTokens.Revoke", in)
+	result, err := s.AccessTokensClient.Revoke(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedAccessTokensServer struct {
+	AccessTokensServer
+	Tracer *Tracer
+}
+
+func (s *TracedAccessTokensServer) Create(ctx context.Context, in *AccessTokensCreateOp) (*NewAccessToken, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "AccessTokens.Create", in)
+	result, err := s.AccessTokensServer.Create(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAccessTokensServer) List(ctx context.Context, in *AccessTokensListOptions) (*AccessTokenList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "AccessTokens.List", in)
+	result, err := s.AccessTokensServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAccessTokensServer) Revoke(ctx context.Context, in *AccessTokenSpec) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "AccessTokens.Revoke", in)
+	result, err := s.AccessTokensServer.Revoke(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedAccessTokensClient struct {
+	AccessTokensClient
+}
+
+func (s *cacheHitTracedAccessTokensClient) Create(ctx context.Context, in *AccessTokensCreateOp, opts ...grpc.CallOption) (*NewAccessToken, error) {
+	var result *NewAccessToken
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.AccessTokensClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedAccessTokensClient) List(ctx context.Context, in *AccessTokensListOptions, opts ...grpc.CallOption) (*AccessTokenList, error) {
+	var result *AccessTokenList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.AccessTokensClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedAccessTokensClient) Revoke(ctx context.Context, in *AccessTokenSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.AccessTokensClient.Revoke(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedAccessTokensClient struct {
+	AccessTokensClient
+}
+
+func (s *cacheMissTracedAccessTokensClient) Create(ctx context.Context, in *AccessTokensCreateOp, opts ...grpc.CallOption) (*NewAccessToken, error) {
+	traceCacheMiss(ctx)
+	return s.AccessTokensClient.Create(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedAccessTokensClient) List(ctx context.Context, in *AccessTokensListOptions, opts ...grpc.CallOption) (*AccessTokenList, error) {
+	traceCacheMiss(ctx)
+	return s.AccessTokensClient.List(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedAccessTokensClient) Revoke(ctx context.Context, in *AccessTokenSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.AccessTokensClient.Revoke(ctx, in, opts...)
+}
+
+type TracedAccountsClient struct {
+	AccountsClient
+	Tracer *Tracer
+}
+
+func (s *TracedAccountsClient) Create(ctx context.Context, in *NewAccount, opts ...grpc.CallOption) (*UserSpec, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Accounts.Create", in)
+	result, err := s.AccountsClient.Create(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAccountsClient) RequestPasswordReset(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (*User, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Accounts.RequestPasswordReset", in)
+	result, err := s.AccountsClient.RequestPasswordReset(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAccountsClient) ResetPassword(ctx context.Context, in *NewPassword, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Accounts.ResetPassword", in)
+	result, err := s.AccountsClient.ResetPassword(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAccountsClient) Update(ctx context.Context, in *User, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Accounts.Update", in)
+	result, err := s.AccountsClient.Update(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedAccountsServer struct {
+	AccountsServer
+	Tracer *Tracer
+}
+
+func (s *TracedAccountsServer) Create(ctx context.Context, in *NewAccount) (*UserSpec, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Accounts.Create", in)
+	result, err := s.AccountsServer.Create(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAccountsServer) RequestPasswordReset(ctx context.Context, in *EmailAddr) (*User, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Accounts.RequestPasswordReset", in)
+	result, err := s.AccountsServer.RequestPasswordReset(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAccountsServer) ResetPassword(ctx context.Context, in *NewPassword) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Accounts.ResetPassword", in)
+	result, err := s.AccountsServer.ResetPassword(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAccountsServer) Update(ctx context.Context, in *User) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Accounts.Update", in)
+	result, err := s.AccountsServer.Update(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedAccountsClient struct {
+	AccountsClient
+}
+
+func (s *cacheHitTracedAccountsClient) Create(ctx context.Context, in *NewAccount, opts ...grpc.CallOption) (*UserSpec, error) {
+	var result *UserSpec
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.AccountsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedAccountsClient) RequestPasswordReset(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (*User, error) {
+	var result *User
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.AccountsClient.RequestPasswordReset(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedAccountsClient) ResetPassword(ctx context.Context, in *NewPassword, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.AccountsClient.ResetPassword(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedAccountsClient) Update(ctx context.Context, in *User, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.AccountsClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedAccountsClient struct {
+	AccountsClient
+}
+
+func (s *cacheMissTracedAccountsClient) Create(ctx context.Context, in *NewAccount, opts ...grpc.CallOption) (*UserSpec, error) {
+	traceCacheMiss(ctx)
+	return s.AccountsClient.Create(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedAccountsClient) RequestPasswordReset(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (*User, error) {
+	traceCacheMiss(ctx)
+	return s.AccountsClient.RequestPasswordReset(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedAccountsClient) ResetPassword(ctx context.Context, in *NewPassword, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.AccountsClient.ResetPassword(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedAccountsClient) Update(ctx context.Context, in *User, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.AccountsClient.Update(ctx, in, opts...)
+}
+
+type TracedAuditLogClient struct {
+	AuditLogClient
+	Tracer *Tracer
+}
+
+func (s *TracedAuditLogClient) List(ctx context.Context, in *AuditEventListOptions, opts ...grpc.CallOption) (*AuditEventList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "AuditLog.List", in)
+	result, err := s.AuditLogClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedAuditLogServer struct {
+	AuditLogServer
+	Tracer *Tracer
+}
+
+func (s *TracedAuditLogServer) List(ctx context.Context, in *AuditEventListOptions) (*AuditEventList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "AuditLog.List", in)
+	result, err := s.AuditLogServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedAuditLogClient struct {
+	AuditLogClient
+}
+
+func (s *cacheHitTracedAuditLogClient) List(ctx context.Context, in *AuditEventListOptions, opts ...grpc.CallOption) (*AuditEventList, error) {
+	var result *AuditEventList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.AuditLogClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedAuditLogClient struct {
+	AuditLogClient
+}
+
+func (s *cacheMissTracedAuditLogClient) List(ctx context.Context, in *AuditEventListOptions, opts ...grpc.CallOption) (*AuditEventList, error) {
+	traceCacheMiss(ctx)
+	return s.AuditLogClient.List(ctx, in, opts...)
+}
+
+type TracedAuthClient struct {
+	AuthClient
+	Tracer *Tracer
+}
+
+func (s *TracedAuthClient) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest, opts ...grpc.CallOption) (*AuthorizationCode, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Auth.GetAuthorizationCode", in)
+	result, err := s.AuthClient.GetAuthorizationCode(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAuthClient) GetAccessToken(ctx context.Context, in *AccessTokenRequest, opts ...grpc.CallOption) (*AccessTokenResponse, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Auth.GetAccessToken", in)
+	result, err := s.AuthClient.GetAccessToken(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAuthClient) Identify(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*AuthInfo, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Auth.Identify", in)
+	result, err := s.AuthClient.Identify(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAuthClient) GetPermissions(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*UserPermissions, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Auth.GetPermissions", in)
+	result, err := s.AuthClient.GetPermissions(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedAuthServer struct {
+	AuthServer
+	Tracer *Tracer
+}
+
+func (s *TracedAuthServer) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest) (*AuthorizationCode, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Auth.GetAuthorizationCode", in)
+	result, err := s.AuthServer.GetAuthorizationCode(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAuthServer) GetAccessToken(ctx context.Context, in *AccessTokenRequest) (*AccessTokenResponse, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Auth.GetAccessToken", in)
+	result, err := s.AuthServer.GetAccessToken(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAuthServer) Identify(ctx context.Context, in *pbtypes.Void) (*AuthInfo, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Auth.Identify", in)
+	result, err := s.AuthServer.Identify(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedAuthServer) GetPermissions(ctx context.Context, in *pbtypes.Void) (*UserPermissions, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Auth.GetPermissions", in)
+	result, err := s.AuthServer.GetPermissions(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedAuthClient struct {
+	AuthClient
+}
+
+func (s *cacheHitTracedAuthClient) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest, opts ...grpc.CallOption) (*AuthorizationCode, error) {
+	var result *AuthorizationCode
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.AuthClient.GetAuthorizationCode(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedAuthClient) GetAccessToken(ctx context.Context, in *AccessTokenRequest, opts ...grpc.CallOption) (*AccessTokenResponse, error) {
+	var result *AccessTokenResponse
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.AuthClient.GetAccessToken(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedAuthClient) Identify(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*AuthInfo, error) {
+	var result *AuthInfo
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.AuthClient.Identify(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedAuthClient) GetPermissions(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*UserPermissions, error) {
+	var result *UserPermissions
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.AuthClient.GetPermissions(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedAuthClient struct {
+	AuthClient
+}
+
+func (s *cacheMissTracedAuthClient) GetAuthorizationCode(ctx context.Context, in *AuthorizationCodeRequest, opts ...grpc.CallOption) (*AuthorizationCode, error) {
+	traceCacheMiss(ctx)
+	return s.AuthClient.GetAuthorizationCode(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedAuthClient) GetAccessToken(ctx context.Context, in *AccessTokenRequest, opts ...grpc.CallOption) (*AccessTokenResponse, error) {
+	traceCacheMiss(ctx)
+	return s.AuthClient.GetAccessToken(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedAuthClient) Identify(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*AuthInfo, error) {
+	traceCacheMiss(ctx)
+	return s.AuthClient.Identify(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedAuthClient) GetPermissions(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*UserPermissions, error) {
+	traceCacheMiss(ctx)
+	return s.AuthClient.GetPermissions(ctx, in, opts...)
+}
+
+type TracedBuildsClient struct {
+	BuildsClient
+	Tracer *Tracer
+}
+
+func (s *TracedBuildsClient) Get(ctx context.Context, in *BuildSpec, opts ...grpc.CallOption) (*Build, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Builds.Get", in)
+	result, err := s.BuildsClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsClient) GetRepoBuildInfo(ctx context.Context, in *BuildsGetRepoBuildInfoOp, opts ...grpc.CallOption) (*RepoBuildInfo, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Builds.GetRepoBuildInfo", in)
+	result, err := s.BuildsClient.GetRepoBuildInfo(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsClient) List(ctx context.Context, in *BuildListOptions, opts ...grpc.CallOption) (*BuildList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Builds.List", in)
+	result, err := s.BuildsClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsClient) Create(ctx context.Context, in *BuildsCreateOp, opts ...grpc.CallOption) (*Build, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Builds.Create", in)
+	result, err := s.BuildsClient.Create(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsClient) Update(ctx context.Context, in *BuildsUpdateOp, opts ...grpc.CallOption) (*Build, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Builds.Update", in)
+	result, err := s.BuildsClient.Update(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsClient) ListBuildTasks(ctx context.Context, in *BuildsListBuildTasksOp, opts ...grpc.CallOption) (*BuildTaskList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Builds.ListBuildTasks", in)
+	result, err := s.BuildsClient.ListBuildTasks(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsClient) CreateTasks(ctx context.Context, in *BuildsCreateTasksOp, opts ...grpc.CallOption) (*BuildTaskList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Builds.CreateTasks", in)
+	result, err := s.BuildsClient.CreateTasks(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsClient) UpdateTask(ctx context.Context, in *BuildsUpdateTaskOp, opts ...grpc.CallOption) (*BuildTask, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Builds.UpdateTask", in)
+	result, err := s.BuildsClient.UpdateTask(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsClient) GetLog(ctx context.Context, in *BuildsGetLogOp, opts ...grpc.CallOption) (*LogEntries, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Builds.GetLog", in)
+	result, err := s.BuildsClient.GetLog(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsClient) GetTaskLog(ctx context.Context, in *BuildsGetTaskLogOp, opts ...grpc.CallOption) (*LogEntries, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Builds.GetTaskLog", in)
+	result, err := s.BuildsClient.GetTaskLog(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsClient) DequeueNext(ctx context.Context, in *BuildsDequeueNextOp, opts ...grpc.CallOption) (*Build, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Builds.DequeueNext", in)
+	result, err := s.BuildsClient.DequeueNext(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedBuildsServer struct {
+	BuildsServer
+	Tracer *Tracer
+}
+
+func (s *TracedBuildsServer) Get(ctx context.Context, in *BuildSpec) (*Build, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Builds.Get", in)
+	result, err := s.BuildsServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsServer) GetRepoBuildInfo(ctx context.Context, in *BuildsGetRepoBuildInfoOp) (*RepoBuildInfo, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Builds.GetRepoBuildInfo", in)
+	result, err := s.BuildsServer.GetRepoBuildInfo(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsServer) List(ctx context.Context, in *BuildListOptions) (*BuildList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Builds.List", in)
+	result, err := s.BuildsServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsServer) Create(ctx context.Context, in *BuildsCreateOp) (*Build, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Builds.Create", in)
+	result, err := s.BuildsServer.Create(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsServer) Update(ctx context.Context, in *BuildsUpdateOp) (*Build, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Builds.Update", in)
+	result, err := s.BuildsServer.Update(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsServer) ListBuildTasks(ctx context.Context, in *BuildsListBuildTasksOp) (*BuildTaskList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Builds.ListBuildTasks", in)
+	result, err := s.BuildsServer.ListBuildTasks(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsServer) CreateTasks(ctx context.Context, in *BuildsCreateTasksOp) (*BuildTaskList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Builds.CreateTasks", in)
+	result, err := s.BuildsServer.CreateTasks(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsServer) UpdateTask(ctx context.Context, in *BuildsUpdateTaskOp) (*BuildTask, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Builds.UpdateTask", in)
+	result, err := s.BuildsServer.UpdateTask(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsServer) GetLog(ctx context.Context, in *BuildsGetLogOp) (*LogEntries, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Builds.GetLog", in)
+	result, err := s.BuildsServer.GetLog(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsServer) GetTaskLog(ctx context.Context, in *BuildsGetTaskLogOp) (*LogEntries, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Builds.GetTaskLog", in)
+	result, err := s.BuildsServer.GetTaskLog(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedBuildsServer) DequeueNext(ctx context.Context, in *BuildsDequeueNextOp) (*Build, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Builds.DequeueNext", in)
+	result, err := s.BuildsServer.DequeueNext(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedBuildsClient struct {
+	BuildsClient
+}
+
+func (s *cacheHitTracedBuildsClient) Get(ctx context.Context, in *BuildSpec, opts ...grpc.CallOption) (*Build, error) {
+	var result *Build
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.BuildsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedBuildsClient) GetRepoBuildInfo(ctx context.Context, in *BuildsGetRepoBuildInfoOp, opts ...grpc.CallOption) (*RepoBuildInfo, error) {
+	var result *RepoBuildInfo
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.BuildsClient.GetRepoBuildInfo(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedBuildsClient) List(ctx context.Context, in *BuildListOptions, opts ...grpc.CallOption) (*BuildList, error) {
+	var result *BuildList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.BuildsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedBuildsClient) Create(ctx context.Context, in *BuildsCreateOp, opts ...grpc.CallOption) (*Build, error) {
+	var result *Build
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.BuildsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedBuildsClient) Update(ctx context.Context, in *BuildsUpdateOp, opts ...grpc.CallOption) (*Build, error) {
+	var result *Build
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.BuildsClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedBuildsClient) ListBuildTasks(ctx context.Context, in *BuildsListBuildTasksOp, opts ...grpc.CallOption) (*BuildTaskList, error) {
+	var result *BuildTaskList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.BuildsClient.ListBuildTasks(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedBuildsClient) CreateTasks(ctx context.Context, in *BuildsCreateTasksOp, opts ...grpc.CallOption) (*BuildTaskList, error) {
+	var result *BuildTaskList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.BuildsClient.CreateTasks(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedBuildsClient) UpdateTask(ctx context.Context, in *BuildsUpdateTaskOp, opts ...grpc.CallOption) (*BuildTask, error) {
+	var result *BuildTask
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.BuildsClient.UpdateTask(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedBuildsClient) GetLog(ctx context.Context, in *BuildsGetLogOp, opts ...grpc.CallOption) (*LogEntries, error) {
+	var result *LogEntries
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.BuildsClient.GetLog(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedBuildsClient) GetTaskLog(ctx context.Context, in *BuildsGetTaskLogOp, opts ...grpc.CallOption) (*LogEntries, error) {
+	var result *LogEntries
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.BuildsClient.GetTaskLog(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedBuildsClient) DequeueNext(ctx context.Context, in *BuildsDequeueNextOp, opts ...grpc.CallOption) (*Build, error) {
+	var result *Build
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.BuildsClient.DequeueNext(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedBuildsClient struct {
+	BuildsClient
+}
+
+func (s *cacheMissTracedBuildsClient) Get(ctx context.Context, in *BuildSpec, opts ...grpc.CallOption) (*Build, error) {
+	traceCacheMiss(ctx)
+	return s.BuildsClient.Get(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedBuildsClient) GetRepoBuildInfo(ctx context.Context, in *BuildsGetRepoBuildInfoOp, opts ...grpc.CallOption) (*RepoBuildInfo, error) {
+	traceCacheMiss(ctx)
+	return s.BuildsClient.GetRepoBuildInfo(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedBuildsClient) List(ctx context.Context, in *BuildListOptions, opts ...grpc.CallOption) (*BuildList, error) {
+	traceCacheMiss(ctx)
+	return s.BuildsClient.List(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedBuildsClient) Create(ctx context.Context, in *BuildsCreateOp, opts ...grpc.CallOption) (*Build, error) {
+	traceCacheMiss(ctx)
+	return s.BuildsClient.Create(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedBuildsClient) Update(ctx context.Context, in *BuildsUpdateOp, opts ...grpc.CallOption) (*Build, error) {
+	traceCacheMiss(ctx)
+	return s.BuildsClient.Update(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedBuildsClient) ListBuildTasks(ctx context.Context, in *BuildsListBuildTasksOp, opts ...grpc.CallOption) (*BuildTaskList, error) {
+	traceCacheMiss(ctx)
+	return s.BuildsClient.ListBuildTasks(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedBuildsClient) CreateTasks(ctx context.Context, in *BuildsCreateTasksOp, opts ...grpc.CallOption) (*BuildTaskList, error) {
+	traceCacheMiss(ctx)
+	return s.BuildsClient.CreateTasks(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedBuildsClient) UpdateTask(ctx context.Context, in *BuildsUpdateTaskOp, opts ...grpc.CallOption) (*BuildTask, error) {
+	traceCacheMiss(ctx)
+	return s.BuildsClient.UpdateTask(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedBuildsClient) GetLog(ctx context.Context, in *BuildsGetLogOp, opts ...grpc.CallOption) (*LogEntries, error) {
+	traceCacheMiss(ctx)
+	return s.BuildsClient.GetLog(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedBuildsClient) GetTaskLog(ctx context.Context, in *BuildsGetTaskLogOp, opts ...grpc.CallOption) (*LogEntries, error) {
+	traceCacheMiss(ctx)
+	return s.BuildsClient.GetTaskLog(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedBuildsClient) DequeueNext(ctx context.Context, in *BuildsDequeueNextOp, opts ...grpc.CallOption) (*Build, error) {
+	traceCacheMiss(ctx)
+	return s.BuildsClient.DequeueNext(ctx, in, opts...)
+}
+
+type TracedChangesetsClient struct {
+	ChangesetsClient
+	Tracer *Tracer
+}
+
+func (s *TracedChangesetsClient) Create(ctx context.Context, in *ChangesetCreateOp, opts ...grpc.CallOption) (*Changeset, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Changesets.Create", in)
+	result, err := s.ChangesetsClient.Create(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsClient) Get(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (*Changeset, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Changesets.Get", in)
+	result, err := s.ChangesetsClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsClient) List(ctx context.Context, in *ChangesetListOp, opts ...grpc.CallOption) (*ChangesetList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Changesets.List", in)
+	result, err := s.ChangesetsClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsClient) Update(ctx context.Context, in *ChangesetUpdateOp, opts ...grpc.CallOption) (*ChangesetEvent, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Changesets.Update", in)
+	result, err := s.ChangesetsClient.Update(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsClient) Merge(ctx context.Context, in *ChangesetMergeOp, opts ...grpc.CallOption) (*ChangesetEvent, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Changesets.Merge", in)
+	result, err := s.ChangesetsClient.Merge(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsClient) UpdateAffected(ctx context.Context, in *ChangesetUpdateAffectedOp, opts ...grpc.CallOption) (*ChangesetEventList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Changesets.UpdateAffected", in)
+	result, err := s.ChangesetsClient.UpdateAffected(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsClient) CreateReview(ctx context.Context, in *ChangesetCreateReviewOp, opts ...grpc.CallOption) (*ChangesetReview, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Changesets.CreateReview", in)
+	result, err := s.ChangesetsClient.CreateReview(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsClient) ListReviews(ctx context.Context, in *ChangesetListReviewsOp, opts ...grpc.CallOption) (*ChangesetReviewList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Changesets.ListReviews", in)
+	result, err := s.ChangesetsClient.ListReviews(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsClient) ListEvents(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (*ChangesetEventList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Changesets.ListEvents", in)
+	result, err := s.ChangesetsClient.ListEvents(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedChangesetsServer struct {
+	ChangesetsServer
+	Tracer *Tracer
+}
+
+func (s *TracedChangesetsServer) Create(ctx context.Context, in *ChangesetCreateOp) (*Changeset, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Changesets.Create", in)
+	result, err := s.ChangesetsServer.Create(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsServer) Get(ctx context.Context, in *ChangesetSpec) (*Changeset, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Changesets.Get", in)
+	result, err := s.ChangesetsServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsServer) List(ctx context.Context, in *ChangesetListOp) (*ChangesetList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Changesets.List", in)
+	result, err := s.ChangesetsServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsServer) Update(ctx context.Context, in *ChangesetUpdateOp) (*ChangesetEvent, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Changesets.Update", in)
+	result, err := s.ChangesetsServer.Update(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsServer) Merge(ctx context.Context, in *ChangesetMergeOp) (*ChangesetEvent, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Changesets.Merge", in)
+	result, err := s.ChangesetsServer.Merge(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsServer) UpdateAffected(ctx context.Context, in *ChangesetUpdateAffectedOp) (*ChangesetEventList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Changesets.UpdateAffected", in)
+	result, err := s.ChangesetsServer.UpdateAffected(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsServer) CreateReview(ctx context.Context, in *ChangesetCreateReviewOp) (*ChangesetReview, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Changesets.CreateReview", in)
+	result, err := s.ChangesetsServer.CreateReview(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsServer) ListReviews(ctx context.Context, in *ChangesetListReviewsOp) (*ChangesetReviewList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Changesets.ListReviews", in)
+	result, err := s.ChangesetsServer.ListReviews(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedChangesetsServer) ListEvents(ctx context.Context, in *ChangesetSpec) (*ChangesetEventList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Changesets.ListEvents", in)
+	result, err := s.ChangesetsServer.ListEvents(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedChangesetsClient struct {
+	ChangesetsClient
+}
+
+func (s *cacheHitTracedChangesetsClient) Create(ctx context.Context, in *ChangesetCreateOp, opts ...grpc.CallOption) (*Changeset, error) {
+	var result *Changeset
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ChangesetsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedChangesetsClient) Get(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (*Changeset, error) {
+	var result *Changeset
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ChangesetsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedChangesetsClient) List(ctx context.Context, in *ChangesetListOp, opts ...grpc.CallOption) (*ChangesetList, error) {
+	var result *ChangesetList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ChangesetsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedChangesetsClient) Update(ctx context.Context, in *ChangesetUpdateOp, opts ...grpc.CallOption) (*ChangesetEvent, error) {
+	var result *ChangesetEvent
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ChangesetsClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedChangesetsClient) Merge(ctx context.Context, in *ChangesetMergeOp, opts ...grpc.CallOption) (*ChangesetEvent, error) {
+	var result *ChangesetEvent
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ChangesetsClient.Merge(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedChangesetsClient) UpdateAffected(ctx context.Context, in *ChangesetUpdateAffectedOp, opts ...grpc.CallOption) (*ChangesetEventList, error) {
+	var result *ChangesetEventList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ChangesetsClient.UpdateAffected(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedChangesetsClient) CreateReview(ctx context.Context, in *ChangesetCreateReviewOp, opts ...grpc.CallOption) (*ChangesetReview, error) {
+	var result *ChangesetReview
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ChangesetsClient.CreateReview(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedChangesetsClient) ListReviews(ctx context.Context, in *ChangesetListReviewsOp, opts ...grpc.CallOption) (*ChangesetReviewList, error) {
+	var result *ChangesetReviewList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ChangesetsClient.ListReviews(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedChangesetsClient) ListEvents(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (*ChangesetEventList, error) {
+	var result *ChangesetEventList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ChangesetsClient.ListEvents(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedChangesetsClient struct {
+	ChangesetsClient
+}
+
+func (s *cacheMissTracedChangesetsClient) Create(ctx context.Context, in *ChangesetCreateOp, opts ...grpc.CallOption) (*Changeset, error) {
+	traceCacheMiss(ctx)
+	return s.ChangesetsClient.Create(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedChangesetsClient) Get(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (*Changeset, error) {
+	traceCacheMiss(ctx)
+	return s.ChangesetsClient.Get(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedChangesetsClient) List(ctx context.Context, in *ChangesetListOp, opts ...grpc.CallOption) (*ChangesetList, error) {
+	traceCacheMiss(ctx)
+	return s.ChangesetsClient.List(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedChangesetsClient) Update(ctx context.Context, in *ChangesetUpdateOp, opts ...grpc.CallOption) (*ChangesetEvent, error) {
+	traceCacheMiss(ctx)
+	return s.ChangesetsClient.Update(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedChangesetsClient) Merge(ctx context.Context, in *ChangesetMergeOp, opts ...grpc.CallOption) (*ChangesetEvent, error) {
+	traceCacheMiss(ctx)
+	return s.ChangesetsClient.Merge(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedChangesetsClient) UpdateAffected(ctx context.Context, in *ChangesetUpdateAffectedOp, opts ...grpc.CallOption) (*ChangesetEventList, error) {
+	traceCacheMiss(ctx)
+	return s.ChangesetsClient.UpdateAffected(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedChangesetsClient) CreateReview(ctx context.Context, in *ChangesetCreateReviewOp, opts ...grpc.CallOption) (*ChangesetReview, error) {
+	traceCacheMiss(ctx)
+	return s.ChangesetsClient.CreateReview(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedChangesetsClient) ListReviews(ctx context.Context, in *ChangesetListReviewsOp, opts ...grpc.CallOption) (*ChangesetReviewList, error) {
+	traceCacheMiss(ctx)
+	return s.ChangesetsClient.ListReviews(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedChangesetsClient) ListEvents(ctx context.Context, in *ChangesetSpec, opts ...grpc.CallOption) (*ChangesetEventList, error) {
+	traceCacheMiss(ctx)
+	return s.ChangesetsClient.ListEvents(ctx, in, opts...)
+}
+
+type TracedDefsClient struct {
+	DefsClient
+	Tracer *Tracer
+}
+
+func (s *TracedDefsClient) Get(ctx context.Context, in *DefsGetOp, opts ...grpc.CallOption) (*Def, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Defs.Get", in)
+	result, err := s.DefsClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDefsClient) List(ctx context.Context, in *DefListOptions, opts ...grpc.CallOption) (*DefList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Defs.List", in)
+	result, err := s.DefsClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDefsClient) ListRefs(ctx context.Context, in *DefsListRefsOp, opts ...grpc.CallOption) (*RefList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Defs.ListRefs", in)
+	result, err := s.DefsClient.ListRefs(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDefsClient) ListExamples(ctx context.Context, in *DefsListExamplesOp, opts ...grpc.CallOption) (*ExampleList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Defs.ListExamples", in)
+	result, err := s.DefsClient.ListExamples(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDefsClient) ListAuthors(ctx context.Context, in *DefsListAuthorsOp, opts ...grpc.CallOption) (*DefAuthorList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Defs.ListAuthors", in)
+	result, err := s.DefsClient.ListAuthors(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDefsClient) ListClients(ctx context.Context, in *DefsListClientsOp, opts ...grpc.CallOption) (*DefClientList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Defs.ListClients", in)
+	result, err := s.DefsClient.ListClients(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedDefsServer struct {
+	DefsServer
+	Tracer *Tracer
+}
+
+func (s *TracedDefsServer) Get(ctx context.Context, in *DefsGetOp) (*Def, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Defs.Get", in)
+	result, err := s.DefsServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDefsServer) List(ctx context.Context, in *DefListOptions) (*DefList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Defs.List", in)
+	result, err := s.DefsServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDefsServer) ListRefs(ctx context.Context, in *DefsListRefsOp) (*RefList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Defs.ListRefs", in)
+	result, err := s.DefsServer.ListRefs(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDefsServer) ListExamples(ctx context.Context, in *DefsListExamplesOp) (*ExampleList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Defs.ListExamples", in)
+	result, err := s.DefsServer.ListExamples(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDefsServer) ListAuthors(ctx context.Context, in *DefsListAuthorsOp) (*DefAuthorList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Defs.ListAuthors", in)
+	result, err := s.DefsServer.ListAuthors(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDefsServer) ListClients(ctx context.Context, in *DefsListClientsOp) (*DefClientList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Defs.ListClients", in)
+	result, err := s.DefsServer.ListClients(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedDefsClient struct {
+	DefsClient
+}
+
+func (s *cacheHitTracedDefsClient) Get(ctx context.Context, in *DefsGetOp, opts ...grpc.CallOption) (*Def, error) {
+	var result *Def
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DefsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDefsClient) List(ctx context.Context, in *DefListOptions, opts ...grpc.CallOption) (*DefList, error) {
+	var result *DefList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DefsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDefsClient) ListRefs(ctx context.Context, in *DefsListRefsOp, opts ...grpc.CallOption) (*RefList, error) {
+	var result *RefList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DefsClient.ListRefs(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDefsClient) ListExamples(ctx context.Context, in *DefsListExamplesOp, opts ...grpc.CallOption) (*ExampleList, error) {
+	var result *ExampleList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DefsClient.ListExamples(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDefsClient) ListAuthors(ctx context.Context, in *DefsListAuthorsOp, opts ...grpc.CallOption) (*DefAuthorList, error) {
+	var result *DefAuthorList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DefsClient.ListAuthors(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDefsClient) ListClients(ctx context.Context, in *DefsListClientsOp, opts ...grpc.CallOption) (*DefClientList, error) {
+	var result *DefClientList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DefsClient.ListClients(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedDefsClient struct {
+	DefsClient
+}
+
+func (s *cacheMissTracedDefsClient) Get(ctx context.Context, in *DefsGetOp, opts ...grpc.CallOption) (*Def, error) {
+	traceCacheMiss(ctx)
+	return s.DefsClient.Get(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDefsClient) List(ctx context.Context, in *DefListOptions, opts ...grpc.CallOption) (*DefList, error) {
+	traceCacheMiss(ctx)
+	return s.DefsClient.List(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDefsClient) ListRefs(ctx context.Context, in *DefsListRefsOp, opts ...grpc.CallOption) (*RefList, error) {
+	traceCacheMiss(ctx)
+	return s.DefsClient.ListRefs(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDefsClient) ListExamples(ctx context.Context, in *DefsListExamplesOp, opts ...grpc.CallOption) (*ExampleList, error) {
+	traceCacheMiss(ctx)
+	return s.DefsClient.ListExamples(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDefsClient) ListAuthors(ctx context.Context, in *DefsListAuthorsOp, opts ...grpc.CallOption) (*DefAuthorList, error) {
+	traceCacheMiss(ctx)
+	return s.DefsClient.ListAuthors(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDefsClient) ListClients(ctx context.Context, in *DefsListClientsOp, opts ...grpc.CallOption) (*DefClientList, error) {
+	traceCacheMiss(ctx)
+	return s.DefsClient.ListClients(ctx, in, opts...)
+}
+
+type TracedDeltasClient struct {
+	DeltasClient
+	Tracer *Tracer
+}
+
+func (s *TracedDeltasClient) Get(ctx context.Context, in *DeltaSpec, opts ...grpc.CallOption) (*Delta, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Deltas.Get", in)
+	result, err := s.DeltasClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDeltasClient) ListUnits(ctx context.Context, in *DeltasListUnitsOp, opts ...grpc.CallOption) (*UnitDeltaList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Deltas.ListUnits", in)
+	result, err := s.DeltasClient.ListUnits(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDeltasClient) ListDefs(ctx context.Context, in *DeltasListDefsOp, opts ...grpc.CallOption) (*DeltaDefs, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Deltas.ListDefs", in)
+	result, err := s.DeltasClient.ListDefs(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDeltasClient) ListFiles(ctx context.Context, in *DeltasListFilesOp, opts ...grpc.CallOption) (*DeltaFiles, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Deltas.ListFiles", in)
+	result, err := s.DeltasClient.ListFiles(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDeltasClient) ListAffectedAuthors(ctx context.Context, in *DeltasListAffectedAuthorsOp, opts ...grpc.CallOption) (*DeltaAffectedPersonList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Deltas.ListAffectedAuthors", in)
+	result, err := s.DeltasClient.ListAffectedAuthors(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDeltasClient) ListAffectedClients(ctx context.Context, in *DeltasListAffectedClientsOp, opts ...grpc.CallOption) (*DeltaAffectedPersonList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Deltas.ListAffectedClients", in)
+	result, err := s.DeltasClient.ListAffectedClients(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedDeltasServer struct {
+	DeltasServer
+	Tracer *Tracer
+}
+
+func (s *TracedDeltasServer) Get(ctx context.Context, in *DeltaSpec) (*Delta, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Deltas.Get", in)
+	result, err := s.DeltasServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDeltasServer) ListUnits(ctx context.Context, in *DeltasListUnitsOp) (*UnitDeltaList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Deltas.ListUnits", in)
+	result, err := s.DeltasServer.ListUnits(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDeltasServer) ListDefs(ctx context.Context, in *DeltasListDefsOp) (*DeltaDefs, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Deltas.ListDefs", in)
+	result, err := s.DeltasServer.ListDefs(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDeltasServer) ListFiles(ctx context.Context, in *DeltasListFilesOp) (*DeltaFiles, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Deltas.ListFiles", in)
+	result, err := s.DeltasServer.ListFiles(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDeltasServer) ListAffectedAuthors(ctx context.Context, in *DeltasListAffectedAuthorsOp) (*DeltaAffectedPersonList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Deltas.ListAffectedAuthors", in)
+	result, err := s.DeltasServer.ListAffectedAuthors(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDeltasServer) ListAffectedClients(ctx context.Context, in *DeltasListAffectedClientsOp) (*DeltaAffectedPersonList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Deltas.ListAffectedClients", in)
+	result, err := s.DeltasServer.ListAffectedClients(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedDeltasClient struct {
+	DeltasClient
+}
+
+func (s *cacheHitTracedDeltasClient) Get(ctx context.Context, in *DeltaSpec, opts ...grpc.CallOption) (*Delta, error) {
+	var result *Delta
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DeltasClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDeltasClient) ListUnits(ctx context.Context, in *DeltasListUnitsOp, opts ...grpc.CallOption) (*UnitDeltaList, error) {
+	var result *UnitDeltaList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DeltasClient.ListUnits(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDeltasClient) ListDefs(ctx context.Context, in *DeltasListDefsOp, opts ...grpc.CallOption) (*DeltaDefs, error) {
+	var result *DeltaDefs
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DeltasClient.ListDefs(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDeltasClient) ListFiles(ctx context.Context, in *DeltasListFilesOp, opts ...grpc.CallOption) (*DeltaFiles, error) {
+	var result *DeltaFiles
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DeltasClient.ListFiles(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDeltasClient) ListAffectedAuthors(ctx context.Context, in *DeltasListAffectedAuthorsOp, opts ...grpc.CallOption) (*DeltaAffectedPersonList, error) {
+	var result *DeltaAffectedPersonList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DeltasClient.ListAffectedAuthors(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDeltasClient) ListAffectedClients(ctx context.Context, in *DeltasListAffectedClientsOp, opts ...grpc.CallOption) (*DeltaAffectedPersonList, error) {
+	var result *DeltaAffectedPersonList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DeltasClient.ListAffectedClients(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedDeltasClient struct {
+	DeltasClient
+}
+
+func (s *cacheMissTracedDeltasClient) Get(ctx context.Context, in *DeltaSpec, opts ...grpc.CallOption) (*Delta, error) {
+	traceCacheMiss(ctx)
+	return s.DeltasClient.Get(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDeltasClient) ListUnits(ctx context.Context, in *DeltasListUnitsOp, opts ...grpc.CallOption) (*UnitDeltaList, error) {
+	traceCacheMiss(ctx)
+	return s.DeltasClient.ListUnits(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDeltasClient) ListDefs(ctx context.Context, in *DeltasListDefsOp, opts ...grpc.CallOption) (*DeltaDefs, error) {
+	traceCacheMiss(ctx)
+	return s.DeltasClient.ListDefs(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDeltasClient) ListFiles(ctx context.Context, in *DeltasListFilesOp, opts ...grpc.CallOption) (*DeltaFiles, error) {
+	traceCacheMiss(ctx)
+	return s.DeltasClient.ListFiles(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDeltasClient) ListAffectedAuthors(ctx context.Context, in *DeltasListAffectedAuthorsOp, opts ...grpc.CallOption) (*DeltaAffectedPersonList, error) {
+	traceCacheMiss(ctx)
+	return s.DeltasClient.ListAffectedAuthors(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDeltasClient) ListAffectedClients(ctx context.Context, in *DeltasListAffectedClientsOp, opts ...grpc.CallOption) (*DeltaAffectedPersonList, error) {
+	traceCacheMiss(ctx)
+	return s.DeltasClient.ListAffectedClients(ctx, in, opts...)
+}
+
+type TracedDiscussionsClient struct {
+	DiscussionsClient
+	Tracer *Tracer
+}
+
+func (s *TracedDiscussionsClient) Create(ctx context.Context, in *Discussion, opts ...grpc.CallOption) (*Discussion, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Discussions.Create", in)
+	result, err := s.DiscussionsClient.Create(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDiscussionsClient) Get(ctx context.Context, in *DiscussionSpec, opts ...grpc.CallOption) (*Discussion, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Discussions.Get", in)
+	result, err := s.DiscussionsClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDiscussionsClient) List(ctx context.Context, in *DiscussionListOp, opts ...grpc.CallOption) (*DiscussionList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Discussions.List", in)
+	result, err := s.DiscussionsClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDiscussionsClient) CreateComment(ctx context.Context, in *DiscussionCommentCreateOp, opts ...grpc.CallOption) (*DiscussionComment, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Discussions.CreateComment", in)
+	result, err := s.DiscussionsClient.CreateComment(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDiscussionsClient) UpdateRating(ctx context.Context, in *DiscussionRatingUpdateOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Discussions.UpdateRating", in)
+	result, err := s.DiscussionsClient.UpdateRating(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedDiscussionsServer struct {
+	DiscussionsServer
+	Tracer *Tracer
+}
+
+func (s *TracedDiscussionsServer) Create(ctx context.Context, in *Discussion) (*Discussion, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Discussions.Create", in)
+	result, err := s.DiscussionsServer.Create(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDiscussionsServer) Get(ctx context.Context, in *DiscussionSpec) (*Discussion, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Discussions.Get", in)
+	result, err := s.DiscussionsServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDiscussionsServer) List(ctx context.Context, in *DiscussionListOp) (*DiscussionList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Discussions.List", in)
+	result, err := s.DiscussionsServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDiscussionsServer) CreateComment(ctx context.Context, in *DiscussionCommentCreateOp) (*DiscussionComment, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Discussions.CreateComment", in)
+	result, err := s.DiscussionsServer.CreateComment(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedDiscussionsServer) UpdateRating(ctx context.Context, in *DiscussionRatingUpdateOp) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Discussions.UpdateRating", in)
+	result, err := s.DiscussionsServer.UpdateRating(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedDiscussionsClient struct {
+	DiscussionsClient
+}
+
+func (s *cacheHitTracedDiscussionsClient) Create(ctx context.Context, in *Discussion, opts ...grpc.CallOption) (*Discussion, error) {
+	var result *Discussion
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DiscussionsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDiscussionsClient) Get(ctx context.Context, in *DiscussionSpec, opts ...grpc.CallOption) (*Discussion, error) {
+	var result *Discussion
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DiscussionsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDiscussionsClient) List(ctx context.Context, in *DiscussionListOp, opts ...grpc.CallOption) (*DiscussionList, error) {
+	var result *DiscussionList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DiscussionsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDiscussionsClient) CreateComment(ctx context.Context, in *DiscussionCommentCreateOp, opts ...grpc.CallOption) (*DiscussionComment, error) {
+	var result *DiscussionComment
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DiscussionsClient.CreateComment(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedDiscussionsClient) UpdateRating(ctx context.Context, in *DiscussionRatingUpdateOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.DiscussionsClient.UpdateRating(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedDiscussionsClient struct {
+	DiscussionsClient
+}
+
+func (s *cacheMissTracedDiscussionsClient) Create(ctx context.Context, in *Discussion, opts ...grpc.CallOption) (*Discussion, error) {
+	traceCacheMiss(ctx)
+	return s.DiscussionsClient.Create(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDiscussionsClient) Get(ctx context.Context, in *DiscussionSpec, opts ...grpc.CallOption) (*Discussion, error) {
+	traceCacheMiss(ctx)
+	return s.DiscussionsClient.Get(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDiscussionsClient) List(ctx context.Context, in *DiscussionListOp, opts ...grpc.CallOption) (*DiscussionList, error) {
+	traceCacheMiss(ctx)
+	return s.DiscussionsClient.List(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDiscussionsClient) CreateComment(ctx context.Context, in *DiscussionCommentCreateOp, opts ...grpc.CallOption) (*DiscussionComment, error) {
+	traceCacheMiss(ctx)
+	return s.DiscussionsClient.CreateComment(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedDiscussionsClient) UpdateRating(ctx context.Context, in *DiscussionRatingUpdateOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.DiscussionsClient.UpdateRating(ctx, in, opts...)
+}
+
+type TracedGraphUplinkClient struct {
+	GraphUplinkClient
+	Tracer *Tracer
+}
+
+func (s *TracedGraphUplinkClient) Push(ctx context.Context, in *MetricsSnapshot, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "GraphUplink.Push", in)
+	result, err := s.GraphUplinkClient.Push(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedGraphUplinkClient) PushEvents(ctx context.Context, in *UserEventList, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "GraphUplink.PushEvents", in)
+	result, err := s.GraphUplinkClient.PushEvents(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedGraphUplinkServer struct {
+	GraphUplinkServer
+	Tracer *Tracer
+}
+
+func (s *TracedGraphUplinkServer) Push(ctx context.Context, in *MetricsSnapshot) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "GraphUplink.Push", in)
+	result, err := s.GraphUplinkServer.Push(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedGraphUplinkServer) PushEvents(ctx context.Context, in *UserEventList) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "GraphUplink.PushEvents", in)
+	result, err := s.GraphUplinkServer.PushEvents(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedGraphUplinkClient struct {
+	GraphUplinkClient
+}
+
+func (s *cacheHitTracedGraphUplinkClient) Push(ctx context.Context, in *MetricsSnapshot, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.GraphUplinkClient.Push(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedGraphUplinkClient) PushEvents(ctx context.Context, in *UserEventList, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.GraphUplinkClient.PushEvents(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedGraphUplinkClient struct {
+	GraphUplinkClient
+}
+
+func (s *cacheMissTracedGraphUplinkClient) Push(ctx context.Context, in *MetricsSnapshot, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.GraphUplinkClient.Push(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedGraphUplinkClient) PushEvents(ctx context.Context, in *UserEventList, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.GraphUplinkClient.PushEvents(ctx, in, opts...)
+}
+
+type TracedMarkdownClient struct {
+	MarkdownClient
+	Tracer *Tracer
+}
+
+func (s *TracedMarkdownClient) Render(ctx context.Context, in *MarkdownRenderOp, opts ...grpc.CallOption) (*MarkdownData, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Markdown.Render", in)
+	result, err := s.MarkdownClient.Render(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedMarkdownServer struct {
+	MarkdownServer
+	Tracer *Tracer
+}
+
+func (s *TracedMarkdownServer) Render(ctx context.Context, in *MarkdownRenderOp) (*MarkdownData, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Markdown.Render", in)
+	result, err := s.MarkdownServer.Render(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedMarkdownClient struct {
+	MarkdownClient
+}
+
+func (s *cacheHitTracedMarkdownClient) Render(ctx context.Context, in *MarkdownRenderOp, opts ...grpc.CallOption) (*MarkdownData, error) {
+	var result *MarkdownData
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.MarkdownClient.Render(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedMarkdownClient struct {
+	MarkdownClient
+}
+
+func (s *cacheMissTracedMarkdownClient) Render(ctx context.Context, in *MarkdownRenderOp, opts ...grpc.CallOption) (*MarkdownData, error) {
+	traceCacheMiss(ctx)
+	return s.MarkdownClient.Render(ctx, in, opts...)
+}
+
+type TracedMetaClient struct {
+	MetaClient
+	Tracer *Tracer
+}
+
+func (s *TracedMetaClient) Status(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerStatus, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Meta.Status", in)
+	result, err := s.MetaClient.Status(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedMetaClient) Config(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerConfig, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Meta.Config", in)
+	result, err := s.MetaClient.Config(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedMetaClient) PubKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerPubKey, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Meta.PubKey", in)
+	result, err := s.MetaClient.PubKey(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedMetaServer struct {
+	MetaServer
+	Tracer *Tracer
+}
+
+func (s *TracedMetaServer) Status(ctx context.Context, in *pbtypes.Void) (*ServerStatus, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Meta.Status", in)
+	result, err := s.MetaServer.Status(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedMetaServer) Config(ctx context.Context, in *pbtypes.Void) (*ServerConfig, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Meta.Config", in)
+	result, err := s.MetaServer.Config(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedMetaServer) PubKey(ctx context.Context, in *pbtypes.Void) (*ServerPubKey, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Meta.PubKey", in)
+	result, err := s.MetaServer.PubKey(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedMetaClient struct {
+	MetaClient
+}
+
+func (s *cacheHitTracedMetaClient) Status(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerStatus, error) {
+	var result *ServerStatus
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.MetaClient.Status(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedMetaClient) Config(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerConfig, error) {
+	var result *ServerConfig
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.MetaClient.Config(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedMetaClient) PubKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerPubKey, error) {
+	var result *ServerPubKey
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.MetaClient.PubKey(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedMetaClient struct {
+	MetaClient
+}
+
+func (s *cacheMissTracedMetaClient) Status(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerStatus, error) {
+	traceCacheMiss(ctx)
+	return s.MetaClient.Status(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedMetaClient) Config(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerConfig, error) {
+	traceCacheMiss(ctx)
+	return s.MetaClient.Config(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedMetaClient) PubKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*ServerPubKey, error) {
+	traceCacheMiss(ctx)
+	return s.MetaClient.PubKey(ctx, in, opts...)
+}
+
+type TracedMirrorReposClient struct {
+	MirrorReposClient
+	Tracer *Tracer
+}
+
+func (s *TracedMirrorReposClient) RefreshVCS(ctx context.Context, in *MirrorReposRefreshVCSOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "MirrorRepos.RefreshVCS", in)
+	result, err := s.MirrorReposClient.RefreshVCS(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedMirrorReposServer struct {
+	MirrorReposServer
+	Tracer *Tracer
+}
+
+func (s *TracedMirrorReposServer) RefreshVCS(ctx context.Context, in *MirrorReposRefreshVCSOp) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "MirrorRepos.RefreshVCS", in)
+	result, err := s.MirrorReposServer.RefreshVCS(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedMirrorReposClient struct {
+	MirrorReposClient
+}
+
+func (s *cacheHitTracedMirrorReposClient) RefreshVCS(ctx context.Context, in *MirrorReposRefreshVCSOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.MirrorReposClient.RefreshVCS(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedMirrorReposClient struct {
+	MirrorReposClient
+}
+
+func (s *cacheMissTracedMirrorReposClient) RefreshVCS(ctx context.Context, in *MirrorReposRefreshVCSOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.MirrorReposClient.RefreshVCS(ctx, in, opts...)
+}
+
+type TracedMirroredRepoSSHKeysClient struct {
+	MirroredRepoSSHKeysClient
+	Tracer *Tracer
+}
+
+func (s *TracedMirroredRepoSSHKeysClient) Create(ctx context.Context, in *MirroredRepoSSHKeysCreateOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "MirroredRepoSSHKeys.Create", in)
+	result, err := s.MirroredRepoSSHKeysClient.Create(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedMirroredRepoSSHKeysClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*SSHPrivateKey, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "MirroredRepoSSHKeys.Get", in)
+	result, err := s.MirroredRepoSSHKeysClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedMirroredRepoSSHKeysClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "MirroredRepoSSHKeys.Delete", in)
+	result, err := s.MirroredRepoSSHKeysClient.Delete(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedMirroredRepoSSHKeysServer struct {
+	MirroredRepoSSHKeysServer
+	Tracer *Tracer
+}
+
+func (s *TracedMirroredRepoSSHKeysServer) Create(ctx context.Context, in *MirroredRepoSSHKeysCreateOp) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "MirroredRepoSSHKeys.Create", in)
+	result, err := s.MirroredRepoSSHKeysServer.Create(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedMirroredRepoSSHKeysServer) Get(ctx context.Context, in *RepoSpec) (*SSHPrivateKey, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "MirroredRepoSSHKeys.Get", in)
+	result, err := s.MirroredRepoSSHKeysServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedMirroredRepoSSHKeysServer) Delete(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "MirroredRepoSSHKeys.Delete", in)
+	result, err := s.MirroredRepoSSHKeysServer.Delete(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedMirroredRepoSSHKeysClient struct {
+	MirroredRepoSSHKeysClient
+}
+
+func (s *cacheHitTracedMirroredRepoSSHKeysClient) Create(ctx context.Context, in *MirroredRepoSSHKeysCreateOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.MirroredRepoSSHKeysClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedMirroredRepoSSHKeysClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*SSHPrivateKey, error) {
+	var result *SSHPrivateKey
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.MirroredRepoSSHKeysClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedMirroredRepoSSHKeysClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.MirroredRepoSSHKeysClient.Delete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedMirroredRepoSSHKeysClient struct {
+	MirroredRepoSSHKeysClient
+}
+
+func (s *cacheMissTracedMirroredRepoSSHKeysClient) Create(ctx context.Context, in *MirroredRepoSSHKeysCreateOp, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.MirroredRepoSSHKeysClient.Create(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedMirroredRepoSSHKeysClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*SSHPrivateKey, error) {
+	traceCacheMiss(ctx)
+	return s.MirroredRepoSSHKeysClient.Get(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedMirroredRepoSSHKeysClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.MirroredRepoSSHKeysClient.Delete(ctx, in, opts...)
+}
+
+type TracedNotifyClient struct {
+	NotifyClient
+	Tracer *Tracer
+}
+
+func (s *TracedNotifyClient) GenericEvent(ctx context.Context, in *NotifyGenericEvent, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Notify.GenericEvent", in)
+	result, err := s.NotifyClient.GenericEvent(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedNotifyServer struct {
+	NotifyServer
+	Tracer *Tracer
+}
+
+func (s *TracedNotifyServer) GenericEvent(ctx context.Context, in *NotifyGenericEvent) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Notify.GenericEvent", in)
+	result, err := s.NotifyServer.GenericEvent(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedNotifyClient struct {
+	NotifyClient
+}
+
+func (s *cacheHitTracedNotifyClient) GenericEvent(ctx context.Context, in *NotifyGenericEvent, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.NotifyClient.GenericEvent(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedNotifyClient struct {
+	NotifyClient
+}
+
+func (s *cacheMissTracedNotifyClient) GenericEvent(ctx context.Context, in *NotifyGenericEvent, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.NotifyClient.GenericEvent(ctx, in, opts...)
+}
+
+type TracedOrgsClient struct {
+	OrgsClient
+	Tracer *Tracer
+}
+
+func (s *TracedOrgsClient) Get(ctx context.Context, in *OrgSpec, opts ...grpc.CallOption) (*Org, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Orgs.Get", in)
+	result, err := s.OrgsClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedOrgsClient) List(ctx context.Context, in *OrgsListOp, opts ...grpc.CallOption) (*OrgList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Orgs.List", in)
+	result, err := s.OrgsClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedOrgsClient) ListMembers(ctx context.Context, in *OrgsListMembersOp, opts ...grpc.CallOption) (*UserList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Orgs.ListMembers", in)
+	result, err := s.OrgsClient.ListMembers(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedOrgsServer struct {
+	OrgsServer
+	Tracer *Tracer
+}
+
+func (s *TracedOrgsServer) Get(ctx context.Context, in *OrgSpec) (*Org, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Orgs.Get", in)
+	result, err := s.OrgsServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedOrgsServer) List(ctx context.Context, in *OrgsListOp) (*OrgList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Orgs.List", in)
+	result, err := s.OrgsServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedOrgsServer) ListMembers(ctx context.Context, in *OrgsListMembersOp) (*UserList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Orgs.ListMembers", in)
+	result, err := s.OrgsServer.ListMembers(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedOrgsClient struct {
+	OrgsClient
+}
+
+func (s *cacheHitTracedOrgsClient) Get(ctx context.Context, in *OrgSpec, opts ...grpc.CallOption) (*Org, error) {
+	var result *Org
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.OrgsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedOrgsClient) List(ctx context.Context, in *OrgsListOp, opts ...grpc.CallOption) (*OrgList, error) {
+	var result *OrgList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.OrgsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedOrgsClient) ListMembers(ctx context.Context, in *OrgsListMembersOp, opts ...grpc.CallOption) (*UserList, error) {
+	var result *UserList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.OrgsClient.ListMembers(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedOrgsClient struct {
+	OrgsClient
+}
+
+func (s *cacheMissTracedOrgsClient) Get(ctx context.Context, in *OrgSpec, opts ...grpc.CallOption) (*Org, error) {
+	traceCacheMiss(ctx)
+	return s.OrgsClient.Get(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedOrgsClient) List(ctx context.Context, in *OrgsListOp, opts ...grpc.CallOption) (*OrgList, error) {
+	traceCacheMiss(ctx)
+	return s.OrgsClient.List(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedOrgsClient) ListMembers(ctx context.Context, in *OrgsListMembersOp, opts ...grpc.CallOption) (*UserList, error) {
+	traceCacheMiss(ctx)
+	return s.OrgsClient.ListMembers(ctx, in, opts...)
+}
+
+type TracedPeopleClient struct {
+	PeopleClient
+	Tracer *Tracer
+}
+
+func (s *TracedPeopleClient) Get(ctx context.Context, in *PersonSpec, opts ...grpc.CallOption) (*Person, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "People.Get", in)
+	result, err := s.PeopleClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedPeopleServer struct {
+	PeopleServer
+	Tracer *Tracer
+}
+
+func (s *TracedPeopleServer) Get(ctx context.Context, in *PersonSpec) (*Person, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "People.Get", in)
+	result, err := s.PeopleServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedPeopleClient struct {
+	PeopleClient
+}
+
+func (s *cacheHitTracedPeopleClient) Get(ctx context.Context, in *PersonSpec, opts ...grpc.CallOption) (*Person, error) {
+	var result *Person
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.PeopleClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedPeopleClient struct {
+	PeopleClient
+}
+
+func (s *cacheMissTracedPeopleClient) Get(ctx context.Context, in *PersonSpec, opts ...grpc.CallOption) (*Person, error) {
+	traceCacheMiss(ctx)
+	return s.PeopleClient.Get(ctx, in, opts...)
+}
+
+type TracedRegisteredClientsClient struct {
+	RegisteredClientsClient
+	Tracer *Tracer
+}
+
+func (s *TracedRegisteredClientsClient) Get(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RegisteredClients.Get", in)
+	result, err := s.RegisteredClientsClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsClient) GetCurrent(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RegisteredClients.GetCurrent", in)
+	result, err := s.RegisteredClientsClient.GetCurrent(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsClient) Create(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RegisteredClients.Create", in)
+	result, err := s.RegisteredClientsClient.Create(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsClient) Update(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RegisteredClients.Update", in)
+	result, err := s.RegisteredClientsClient.Update(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsClient) Delete(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RegisteredClients.Delete", in)
+	result, err := s.RegisteredClientsClient.Delete(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsClient) List(ctx context.Context, in *RegisteredClientListOptions, opts ...grpc.CallOption) (*RegisteredClientList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RegisteredClients.List", in)
+	result, err := s.RegisteredClientsClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsClient) GetUserPermissions(ctx context.Context, in *UserPermissionsOptions, opts ...grpc.CallOption) (*UserPermissions, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RegisteredClients.GetUserPermissions", in)
+	result, err := s.RegisteredClientsClient.GetUserPermissions(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsClient) SetUserPermissions(ctx context.Context, in *UserPermissions, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RegisteredClients.SetUserPermissions", in)
+	result, err := s.RegisteredClientsClient.SetUserPermissions(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsClient) ListUserPermissions(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*UserPermissionsList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RegisteredClients.ListUserPermissions", in)
+	result, err := s.RegisteredClientsClient.ListUserPermissions(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedRegisteredClientsServer struct {
+	RegisteredClientsServer
+	Tracer *Tracer
+}
+
+func (s *TracedRegisteredClientsServer) Get(ctx context.Context, in *RegisteredClientSpec) (*RegisteredClient, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RegisteredClients.Get", in)
+	result, err := s.RegisteredClientsServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsServer) GetCurrent(ctx context.Context, in *pbtypes.Void) (*RegisteredClient, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RegisteredClients.GetCurrent", in)
+	result, err := s.RegisteredClientsServer.GetCurrent(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsServer) Create(ctx context.Context, in *RegisteredClient) (*RegisteredClient, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RegisteredClients.Create", in)
+	result, err := s.RegisteredClientsServer.Create(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsServer) Update(ctx context.Context, in *RegisteredClient) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RegisteredClients.Update", in)
+	result, err := s.RegisteredClientsServer.Update(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsServer) Delete(ctx context.Context, in *RegisteredClientSpec) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RegisteredClients.Delete", in)
+	result, err := s.RegisteredClientsServer.Delete(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsServer) List(ctx context.Context, in *RegisteredClientListOptions) (*RegisteredClientList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RegisteredClients.List", in)
+	result, err := s.RegisteredClientsServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsServer) GetUserPermissions(ctx context.Context, in *UserPermissionsOptions) (*UserPermissions, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RegisteredClients.GetUserPermissions", in)
+	result, err := s.RegisteredClientsServer.GetUserPermissions(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsServer) SetUserPermissions(ctx context.Context, in *UserPermissions) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RegisteredClients.SetUserPermissions", in)
+	result, err := s.RegisteredClientsServer.SetUserPermissions(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRegisteredClientsServer) ListUserPermissions(ctx context.Context, in *RegisteredClientSpec) (*UserPermissionsList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RegisteredClients.ListUserPermissions", in)
+	result, err := s.RegisteredClientsServer.ListUserPermissions(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedRegisteredClientsClient struct {
+	RegisteredClientsClient
+}
+
+func (s *cacheHitTracedRegisteredClientsClient) Get(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	var result *RegisteredClient
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RegisteredClientsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRegisteredClientsClient) GetCurrent(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	var result *RegisteredClient
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RegisteredClientsClient.GetCurrent(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRegisteredClientsClient) Create(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	var result *RegisteredClient
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RegisteredClientsClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRegisteredClientsClient) Update(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RegisteredClientsClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRegisteredClientsClient) Delete(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RegisteredClientsClient.Delete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRegisteredClientsClient) List(ctx context.Context, in *RegisteredClientListOptions, opts ...grpc.CallOption) (*RegisteredClientList, error) {
+	var result *RegisteredClientList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RegisteredClientsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRegisteredClientsClient) GetUserPermissions(ctx context.Context, in *UserPermissionsOptions, opts ...grpc.CallOption) (*UserPermissions, error) {
+	var result *UserPermissions
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RegisteredClientsClient.GetUserPermissions(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRegisteredClientsClient) SetUserPermissions(ctx context.Context, in *UserPermissions, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RegisteredClientsClient.SetUserPermissions(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRegisteredClientsClient) ListUserPermissions(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*UserPermissionsList, error) {
+	var result *UserPermissionsList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RegisteredClientsClient.ListUserPermissions(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedRegisteredClientsClient struct {
+	RegisteredClientsClient
+}
+
+func (s *cacheMissTracedRegisteredClientsClient) Get(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	traceCacheMiss(ctx)
+	return s.RegisteredClientsClient.Get(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRegisteredClientsClient) GetCurrent(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	traceCacheMiss(ctx)
+	return s.RegisteredClientsClient.GetCurrent(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRegisteredClientsClient) Create(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (*RegisteredClient, error) {
+	traceCacheMiss(ctx)
+	return s.RegisteredClientsClient.Create(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRegisteredClientsClient) Update(ctx context.Context, in *RegisteredClient, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.RegisteredClientsClient.Update(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRegisteredClientsClient) Delete(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.RegisteredClientsClient.Delete(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRegisteredClientsClient) List(ctx context.Context, in *RegisteredClientListOptions, opts ...grpc.CallOption) (*RegisteredClientList, error) {
+	traceCacheMiss(ctx)
+	return s.RegisteredClientsClient.List(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRegisteredClientsClient) GetUserPermissions(ctx context.Context, in *UserPermissionsOptions, opts ...grpc.CallOption) (*UserPermissions, error) {
+	traceCacheMiss(ctx)
+	return s.RegisteredClientsClient.GetUserPermissions(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRegisteredClientsClient) SetUserPermissions(ctx context.Context, in *UserPermissions, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.RegisteredClientsClient.SetUserPermissions(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRegisteredClientsClient) ListUserPermissions(ctx context.Context, in *RegisteredClientSpec, opts ...grpc.CallOption) (*UserPermissionsList, error) {
+	traceCacheMiss(ctx)
+	return s.RegisteredClientsClient.ListUserPermissions(ctx, in, opts...)
+}
+
+type TracedRepoBadgesClient struct {
+	RepoBadgesClient
+	Tracer *Tracer
+}
+
+func (s *TracedRepoBadgesClient) ListBadges(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*BadgeList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RepoBadges.ListBadges", in)
+	result, err := s.RepoBadgesClient.ListBadges(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRepoBadgesClient) ListCounters(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*CounterList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RepoBadges.ListCounters", in)
+	result, err := s.RepoBadgesClient.ListCounters(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRepoBadgesClient) RecordHit(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RepoBadges.RecordHit", in)
+	result, err := s.RepoBadgesClient.RecordHit(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRepoBadgesClient) CountHits(ctx context.Context, in *RepoBadgesCountHitsOp, opts ...grpc.CallOption) (*RepoBadgesCountHitsResult, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RepoBadges.CountHits", in)
+	result, err := s.RepoBadgesClient.CountHits(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedRepoBadgesServer struct {
+	RepoBadgesServer
+	Tracer *Tracer
+}
+
+func (s *TracedRepoBadgesServer) ListBadges(ctx context.Context, in *RepoSpec) (*BadgeList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RepoBadges.ListBadges", in)
+	result, err := s.RepoBadgesServer.ListBadges(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRepoBadgesServer) ListCounters(ctx context.Context, in *RepoSpec) (*CounterList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RepoBadges.ListCounters", in)
+	result, err := s.RepoBadgesServer.ListCounters(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRepoBadgesServer) RecordHit(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RepoBadges.RecordHit", in)
+	result, err := s.RepoBadgesServer.RecordHit(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRepoBadgesServer) CountHits(ctx context.Context, in *RepoBadgesCountHitsOp) (*RepoBadgesCountHitsResult, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RepoBadges.CountHits", in)
+	result, err := s.RepoBadgesServer.CountHits(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedRepoBadgesClient struct {
+	RepoBadgesClient
+}
+
+func (s *cacheHitTracedRepoBadgesClient) ListBadges(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*BadgeList, error) {
+	var result *BadgeList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RepoBadgesClient.ListBadges(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRepoBadgesClient) ListCounters(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*CounterList, error) {
+	var result *CounterList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RepoBadgesClient.ListCounters(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRepoBadgesClient) RecordHit(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RepoBadgesClient.RecordHit(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRepoBadgesClient) CountHits(ctx context.Context, in *RepoBadgesCountHitsOp, opts ...grpc.CallOption) (*RepoBadgesCountHitsResult, error) {
+	var result *RepoBadgesCountHitsResult
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RepoBadgesClient.CountHits(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedRepoBadgesClient struct {
+	RepoBadgesClient
+}
+
+func (s *cacheMissTracedRepoBadgesClient) ListBadges(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*BadgeList, error) {
+	traceCacheMiss(ctx)
+	return s.RepoBadgesClient.ListBadges(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRepoBadgesClient) ListCounters(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*CounterList, error) {
+	traceCacheMiss(ctx)
+	return s.RepoBadgesClient.ListCounters(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRepoBadgesClient) RecordHit(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.RepoBadgesClient.RecordHit(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRepoBadgesClient) CountHits(ctx context.Context, in *RepoBadgesCountHitsOp, opts ...grpc.CallOption) (*RepoBadgesCountHitsResult, error) {
+	traceCacheMiss(ctx)
+	return s.RepoBadgesClient.CountHits(ctx, in, opts...)
+}
+
+type TracedRepoStatusesClient struct {
+	RepoStatusesClient
+	Tracer *Tracer
+}
+
+func (s *TracedRepoStatusesClient) GetCombined(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*CombinedStatus, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RepoStatuses.GetCombined", in)
+	result, err := s.RepoStatusesClient.GetCombined(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRepoStatusesClient) Create(ctx context.Context, in *RepoStatusesCreateOp, opts ...grpc.CallOption) (*RepoStatus, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RepoStatuses.Create", in)
+	result, err := s.RepoStatusesClient.Create(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedRepoStatusesServer struct {
+	RepoStatusesServer
+	Tracer *Tracer
+}
+
+func (s *TracedRepoStatusesServer) GetCombined(ctx context.Context, in *RepoRevSpec) (*CombinedStatus, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RepoStatuses.GetCombined", in)
+	result, err := s.RepoStatusesServer.GetCombined(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRepoStatusesServer) Create(ctx context.Context, in *RepoStatusesCreateOp) (*RepoStatus, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RepoStatuses.Create", in)
+	result, err := s.RepoStatusesServer.Create(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedRepoStatusesClient struct {
+	RepoStatusesClient
+}
+
+func (s *cacheHitTracedRepoStatusesClient) GetCombined(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*CombinedStatus, error) {
+	var result *CombinedStatus
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RepoStatusesClient.GetCombined(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRepoStatusesClient) Create(ctx context.Context, in *RepoStatusesCreateOp, opts ...grpc.CallOption) (*RepoStatus, error) {
+	var result *RepoStatus
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RepoStatusesClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedRepoStatusesClient struct {
+	RepoStatusesClient
+}
+
+func (s *cacheMissTracedRepoStatusesClient) GetCombined(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*CombinedStatus, error) {
+	traceCacheMiss(ctx)
+	return s.RepoStatusesClient.GetCombined(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRepoStatusesClient) Create(ctx context.Context, in *RepoStatusesCreateOp, opts ...grpc.CallOption) (*RepoStatus, error) {
+	traceCacheMiss(ctx)
+	return s.RepoStatusesClient.Create(ctx, in, opts...)
+}
+
+type TracedRepoTreeClient struct {
+	RepoTreeClient
+	Tracer *Tracer
+}
+
+func (s *TracedRepoTreeClient) Get(ctx context.Context, in *RepoTreeGetOp, opts ...grpc.CallOption) (*TreeEntry, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RepoTree.Get", in)
+	result, err := s.RepoTreeClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRepoTreeClient) Search(ctx context.Context, in *RepoTreeSearchOp, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RepoTree.Search", in)
+	result, err := s.RepoTreeClient.Search(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRepoTreeClient) List(ctx context.Context, in *RepoTreeListOp, opts ...grpc.CallOption) (*RepoTreeListResult, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "RepoTree.List", in)
+	result, err := s.RepoTreeClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedRepoTreeServer struct {
+	RepoTreeServer
+	Tracer *Tracer
+}
+
+func (s *TracedRepoTreeServer) Get(ctx context.Context, in *RepoTreeGetOp) (*TreeEntry, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RepoTree.Get", in)
+	result, err := s.RepoTreeServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRepoTreeServer) Search(ctx context.Context, in *RepoTreeSearchOp) (*VCSSearchResultList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RepoTree.Search", in)
+	result, err := s.RepoTreeServer.Search(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedRepoTreeServer) List(ctx context.Context, in *RepoTreeListOp) (*RepoTreeListResult, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "RepoTree.List", in)
+	result, err := s.RepoTreeServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedRepoTreeClient struct {
+	RepoTreeClient
+}
+
+func (s *cacheHitTracedRepoTreeClient) Get(ctx context.Context, in *RepoTreeGetOp, opts ...grpc.CallOption) (*TreeEntry, error) {
+	var result *TreeEntry
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RepoTreeClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRepoTreeClient) Search(ctx context.Context, in *RepoTreeSearchOp, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	var result *VCSSearchResultList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RepoTreeClient.Search(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedRepoTreeClient) List(ctx context.Context, in *RepoTreeListOp, opts ...grpc.CallOption) (*RepoTreeListResult, error) {
+	var result *RepoTreeListResult
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.RepoTreeClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedRepoTreeClient struct {
+	RepoTreeClient
+}
+
+func (s *cacheMissTracedRepoTreeClient) Get(ctx context.Context, in *RepoTreeGetOp, opts ...grpc.CallOption) (*TreeEntry, error) {
+	traceCacheMiss(ctx)
+	return s.RepoTreeClient.Get(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRepoTreeClient) Search(ctx context.Context, in *RepoTreeSearchOp, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	traceCacheMiss(ctx)
+	return s.RepoTreeClient.Search(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedRepoTreeClient) List(ctx context.Context, in *RepoTreeListOp, opts ...grpc.CallOption) (*RepoTreeListResult, error) {
+	traceCacheMiss(ctx)
+	return s.RepoTreeClient.List(ctx, in, opts...)
+}
+
+type TracedReposClient struct {
+	ReposClient
+	Tracer *Tracer
+}
+
+func (s *TracedReposClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*Repo, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.Get", in)
+	result, err := s.ReposClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) List(ctx context.Context, in *RepoListOptions, opts ...grpc.CallOption) (*RepoList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.List", in)
+	result, err := s.ReposClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) Create(ctx context.Context, in *ReposCreateOp, opts ...grpc.CallOption) (*Repo, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.Create", in)
+	result, err := s.ReposClient.Create(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) Update(ctx context.Context, in *ReposUpdateOp, opts ...grpc.CallOption) (*Repo, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.Update", in)
+	result, err := s.ReposClient.Update(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.Delete", in)
+	result, err := s.ReposClient.Delete(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) GetReadme(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*Readme, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.GetReadme", in)
+	result, err := s.ReposClient.GetReadme(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) Enable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.Enable", in)
+	result, err := s.ReposClient.Enable(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) Disable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.Disable", in)
+	result, err := s.ReposClient.Disable(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) GetConfig(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*RepoConfig, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.GetConfig", in)
+	result, err := s.ReposClient.GetConfig(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) GetCommit(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*vcs.Commit, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.GetCommit", in)
+	result, err := s.ReposClient.GetCommit(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) ListCommits(ctx context.Context, in *ReposListCommitsOp, opts ...grpc.CallOption) (*CommitList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.ListCommits", in)
+	result, err := s.ReposClient.ListCommits(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) ListBranches(ctx context.Context, in *ReposListBranchesOp, opts ...grpc.CallOption) (*BranchList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.ListBranches", in)
+	result, err := s.ReposClient.ListBranches(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) ListTags(ctx context.Context, in *ReposListTagsOp, opts ...grpc.CallOption) (*TagList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.ListTags", in)
+	result, err := s.ReposClient.ListTags(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposClient) ListCommitters(ctx context.Context, in *ReposListCommittersOp, opts ...grpc.CallOption) (*CommitterList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Repos.ListCommitters", in)
+	result, err := s.ReposClient.ListCommitters(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedReposServer struct {
+	ReposServer
+	Tracer *Tracer
+}
+
+func (s *TracedReposServer) Get(ctx context.Context, in *RepoSpec) (*Repo, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.Get", in)
+	result, err := s.ReposServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) List(ctx context.Context, in *RepoListOptions) (*RepoList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.List", in)
+	result, err := s.ReposServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) Create(ctx context.Context, in *ReposCreateOp) (*Repo, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.Create", in)
+	result, err := s.ReposServer.Create(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) Update(ctx context.Context, in *ReposUpdateOp) (*Repo, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.Update", in)
+	result, err := s.ReposServer.Update(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) Delete(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.Delete", in)
+	result, err := s.ReposServer.Delete(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) GetReadme(ctx context.Context, in *RepoRevSpec) (*Readme, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.GetReadme", in)
+	result, err := s.ReposServer.GetReadme(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) Enable(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.Enable", in)
+	result, err := s.ReposServer.Enable(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) Disable(ctx context.Context, in *RepoSpec) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.Disable", in)
+	result, err := s.ReposServer.Disable(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) GetConfig(ctx context.Context, in *RepoSpec) (*RepoConfig, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.GetConfig", in)
+	result, err := s.ReposServer.GetConfig(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) GetCommit(ctx context.Context, in *RepoRevSpec) (*vcs.Commit, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.GetCommit", in)
+	result, err := s.ReposServer.GetCommit(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) ListCommits(ctx context.Context, in *ReposListCommitsOp) (*CommitList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.ListCommits", in)
+	result, err := s.ReposServer.ListCommits(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) ListBranches(ctx context.Context, in *ReposListBranchesOp) (*BranchList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.ListBranches", in)
+	result, err := s.ReposServer.ListBranches(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) ListTags(ctx context.Context, in *ReposListTagsOp) (*TagList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.ListTags", in)
+	result, err := s.ReposServer.ListTags(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedReposServer) ListCommitters(ctx context.Context, in *ReposListCommittersOp) (*CommitterList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Repos.ListCommitters", in)
+	result, err := s.ReposServer.ListCommitters(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedReposClient struct {
+	ReposClient
+}
+
+func (s *cacheHitTracedReposClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*Repo, error) {
+	var result *Repo
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) List(ctx context.Context, in *RepoListOptions, opts ...grpc.CallOption) (*RepoList, error) {
+	var result *RepoList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) Create(ctx context.Context, in *ReposCreateOp, opts ...grpc.CallOption) (*Repo, error) {
+	var result *Repo
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) Update(ctx context.Context, in *ReposUpdateOp, opts ...grpc.CallOption) (*Repo, error) {
+	var result *Repo
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.Update(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.Delete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) GetReadme(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*Readme, error) {
+	var result *Readme
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.GetReadme(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) Enable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.Enable(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) Disable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.Disable(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) GetConfig(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*RepoConfig, error) {
+	var result *RepoConfig
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.GetConfig(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) GetCommit(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*vcs.Commit, error) {
+	var result *vcs.Commit
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.GetCommit(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) ListCommits(ctx context.Context, in *ReposListCommitsOp, opts ...grpc.CallOption) (*CommitList, error) {
+	var result *CommitList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.ListCommits(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) ListBranches(ctx context.Context, in *ReposListBranchesOp, opts ...grpc.CallOption) (*BranchList, error) {
+	var result *BranchList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.ListBranches(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) ListTags(ctx context.Context, in *ReposListTagsOp, opts ...grpc.CallOption) (*TagList, error) {
+	var result *TagList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.ListTags(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedReposClient) ListCommitters(ctx context.Context, in *ReposListCommittersOp, opts ...grpc.CallOption) (*CommitterList, error) {
+	var result *CommitterList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ReposClient.ListCommitters(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedReposClient struct {
+	ReposClient
+}
+
+func (s *cacheMissTracedReposClient) Get(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*Repo, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.Get(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) List(ctx context.Context, in *RepoListOptions, opts ...grpc.CallOption) (*RepoList, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.List(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) Create(ctx context.Context, in *ReposCreateOp, opts ...grpc.CallOption) (*Repo, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.Create(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) Update(ctx context.Context, in *ReposUpdateOp, opts ...grpc.CallOption) (*Repo, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.Update(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) Delete(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.Delete(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) GetReadme(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*Readme, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.GetReadme(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) Enable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.Enable(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) Disable(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.Disable(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) GetConfig(ctx context.Context, in *RepoSpec, opts ...grpc.CallOption) (*RepoConfig, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.GetConfig(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) GetCommit(ctx context.Context, in *RepoRevSpec, opts ...grpc.CallOption) (*vcs.Commit, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.GetCommit(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) ListCommits(ctx context.Context, in *ReposListCommitsOp, opts ...grpc.CallOption) (*CommitList, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.ListCommits(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) ListBranches(ctx context.Context, in *ReposListBranchesOp, opts ...grpc.CallOption) (*BranchList, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.ListBranches(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) ListTags(ctx context.Context, in *ReposListTagsOp, opts ...grpc.CallOption) (*TagList, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.ListTags(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedReposClient) ListCommitters(ctx context.Context, in *ReposListCommittersOp, opts ...grpc.CallOption) (*CommitterList, error) {
+	traceCacheMiss(ctx)
+	return s.ReposClient.ListCommitters(ctx, in, opts...)
+}
+
+type TracedSavedSearchesClient struct {
+	SavedSearchesClient
+	Tracer *Tracer
+}
+
+func (s *TracedSavedSearchesClient) Create(ctx context.Context, in *SavedSearchesCreateOp, opts ...grpc.CallOption) (*SavedSearch, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "SavedSearches.Create", in)
+	result, err := s.SavedSearchesClient.Create(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSavedSearchesClient) List(ctx context.Context, in *SavedSearchListOptions, opts ...grpc.CallOption) (*SavedSearchList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "SavedSearches.List", in)
+	result, err := s.SavedSearchesClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSavedSearchesClient) Delete(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "SavedSearches.Delete", in)
+	result, err := s.SavedSearchesClient.Delete(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSavedSearchesClient) Run(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*SavedSearchRunResult, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "SavedSearches.Run", in)
+	result, err := s.SavedSearchesClient.Run(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedSavedSearchesServer struct {
+	SavedSearchesServer
+	Tracer *Tracer
+}
+
+func (s *TracedSavedSearchesServer) Create(ctx context.Context, in *SavedSearchesCreateOp) (*SavedSearch, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "SavedSearches.Create", in)
+	result, err := s.SavedSearchesServer.Create(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSavedSearchesServer) List(ctx context.Context, in *SavedSearchListOptions) (*SavedSearchList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "SavedSearches.List", in)
+	result, err := s.SavedSearchesServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSavedSearchesServer) Delete(ctx context.Context, in *SavedSearchSpec) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "SavedSearches.Delete", in)
+	result, err := s.SavedSearchesServer.Delete(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSavedSearchesServer) Run(ctx context.Context, in *SavedSearchSpec) (*SavedSearchRunResult, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "SavedSearches.Run", in)
+	result, err := s.SavedSearchesServer.Run(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedSavedSearchesClient struct {
+	SavedSearchesClient
+}
+
+func (s *cacheHitTracedSavedSearchesClient) Create(ctx context.Context, in *SavedSearchesCreateOp, opts ...grpc.CallOption) (*SavedSearch, error) {
+	var result *SavedSearch
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.SavedSearchesClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedSavedSearchesClient) List(ctx context.Context, in *SavedSearchListOptions, opts ...grpc.CallOption) (*SavedSearchList, error) {
+	var result *SavedSearchList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.SavedSearchesClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedSavedSearchesClient) Delete(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.SavedSearchesClient.Delete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedSavedSearchesClient) Run(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*SavedSearchRunResult, error) {
+	var result *SavedSearchRunResult
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.SavedSearchesClient.Run(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedSavedSearchesClient struct {
+	SavedSearchesClient
+}
+
+func (s *cacheMissTracedSavedSearchesClient) Create(ctx context.Context, in *SavedSearchesCreateOp, opts ...grpc.CallOption) (*SavedSearch, error) {
+	traceCacheMiss(ctx)
+	return s.SavedSearchesClient.Create(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedSavedSearchesClient) List(ctx context.Context, in *SavedSearchListOptions, opts ...grpc.CallOption) (*SavedSearchList, error) {
+	traceCacheMiss(ctx)
+	return s.SavedSearchesClient.List(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedSavedSearchesClient) Delete(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.SavedSearchesClient.Delete(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedSavedSearchesClient) Run(ctx context.Context, in *SavedSearchSpec, opts ...grpc.CallOption) (*SavedSearchRunResult, error) {
+	traceCacheMiss(ctx)
+	return s.SavedSearchesClient.Run(ctx, in, opts...)
+}
+
+type TracedSearchClient struct {
+	SearchClient
+	Tracer *Tracer
+}
+
+func (s *TracedSearchClient) Search(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (*SearchResults, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Search.Search", in)
+	result, err := s.SearchClient.Search(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSearchClient) SearchTokens(ctx context.Context, in *TokenSearchOptions, opts ...grpc.CallOption) (*DefList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Search.SearchTokens", in)
+	result, err := s.SearchClient.SearchTokens(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSearchClient) SearchText(ctx context.Context, in *TextSearchOptions, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Search.SearchText", in)
+	result, err := s.SearchClient.SearchText(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSearchClient) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions, opts ...grpc.CallOption) (*MultiTextSearchResults, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Search.SearchTextMulti", in)
+	result, err := s.SearchClient.SearchTextMulti(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSearchClient) Complete(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*Completions, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Search.Complete", in)
+	result, err := s.SearchClient.Complete(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSearchClient) Suggest(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*SuggestionList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Search.Suggest", in)
+	result, err := s.SearchClient.Suggest(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSearchClient) StreamSearch(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (Search_StreamSearchClient, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Search.StreamSearch", in)
+	stream, err := s.SearchClient.StreamSearch(ctx, in, opts...)
+	if err != nil {
+		span.finishCall(err)
+		return nil, err
+	}
+	return &tracedSearchStreamSearchClient{stream, span}, nil
+}
+
+// tracedSearchStreamSearchClient finishes the span of the call when the
+// stream ends.
+type tracedSearchStreamSearchClient struct {
+	Search_StreamSearchClient
+	span *Span
+}
+
+func (x *tracedSearchStreamSearchClient) Recv() (*SearchEvent, error) {
+	m, err := x.Search_StreamSearchClient.Recv()
+	if err != nil && x.span != nil {
+		if err == io.EOF {
+			x.span.finishCall(nil)
+		} else {
+			x.span.finishCall(err)
+		}
+		x.span = nil
+	}
+	return m, err
+}
+
+type TracedSearchServer struct {
+	SearchServer
+	Tracer *Tracer
+}
+
+func (s *TracedSearchServer) Search(ctx context.Context, in *SearchOptions) (*SearchResults, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Search.Search", in)
+	result, err := s.SearchServer.Search(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSearchServer) SearchTokens(ctx context.Context, in *TokenSearchOptions) (*DefList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Search.SearchTokens", in)
+	result, err := s.SearchServer.SearchTokens(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSearchServer) SearchText(ctx context.Context, in *TextSearchOptions) (*VCSSearchResultList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Search.SearchText", in)
+	result, err := s.SearchServer.SearchText(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSearchServer) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions) (*MultiTextSearchResults, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Search.SearchTextMulti", in)
+	result, err := s.SearchServer.SearchTextMulti(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSearchServer) Complete(ctx context.Context, in *RawQuery) (*Completions, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Search.Complete", in)
+	result, err := s.SearchServer.Complete(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSearchServer) Suggest(ctx context.Context, in *RawQuery) (*SuggestionList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Search.Suggest", in)
+	result, err := s.SearchServer.Suggest(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedSearchServer) StreamSearch(in *SearchOptions, stream Search_StreamSearchServer) error {
+	ctx, span := s.Tracer.startServerSpan(stream.Context(), "Search.StreamSearch", in)
+	err := s.SearchServer.StreamSearch(in, &tracedSearchStreamSearchServer{stream, ctx})
+	span.finishCall(err)
+	return err
+}
+
+// tracedSearchStreamSearchServer is a stream whose context has the span
+// of the call.
+type tracedSearchStreamSearchServer struct {
+	Search_StreamSearchServer
+	ctx context.Context
+}
+
+func (x *tracedSearchStreamSearchServer) Context() context.Context { return x.ctx }
+
+type cacheHitTracedSearchClient struct {
+	SearchClient
+}
+
+func (s *cacheHitTracedSearchClient) Search(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (*SearchResults, error) {
+	var result *SearchResults
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.SearchClient.Search(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedSearchClient) SearchTokens(ctx context.Context, in *TokenSearchOptions, opts ...grpc.CallOption) (*DefList, error) {
+	var result *DefList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.SearchClient.SearchTokens(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedSearchClient) SearchText(ctx context.Context, in *TextSearchOptions, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	var result *VCSSearchResultList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.SearchClient.SearchText(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedSearchClient) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions, opts ...grpc.CallOption) (*MultiTextSearchResults, error) {
+	var result *MultiTextSearchResults
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.SearchClient.SearchTextMulti(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedSearchClient) Complete(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*Completions, error) {
+	var result *Completions
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.SearchClient.Complete(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedSearchClient) Suggest(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*SuggestionList, error) {
+	var result *SuggestionList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.SearchClient.Suggest(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedSearchClient struct {
+	SearchClient
+}
+
+func (s *cacheMissTracedSearchClient) Search(ctx context.Context, in *SearchOptions, opts ...grpc.CallOption) (*SearchResults, error) {
+	traceCacheMiss(ctx)
+	return s.SearchClient.Search(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedSearchClient) SearchTokens(ctx context.Context, in *TokenSearchOptions, opts ...grpc.CallOption) (*DefList, error) {
+	traceCacheMiss(ctx)
+	return s.SearchClient.SearchTokens(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedSearchClient) SearchText(ctx context.Context, in *TextSearchOptions, opts ...grpc.CallOption) (*VCSSearchResultList, error) {
+	traceCacheMiss(ctx)
+	return s.SearchClient.SearchText(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedSearchClient) SearchTextMulti(ctx context.Context, in *MultiTextSearchOptions, opts ...grpc.CallOption) (*MultiTextSearchResults, error) {
+	traceCacheMiss(ctx)
+	return s.SearchClient.SearchTextMulti(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedSearchClient) Complete(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*Completions, error) {
+	traceCacheMiss(ctx)
+	return s.SearchClient.Complete(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedSearchClient) Suggest(ctx context.Context, in *RawQuery, opts ...grpc.CallOption) (*SuggestionList, error) {
+	traceCacheMiss(ctx)
+	return s.SearchClient.Suggest(ctx, in, opts...)
+}
+
+type TracedStorageClient struct {
+	StorageClient
+	Tracer *Tracer
+}
+
+func (s *TracedStorageClient) Create(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Storage.Create", in)
+	result, err := s.StorageClient.Create(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedStorageClient) RemoveAll(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Storage.RemoveAll", in)
+	result, err := s.StorageClient.RemoveAll(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedStorageClient) Read(ctx context.Context, in *StorageReadOp, opts ...grpc.CallOption) (*StorageRead, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Storage.Read", in)
+	result, err := s.StorageClient.Read(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedStorageClient) Write(ctx context.Context, in *StorageWriteOp, opts ...grpc.CallOption) (*StorageWrite, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Storage.Write", in)
+	result, err := s.StorageClient.Write(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedStorageClient) Stat(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageStat, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Storage.Stat", in)
+	result, err := s.StorageClient.Stat(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedStorageClient) ReadDir(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageReadDir, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Storage.ReadDir", in)
+	result, err := s.StorageClient.ReadDir(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedStorageClient) Close(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Storage.Close", in)
+	result, err := s.StorageClient.Close(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedStorageServer struct {
+	StorageServer
+	Tracer *Tracer
+}
+
+func (s *TracedStorageServer) Create(ctx context.Context, in *StorageName) (*StorageError, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Storage.Create", in)
+	result, err := s.StorageServer.Create(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedStorageServer) RemoveAll(ctx context.Context, in *StorageName) (*StorageError, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Storage.RemoveAll", in)
+	result, err := s.StorageServer.RemoveAll(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedStorageServer) Read(ctx context.Context, in *StorageReadOp) (*StorageRead, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Storage.Read", in)
+	result, err := s.StorageServer.Read(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedStorageServer) Write(ctx context.Context, in *StorageWriteOp) (*StorageWrite, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Storage.Write", in)
+	result, err := s.StorageServer.Write(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedStorageServer) Stat(ctx context.Context, in *StorageName) (*StorageStat, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Storage.Stat", in)
+	result, err := s.StorageServer.Stat(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedStorageServer) ReadDir(ctx context.Context, in *StorageName) (*StorageReadDir, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Storage.ReadDir", in)
+	result, err := s.StorageServer.ReadDir(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedStorageServer) Close(ctx context.Context, in *StorageName) (*StorageError, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Storage.Close", in)
+	result, err := s.StorageServer.Close(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedStorageClient struct {
+	StorageClient
+}
+
+func (s *cacheHitTracedStorageClient) Create(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	var result *StorageError
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.StorageClient.Create(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedStorageClient) RemoveAll(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	var result *StorageError
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.StorageClient.RemoveAll(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedStorageClient) Read(ctx context.Context, in *StorageReadOp, opts ...grpc.CallOption) (*StorageRead, error) {
+	var result *StorageRead
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.StorageClient.Read(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedStorageClient) Write(ctx context.Context, in *StorageWriteOp, opts ...grpc.CallOption) (*StorageWrite, error) {
+	var result *StorageWrite
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.StorageClient.Write(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedStorageClient) Stat(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageStat, error) {
+	var result *StorageStat
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.StorageClient.Stat(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedStorageClient) ReadDir(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageReadDir, error) {
+	var result *StorageReadDir
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.StorageClient.ReadDir(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedStorageClient) Close(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	var result *StorageError
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.StorageClient.Close(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedStorageClient struct {
+	StorageClient
+}
+
+func (s *cacheMissTracedStorageClient) Create(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	traceCacheMiss(ctx)
+	return s.StorageClient.Create(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedStorageClient) RemoveAll(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	traceCacheMiss(ctx)
+	return s.StorageClient.RemoveAll(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedStorageClient) Read(ctx context.Context, in *StorageReadOp, opts ...grpc.CallOption) (*StorageRead, error) {
+	traceCacheMiss(ctx)
+	return s.StorageClient.Read(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedStorageClient) Write(ctx context.Context, in *StorageWriteOp, opts ...grpc.CallOption) (*StorageWrite, error) {
+	traceCacheMiss(ctx)
+	return s.StorageClient.Write(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedStorageClient) Stat(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageStat, error) {
+	traceCacheMiss(ctx)
+	return s.StorageClient.Stat(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedStorageClient) ReadDir(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageReadDir, error) {
+	traceCacheMiss(ctx)
+	return s.StorageClient.ReadDir(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedStorageClient) Close(ctx context.Context, in *StorageName, opts ...grpc.CallOption) (*StorageError, error) {
+	traceCacheMiss(ctx)
+	return s.StorageClient.Close(ctx, in, opts...)
+}
+
+type TracedUnitsClient struct {
+	UnitsClient
+	Tracer *Tracer
+}
+
+func (s *TracedUnitsClient) Get(ctx context.Context, in *UnitSpec, opts ...grpc.CallOption) (*unit.RepoSourceUnit, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Units.Get", in)
+	result, err := s.UnitsClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedUnitsClient) List(ctx context.Context, in *UnitListOptions, opts ...grpc.CallOption) (*RepoSourceUnitList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Units.List", in)
+	result, err := s.UnitsClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedUnitsServer struct {
+	UnitsServer
+	Tracer *Tracer
+}
+
+func (s *TracedUnitsServer) Get(ctx context.Context, in *UnitSpec) (*unit.RepoSourceUnit, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Units.Get", in)
+	result, err := s.UnitsServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedUnitsServer) List(ctx context.Context, in *UnitListOptions) (*RepoSourceUnitList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Units.List", in)
+	result, err := s.UnitsServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedUnitsClient struct {
+	UnitsClient
+}
+
+func (s *cacheHitTracedUnitsClient) Get(ctx context.Context, in *UnitSpec, opts ...grpc.CallOption) (*unit.RepoSourceUnit, error) {
+	var result *unit.RepoSourceUnit
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.UnitsClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedUnitsClient) List(ctx context.Context, in *UnitListOptions, opts ...grpc.CallOption) (*RepoSourceUnitList, error) {
+	var result *RepoSourceUnitList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.UnitsClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedUnitsClient struct {
+	UnitsClient
+}
+
+func (s *cacheMissTracedUnitsClient) Get(ctx context.Context, in *UnitSpec, opts ...grpc.CallOption) (*unit.RepoSourceUnit, error) {
+	traceCacheMiss(ctx)
+	return s.UnitsClient.Get(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedUnitsClient) List(ctx context.Context, in *UnitListOptions, opts ...grpc.CallOption) (*RepoSourceUnitList, error) {
+	traceCacheMiss(ctx)
+	return s.UnitsClient.List(ctx, in, opts...)
+}
+
+type TracedUserKeysClient struct {
+	UserKeysClient
+	Tracer *Tracer
+}
+
+func (s *TracedUserKeysClient) AddKey(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "UserKeys.AddKey", in)
+	result, err := s.UserKeysClient.AddKey(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedUserKeysClient) LookupUser(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (*UserSpec, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "UserKeys.LookupUser", in)
+	result, err := s.UserKeysClient.LookupUser(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedUserKeysClient) DeleteKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "UserKeys.DeleteKey", in)
+	result, err := s.UserKeysClient.DeleteKey(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedUserKeysServer struct {
+	UserKeysServer
+	Tracer *Tracer
+}
+
+func (s *TracedUserKeysServer) AddKey(ctx context.Context, in *SSHPublicKey) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "UserKeys.AddKey", in)
+	result, err := s.UserKeysServer.AddKey(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedUserKeysServer) LookupUser(ctx context.Context, in *SSHPublicKey) (*UserSpec, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "UserKeys.LookupUser", in)
+	result, err := s.UserKeysServer.LookupUser(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedUserKeysServer) DeleteKey(ctx context.Context, in *pbtypes.Void) (*pbtypes.Void, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "UserKeys.DeleteKey", in)
+	result, err := s.UserKeysServer.DeleteKey(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedUserKeysClient struct {
+	UserKeysClient
+}
+
+func (s *cacheHitTracedUserKeysClient) AddKey(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.UserKeysClient.AddKey(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedUserKeysClient) LookupUser(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (*UserSpec, error) {
+	var result *UserSpec
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.UserKeysClient.LookupUser(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedUserKeysClient) DeleteKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	var result *pbtypes.Void
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.UserKeysClient.DeleteKey(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedUserKeysClient struct {
+	UserKeysClient
+}
+
+func (s *cacheMissTracedUserKeysClient) AddKey(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.UserKeysClient.AddKey(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedUserKeysClient) LookupUser(ctx context.Context, in *SSHPublicKey, opts ...grpc.CallOption) (*UserSpec, error) {
+	traceCacheMiss(ctx)
+	return s.UserKeysClient.LookupUser(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedUserKeysClient) DeleteKey(ctx context.Context, in *pbtypes.Void, opts ...grpc.CallOption) (*pbtypes.Void, error) {
+	traceCacheMiss(ctx)
+	return s.UserKeysClient.DeleteKey(ctx, in, opts...)
+}
+
+type TracedUsersClient struct {
+	UsersClient
+	Tracer *Tracer
+}
+
+func (s *TracedUsersClient) Get(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*User, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Users.Get", in)
+	result, err := s.UsersClient.Get(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedUsersClient) GetWithEmail(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (*User, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Users.GetWithEmail", in)
+	result, err := s.UsersClient.GetWithEmail(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedUsersClient) ListEmails(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*EmailAddrList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Users.ListEmails", in)
+	result, err := s.UsersClient.ListEmails(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedUsersClient) List(ctx context.Context, in *UsersListOptions, opts ...grpc.CallOption) (*UserList, error) {
+	ctx, span := s.Tracer.startClientSpan(ctx, "Users.List", in)
+	result, err := s.UsersClient.List(ctx, in, opts...)
+	span.finishCall(err)
+	return result, err
+}
+
+type TracedUsersServer struct {
+	UsersServer
+	Tracer *Tracer
+}
+
+func (s *TracedUsersServer) Get(ctx context.Context, in *UserSpec) (*User, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Users.Get", in)
+	result, err := s.UsersServer.Get(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedUsersServer) GetWithEmail(ctx context.Context, in *EmailAddr) (*User, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Users.GetWithEmail", in)
+	result, err := s.UsersServer.GetWithEmail(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedUsersServer) ListEmails(ctx context.Context, in *UserSpec) (*EmailAddrList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Users.ListEmails", in)
+	result, err := s.UsersServer.ListEmails(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+func (s *TracedUsersServer) List(ctx context.Context, in *UsersListOptions) (*UserList, error) {
+	ctx, span := s.Tracer.startServerSpan(ctx, "Users.List", in)
+	result, err := s.UsersServer.List(ctx, in)
+	span.finishCall(err)
+	return result, err
+}
+
+type cacheHitTracedUsersClient struct {
+	UsersClient
+}
+
+func (s *cacheHitTracedUsersClient) Get(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*User, error) {
+	var result *User
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.UsersClient.Get(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedUsersClient) GetWithEmail(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (*User, error) {
+	var result *User
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.UsersClient.GetWithEmail(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedUsersClient) ListEmails(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*EmailAddrList, error) {
+	var result *EmailAddrList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.UsersClient.ListEmails(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+func (s *cacheHitTracedUsersClient) List(ctx context.Context, in *UsersListOptions, opts ...grpc.CallOption) (*UserList, error) {
+	var result *UserList
+	err := traceCacheHit(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.UsersClient.List(ctx, in, opts...)
+		return err
+	})
+	return result, err
+}
+
+type cacheMissTracedUsersClient struct {
+	UsersClient
+}
+
+func (s *cacheMissTracedUsersClient) Get(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*User, error) {
+	traceCacheMiss(ctx)
+	return s.UsersClient.Get(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedUsersClient) GetWithEmail(ctx context.Context, in *EmailAddr, opts ...grpc.CallOption) (*User, error) {
+	traceCacheMiss(ctx)
+	return s.UsersClient.GetWithEmail(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedUsersClient) ListEmails(ctx context.Context, in *UserSpec, opts ...grpc.CallOption) (*EmailAddrList, error) {
+	traceCacheMiss(ctx)
+	return s.UsersClient.ListEmails(ctx, in, opts...)
+}
+
+func (s *cacheMissTracedUsersClient) List(ctx context.Context, in *UsersListOptions, opts ...grpc.CallOption) (*UserList, error) {
+	traceCacheMiss(ctx)
+	return s.UsersClient.List(ctx, in, opts...)
+}
+
+// traceClient wraps each of c's services in a Traced*Client that
+// records spans with tracer.
+func traceClient(c *Client, tracer *Tracer) {
+	c.AccessTokens = &TracedAccessTokensClient{c.AccessTokens, tracer}
+	c.Accounts = &TracedAccountsClient{c.Accounts, tracer}
+	c.AuditLog = &TracedAuditLogClient{c.AuditLog, tracer}
+	c.Auth = &TracedAuthClient{c.Auth, tracer}
+	c.Builds = &TracedBuildsClient{c.Builds, tracer}
+	c.Changesets = &TracedChangesetsClient{c.Changesets, tracer}
+	c.Defs = &TracedDefsClient{c.Defs, tracer}
+	c.Deltas = &TracedDeltasClient{c.Deltas, tracer}
+	c.Discussions = &TracedDiscussionsClient{c.Discussions, tracer}
+	c.GraphUplink = &TracedGraphUplinkClient{c.GraphUplink, tracer}
+	c.Markdown = &TracedMarkdownClient{c.Markdown, tracer}
+	c.Meta = &TracedMetaClient{c.Meta, tracer}
+	c.MirrorRepos = &TracedMirrorReposClient{c.MirrorRepos, tracer}
+	c.MirroredRepoSSHKeys = &TracedMirroredRepoSSHKeysClient{c.MirroredRepoSSHKeys, tracer}
+	c.Notify = &TracedNotifyClient{c.Notify, tracer}
+	c.Orgs = &TracedOrgsClient{c.Orgs, tracer}
+	c.People = &TracedPeopleClient{c.People, tracer}
+	c.RegisteredClients = &TracedRegisteredClientsClient{c.RegisteredClients, tracer}
+	c.RepoBadges = &TracedRepoBadgesClient{c.RepoBadges, tracer}
+	c.RepoStatuses = &TracedRepoStatusesClient{c.RepoStatuses, tracer}
+	c.RepoTree = &TracedRepoTreeClient{c.RepoTree, tracer}
+	c.Repos = &TracedReposClient{c.Repos, tracer}
+	c.SavedSearches = &TracedSavedSearchesClient{c.SavedSearches, tracer}
+	c.Search = &TracedSearchClient{c.Search, tracer}
+	c.Storage = &TracedStorageClient{c.Storage, tracer}
+	c.Units = &TracedUnitsClient{c.Units, tracer}
+	c.UserKeys = &TracedUserKeysClient{c.UserKeys, tracer}
+	c.Users = &TracedUsersClient{c.Users, tracer}
+}
+
+// traceCacheHitClient wraps each of c's services (the Cached*Client
+// wrappers) so that the spans of calls that they answer from the
+// cache are tagged as cache hits. The services that the Cached*Client
+// wrappers wrap must have been wrapped by traceCacheMissClient.
+func traceCacheHitClient(c *Client) {
+	c.AccessTokens = &cacheHitTracedAccessTokensClient{c.AccessTokens}
+	c.Accounts = &cacheHitTracedAccountsClient{c.Accounts}
+	c.AuditLog = &cacheHitTracedAuditLogClient{c.AuditLog}
+	c.Auth = &cacheHitTracedAuthClient{c.Auth}
+	c.Builds = &cacheHitTracedBuildsClient{c.Builds}
+	c.Changesets = &cacheHitTracedChangesetsClient{c.Changesets}
+	c.Defs = &cacheHitTracedDefsClient{c.Defs}
+	c.Deltas = &cacheHitTracedDeltasClient{c.Deltas}
+	c.Discussions = &cacheHitTracedDiscussionsClient{c.Discussions}
+	c.GraphUplink = &cacheHitTracedGraphUplinkClient{c.GraphUplink}
+	c.Markdown = &cacheHitTracedMarkdownClient{c.Markdown}
+	c.Meta = &cacheHitTracedMetaClient{c.Meta}
+	c.MirrorRepos = &cacheHitTracedMirrorReposClient{c.MirrorRepos}
+	c.MirroredRepoSSHKeys = &cacheHitTracedMirroredRepoSSHKeysClient{c.MirroredRepoSSHKeys}
+	c.Notify = &cacheHitTracedNotifyClient{c.Notify}
+	c.Orgs = &cacheHitTracedOrgsClient{c.Orgs}
+	c.People = &cacheHitTracedPeopleClient{c.People}
+	c.RegisteredClients = &cacheHitTracedRegisteredClientsClient{c.RegisteredClients}
+	c.RepoBadges = &cacheHitTracedRepoBadgesClient{c.RepoBadges}
+	c.RepoStatuses = &cacheHitTracedRepoStatusesClient{c.RepoStatuses}
+	c.RepoTree = &cacheHitTracedRepoTreeClient{c.RepoTree}
+	c.Repos = &cacheHitTracedReposClient{c.Repos}
+	c.SavedSearches = &cacheHitTracedSavedSearchesClient{c.SavedSearches}
+	c.Search = &cacheHitTracedSearchClient{c.Search}
+	c.Storage = &cacheHitTracedStorageClient{c.Storage}
+	c.Units = &cacheHitTracedUnitsClient{c.Units}
+	c.UserKeys = &cacheHitTracedUserKeysClient{c.UserKeys}
+	c.Users = &cacheHitTracedUsersClient{c.Users}
+}
+
+// traceCacheMissClient wraps each of c's services so that calls that
+// reach them are not tagged as cache hits (see traceCacheHitClient).
+func traceCacheMissClient(c *Client) {
+	c.AccessTokens = &cacheMissTracedAccessTokensClient{c.AccessTokens}
+	c.Accounts = &cacheMissTracedAccountsClient{c.Accounts}
+	c.AuditLog = &cacheMissTracedAuditLogClient{c.AuditLog}
+	c.Auth = &cacheMissTracedAuthClient{c.Auth}
+	c.Builds = &cacheMissTracedBuildsClient{c.Builds}
+	c.Changesets = &cacheMissTracedChangesetsClient{c.Changesets}
+	c.Defs = &cacheMissTracedDefsClient{c.Defs}
+	c.Deltas = &cacheMissTracedDeltasClient{c.Deltas}
+	c.Discussions = &cacheMissTracedDiscussionsClient{c.Discussions}
+	c.GraphUplink = &cacheMissTracedGraphUplinkClient{c.GraphUplink}
+	c.Markdown = &cacheMissTracedMarkdownClient{c.Markdown}
+	c.Meta = &cacheMissTracedMetaClient{c.Meta}
+	c.MirrorRepos = &cacheMissTracedMirrorReposClient{c.MirrorRepos}
+	c.MirroredRepoSSHKeys = &cacheMissTracedMirroredRepoSSHKeysClient{c.MirroredRepoSSHKeys}
+	c.Notify = &cacheMissTracedNotifyClient{c.Notify}
+	c.Orgs = &cacheMissTracedOrgsClient{c.Orgs}
+	c.People = &cacheMissTracedPeopleClient{c.People}
+	c.RegisteredClients = &cacheMissTracedRegisteredClientsClient{c.RegisteredClients}
+	c.RepoBadges = &cacheMissTracedRepoBadgesClient{c.RepoBadges}
+	c.RepoStatuses = &cacheMissTracedRepoStatusesClient{c.RepoStatuses}
+	c.RepoTree = &cacheMissTracedRepoTreeClient{c.RepoTree}
+	c.Repos = &cacheMissTracedReposClient{c.Repos}
+	c.SavedSearches = &cacheMissTracedSavedSearchesClient{c.SavedSearches}
+	c.Search = &cacheMissTracedSearchClient{c.Search}
+	c.Storage = &cacheMissTracedStorageClient{c.Storage}
+	c.Units = &cacheMissTracedUnitsClient{c.Units}
+	c.UserKeys = &cacheMissTracedUserKeysClient{c.UserKeys}
+	c.Users = &cacheMissTracedUsersClient{c.Users}
+}